@@ -1,12 +1,17 @@
 package main
 
 import (
+	"context"
+	"database/sql"
 	"log"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 	redisv9 "github.com/redis/go-redis/v9"
 	_ "sportsin_backend/docs" // swag docs
+	"sportsin_backend/internals/auth"
 	"sportsin_backend/internals/chat/redis"
 	"sportsin_backend/internals/config"
 	"sportsin_backend/internals/db"
@@ -15,6 +20,8 @@ import (
 	"sportsin_backend/internals/middleware"
 	"sportsin_backend/internals/notifications"
 	"sportsin_backend/internals/services"
+	"sportsin_backend/internals/store"
+	"sportsin_backend/internals/store/memory"
 )
 
 // @title           SportsIN Backend API
@@ -39,21 +46,80 @@ func main() {
 		log.Println(" Warning: .env file not found, using environment variables")
 	}
 	cfg := config.LoadConfig()
-	conn, err := db.Connect(cfg)
-	if err != nil {
-		log.Fatal("Error connecting to database")
+	reportHideLimit := 5
+	if cfg.REPORT_HIDE_LIMIT != "" {
+		reportHideLimit, err = strconv.Atoi(cfg.REPORT_HIDE_LIMIT)
+		if err != nil || reportHideLimit < 0 {
+			log.Fatal("REPORT_HIDE_LIMIT must be a non-negative number, got ", cfg.REPORT_HIDE_LIMIT)
+		}
 	}
-	defer conn.Close()
-	err = db.RunMigrations(conn)
-	if err != nil {
-		log.Fatal("Error running migrations:", err)
+	maxVideoMB := 100
+	if cfg.MAX_VIDEO_MB != "" {
+		maxVideoMB, err = strconv.Atoi(cfg.MAX_VIDEO_MB)
+		if err != nil || maxVideoMB <= 0 {
+			log.Fatal("MAX_VIDEO_MB must be a positive number, got ", cfg.MAX_VIDEO_MB)
+		}
 	}
-	s3Service, err := services.NewS3Service(cfg.S3_BUCKET_NAME, cfg.AWS_REGION)
-	if err != nil {
-		log.Fatal("Error initializing S3 service: ", err)
+	maxVideoSeconds := 60
+	if cfg.MAX_VIDEO_SECONDS != "" {
+		maxVideoSeconds, err = strconv.Atoi(cfg.MAX_VIDEO_SECONDS)
+		if err != nil || maxVideoSeconds <= 0 {
+			log.Fatal("MAX_VIDEO_SECONDS must be a positive number, got ", cfg.MAX_VIDEO_SECONDS)
+		}
 	}
 	// Initialize repository
-	repo := &repositories.Repository{DB: conn}
+	var repo store.Store
+	var conn *sql.DB
+	if cfg.STORE_DRIVER == "memory" {
+		log.Println("Using in-memory store, data will not survive a restart")
+		memStore := memory.NewStore()
+		memStore.ReportHideThreshold = reportHideLimit
+		repo = memStore
+	} else {
+		conn, err = db.Connect(cfg)
+		if err != nil {
+			log.Fatal("Error connecting to database")
+		}
+		defer conn.Close()
+		err = db.RunMigrations(conn)
+		if err != nil {
+			log.Fatal("Error running migrations:", err)
+		}
+		repo = &repositories.Repository{DB: conn, ReportHideThreshold: reportHideLimit}
+	}
+	// Initialize object storage
+	var blobs services.BlobStore
+	var localStorage *services.LocalStorage
+	if cfg.STORAGE_DRIVER == "local" {
+		localStorage, err = services.NewLocalStorage(cfg.LOCAL_STORAGE_PATH, cfg.LOCAL_STORAGE_URL, cfg.LOCAL_STORAGE_SECRET)
+		if err != nil {
+			log.Fatal("Error initializing local storage: ", err)
+		}
+		log.Println("Using local storage at", cfg.LOCAL_STORAGE_PATH)
+		blobs = localStorage
+	} else {
+		blobs, err = services.NewS3Service(cfg.S3_BUCKET_NAME, cfg.AWS_REGION)
+		if err != nil {
+			log.Fatal("Error initializing S3 service: ", err)
+		}
+	}
+	storage := services.NewStorageService(blobs, int64(maxVideoMB)<<20)
+	// Initialize the post video pipeline. Without ffmpeg on PATH video
+	// uploads are turned off rather than failing the whole server.
+	var videoProcessor services.VideoProcessor
+	if cfg.VIDEO_PROCESSOR == "stub" {
+		log.Println("Using stub video processor, videos get placeholder thumbnails")
+		videoProcessor = services.NewStubVideoProcessor(10 * time.Second)
+	} else if ffmpeg, err := services.NewFFmpegVideoProcessor(); err != nil {
+		log.Printf("WARNING: %v. Post video uploads are disabled until ffmpeg is installed", err)
+	} else {
+		videoProcessor = ffmpeg
+	}
+	var mediaWorker *services.MediaWorker
+	if videoProcessor != nil {
+		mediaWorker = services.NewMediaWorker(repo, storage, videoProcessor, time.Duration(maxVideoSeconds)*time.Second)
+		mediaWorker.Start(context.Background(), 2)
+	}
 	// Initialize Redis client
 	redisClient := redisv9.NewClient(&redisv9.Options{
 		Addr: cfg.REDIS_URL,
@@ -64,43 +130,102 @@ func main() {
 	// CRITICAL FIX: Start the hub in a goroutine
 	go chatHub.Run()
 
-	// Initialize SNSService for notifications (Android only)
-	snsService := notifications.NewSNSService(cfg.AWS_REGION, cfg.AWS_PLATFORM_ARN)
+	// Initialize push notifications
+	var notifier notifications.TopicNotifier
+	switch cfg.NOTIFIER_DRIVER {
+	case "log":
+		log.Println("Using log notifier, push notifications will only be logged")
+		notifier = notifications.NewLogNotifier(nil)
+	case "webhook":
+		if cfg.NOTIFIER_WEBHOOK_URL == "" {
+			log.Fatal("NOTIFIER_WEBHOOK_URL is required for the webhook notifier")
+		}
+		log.Println("Using webhook notifier at", cfg.NOTIFIER_WEBHOOK_URL)
+		notifier = notifications.NewWebhookNotifier(cfg.NOTIFIER_WEBHOOK_URL)
+	default:
+		// SNSService for notifications (Android only)
+		notifier = notifications.NewSNSService(cfg.AWS_REGION, cfg.AWS_PLATFORM_ARN)
+	}
+	// Revoked sessions and suspensions are tracked in Redis and rejected by
+	// the JWT middleware
+	revocations := auth.NewRevocationList(redisClient, repo)
+	middleware.SetRevocationChecker(revocations)
+	// Initialize auth provider
+	var authProvider auth.Provider
+	var localAuth *auth.LocalAuthService
+	if cfg.AUTH_PROVIDER == "local" {
+		localAuth, err = auth.NewLocalAuthService(cfg, repo, repo, revocations, redisClient)
+		if err != nil {
+			log.Fatal("Error initializing local auth: ", err)
+		}
+		log.Println("Using local auth provider with issuer", cfg.LOCAL_AUTH_ISSUER)
+		middleware.SetLocalSigningKey(localAuth.PublicKey())
+		authProvider = localAuth
+	} else {
+		authProvider = auth.NewCognitoService(cfg)
+	}
 	// Register chat handlers
-	chatHandler := handlers.NewChatHandler(chatHub, repo, snsService)
+	chatHandler := handlers.NewChatHandler(chatHub, repo, storage)
 	r := gin.Default()
 	// CORS middleware for Swagger UI
 	r.Use(func(c *gin.Context) {
 		c.Header("Access-Control-Allow-Origin", "*")
 		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Request-ID")
+		c.Header("Access-Control-Expose-Headers", "X-Request-ID")
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
 			return
 		}
 		c.Next()
 	})
+	r.Use(middleware.RequestID())
 	// Register documentation and health routes
 	handlers.RegisterDocsRoutes(r.Group(""))
 	handlers.RegisterHealthRoutes(r.Group(""))
 	// Register routes
-	handlers.RegisterAuthRoutes(r.Group(""), cfg, repo)
+	handlers.RegisterAuthRoutes(r.Group(""), cfg, repo, authProvider, revocations, middleware.NewRateLimiter(redisClient))
 	handlers.RegisterProfileRoutes(r.Group(""), cfg, repo)
-	handlers.RegisterImageRoutes(r.Group(""), cfg, repo, s3Service)
-	handlers.RegisterPostRoutes(r.Group(""), cfg, repo, s3Service)
-	handlers.RegisterCommentRoutes(r.Group(""), cfg, repo)
-	handlers.RegisterTournamentRoutes(r.Group(""), cfg, repo, s3Service)
-	handlers.RegisterAchievementRoutes(r.Group(""), cfg, repo, s3Service)
+	handlers.RegisterFollowRoutes(r.Group(""), cfg, repo)
+	handlers.RegisterImageRoutes(r.Group(""), cfg, repo, storage)
+	mentions := services.NewMentionService(repo, notifier)
+	handlers.RegisterPostRoutes(r.Group(""), cfg, repo, mentions, storage, mediaWorker)
+	handlers.RegisterFeedRoutes(r.Group(""), cfg, services.NewFeedService(repo))
+	handlers.RegisterTagRoutes(r.Group(""), cfg, repo)
+	handlers.RegisterMentionRoutes(r.Group(""), cfg, repo)
+	handlers.RegisterCommentRoutes(r.Group(""), cfg, repo, mentions)
+	tournamentChats := services.NewTournamentChatService(repo, chatHub, notifier)
+	handlers.RegisterTournamentRoutes(r.Group(""), cfg, repo, storage, tournamentChats)
+	handlers.RegisterAchievementRoutes(r.Group(""), cfg, repo, storage)
 	handlers.RegisterOpeningRoutes(r.Group(""), cfg, repo)
-	handlers.RegisterSportRoutes(r.Group(""), cfg, repo)
-	// Register search route
-	r.GET("/search/users", handlers.SearchUsersHandler(repo.DB))
+	handlers.RegisterSportRoutes(r.Group(""), repo)
+	handlers.RegisterReportRoutes(r.Group(""), cfg, repo)
+	handlers.RegisterSavedRoutes(r.Group(""), cfg, repo)
+	handlers.RegisterAdminRoutes(r.Group(""), cfg, repo, authProvider, revocations, storage)
+	if localAuth != nil {
+		handlers.RegisterJWKSRoutes(r.Group(""), localAuth)
+	}
+	if localStorage != nil {
+		handlers.RegisterLocalStorageRoutes(r.Group(""), localStorage)
+	}
+	// Register search route (backed by a Postgres search index)
+	if conn != nil {
+		r.GET("/search/users", handlers.SearchUsersHandler(conn))
+	}
 	// Create JWT middleware instance
 	jwtMiddleware := middleware.NewJWTMiddleware(cfg).AuthMiddleware()
 
 	// All chat routes need authentication
 	r.GET("/ws/chat", jwtMiddleware, chatHandler.ServeWs)
+	r.GET("/chat/presence", jwtMiddleware, chatHandler.GetPresence)
 	r.GET("/chat/rooms", jwtMiddleware, chatHandler.GetChatRooms)
+	r.POST("/chat/rooms", jwtMiddleware, chatHandler.CreateGroupRoom)
+	r.GET("/chat/rooms/:roomID", jwtMiddleware, chatHandler.GetChatRoom)
+	r.PUT("/chat/rooms/:roomID", jwtMiddleware, chatHandler.UpdateGroupRoom)
+	r.POST("/chat/rooms/:roomID/members", jwtMiddleware, chatHandler.AddGroupMember)
+	r.PUT("/chat/rooms/:roomID/members/:userID", jwtMiddleware, chatHandler.UpdateGroupMemberRole)
+	r.DELETE("/chat/rooms/:roomID/members/:userID", jwtMiddleware, chatHandler.RemoveGroupMember)
+	r.POST("/chat/rooms/:roomID/leave", jwtMiddleware, chatHandler.LeaveGroupRoom)
 	r.GET("/chat/rooms/:roomID/messages", jwtMiddleware, chatHandler.GetMessages)
 	r.POST("/chat/rooms/:roomID/read", jwtMiddleware, chatHandler.MarkRoomAsRead)
 	r.Run()
@@ -26,6 +26,12 @@ func (e *UserNotConfirmedError) Error() string {
 	return fmt.Sprintf("user '%s' is not confirmed. Please check your email for verification code", e.Email)
 }
 
+type UserSuspendedError struct{}
+
+func (e *UserSuspendedError) Error() string {
+	return "user account is suspended"
+}
+
 type UserNotFoundError struct {
 	Email string
 }
@@ -155,9 +155,9 @@ func (s *CognitoService) Confirm(input ConfirmInput) error {
 	return nil
 }
 
-func (s *CognitoService) Login(input LoginInput) (string, error) {
+func (s *CognitoService) Login(input LoginInput) (*Tokens, error) {
 	if input.Email == "" || input.Password == "" {
-		return "", &InvalidParameterError{
+		return nil, &InvalidParameterError{
 			Parameter: "input",
 			Message:   "email and password are required",
 		}
@@ -175,11 +175,83 @@ func (s *CognitoService) Login(input LoginInput) (string, error) {
 	})
 	if err != nil {
 		log.Printf("❌ Login error for %s: %v", input.Email, err)
-		return "", s.handleCognitoError(err, input.Email)
+		return nil, s.handleCognitoError(err, input.Email)
 	}
 
 	log.Printf("✅ Login successful for %s", input.Email)
-	return *resp.AuthenticationResult.IdToken, nil
+	return &Tokens{
+		IDToken:      aws.ToString(resp.AuthenticationResult.IdToken),
+		RefreshToken: aws.ToString(resp.AuthenticationResult.RefreshToken),
+		ExpiresIn:    resp.AuthenticationResult.ExpiresIn,
+	}, nil
+}
+
+func (s *CognitoService) Refresh(refreshToken string) (*Tokens, error) {
+	if refreshToken == "" {
+		return nil, &InvalidParameterError{
+			Parameter: "refresh_token",
+			Message:   "refresh token is required",
+		}
+	}
+
+	resp, err := s.client.InitiateAuth(context.TODO(), &cognitoidentityprovider.InitiateAuthInput{
+		AuthFlow: types.AuthFlowTypeRefreshTokenAuth,
+		ClientId: aws.String(s.clientID),
+		AuthParameters: map[string]string{
+			"REFRESH_TOKEN": refreshToken,
+		},
+	})
+	if err != nil {
+		log.Printf("❌ Refresh error: %v", err)
+		return nil, s.handleCognitoError(err, "")
+	}
+
+	// Cognito only returns a new refresh token when rotation is enabled
+	newRefreshToken := aws.ToString(resp.AuthenticationResult.RefreshToken)
+	if newRefreshToken == "" {
+		newRefreshToken = refreshToken
+	}
+
+	return &Tokens{
+		IDToken:      aws.ToString(resp.AuthenticationResult.IdToken),
+		RefreshToken: newRefreshToken,
+		ExpiresIn:    resp.AuthenticationResult.ExpiresIn,
+	}, nil
+}
+
+// RevokeRefreshToken revokes a refresh token and the tokens issued from it
+func (s *CognitoService) RevokeRefreshToken(refreshToken string) error {
+	if refreshToken == "" {
+		return &InvalidParameterError{
+			Parameter: "refresh_token",
+			Message:   "refresh token is required",
+		}
+	}
+
+	_, err := s.client.RevokeToken(context.TODO(), &cognitoidentityprovider.RevokeTokenInput{
+		ClientId: aws.String(s.clientID),
+		Token:    aws.String(refreshToken),
+	})
+	if err != nil {
+		log.Printf("❌ RevokeToken error: %v", err)
+		return s.handleCognitoError(err, "")
+	}
+	return nil
+}
+
+// SignOutEverywhere invalidates every refresh token issued to the user
+func (s *CognitoService) SignOutEverywhere(userID string) error {
+	_, err := s.client.AdminUserGlobalSignOut(context.TODO(), &cognitoidentityprovider.AdminUserGlobalSignOutInput{
+		UserPoolId: aws.String(s.userPoolID),
+		Username:   aws.String(userID),
+	})
+	if err != nil {
+		log.Printf("❌ GlobalSignOut error for %s: %v", userID, err)
+		return s.handleCognitoError(err, "")
+	}
+
+	log.Printf("✅ GlobalSignOut succeeded for %s", userID)
+	return nil
 }
 
 func (s *CognitoService) ForgotPassword(input ForgotPasswordInput) error {
@@ -0,0 +1,590 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	appConfig "sportsin_backend/internals/config"
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/model"
+	"sportsin_backend/internals/services"
+	"sportsin_backend/internals/store"
+)
+
+const (
+	// LocalTokenTTL matches the lifetime of a Cognito ID token
+	LocalTokenTTL = time.Hour
+	// LocalRefreshTokenTTL matches the default Cognito refresh token lifetime
+	LocalRefreshTokenTTL = 30 * 24 * time.Hour
+
+	verificationCodeTTL = 24 * time.Hour
+	resetCodeTTL        = time.Hour
+
+	// maxCodeAttempts is the number of wrong guesses after which a
+	// verification or reset code is thrown away
+	maxCodeAttempts = 5
+	// maxCodesPerWindow caps the codes sent for one account per codeWindow
+	maxCodesPerWindow = 5
+	codeWindow        = time.Hour
+)
+
+// LocalAuthService is a Provider that keeps bcrypt password hashes in the
+// database and signs its own RS256 ID tokens, so the API can run without
+// Cognito. There is no mail delivery: verification and reset codes are
+// written to the server log. As with Cognito, codes allow a few guesses and
+// only a few are sent per account an hour. Refresh tokens are opaque random
+// strings kept in Redis and rotated on every use. Suspended accounts can
+// neither log in nor refresh their tokens.
+type LocalAuthService struct {
+	creds       store.CredentialStore
+	users       store.UserStore
+	revocations *RevocationList
+	rdb         *redis.Client
+	issuer      string
+	privateKey  *rsa.PrivateKey
+	keyID       string
+}
+
+var _ Provider = (*LocalAuthService)(nil)
+
+func NewLocalAuthService(cfg *appConfig.Config, creds store.CredentialStore, users store.UserStore, revocations *RevocationList, rdb *redis.Client) (*LocalAuthService, error) {
+	if cfg.LOCAL_AUTH_ISSUER == "" {
+		return nil, errors.New("LOCAL_AUTH_ISSUER is required for the local auth provider")
+	}
+
+	var privateKey *rsa.PrivateKey
+	if cfg.LOCAL_AUTH_KEY_PATH != "" {
+		pemBytes, err := os.ReadFile(cfg.LOCAL_AUTH_KEY_PATH)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read signing key: %w", err)
+		}
+		privateKey, err = jwt.ParseRSAPrivateKeyFromPEM(pemBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse signing key: %w", err)
+		}
+	} else {
+		log.Println("⚠️ LOCAL_AUTH_KEY_PATH not set, generating a temporary signing key. Tokens will not survive a restart")
+		var err error
+		privateKey, err = rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate signing key: %w", err)
+		}
+	}
+
+	keyHash := sha256.Sum256(privateKey.PublicKey.N.Bytes())
+
+	return &LocalAuthService{
+		creds:       creds,
+		users:       users,
+		revocations: revocations,
+		rdb:         rdb,
+		issuer:      strings.TrimRight(cfg.LOCAL_AUTH_ISSUER, "/"),
+		privateKey:  privateKey,
+		keyID:       base64.RawURLEncoding.EncodeToString(keyHash[:16]),
+	}, nil
+}
+
+// PublicKey returns the token verification key and its key ID
+func (s *LocalAuthService) PublicKey() (string, *rsa.PublicKey) {
+	return s.keyID, &s.privateKey.PublicKey
+}
+
+func (s *LocalAuthService) Signup(input SignupInput) (string, error) {
+	if input.Email == "" || input.Password == "" || input.Role == "" {
+		return "", &InvalidParameterError{
+			Parameter: "input",
+			Message:   "email, password, and role are required",
+		}
+	}
+
+	hash, err := services.HashPassword(input.Password)
+	if err != nil {
+		if errors.Is(err, services.ErrPasswordTooShort) {
+			return "", &InvalidPasswordError{Message: err.Error()}
+		}
+		return "", err
+	}
+
+	credential := &model.Credential{
+		UserId:       uuid.New().String(),
+		Email:        input.Email,
+		Role:         input.Role,
+		PasswordHash: hash,
+	}
+	if err := s.setCode(context.TODO(), credential, verificationCodeTTL); err != nil {
+		return "", err
+	}
+	if err := s.creds.CreateCredential(credential); err != nil {
+		var alreadyExistsErr *db.AlreadyExistsError
+		if errors.As(err, &alreadyExistsErr) {
+			return "", &UserExistsError{Email: input.Email}
+		}
+		return "", err
+	}
+
+	log.Printf("✅ SignUp succeeded for %s, verification code: %s", input.Email, credential.Code)
+	return credential.UserId, nil
+}
+
+func (s *LocalAuthService) Confirm(input ConfirmInput) error {
+	if input.Email == "" || input.Code == "" {
+		return &InvalidParameterError{
+			Parameter: "input",
+			Message:   "email and confirmation code are required",
+		}
+	}
+
+	credential, err := s.getCredential(input.Email)
+	if err != nil {
+		return err
+	}
+	if credential.Confirmed {
+		return &InvalidParameterError{
+			Parameter: "email",
+			Message:   "user is already confirmed",
+		}
+	}
+	if err := s.checkCode(context.TODO(), credential, input.Code); err != nil {
+		return err
+	}
+
+	credential.Confirmed = true
+	credential.Code = ""
+	credential.CodeExpiresAt = time.Time{}
+	if err := s.creds.UpdateCredential(credential); err != nil {
+		return err
+	}
+
+	log.Printf("✅ ConfirmSignUp succeeded for %s", input.Email)
+	return nil
+}
+
+func (s *LocalAuthService) Login(input LoginInput) (*Tokens, error) {
+	if input.Email == "" || input.Password == "" {
+		return nil, &InvalidParameterError{
+			Parameter: "input",
+			Message:   "email and password are required",
+		}
+	}
+
+	credential, err := s.getCredential(input.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	ok, err := services.CheckPasswordHash(input.Password, credential.PasswordHash)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, &InvalidCredentialsError{}
+	}
+	if !credential.Confirmed {
+		return nil, &UserNotConfirmedError{Email: input.Email}
+	}
+	if err := s.checkSuspended(context.TODO(), credential.UserId); err != nil {
+		return nil, err
+	}
+
+	log.Printf("✅ Login successful for %s", input.Email)
+	return s.issueTokens(credential)
+}
+
+func (s *LocalAuthService) Refresh(refreshToken string) (*Tokens, error) {
+	if refreshToken == "" {
+		return nil, &InvalidParameterError{
+			Parameter: "refresh_token",
+			Message:   "refresh token is required",
+		}
+	}
+
+	// Rotate: GETDEL makes sure the presented token can only be used once
+	ctx := context.TODO()
+	key := refreshTokenKey(refreshToken)
+	email, err := s.rdb.GetDel(ctx, key).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, &InvalidCredentialsError{}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up refresh token: %w", err)
+	}
+
+	credential, err := s.getCredential(email)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.rdb.SRem(ctx, userRefreshTokensKey(credential.UserId), key).Err(); err != nil {
+		return nil, fmt.Errorf("failed to delete refresh token: %w", err)
+	}
+	if err := s.checkSuspended(ctx, credential.UserId); err != nil {
+		return nil, err
+	}
+
+	return s.issueTokens(credential)
+}
+
+func (s *LocalAuthService) RevokeRefreshToken(refreshToken string) error {
+	if refreshToken == "" {
+		return &InvalidParameterError{
+			Parameter: "refresh_token",
+			Message:   "refresh token is required",
+		}
+	}
+
+	ctx := context.TODO()
+	key := refreshTokenKey(refreshToken)
+	email, err := s.rdb.Get(ctx, key).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to look up refresh token: %w", err)
+	}
+
+	credential, err := s.getCredential(email)
+	if err != nil {
+		return err
+	}
+	return s.deleteRefreshToken(ctx, credential.UserId, key)
+}
+
+// SignOutEverywhere deletes every refresh token issued to the user
+func (s *LocalAuthService) SignOutEverywhere(userID string) error {
+	ctx := context.TODO()
+	userKey := userRefreshTokensKey(userID)
+
+	keys, err := s.rdb.SMembers(ctx, userKey).Result()
+	if err != nil {
+		return fmt.Errorf("failed to list refresh tokens: %w", err)
+	}
+	if err := s.rdb.Del(ctx, append(keys, userKey)...).Err(); err != nil {
+		return fmt.Errorf("failed to delete refresh tokens: %w", err)
+	}
+
+	log.Printf("✅ GlobalSignOut succeeded for %s", userID)
+	return nil
+}
+
+func (s *LocalAuthService) ForgotPassword(input ForgotPasswordInput) error {
+	if input.Email == "" {
+		return &InvalidParameterError{
+			Parameter: "email",
+			Message:   "email is required",
+		}
+	}
+
+	credential, err := s.getCredential(input.Email)
+	if err != nil {
+		return err
+	}
+
+	if err := s.setCode(context.TODO(), credential, resetCodeTTL); err != nil {
+		return err
+	}
+	if err := s.creds.UpdateCredential(credential); err != nil {
+		return err
+	}
+
+	log.Printf("✅ ForgotPassword code for %s: %s", input.Email, credential.Code)
+	return nil
+}
+
+func (s *LocalAuthService) ResetPassword(input ResetPasswordInput) error {
+	if input.Email == "" || input.Code == "" || input.NewPassword == "" {
+		return &InvalidParameterError{
+			Parameter: "input",
+			Message:   "email, confirmation code, and new password are required",
+		}
+	}
+
+	credential, err := s.getCredential(input.Email)
+	if err != nil {
+		return err
+	}
+	if err := s.checkCode(context.TODO(), credential, input.Code); err != nil {
+		return err
+	}
+
+	hash, err := services.HashPassword(input.NewPassword)
+	if err != nil {
+		if errors.Is(err, services.ErrPasswordTooShort) {
+			return &InvalidPasswordError{Message: err.Error()}
+		}
+		return err
+	}
+
+	credential.PasswordHash = hash
+	credential.Code = ""
+	credential.CodeExpiresAt = time.Time{}
+	if err := s.creds.UpdateCredential(credential); err != nil {
+		return err
+	}
+
+	log.Printf("✅ Password reset successful for %s", input.Email)
+	return nil
+}
+
+func (s *LocalAuthService) ResendConfirmationCode(input ResendCodeInput) error {
+	if input.Email == "" {
+		return &InvalidParameterError{
+			Parameter: "email",
+			Message:   "email is required",
+		}
+	}
+
+	credential, err := s.getCredential(input.Email)
+	if err != nil {
+		return err
+	}
+	if credential.Confirmed {
+		return &InvalidParameterError{
+			Parameter: "email",
+			Message:   "user is already confirmed",
+		}
+	}
+
+	if err := s.setCode(context.TODO(), credential, verificationCodeTTL); err != nil {
+		return err
+	}
+	if err := s.creds.UpdateCredential(credential); err != nil {
+		return err
+	}
+
+	log.Printf("✅ ResendConfirmationCode for %s: %s", input.Email, credential.Code)
+	return nil
+}
+
+// GetGoogleLoginURL returns "" because Google sign-in goes through the Cognito hosted UI
+func (s *LocalAuthService) GetGoogleLoginURL() string {
+	return ""
+}
+
+func (s *LocalAuthService) ExchangeGoogleCodeForToken(code string) (string, error) {
+	return "", &InvalidParameterError{
+		Parameter: "config",
+		Message:   "Google login is not available with the local auth provider",
+	}
+}
+
+// ParseToken verifies a token issued by this service and returns its claims
+func (s *LocalAuthService) ParseToken(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		return &s.privateKey.PublicKey, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}),
+		jwt.WithIssuer(s.issuer),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// issueTokens creates an ID token and a new refresh token for the credential
+func (s *LocalAuthService) issueTokens(credential *model.Credential) (*Tokens, error) {
+	idToken, err := s.issueToken(credential)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	refreshToken := base64.RawURLEncoding.EncodeToString(buf)
+
+	ctx := context.TODO()
+	key := refreshTokenKey(refreshToken)
+	userKey := userRefreshTokensKey(credential.UserId)
+	pipe := s.rdb.TxPipeline()
+	pipe.Set(ctx, key, credential.Email, LocalRefreshTokenTTL)
+	pipe.SAdd(ctx, userKey, key)
+	pipe.Expire(ctx, userKey, LocalRefreshTokenTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to store refresh token: %w", err)
+	}
+
+	return &Tokens{
+		IDToken:      idToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int32(LocalTokenTTL.Seconds()),
+	}, nil
+}
+
+func (s *LocalAuthService) deleteRefreshToken(ctx context.Context, userID, key string) error {
+	pipe := s.rdb.TxPipeline()
+	pipe.Del(ctx, key)
+	pipe.SRem(ctx, userRefreshTokensKey(userID), key)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to delete refresh token: %w", err)
+	}
+	return nil
+}
+
+// refreshTokenKey stores refresh tokens by hash so a Redis dump does not leak them
+func refreshTokenKey(refreshToken string) string {
+	sum := sha256.Sum256([]byte(refreshToken))
+	return "auth:refresh:" + hex.EncodeToString(sum[:])
+}
+
+func userRefreshTokensKey(userID string) string {
+	return "auth:refresh:user:" + userID
+}
+
+// issueToken signs an ID token carrying the same claims the middleware reads from Cognito tokens
+func (s *LocalAuthService) issueToken(credential *model.Credential) (string, error) {
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"sub":         credential.UserId,
+		"email":       credential.Email,
+		"custom:role": string(credential.Role),
+		"jti":         uuid.New().String(),
+		"iss":         s.issuer,
+		"aud":         s.issuer,
+		"token_use":   "id",
+		"iat":         float64(now.UnixMilli()) / 1000, // see RevocationList.RevokeUser
+		"exp":         now.Add(LocalTokenTTL).Unix(),
+	})
+	token.Header["kid"] = s.keyID
+
+	signed, err := token.SignedString(s.privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %w", err)
+	}
+	return signed, nil
+}
+
+// checkSuspended returns a UserSuspendedError when the account is suspended
+// in the database. The Redis entry that rejects its tokens is set again on
+// the way, in case Redis has lost it.
+func (s *LocalAuthService) checkSuspended(ctx context.Context, userID string) error {
+	user, err := s.users.GetUserByID(userID)
+	if err != nil {
+		return fmt.Errorf("failed to look up user: %w", err)
+	}
+	if user.SuspendedAt == nil {
+		return nil
+	}
+	if err := s.revocations.SuspendUser(ctx, userID); err != nil {
+		log.Printf("Warning: failed to restore the suspension of user %s: %v", userID, err)
+	}
+	return &UserSuspendedError{}
+}
+
+func (s *LocalAuthService) getCredential(email string) (*model.Credential, error) {
+	credential, err := s.creds.GetCredentialByEmail(email)
+	if err != nil {
+		if db.IsNotFoundError(err) {
+			return nil, &UserNotFoundError{Email: email}
+		}
+		return nil, err
+	}
+	return credential, nil
+}
+
+// setCode gives the credential a new code valid for ttl, without saving
+// it. The code starts with a fresh set of attempts; once maxCodesPerWindow
+// codes have been sent for the account in codeWindow a TooManyRequestsError
+// is returned instead.
+func (s *LocalAuthService) setCode(ctx context.Context, credential *model.Credential, ttl time.Duration) error {
+	key := codesSentKey(credential.UserId)
+	sent, err := s.rdb.Incr(ctx, key).Result()
+	if err != nil {
+		return fmt.Errorf("failed to count verification codes: %w", err)
+	}
+	if sent == 1 {
+		if err := s.rdb.Expire(ctx, key, codeWindow).Err(); err != nil {
+			return fmt.Errorf("failed to count verification codes: %w", err)
+		}
+	}
+	if sent > maxCodesPerWindow {
+		log.Printf("⚠️ Verification code limit reached for %s", credential.Email)
+		return &TooManyRequestsError{}
+	}
+
+	code, err := generateCode()
+	if err != nil {
+		return err
+	}
+	if err := s.rdb.Del(ctx, codeAttemptsKey(credential.UserId)).Err(); err != nil {
+		return fmt.Errorf("failed to reset code attempts: %w", err)
+	}
+	credential.Code = code
+	credential.CodeExpiresAt = time.Now().Add(ttl)
+	return nil
+}
+
+// checkCode compares code with the pending code of the credential. Every
+// guess uses up one of maxCodeAttempts, counted before comparing so that
+// concurrent guesses cannot go past the limit. The guess that uses up the
+// last attempt discards the code, and a new one has to be requested.
+func (s *LocalAuthService) checkCode(ctx context.Context, credential *model.Credential, code string) error {
+	if credential.Code == "" {
+		return &InvalidCodeError{}
+	}
+	if time.Now().After(credential.CodeExpiresAt) {
+		return &CodeExpiredError{}
+	}
+
+	key := codeAttemptsKey(credential.UserId)
+	pipe := s.rdb.TxPipeline()
+	attempts := pipe.Incr(ctx, key)
+	pipe.ExpireAt(ctx, key, credential.CodeExpiresAt)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to count code attempts: %w", err)
+	}
+	if attempts.Val() > maxCodeAttempts {
+		return &TooManyRequestsError{}
+	}
+
+	if subtle.ConstantTimeCompare([]byte(credential.Code), []byte(code)) == 1 {
+		if err := s.rdb.Del(ctx, key).Err(); err != nil {
+			log.Printf("WARNING: failed to reset code attempts for %s: %v", credential.Email, err)
+		}
+		return nil
+	}
+	if attempts.Val() < maxCodeAttempts {
+		return &InvalidCodeError{}
+	}
+
+	log.Printf("⚠️ Too many wrong codes for %s, discarding the code", credential.Email)
+	credential.Code = ""
+	credential.CodeExpiresAt = time.Time{}
+	if err := s.creds.UpdateCredential(credential); err != nil {
+		return err
+	}
+	return &TooManyRequestsError{}
+}
+
+func codeAttemptsKey(userID string) string {
+	return "auth:code:attempts:" + userID
+}
+
+func codesSentKey(userID string) string {
+	return "auth:code:sent:" + userID
+}
+
+// generateCode returns a random six digit verification code
+func generateCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate verification code: %w", err)
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
@@ -0,0 +1,20 @@
+package auth
+
+// Provider is an identity backend behind the /signup, /verify, /login,
+// /auth/refresh, /auth/logout and password reset routes. CognitoService and LocalAuthService implement it.
+type Provider interface {
+	Signup(input SignupInput) (string, error)
+	Confirm(input ConfirmInput) error
+	Login(input LoginInput) (*Tokens, error)
+	Refresh(refreshToken string) (*Tokens, error)
+	RevokeRefreshToken(refreshToken string) error
+	SignOutEverywhere(userID string) error
+	ForgotPassword(input ForgotPasswordInput) error
+	ResetPassword(input ResetPasswordInput) error
+	ResendConfirmationCode(input ResendCodeInput) error
+	GetGoogleLoginURL() string
+	ExchangeGoogleCodeForToken(code string) (string, error)
+	ParseToken(tokenString string) (*Claims, error)
+}
+
+var _ Provider = (*CognitoService)(nil)
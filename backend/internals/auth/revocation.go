@@ -0,0 +1,176 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/redis/go-redis/v9"
+	"sportsin_backend/internals/model"
+	"sportsin_backend/internals/store"
+)
+
+// MaxIDTokenLifetime bounds how long a revocation entry has to be kept.
+// Cognito ID tokens can be configured to live at most a day.
+const MaxIDTokenLifetime = 24 * time.Hour
+
+// suspensionsLoadedKey marks that the suspended accounts of the database
+// have been copied to Redis. Without it, as after Redis was flushed or
+// first started, the next check copies them again.
+const suspensionsLoadedKey = "auth:suspended:loaded"
+
+// suspensionsPageSize is the number of suspended accounts read at a time
+// when copying them to Redis
+const suspensionsPageSize = 500
+
+// RevocationList records ID tokens that must be rejected before they expire.
+// A single token is revoked by its jti; revoking a user rejects every token
+// issued to them up to that moment. Suspensions are kept in the database as
+// User.suspended_at and mirrored here, so that checking a token does not
+// need a query.
+type RevocationList struct {
+	client *redis.Client
+	users  store.AdminStore
+}
+
+// NewRevocationList returns a RevocationList that restores the suspensions
+// from users whenever Redis has lost them. With nil users only the Redis
+// entries are used.
+func NewRevocationList(client *redis.Client, users store.AdminStore) *RevocationList {
+	return &RevocationList{client: client, users: users}
+}
+
+// RevokeToken rejects the token with the given jti until it expires
+func (r *RevocationList) RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+	if err := r.client.Set(ctx, revokedTokenKey(jti), 1, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to revoke token: %w", err)
+	}
+	return nil
+}
+
+// RevokeUser rejects every token issued to userID at or before now. The
+// cutoff is kept in milliseconds, the precision of the iat of local tokens,
+// so that a token issued right after it, as on an immediate re-login, is
+// accepted. Cognito tokens carry a whole-second iat, so one of them issued
+// in the same second as the cutoff is still rejected.
+func (r *RevocationList) RevokeUser(ctx context.Context, userID string) error {
+	cutoff := strconv.FormatInt(time.Now().UnixMilli(), 10)
+	if err := r.client.Set(ctx, revokedUserKey(userID), cutoff, MaxIDTokenLifetime).Err(); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+	return nil
+}
+
+// SuspendUser rejects every token issued to userID until ReinstateUser is
+// called. Unlike RevokeUser this also covers tokens issued later on, so a
+// suspended account cannot simply log in again. The entry has no TTL: it
+// mirrors User.suspended_at, which is what must be set for the suspension
+// to survive a loss of Redis.
+func (r *RevocationList) SuspendUser(ctx context.Context, userID string) error {
+	if err := r.client.Set(ctx, suspendedUserKey(userID), 1, 0).Err(); err != nil {
+		return fmt.Errorf("failed to suspend user: %w", err)
+	}
+	return nil
+}
+
+// ReinstateUser lifts a suspension set by SuspendUser
+func (r *RevocationList) ReinstateUser(ctx context.Context, userID string) error {
+	if err := r.client.Del(ctx, suspendedUserKey(userID)).Err(); err != nil {
+		return fmt.Errorf("failed to reinstate user: %w", err)
+	}
+	return nil
+}
+
+// LoadSuspensions copies every suspension recorded in the database to
+// Redis. IsRevoked calls it when they are missing there.
+func (r *RevocationList) LoadSuspensions(ctx context.Context) error {
+	if r.users == nil {
+		return nil
+	}
+
+	suspended := true
+	filter := &model.UserFilter{Suspended: &suspended}
+	for offset := 0; ; offset += suspensionsPageSize {
+		users, err := r.users.ListUsers(filter, suspensionsPageSize, offset)
+		if err != nil {
+			return fmt.Errorf("failed to list suspended users: %w", err)
+		}
+		if len(users) > 0 {
+			pipe := r.client.Pipeline()
+			for _, u := range users {
+				pipe.Set(ctx, suspendedUserKey(u.Id), 1, 0)
+			}
+			if _, err := pipe.Exec(ctx); err != nil {
+				return fmt.Errorf("failed to restore suspensions: %w", err)
+			}
+		}
+		if len(users) < suspensionsPageSize {
+			break
+		}
+	}
+
+	if err := r.client.Set(ctx, suspensionsLoadedKey, 1, 0).Err(); err != nil {
+		return fmt.Errorf("failed to restore suspensions: %w", err)
+	}
+	return nil
+}
+
+// IsRevoked reports whether a validated token has been revoked
+func (r *RevocationList) IsRevoked(ctx context.Context, claims jwt.MapClaims) (bool, error) {
+	sub, _ := claims["sub"].(string)
+
+	keys := []string{suspendedUserKey(sub)}
+	if jti, ok := claims["jti"].(string); ok && jti != "" {
+		keys = append(keys, revokedTokenKey(jti))
+	}
+	pipe := r.client.Pipeline()
+	loaded := pipe.Exists(ctx, suspensionsLoadedKey)
+	revoked := pipe.Exists(ctx, keys...)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return false, fmt.Errorf("failed to check token revocation: %w", err)
+	}
+	if loaded.Val() == 0 && r.users != nil {
+		if err := r.LoadSuspensions(ctx); err != nil {
+			return false, err
+		}
+		n, err := r.client.Exists(ctx, keys...).Result()
+		if err != nil {
+			return false, fmt.Errorf("failed to check token revocation: %w", err)
+		}
+		revoked.SetVal(n)
+	}
+	if revoked.Val() > 0 {
+		return true, nil
+	}
+
+	cutoff, err := r.client.Get(ctx, revokedUserKey(sub)).Int64()
+	if errors.Is(err, redis.Nil) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to check session revocation: %w", err)
+	}
+
+	iat, ok := claims["iat"].(float64)
+	return !ok || int64(math.Round(iat*1000)) <= cutoff, nil
+}
+
+func revokedTokenKey(jti string) string {
+	return "auth:revoked:token:" + jti
+}
+
+func revokedUserKey(userID string) string {
+	return "auth:revoked:user:" + userID
+}
+
+func suspendedUserKey(userID string) string {
+	return "auth:suspended:user:" + userID
+}
@@ -23,6 +23,23 @@ type LoginInput struct {
 	Password string `json:"password"`
 }
 
+// Tokens is the result of a login or refresh. RefreshToken is long lived and
+// is exchanged at /auth/refresh for a new ID token.
+type Tokens struct {
+	IDToken      string `json:"id_token"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	ExpiresIn    int32  `json:"expires_in"` // ID token lifetime in seconds
+}
+
+type RefreshInput struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type LogoutInput struct {
+	RefreshToken string `json:"refresh_token,omitempty"`
+	AllSessions  bool   `json:"all_sessions"` // also sign out every other device
+}
+
 type CognitoService struct {
 	client        *cognitoidentityprovider.Client
 	userPoolID    string
@@ -46,9 +63,9 @@ type ResendCodeInput struct {
 }
 
 type Claims struct {
-	Email   string   `json:"email"`
-	Scope   string   `json:"scope"`
-	Subject string   `json:"sub"`
+	Email   string     `json:"email"`
+	Scope   string     `json:"scope"`
+	Subject string     `json:"sub"`
 	Role    model.Role `json:"custom:role"`
 	jwt.RegisteredClaims
-}
\ No newline at end of file
+}
@@ -5,9 +5,9 @@ import (
 	"log"
 	"time"
 
-	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
-	"sportsin_backend/internals/db/repositories"
+	"sportsin_backend/internals/model"
+	"sportsin_backend/internals/store"
 )
 
 const (
@@ -19,26 +19,65 @@ const (
 	pingPeriod = (pongWait * 9) / 10
 )
 
-// IncomingChatMessage defines the structure for messages received from the client.
+// EventType is the type of a chat protocol frame
+type EventType string
+
+const (
+	// EventMessage carries a chat message, in both directions
+	EventMessage EventType = "message"
+	// EventTyping tells the other user of a room that the sender is typing
+	EventTyping EventType = "typing"
+	// EventRead marks a room as read and tells the other user
+	EventRead EventType = "read"
+	// EventAck confirms to the sending connection that a message was stored
+	EventAck EventType = "ack"
+	// EventError reports a frame the server could not handle
+	EventError EventType = "error"
+	// EventPresence tells the users sharing a chat room with a user that
+	// they came online or went offline
+	EventPresence EventType = "presence"
+)
+
+// IncomingChatMessage defines the structure for frames received from the
+// client. A frame without a type is a message, as sent by clients that
+// predate the other types.
 type IncomingChatMessage struct {
-	RecipientID string `json:"recipient_id"`
-	Content     string `json:"content"`
+	Type EventType `json:"type"`
+	// ClientMessageID is generated by the client for each message. Resending
+	// a message with the same ID acks it again without storing it twice.
+	ClientMessageID string `json:"client_message_id,omitempty"`
+	// RecipientID starts or continues the one-to-one chat with a user;
+	// ChatRoomID addresses an existing room instead
+	RecipientID string `json:"recipient_id,omitempty"`
+	ChatRoomID  string `json:"chat_room_id,omitempty"`
+	Content     string `json:"content,omitempty"`
 }
 
-// OutgoingChatMessage defines the structure for messages sent to the client.
+// OutgoingChatMessage defines the structure for frames sent to the client.
+// Which fields are set depends on the type.
 type OutgoingChatMessage struct {
-	MessageID  string `json:"message_id"`
-	SenderID   string `json:"sender_id"`
-	Content    string `json:"content"`
-	SentAt     string `json:"sent_at"`
-	ChatRoomID string `json:"chat_room_id"`
+	Type            EventType            `json:"type"`
+	MessageID       string               `json:"message_id,omitempty"`
+	ClientMessageID string               `json:"client_message_id,omitempty"`
+	SenderID        string               `json:"sender_id,omitempty"`
+	Content         string               `json:"content,omitempty"`
+	SentAt          string               `json:"sent_at,omitempty"`
+	ChatRoomID      string               `json:"chat_room_id,omitempty"`
+	ReadAt          string               `json:"read_at,omitempty"`
+	Announcement    bool                 `json:"announcement,omitempty"`
+	UserID          string               `json:"user_id,omitempty"`
+	Status          model.PresenceStatus `json:"status,omitempty"`
+	LastSeen        string               `json:"last_seen,omitempty"`
+	Error           string               `json:"error,omitempty"`
 }
 
-// ReadPump pumps messages from the websocket connection to the hub.
-func (c *Client) ReadPump(repo *repositories.Repository) {
+// ReadPump pumps frames from the websocket connection to the hub.
+func (c *Client) ReadPump(repo store.ChatStore) {
+	c.Hub.connect(repo, c)
 	defer func() {
 		c.Hub.unregister <- c
 		c.Conn.Close()
+		c.Hub.disconnect(repo, c)
 	}()
 	c.Conn.SetReadDeadline(time.Now().Add(pongWait))
 	c.Conn.SetPongHandler(func(string) error { c.Conn.SetReadDeadline(time.Now().Add(pongWait)); return nil })
@@ -55,45 +94,20 @@ func (c *Client) ReadPump(repo *repositories.Repository) {
 		var msg IncomingChatMessage
 		if err := json.Unmarshal(message, &msg); err != nil {
 			log.Printf("Error decoding incoming message: %v", err)
+			c.replyError("", "invalid frame")
 			continue
 		}
 
-		senderID, _ := uuid.Parse(c.UserID)
-		recipientID, _ := uuid.Parse(msg.RecipientID)
-
-		// Find or create a chat room
-		room, err := repo.FindOrCreateChatRoom(senderID, recipientID)
-		if err != nil {
-			log.Printf("Could not find or create chat room: %v", err)
-			continue
-		}
-
-		// Save the message to the database
-		dbMsg, err := repo.CreateMessage(uuid.MustParse(room.Id), senderID, msg.Content)
-		if err != nil {
-			log.Printf("Failed to save message to database: %v", err)
-			continue
-		}
-
-		// Prepare the outgoing message payload
-		outgoingMsg := OutgoingChatMessage{
-			MessageID:  dbMsg.Id,
-			SenderID:   c.UserID,
-			Content:    dbMsg.Message,
-			SentAt:     dbMsg.CreatedAt,
-			ChatRoomID: dbMsg.ChatRoomId,
-		}
-
-		payload, err := json.Marshal(outgoingMsg)
-		if err != nil {
-			log.Printf("Error encoding outgoing message: %v", err)
-			continue
+		switch msg.Type {
+		case EventMessage, "":
+			c.handleMessage(repo, msg)
+		case EventTyping:
+			c.handleTyping(repo, msg)
+		case EventRead:
+			c.handleRead(repo, msg)
+		default:
+			c.replyError(msg.ClientMessageID, "unsupported frame type")
 		}
-
-		// Publish to the recipient's channel
-		c.Hub.PublishToUser(msg.RecipientID, payload)
-		// Also send the message back to the sender so their UI updates
-		c.Hub.PublishToUser(c.UserID, payload)
 	}
 }
 
@@ -116,14 +130,12 @@ func (c *Client) WritePump() {
 				c.Conn.WriteMessage(websocket.CloseMessage, []byte{})
 				return
 			}
-
-			w, err := c.Conn.NextWriter(websocket.TextMessage)
-			if err != nil {
+			if err := c.write(message); err != nil {
 				return
 			}
-			w.Write(message)
-
-			if err := w.Close(); err != nil {
+		case message := <-c.replies:
+			c.Conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.write(message); err != nil {
 				return
 			}
 		case <-ticker.C:
@@ -131,6 +143,16 @@ func (c *Client) WritePump() {
 			if err := c.Conn.WriteMessage(websocket.PingMessage, nil); err != nil {
 				return
 			}
+			c.Hub.heartbeat(c)
 		}
 	}
 }
+
+func (c *Client) write(message []byte) error {
+	w, err := c.Conn.NextWriter(websocket.TextMessage)
+	if err != nil {
+		return err
+	}
+	w.Write(message)
+	return w.Close()
+}
@@ -0,0 +1,224 @@
+package redis
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/model"
+	"sportsin_backend/internals/store"
+)
+
+// maxClientMessageIDLength matches the client_message_id column
+const maxClientMessageIDLength = 64
+
+// handleMessage stores a chat message, acks it to the sending connection and
+// delivers it to every connection of every member of the room
+func (c *Client) handleMessage(repo store.ChatStore, in IncomingChatMessage) {
+	if strings.TrimSpace(in.Content) == "" {
+		c.replyError(in.ClientMessageID, "content is required")
+		return
+	}
+	if len(in.ClientMessageID) > maxClientMessageIDLength {
+		c.replyError(in.ClientMessageID, "client_message_id is too long")
+		return
+	}
+	senderID := uuid.MustParse(c.UserID)
+
+	// A message resent after a lost ack is acked again but not delivered twice
+	if in.ClientMessageID != "" {
+		if existing, err := repo.GetMessageByClientId(senderID, in.ClientMessageID); err == nil {
+			c.ack(existing)
+			return
+		}
+	}
+
+	room, err := c.resolveRoom(repo, in, true)
+	if err != nil {
+		c.replyError(in.ClientMessageID, err.Error())
+		return
+	}
+
+	dbMsg, err := repo.CreateMessage(uuid.MustParse(room.Id), senderID, in.Content, in.ClientMessageID)
+	if db.IsAlreadyExistsError(err) {
+		// Another connection of the sender stored the same message first
+		if existing, err := repo.GetMessageByClientId(senderID, in.ClientMessageID); err == nil {
+			c.ack(existing)
+			return
+		}
+	}
+	if err != nil {
+		log.Printf("Failed to save message to database: %v", err)
+		c.replyError(in.ClientMessageID, "failed to send message")
+		return
+	}
+	c.ack(dbMsg)
+
+	c.Hub.PublishToRoom(repo, room.Id, OutgoingChatMessage{
+		Type:            EventMessage,
+		MessageID:       dbMsg.Id,
+		ClientMessageID: dbMsg.ClientMessageId,
+		SenderID:        c.UserID,
+		Content:         dbMsg.Message,
+		SentAt:          dbMsg.CreatedAt,
+		ChatRoomID:      dbMsg.ChatRoomId,
+	}, "")
+}
+
+// handleTyping tells the other members of a room that this user is typing.
+// Typing events are not stored.
+func (c *Client) handleTyping(repo store.ChatStore, in IncomingChatMessage) {
+	room, err := c.resolveRoom(repo, in, false)
+	if err != nil {
+		c.replyError("", err.Error())
+		return
+	}
+	c.Hub.PublishToRoom(repo, room.Id, OutgoingChatMessage{
+		Type:       EventTyping,
+		SenderID:   c.UserID,
+		ChatRoomID: room.Id,
+	}, c.UserID)
+}
+
+// handleRead marks the messages of a room as read and sends a read receipt
+// to the other members, and to this user's other connections so they can
+// clear their unread counts
+func (c *Client) handleRead(repo store.ChatStore, in IncomingChatMessage) {
+	room, err := c.resolveRoom(repo, in, false)
+	if err != nil {
+		c.replyError("", err.Error())
+		return
+	}
+	readAt, err := repo.MarkMessagesAsRead(uuid.MustParse(room.Id), uuid.MustParse(c.UserID))
+	if err != nil {
+		log.Printf("Error marking messages as read for user %s in room %s: %v", c.UserID, room.Id, err)
+		c.replyError("", "failed to mark messages as read")
+		return
+	}
+	c.Hub.PublishReadReceipt(repo, room.Id, c.UserID, readAt)
+}
+
+// resolveRoom returns the room a frame is addressed to. Frames name either a
+// room the user is in or, when create is set, a recipient to chat with.
+func (c *Client) resolveRoom(repo store.ChatStore, in IncomingChatMessage, create bool) (*model.ChatRoom, error) {
+	if in.ChatRoomID != "" {
+		roomID, err := uuid.Parse(in.ChatRoomID)
+		if err != nil {
+			return nil, errors.New("invalid chat_room_id")
+		}
+		room, err := repo.GetChatRoomById(roomID)
+		if err != nil {
+			return nil, errors.New("access denied to this chat room")
+		}
+		member, err := repo.IsUserInChatRoom(roomID, uuid.MustParse(c.UserID))
+		if err != nil || !member {
+			return nil, errors.New("access denied to this chat room")
+		}
+		return room, nil
+	}
+	if !create {
+		return nil, errors.New("chat_room_id is required")
+	}
+
+	recipientID, err := uuid.Parse(in.RecipientID)
+	if err != nil {
+		return nil, errors.New("recipient_id or chat_room_id is required")
+	}
+	room, err := repo.FindOrCreateChatRoom(uuid.MustParse(c.UserID), recipientID)
+	if err != nil {
+		log.Printf("Could not find or create chat room: %v", err)
+		return nil, errors.New("failed to find chat room")
+	}
+	return room, nil
+}
+
+// ack confirms a stored message to this connection with its persisted ID
+func (c *Client) ack(msg *model.ChatMessage) {
+	c.reply(OutgoingChatMessage{
+		Type:            EventAck,
+		MessageID:       msg.Id,
+		ClientMessageID: msg.ClientMessageId,
+		SentAt:          msg.CreatedAt,
+		ChatRoomID:      msg.ChatRoomId,
+	})
+}
+
+// replyError reports a frame that could not be handled to this connection
+func (c *Client) replyError(clientMessageID, reason string) {
+	c.reply(OutgoingChatMessage{
+		Type:            EventError,
+		ClientMessageID: clientMessageID,
+		Error:           reason,
+	})
+}
+
+func (c *Client) reply(event OutgoingChatMessage) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("Error encoding %s frame: %v", event.Type, err)
+		return
+	}
+	select {
+	case c.replies <- payload:
+	default:
+		log.Printf("Reply channel full for user %s, dropping %s frame", c.UserID, event.Type)
+	}
+}
+
+// PublishEvent sends a frame to every connection of the given users
+func (h *Hub) PublishEvent(event OutgoingChatMessage, userIDs ...string) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("Error encoding %s frame: %v", event.Type, err)
+		return
+	}
+	for _, userID := range userIDs {
+		h.PublishToUser(userID, payload)
+	}
+}
+
+// PublishToRoom sends a frame to every connection of the members of a room,
+// except those of the user except when it is set
+func (h *Hub) PublishToRoom(repo store.ChatStore, roomID string, event OutgoingChatMessage, except string) {
+	members, err := repo.GetChatRoomMembers(uuid.MustParse(roomID))
+	if err != nil {
+		log.Printf("Error getting members of chat room %s: %v", roomID, err)
+		return
+	}
+	var userIDs []string
+	for _, m := range members {
+		if m.UserId != except {
+			userIDs = append(userIDs, m.UserId)
+		}
+	}
+	h.PublishEvent(event, userIDs...)
+}
+
+// PublishAnnouncement delivers an announcement to every connection of every
+// member of its room, the sender's included
+func (h *Hub) PublishAnnouncement(repo store.ChatStore, msg *model.ChatMessage) {
+	h.PublishToRoom(repo, msg.ChatRoomId, OutgoingChatMessage{
+		Type:         EventMessage,
+		MessageID:    msg.Id,
+		SenderID:     msg.SenderId,
+		Content:      msg.Message,
+		SentAt:       msg.CreatedAt,
+		ChatRoomID:   msg.ChatRoomId,
+		Announcement: true,
+	}, "")
+}
+
+// PublishReadReceipt tells the members of a room that readerID has read it
+// up to readAt, their stored last_read_at. Messages sent up to then are read
+// by them.
+func (h *Hub) PublishReadReceipt(repo store.ChatStore, roomID, readerID, readAt string) {
+	h.PublishToRoom(repo, roomID, OutgoingChatMessage{
+		Type:       EventRead,
+		SenderID:   readerID,
+		ChatRoomID: roomID,
+		ReadAt:     readAt,
+	}, "")
+}
@@ -2,13 +2,15 @@ package redis
 
 import (
 	"context"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
 	"github.com/redis/go-redis/v9"
 	"log"
 )
 
 func NewHub(rdb *redis.Client) *Hub {
 	return &Hub{
-		clients:    make(map[string]*Client),
+		clients:    make(map[string]map[*Client]bool),
 		register:   make(chan *Client),
 		unregister: make(chan *Client),
 		rdb:        rdb,
@@ -16,31 +18,55 @@ func NewHub(rdb *redis.Client) *Hub {
 	}
 }
 
+// NewClient creates the client of a new connection from userID
+func NewClient(hub *Hub, userID string, conn *websocket.Conn) *Client {
+	return &Client{
+		id:      uuid.NewString(),
+		UserID:  userID,
+		Conn:    conn,
+		Send:    make(chan []byte, 256),
+		Hub:     hub,
+		replies: make(chan []byte, 16),
+		done:    make(chan struct{}),
+	}
+}
+
 func (h *Hub) Run() {
 	log.Println("Chat Hub started and running...")
 	for {
 		select {
 		case client := <-h.register:
 			h.mu.Lock()
-			h.clients[client.UserID] = client
+			conns, ok := h.clients[client.UserID]
+			if !ok {
+				conns = make(map[*Client]bool)
+				h.clients[client.UserID] = conns
+			}
+			conns[client] = true
 			h.mu.Unlock()
-			log.Printf("Client registered: %s (Total clients: %d)", client.UserID, len(h.clients))
+			log.Printf("Client registered: %s (%d connection(s) for user, %d user(s) online)", client.UserID, len(conns), len(h.clients))
 		case client := <-h.unregister:
 			h.mu.Lock()
-			if _, ok := h.clients[client.UserID]; ok {
-				delete(h.clients, client.UserID)
-				close(client.Send)
-				if client.Sub != nil {
-					client.Sub.Close()
+			conns := h.clients[client.UserID]
+			if conns[client] {
+				delete(conns, client)
+				if len(conns) == 0 {
+					delete(h.clients, client.UserID)
 				}
+				// Stops the client's Redis listener, which closes Send
+				close(client.done)
 			}
 			h.mu.Unlock()
-			log.Printf("Client unregistered: %s (Total clients: %d)", client.UserID, len(h.clients))
+			log.Printf("Client unregistered: %s (%d connection(s) left for user, %d user(s) online)", client.UserID, len(conns), len(h.clients))
 		}
 	}
 }
 
+// RegisterClient subscribes the client to its user's channel and adds it to
+// the hub. Subscribing before the pumps start means no message published
+// after the connection was accepted is missed.
 func (h *Hub) RegisterClient(client *Client) {
 	log.Printf("Attempting to register client: %s", client.UserID)
+	client.Sub = h.rdb.Subscribe(h.ctx, h.userChannel(client.UserID))
 	h.register <- client
 }
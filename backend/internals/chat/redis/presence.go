@@ -0,0 +1,154 @@
+package redis
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"sportsin_backend/internals/model"
+	"sportsin_backend/internals/store"
+)
+
+// presenceTTL is how long a connection counts as online after its last
+// heartbeat. Heartbeats go out with every ping, and a connection whose pong
+// is late by more than pongWait is dropped anyway.
+const presenceTTL = pongWait + writeWait
+
+// presenceKey names the hash of a user's live connections, across all
+// backend instances. It maps connection IDs to the unix time their heartbeat
+// runs out, so the connections of an instance that died without cleaning
+// up lapse on their own, and the key expires with the last of them.
+func presenceKey(userID string) string {
+	return fmt.Sprintf("presence:%s", userID)
+}
+
+// lastSeenKey names the time a user was last connected
+func lastSeenKey(userID string) string {
+	return fmt.Sprintf("last_seen:%s", userID)
+}
+
+// GetPresence returns whether each of userIDs is connected to the chat, on
+// any backend instance, and when they last were
+func (h *Hub) GetPresence(userIDs []string) ([]model.UserPresence, error) {
+	pipe := h.rdb.Pipeline()
+	conns := make([]*redis.MapStringStringCmd, len(userIDs))
+	lastSeen := make([]*redis.StringCmd, len(userIDs))
+	for i, userID := range userIDs {
+		conns[i] = pipe.HGetAll(h.ctx, presenceKey(userID))
+		lastSeen[i] = pipe.Get(h.ctx, lastSeenKey(userID))
+	}
+	if _, err := pipe.Exec(h.ctx); err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	presence := make([]model.UserPresence, len(userIDs))
+	for i, userID := range userIDs {
+		presence[i] = model.UserPresence{
+			UserID:   userID,
+			Online:   len(liveConnections(conns[i].Val(), now)) > 0,
+			LastSeen: lastSeen[i].Val(),
+		}
+	}
+	return presence, nil
+}
+
+// connect records a new connection of a client's user and, when the user was
+// offline until now, tells the users they share a chat room with
+func (h *Hub) connect(repo store.ChatStore, c *Client) {
+	key := presenceKey(c.UserID)
+	now := time.Now()
+	pipe := h.rdb.TxPipeline()
+	conns := pipe.HGetAll(h.ctx, key)
+	pipe.HSet(h.ctx, key, c.id, now.Add(presenceTTL).Unix())
+	pipe.Expire(h.ctx, key, presenceTTL)
+	pipe.Set(h.ctx, lastSeenKey(c.UserID), now.UTC().Format(time.RFC3339), 0)
+	if _, err := pipe.Exec(h.ctx); err != nil {
+		log.Printf("Error recording presence of user %s: %v", c.UserID, err)
+		return
+	}
+
+	live := liveConnections(conns.Val(), now)
+	if len(live) < len(conns.Val()) {
+		// Drop the connections of instances that died without cleaning up
+		var fields []string
+		for id := range conns.Val() {
+			if _, ok := live[id]; !ok {
+				fields = append(fields, id)
+			}
+		}
+		h.rdb.HDel(h.ctx, key, fields...)
+	}
+	if len(live) == 0 {
+		h.publishPresence(repo, c.UserID, true, now)
+	}
+}
+
+// heartbeat keeps a connection online for another presenceTTL
+func (h *Hub) heartbeat(c *Client) {
+	key := presenceKey(c.UserID)
+	now := time.Now()
+	pipe := h.rdb.TxPipeline()
+	pipe.HSet(h.ctx, key, c.id, now.Add(presenceTTL).Unix())
+	pipe.Expire(h.ctx, key, presenceTTL)
+	pipe.Set(h.ctx, lastSeenKey(c.UserID), now.UTC().Format(time.RFC3339), 0)
+	if _, err := pipe.Exec(h.ctx); err != nil {
+		log.Printf("Error refreshing presence of user %s: %v", c.UserID, err)
+	}
+}
+
+// disconnect removes a closed connection and, when it was the user's last
+// one, tells the users they share a chat room with
+func (h *Hub) disconnect(repo store.ChatStore, c *Client) {
+	key := presenceKey(c.UserID)
+	now := time.Now()
+	pipe := h.rdb.TxPipeline()
+	pipe.HDel(h.ctx, key, c.id)
+	conns := pipe.HGetAll(h.ctx, key)
+	pipe.Set(h.ctx, lastSeenKey(c.UserID), now.UTC().Format(time.RFC3339), 0)
+	if _, err := pipe.Exec(h.ctx); err != nil {
+		log.Printf("Error clearing presence of user %s: %v", c.UserID, err)
+		return
+	}
+	if len(liveConnections(conns.Val(), now)) == 0 {
+		h.publishPresence(repo, c.UserID, false, now)
+	}
+}
+
+// publishPresence sends a presence frame about userID to the users they
+// share a chat room with
+func (h *Hub) publishPresence(repo store.ChatStore, userID string, online bool, at time.Time) {
+	contacts, err := repo.GetChatContacts(uuid.MustParse(userID))
+	if err != nil {
+		log.Printf("Error getting chat contacts of user %s: %v", userID, err)
+		return
+	}
+	if len(contacts) == 0 {
+		return
+	}
+	status := model.PresenceOffline
+	if online {
+		status = model.PresenceOnline
+	}
+	h.PublishEvent(OutgoingChatMessage{
+		Type:     EventPresence,
+		UserID:   userID,
+		Status:   status,
+		LastSeen: at.UTC().Format(time.RFC3339),
+	}, contacts...)
+}
+
+// liveConnections returns the connections of a presence hash whose
+// heartbeat has not run out
+func liveConnections(conns map[string]string, now time.Time) map[string]string {
+	live := make(map[string]string, len(conns))
+	for id, deadline := range conns {
+		if unix, err := strconv.ParseInt(deadline, 10, 64); err == nil && unix > now.Unix() {
+			live[id] = deadline
+		}
+	}
+	return live
+}
@@ -21,29 +21,33 @@ func (h *Hub) userChannel(userID string) string {
 	return fmt.Sprintf("user:%s", userID)
 }
 
-// listenToRedis creates a subscription to the user's channel and forwards messages.
-// This is now started per-client.
+// listenToRedis forwards the messages of the client's subscription to its
+// Send channel until the hub unregisters the client. It is the only sender
+// on Send, so it is also the one to close it.
 func (c *Client) listenToRedis() {
-	channel := c.Hub.userChannel(c.UserID)
-	log.Printf("Starting Redis subscription for user %s on channel %s", c.UserID, channel)
-
-	c.Sub = c.Hub.rdb.Subscribe(c.Hub.ctx, channel)
+	log.Printf("Redis subscription active for user %s", c.UserID)
 	defer func() {
 		log.Printf("Closing Redis subscription for user %s", c.UserID)
 		c.Sub.Close()
+		close(c.Send)
 	}()
 
 	ch := c.Sub.Channel()
-	log.Printf("Redis subscription active for user %s", c.UserID)
-
-	for msg := range ch {
-		log.Printf("Received message from Redis for user %s: %s", c.UserID, msg.Payload)
-		// Forward message directly to the client's send channel
+	for {
 		select {
-		case c.Send <- []byte(msg.Payload):
-			log.Printf("Message forwarded to send channel for user %s", c.UserID)
-		default:
-			log.Printf("Send channel full for user %s, dropping message", c.UserID)
+		case <-c.done:
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			log.Printf("Received message from Redis for user %s: %s", c.UserID, msg.Payload)
+			select {
+			case c.Send <- []byte(msg.Payload):
+				log.Printf("Message forwarded to send channel for user %s", c.UserID)
+			default:
+				log.Printf("Send channel full for user %s, dropping message", c.UserID)
+			}
 		}
 	}
 }
@@ -7,16 +7,26 @@ import (
 	"sync"
 )
 
+// Client is a single WebSocket connection. A user signed in on several
+// devices has one Client per connection, each with its own subscription to
+// the user's channel.
 type Client struct {
+	// id tells the connections of a user apart in their presence
+	id     string
 	UserID string
 	Conn   *websocket.Conn
 	Send   chan []byte
 	Hub    *Hub
 	Sub    *redis.PubSub
+	// replies holds acks and errors meant for this connection only
+	replies chan []byte
+	// done is closed by the hub when the connection is unregistered
+	done chan struct{}
 }
 
 type Hub struct {
-	clients    map[string]*Client
+	// clients holds the open connections of each user, keyed by UserID
+	clients    map[string]map[*Client]bool
 	mu         sync.RWMutex
 	register   chan *Client
 	unregister chan *Client
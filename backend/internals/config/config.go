@@ -18,6 +18,20 @@ type Config struct {
 	AWS_PLATFORM_ARN     string // Added Platform ARN
 	AWS_TOPIC_ARN        string // Added Topic ARN
 	PORT                 string // Added Port
+	STORE_DRIVER         string // "postgres" (default) or "memory"
+	STORAGE_DRIVER       string // "s3" (default) or "local"
+	LOCAL_STORAGE_PATH   string // Directory used by the local storage driver
+	LOCAL_STORAGE_URL    string // Public base URL of this server, e.g. http://localhost:8080
+	LOCAL_STORAGE_SECRET string // Key used to sign local upload URLs
+	NOTIFIER_DRIVER      string // "sns" (default), "log" or "webhook"
+	NOTIFIER_WEBHOOK_URL string // Endpoint the webhook notifier POSTs to
+	AUTH_PROVIDER        string // "cognito" (default) or "local"
+	LOCAL_AUTH_ISSUER    string // Public base URL of this server, used as iss/aud of local tokens
+	LOCAL_AUTH_KEY_PATH  string // PEM encoded RSA private key; a temporary key is generated if empty
+	REPORT_HIDE_LIMIT    string // Reports that hide a post or comment until reviewed (default 5, "0" turns hiding off)
+	VIDEO_PROCESSOR      string // "ffmpeg" (default) or "stub", which fakes thumbnails without ffmpeg
+	MAX_VIDEO_MB         string // Largest post video upload in megabytes (default 100)
+	MAX_VIDEO_SECONDS    string // Longest post video in seconds (default 60)
 }
 
 func LoadConfig() *Config {
@@ -35,5 +49,19 @@ func LoadConfig() *Config {
 		AWS_PLATFORM_ARN:     os.Getenv("AWS_PLATFORM_ARN"),
 		AWS_TOPIC_ARN:        os.Getenv("AWS_TOPIC_ARN"),
 		PORT:                 os.Getenv("PORT"),
+		STORE_DRIVER:         os.Getenv("STORE_DRIVER"),
+		STORAGE_DRIVER:       os.Getenv("STORAGE_DRIVER"),
+		LOCAL_STORAGE_PATH:   os.Getenv("LOCAL_STORAGE_PATH"),
+		LOCAL_STORAGE_URL:    os.Getenv("LOCAL_STORAGE_URL"),
+		LOCAL_STORAGE_SECRET: os.Getenv("LOCAL_STORAGE_SECRET"),
+		NOTIFIER_DRIVER:      os.Getenv("NOTIFIER_DRIVER"),
+		NOTIFIER_WEBHOOK_URL: os.Getenv("NOTIFIER_WEBHOOK_URL"),
+		AUTH_PROVIDER:        os.Getenv("AUTH_PROVIDER"),
+		LOCAL_AUTH_ISSUER:    os.Getenv("LOCAL_AUTH_ISSUER"),
+		LOCAL_AUTH_KEY_PATH:  os.Getenv("LOCAL_AUTH_KEY_PATH"),
+		REPORT_HIDE_LIMIT:    os.Getenv("REPORT_HIDE_LIMIT"),
+		VIDEO_PROCESSOR:      os.Getenv("VIDEO_PROCESSOR"),
+		MAX_VIDEO_MB:         os.Getenv("MAX_VIDEO_MB"),
+		MAX_VIDEO_SECONDS:    os.Getenv("MAX_VIDEO_SECONDS"),
 	}
 }
@@ -61,6 +61,17 @@ func (e *AuthorizationError) Error() string {
 	return "unauthorized access"
 }
 
+// ConflictError represents a change refused because of the current state of
+// the data, such as deleting a row other rows still depend on
+type ConflictError struct {
+	Resource string
+	Reason   string
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s cannot be changed: %s", e.Resource, e.Reason)
+}
+
 // DatabaseError represents serious database-level errors
 type DatabaseError struct {
 	Operation string
@@ -131,6 +142,10 @@ func NewAuthorizationError(action, resource, userID string) *AuthorizationError
 	return &AuthorizationError{Action: action, Resource: resource, UserID: userID}
 }
 
+func NewConflictError(resource, reason string) *ConflictError {
+	return &ConflictError{Resource: resource, Reason: reason}
+}
+
 func NewDatabaseError(operation, table string, err error) *DatabaseError {
 	return &DatabaseError{Operation: operation, Table: table, Err: err}
 }
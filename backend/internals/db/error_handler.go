@@ -29,6 +29,7 @@ func ToHTTPError(err error) ErrorResponse {
 	var alreadyExistsErr *AlreadyExistsError
 	var validationErr *ValidationError
 	var authErr *AuthorizationError
+	var conflictErr *ConflictError
 	var dbErr *DatabaseError
 
 	switch {
@@ -62,6 +63,13 @@ func ToHTTPError(err error) ErrorResponse {
 			Message:    err.Error(),
 		}
 
+	case errors.As(err, &conflictErr):
+		return ErrorResponse{
+			StatusCode: http.StatusConflict,
+			Code:       "conflict",
+			Message:    err.Error(),
+		}
+
 	case errors.As(err, &dbErr):
 		// Don't expose internal database errors to users
 		return ErrorResponse{
@@ -104,6 +112,12 @@ func IsAlreadyExistsError(err error) bool {
 	return errors.As(err, &existsErr)
 }
 
+// IsConflictError checks if the error is a ConflictError
+func IsConflictError(err error) bool {
+	var conflictErr *ConflictError
+	return errors.As(err, &conflictErr)
+}
+
 // IsDatabaseError checks if the error is a DatabaseError
 func IsDatabaseError(err error) bool {
 	var dbErr *DatabaseError
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 
+	"sportsin_backend/internals/db"
 	"sportsin_backend/internals/model"
 )
 
@@ -67,27 +68,35 @@ func (repo *Repository) GetAchievementById(id string) (*model.Achievement, error
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("achievement not found")
+			return nil, db.NewNotFoundError("achievement", id)
 		}
 		log.Printf("Error fetching achievement: %v", err)
-		return nil, fmt.Errorf("failed to get achievement: %w", err)
+		return nil, db.NewDatabaseError("select", "Achievements", err)
 	}
 
 	_ = json.Unmarshal(statsJSON, &achievement.Stats)
 	return &achievement, nil
 }
 
-// GetAchievementsByUser returns a paginated list of achievements for a user
-func (repo *Repository) GetAchievementsByUser(userId string, limit, offset int) ([]model.Achievement, error) {
+// GetAchievementsByUser returns a paginated list of achievements for a user,
+// most recent date first. The cursor of an achievement is keyed on its date.
+func (repo *Repository) GetAchievementsByUser(userId string, limit, offset int, after *model.Cursor) ([]model.Achievement, error) {
+	where := `user_id = $1`
+	args := []any{userId, limit, offset}
+	if after != nil {
+		where += ` AND ` + afterCursor("date", "id", 4, true)
+		args = append(args, after.At, after.Id)
+		args[2] = 0
+	}
 	query := `
 		SELECT id, user_id, date, sport_id, tournament_title, description, level, stats, certificate_link
 		FROM "Achievements"
-		WHERE user_id = $1
-		ORDER BY date DESC
+		WHERE ` + where + `
+		ORDER BY date DESC, id DESC
 		LIMIT $2 OFFSET $3
 	`
 
-	rows, err := repo.DB.Query(query, userId, limit, offset)
+	rows, err := repo.DB.Query(query, args...)
 	if err != nil {
 		log.Printf("Error querying achievements: %v", err)
 		return nil, fmt.Errorf("failed to get achievements: %w", err)
@@ -231,19 +240,32 @@ func (repo *Repository) DeleteAchievement(id, userId string) error {
 }
 
 // DeleteAchievementById deletes an achievement by its ID (admin or system use)
-func (repo *Repository) DeleteAchievementById(id string) error {
+func (repo *Repository) DeleteAchievementById(id string, action *model.AdminAction) error {
+	tx, err := repo.DB.Begin()
+	if err != nil {
+		return db.NewDatabaseError("begin", "transaction", err)
+	}
+	defer tx.Rollback()
+
 	query := `DELETE FROM "Achievements" WHERE id = $1`
-	result, err := repo.DB.Exec(query, id)
+	result, err := tx.Exec(query, id)
 	if err != nil {
 		log.Printf("Error deleting achievement by id: %v", err)
-		return fmt.Errorf("failed to delete achievement: %w", err)
+		return db.NewDatabaseError("delete", "Achievements", err)
 	}
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("could not verify deletion: %w", err)
+		return db.NewDatabaseError("delete", "Achievements", err)
 	}
 	if rowsAffected == 0 {
-		return fmt.Errorf("achievement not found")
+		return db.NewNotFoundError("achievement", id)
+	}
+
+	if err := insertAdminAction(tx, action); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return db.NewDatabaseError("commit", "transaction", err)
 	}
 	return nil
 }
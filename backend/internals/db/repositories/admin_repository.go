@@ -0,0 +1,53 @@
+package repositories
+
+import (
+	"database/sql"
+	"log"
+
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/model"
+)
+
+// insertAdminAction adds action to the admin audit trail within tx, so the
+// entry commits or rolls back together with the change it describes. A nil
+// action records nothing, for when the same change is made outside /admin.
+func insertAdminAction(tx *sql.Tx, action *model.AdminAction) error {
+	if action == nil {
+		return nil
+	}
+	err := tx.QueryRow(`INSERT INTO "AdminAction" (admin_id, action, target_type, target_id, reason)
+	VALUES ($1, $2, $3, $4, $5) RETURNING id, created_at`,
+		action.AdminId, action.Action, action.TargetType, action.TargetId, action.Reason,
+	).Scan(&action.Id, &action.CreatedAt)
+	if err != nil {
+		log.Printf("ERROR: failed to create admin action: %v", err)
+		return db.NewDatabaseError("insert", "AdminAction", err)
+	}
+	return nil
+}
+
+func (repo *Repository) GetAdminActions(limit, offset int) ([]model.AdminAction, error) {
+	rows, err := repo.DB.Query(`SELECT id, admin_id, action, target_type, target_id, reason, created_at
+	FROM "AdminAction" ORDER BY created_at DESC LIMIT $1 OFFSET $2`, limit, offset)
+	if err != nil {
+		log.Printf("ERROR: failed to get admin actions: %v", err)
+		return nil, db.NewDatabaseError("select", "AdminAction", err)
+	}
+	defer rows.Close()
+
+	actions := []model.AdminAction{}
+	for rows.Next() {
+		var action model.AdminAction
+		if err := rows.Scan(&action.Id, &action.AdminId, &action.Action, &action.TargetType,
+			&action.TargetId, &action.Reason, &action.CreatedAt); err != nil {
+			log.Printf("ERROR: failed to scan admin action: %v", err)
+			return nil, db.NewDatabaseError("scan", "AdminAction", err)
+		}
+		actions = append(actions, action)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, db.NewDatabaseError("select", "AdminAction", err)
+	}
+
+	return actions, nil
+}
@@ -196,7 +196,7 @@ func (r *Repository) GetApplicationsByPlayerID(playerID string) ([]*model.Applic
 	return applications, nil
 }
 
-func (r *Repository) UpdateApplicationStatus(applicationID string, status model.ApplicationStatus) error {
+func (r *Repository) UpdateApplicationStatus(applicationID string, status model.ApplicationStatus, actor model.AuditActor) error {
 	// Validate input
 	if strings.TrimSpace(applicationID) == "" {
 		return db.NewValidationError("application_id", "application ID cannot be empty")
@@ -207,25 +207,37 @@ func (r *Repository) UpdateApplicationStatus(applicationID string, status model.
 		return db.NewValidationError("status", "invalid application status")
 	}
 
-	result, err := r.DB.Exec(
-		`UPDATE "Application" SET status = $1, updated_at = NOW() 
-		 WHERE id = $2`,
-		status, applicationID,
-	)
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return db.NewDatabaseError("begin", "transaction", err)
+	}
+	defer tx.Rollback()
 
+	var before, after []byte
+	err = tx.QueryRow(`SELECT row_to_json(a) FROM "Application" a WHERE id = $1 FOR UPDATE`, applicationID).Scan(&before)
 	if err != nil {
-		return db.NewDatabaseError("update", "Application", err)
+		if err == sql.ErrNoRows {
+			return db.NewNotFoundError("application", applicationID)
+		}
+		return db.NewDatabaseError("select", "Application", err)
 	}
 
-	rowsAffected, err := result.RowsAffected()
+	err = tx.QueryRow(
+		`UPDATE "Application" a SET status = $1, updated_at = NOW() 
+		 WHERE id = $2 RETURNING row_to_json(a)`,
+		status, applicationID,
+	).Scan(&after)
 	if err != nil {
-		return db.NewDatabaseError("check rows affected", "Application", err)
+		return db.NewDatabaseError("update", "Application", err)
 	}
 
-	if rowsAffected == 0 {
-		return db.NewNotFoundError("application", applicationID)
+	if err := insertAuditEvent(tx, actor, model.AuditApplicationStatusChanged, "application", applicationID, before, after); err != nil {
+		return err
 	}
 
+	if err := tx.Commit(); err != nil {
+		return db.NewDatabaseError("commit", "transaction", err)
+	}
 	return nil
 }
 
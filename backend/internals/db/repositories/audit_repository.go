@@ -0,0 +1,97 @@
+package repositories
+
+import (
+	"database/sql"
+	"log"
+	"strconv"
+	"strings"
+
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/model"
+)
+
+// insertAuditEvent writes an audit event inside the transaction that made
+// the change, so the change and its record commit or roll back together.
+// before and after are JSON snapshots of the affected row; either may be nil.
+func insertAuditEvent(tx *sql.Tx, actor model.AuditActor, action model.AuditAction, entityType, entityID string, before, after []byte) error {
+	_, err := tx.Exec(`INSERT INTO "AuditEvent" (actor_id, action, entity_type, entity_id, before_state, after_state, request_id)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		actor.UserId, action, entityType, entityID, nullJSON(before), nullJSON(after), actor.RequestId,
+	)
+	if err != nil {
+		log.Printf("ERROR: failed to create audit event: %v", err)
+		return db.NewDatabaseError("insert", "AuditEvent", err)
+	}
+	return nil
+}
+
+func (repo *Repository) GetAuditEvents(filter *model.AuditEventFilter, limit, offset int) ([]model.AuditEvent, error) {
+	query := `SELECT id, actor_id, action, entity_type, entity_id, before_state, after_state, request_id, created_at
+	FROM "AuditEvent"`
+
+	var conditions []string
+	var args []any
+	argIndex := 1
+
+	if filter.ActorId != nil {
+		conditions = append(conditions, "actor_id = $"+strconv.Itoa(argIndex))
+		args = append(args, *filter.ActorId)
+		argIndex++
+	}
+	if filter.Action != nil {
+		conditions = append(conditions, "action = $"+strconv.Itoa(argIndex))
+		args = append(args, *filter.Action)
+		argIndex++
+	}
+	if filter.EntityType != nil {
+		conditions = append(conditions, "entity_type = $"+strconv.Itoa(argIndex))
+		args = append(args, *filter.EntityType)
+		argIndex++
+	}
+	if filter.EntityId != nil {
+		conditions = append(conditions, "entity_id = $"+strconv.Itoa(argIndex))
+		args = append(args, *filter.EntityId)
+		argIndex++
+	}
+
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY created_at DESC LIMIT $" + strconv.Itoa(argIndex) + " OFFSET $" + strconv.Itoa(argIndex+1)
+	args = append(args, limit, offset)
+
+	rows, err := repo.DB.Query(query, args...)
+	if err != nil {
+		log.Printf("ERROR: failed to get audit events: %v", err)
+		return nil, db.NewDatabaseError("select", "AuditEvent", err)
+	}
+	defer rows.Close()
+
+	events := []model.AuditEvent{}
+	for rows.Next() {
+		var event model.AuditEvent
+		var before, after []byte
+		if err := rows.Scan(&event.Id, &event.ActorId, &event.Action, &event.EntityType, &event.EntityId,
+			&before, &after, &event.RequestId, &event.CreatedAt); err != nil {
+			log.Printf("ERROR: failed to scan audit event: %v", err)
+			return nil, db.NewDatabaseError("scan", "AuditEvent", err)
+		}
+		event.Before = before
+		event.After = after
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, db.NewDatabaseError("select", "AuditEvent", err)
+	}
+
+	return events, nil
+}
+
+// nullJSON passes a JSON document as text, since lib/pq would send a raw
+// []byte as bytea
+func nullJSON(raw []byte) any {
+	if raw == nil {
+		return nil
+	}
+	return string(raw)
+}
@@ -0,0 +1,238 @@
+package repositories
+
+import (
+	"database/sql"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/model"
+)
+
+// chatRoomMemberColumns are the columns of a "ChatRoomMember" as read by
+// scanChatRoomMember
+const chatRoomMemberColumns = `room_id, user_id, role, joined_at, last_read_at`
+
+func scanChatRoomMember(row interface{ Scan(dest ...any) error }, m *model.ChatRoomMember) error {
+	return row.Scan(&m.RoomId, &m.UserId, &m.Role, &m.JoinedAt, &m.LastReadAt)
+}
+
+// CreateGroupChatRoom creates a group room owned by ownerID with the given
+// members. Duplicate member IDs and the owner's own ID are ignored.
+func (r *Repository) CreateGroupChatRoom(room *model.ChatRoom, ownerID uuid.UUID, memberIDs []uuid.UUID) error {
+	members := uniqueMembers(ownerID, memberIDs)
+	if err := r.checkUsersExist(members); err != nil {
+		return err
+	}
+
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return db.NewDatabaseError("begin", "ChatRoom", err)
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO "ChatRoom" AS r (id, is_group, name, avatar_url, created_at) VALUES ($1, TRUE, $2, $3, $4) RETURNING ` + chatRoomColumns
+	if err := scanChatRoom(tx.QueryRow(query, uuid.New(), room.Name, room.AvatarUrl, time.Now().UTC()), room); err != nil {
+		log.Printf("Error creating group chat room: %v", err)
+		return db.NewDatabaseError("insert", "ChatRoom", err)
+	}
+
+	_, err = tx.Exec(`INSERT INTO "ChatRoomMember" (room_id, user_id, role) VALUES ($1, $2, 'owner')`, room.Id, ownerID)
+	if err != nil {
+		log.Printf("Error adding owner to chat room %s: %v", room.Id, err)
+		return db.NewDatabaseError("insert", "ChatRoomMember", err)
+	}
+	if len(members) > 0 {
+		_, err = tx.Exec(`INSERT INTO "ChatRoomMember" (room_id, user_id)
+		SELECT $1, unnest($2::uuid[])`, room.Id, pq.Array(uuidStrings(members)))
+		if err != nil {
+			log.Printf("Error adding members to chat room %s: %v", room.Id, err)
+			return db.NewDatabaseError("insert", "ChatRoomMember", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return db.NewDatabaseError("commit", "ChatRoom", err)
+	}
+
+	room.Members, err = r.GetChatRoomMembers(uuid.MustParse(room.Id))
+	return err
+}
+
+// UpdateChatRoom saves the name and avatar of a group room
+func (r *Repository) UpdateChatRoom(room *model.ChatRoom) error {
+	result, err := r.DB.Exec(`UPDATE "ChatRoom" SET name = $2, avatar_url = $3 WHERE id = $1 AND is_group`,
+		room.Id, room.Name, room.AvatarUrl)
+	if err != nil {
+		log.Printf("Error updating chat room %s: %v", room.Id, err)
+		return db.NewDatabaseError("update", "ChatRoom", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return db.NewNotFoundError("chat room", room.Id)
+	}
+	return nil
+}
+
+// GetChatRoomMembers returns the members of a room in the order they joined
+func (r *Repository) GetChatRoomMembers(roomID uuid.UUID) ([]model.ChatRoomMember, error) {
+	rows, err := r.DB.Query(`SELECT `+chatRoomMemberColumns+` FROM "ChatRoomMember"
+	WHERE room_id = $1 ORDER BY joined_at, user_id`, roomID)
+	if err != nil {
+		log.Printf("Error getting members of chat room %s: %v", roomID, err)
+		return nil, db.NewDatabaseError("select", "ChatRoomMember", err)
+	}
+	defer rows.Close()
+
+	members := []model.ChatRoomMember{}
+	for rows.Next() {
+		var m model.ChatRoomMember
+		if err := scanChatRoomMember(rows, &m); err != nil {
+			log.Printf("Error scanning chat room member: %v", err)
+			return nil, db.NewDatabaseError("scan", "ChatRoomMember", err)
+		}
+		members = append(members, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, db.NewDatabaseError("select", "ChatRoomMember", err)
+	}
+	return members, nil
+}
+
+// GetChatRoomMember returns a user's membership of a room
+func (r *Repository) GetChatRoomMember(roomID, userID uuid.UUID) (*model.ChatRoomMember, error) {
+	var m model.ChatRoomMember
+	err := scanChatRoomMember(r.DB.QueryRow(`SELECT `+chatRoomMemberColumns+` FROM "ChatRoomMember"
+	WHERE room_id = $1 AND user_id = $2`, roomID, userID), &m)
+	if err == sql.ErrNoRows {
+		return nil, db.NewNotFoundError("chat room member", userID.String())
+	}
+	if err != nil {
+		log.Printf("Error getting member %s of chat room %s: %v", userID, roomID, err)
+		return nil, db.NewDatabaseError("select", "ChatRoomMember", err)
+	}
+	return &m, nil
+}
+
+// AddChatRoomMember adds a user to a group room as a member
+func (r *Repository) AddChatRoomMember(roomID, userID uuid.UUID) (*model.ChatRoomMember, error) {
+	if err := r.checkUsersExist([]uuid.UUID{userID}); err != nil {
+		return nil, err
+	}
+
+	var m model.ChatRoomMember
+	err := scanChatRoomMember(r.DB.QueryRow(`INSERT INTO "ChatRoomMember" (room_id, user_id)
+	SELECT id, $2 FROM "ChatRoom" WHERE id = $1 AND is_group
+	ON CONFLICT (room_id, user_id) DO NOTHING
+	RETURNING `+chatRoomMemberColumns, roomID, userID), &m)
+	if err == sql.ErrNoRows {
+		if _, err := r.GetChatRoomMember(roomID, userID); err == nil {
+			return nil, db.NewAlreadyExistsError("chat room member", "user_id", userID.String())
+		}
+		return nil, db.NewNotFoundError("chat room", roomID.String())
+	}
+	if err != nil {
+		log.Printf("Error adding member %s to chat room %s: %v", userID, roomID, err)
+		return nil, db.NewDatabaseError("insert", "ChatRoomMember", err)
+	}
+	return &m, nil
+}
+
+// UpdateChatRoomMemberRole changes the role of a member of a room
+func (r *Repository) UpdateChatRoomMemberRole(roomID, userID uuid.UUID, role model.ChatMemberRole) error {
+	result, err := r.DB.Exec(`UPDATE "ChatRoomMember" SET role = $3 WHERE room_id = $1 AND user_id = $2`, roomID, userID, role)
+	if err != nil {
+		log.Printf("Error updating role of member %s of chat room %s: %v", userID, roomID, err)
+		return db.NewDatabaseError("update", "ChatRoomMember", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return db.NewNotFoundError("chat room member", userID.String())
+	}
+	return nil
+}
+
+// RemoveChatRoomMember removes a user from a group room. When the owner
+// leaves, the longest standing admin, or else member, becomes the owner, and
+// a room left without members is deleted.
+func (r *Repository) RemoveChatRoomMember(roomID, userID uuid.UUID) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return db.NewDatabaseError("begin", "ChatRoomMember", err)
+	}
+	defer tx.Rollback()
+
+	var role model.ChatMemberRole
+	err = tx.QueryRow(`DELETE FROM "ChatRoomMember" m USING "ChatRoom" r
+	WHERE r.id = m.room_id AND r.is_group AND m.room_id = $1 AND m.user_id = $2
+	RETURNING m.role`, roomID, userID).Scan(&role)
+	if err == sql.ErrNoRows {
+		return db.NewNotFoundError("chat room member", userID.String())
+	}
+	if err != nil {
+		log.Printf("Error removing member %s from chat room %s: %v", userID, roomID, err)
+		return db.NewDatabaseError("delete", "ChatRoomMember", err)
+	}
+
+	if role == model.ChatRoleOwner {
+		_, err = tx.Exec(`UPDATE "ChatRoomMember" SET role = 'owner'
+		WHERE room_id = $1 AND user_id = (
+			SELECT user_id FROM "ChatRoomMember" WHERE room_id = $1
+			ORDER BY role = 'admin' DESC, joined_at, user_id LIMIT 1
+		)`, roomID)
+		if err != nil {
+			log.Printf("Error handing over chat room %s: %v", roomID, err)
+			return db.NewDatabaseError("update", "ChatRoomMember", err)
+		}
+	}
+	_, err = tx.Exec(`DELETE FROM "ChatRoom" WHERE id = $1
+	AND NOT EXISTS (SELECT 1 FROM "ChatRoomMember" WHERE room_id = $1)`, roomID)
+	if err != nil {
+		log.Printf("Error deleting empty chat room %s: %v", roomID, err)
+		return db.NewDatabaseError("delete", "ChatRoom", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return db.NewDatabaseError("commit", "ChatRoomMember", err)
+	}
+	return nil
+}
+
+// checkUsersExist returns a NotFoundError naming the first of userIDs that
+// is not a user
+func (r *Repository) checkUsersExist(userIDs []uuid.UUID) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	var missing string
+	err := r.DB.QueryRow(`SELECT t.id FROM unnest($1::uuid[]) AS t(id)
+	WHERE NOT EXISTS (SELECT 1 FROM "User" u WHERE u.id = t.id) LIMIT 1`, pq.Array(uuidStrings(userIDs))).Scan(&missing)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		log.Printf("Error checking users exist: %v", err)
+		return db.NewDatabaseError("select", "User", err)
+	}
+	return db.NewNotFoundError("user", missing)
+}
+
+// uniqueMembers returns memberIDs without duplicates and without the owner
+func uniqueMembers(ownerID uuid.UUID, memberIDs []uuid.UUID) []uuid.UUID {
+	seen := map[uuid.UUID]bool{ownerID: true}
+	var members []uuid.UUID
+	for _, id := range memberIDs {
+		if !seen[id] {
+			seen[id] = true
+			members = append(members, id)
+		}
+	}
+	return members
+}
+
+func uuidStrings(ids []uuid.UUID) []string {
+	strs := make([]string, len(ids))
+	for i, id := range ids {
+		strs[i] = id.String()
+	}
+	return strs
+}
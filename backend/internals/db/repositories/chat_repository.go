@@ -6,9 +6,33 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"sportsin_backend/internals/db"
 	"sportsin_backend/internals/model"
 )
 
+// chatRoomColumns are the columns of a "ChatRoom" aliased r, as read by
+// scanChatRoom. user1 and user2 are NULL for group rooms.
+const chatRoomColumns = `r.id, COALESCE(r.user1::text, ''), COALESCE(r.user2::text, ''), r.created_at, r.last_message_at, r.is_group, r.name, r.avatar_url, r.tournament_id`
+
+// scanChatRoom reads chatRoomColumns into room, followed by any extra
+// columns the query selects
+func scanChatRoom(row interface{ Scan(dest ...any) error }, room *model.ChatRoom, extra ...any) error {
+	dest := []any{&room.Id, &room.User1, &room.User2, &room.CreatedAt, &room.LastMessageAt, &room.IsGroup, &room.Name, &room.AvatarUrl, &room.TournamentId}
+	return row.Scan(append(dest, extra...)...)
+}
+
+// messageColumns are the columns of a "Messages" aliased m, as read by
+// scanMessage. A message is read once no member other than its sender last
+// read the room before it was sent.
+const messageColumns = `m.id, m.chat_room_id, m.sent_from, m.content, m.sent_at,
+	NOT EXISTS (SELECT 1 FROM "ChatRoomMember" cm WHERE cm.room_id = m.chat_room_id AND cm.user_id != m.sent_from
+		AND (cm.last_read_at IS NULL OR cm.last_read_at < m.sent_at)),
+	COALESCE(m.client_message_id, ''), m.announcement`
+
+func scanMessage(row interface{ Scan(dest ...any) error }, msg *model.ChatMessage) error {
+	return row.Scan(&msg.Id, &msg.ChatRoomId, &msg.SenderId, &msg.Message, &msg.CreatedAt, &msg.Read, &msg.ClientMessageId, &msg.Announcement)
+}
+
 // Find or create a chat room between two users
 func (r *Repository) FindOrCreateChatRoom(user1ID, user2ID uuid.UUID) (*model.ChatRoom, error) {
 	log.Printf("[DEBUG] FindOrCreateChatRoom called with user1ID: %s, user2ID: %s", user1ID, user2ID)
@@ -19,25 +43,32 @@ func (r *Repository) FindOrCreateChatRoom(user1ID, user2ID uuid.UUID) (*model.Ch
 	log.Printf("[DEBUG] Ordered user IDs: user1ID: %s, user2ID: %s", user1ID, user2ID)
 
 	room := &model.ChatRoom{}
-	var lastMessageAt sql.NullString
-	query := `SELECT id, user1, user2, created_at, last_message_at FROM "ChatRoom" WHERE user1 = $1 AND user2 = $2`
-	row := r.DB.QueryRow(query, user1ID, user2ID)
-	err := row.Scan(&room.Id, &room.User1, &room.User2, &room.CreatedAt, &lastMessageAt)
-	room.LastMessageAt = lastMessageAt
+	query := `SELECT ` + chatRoomColumns + ` FROM "ChatRoom" r WHERE r.user1 = $1 AND r.user2 = $2`
+	err := scanChatRoom(r.DB.QueryRow(query, user1ID, user2ID), room)
 
 	if err == sql.ErrNoRows {
 		roomID := uuid.New().String()
 		constraintKey := user1ID.String() + ":" + user2ID.String()
 		log.Printf("[DEBUG] Creating new chat room with id: %s for users %s and %s, constraint_key: %s", roomID, user1ID, user2ID, constraintKey)
-		var lastMessageAt sql.NullString
-		insertQuery := `INSERT INTO "ChatRoom" (id, user1, user2, created_at, constraint_user1_user2) VALUES ($1, $2, $3, $4, $5) RETURNING id, user1, user2, created_at, last_message_at`
-		err = r.DB.QueryRow(insertQuery, roomID, user1ID, user2ID, time.Now().UTC(), constraintKey).Scan(&room.Id, &room.User1, &room.User2, &room.CreatedAt, &lastMessageAt)
-		room.LastMessageAt = lastMessageAt
+		tx, err := r.DB.Begin()
+		if err != nil {
+			return nil, err
+		}
+		defer tx.Rollback()
+
+		insertQuery := `INSERT INTO "ChatRoom" AS r (id, user1, user2, created_at, constraint_user1_user2) VALUES ($1, $2, $3, $4, $5) RETURNING ` + chatRoomColumns
+		err = scanChatRoom(tx.QueryRow(insertQuery, roomID, user1ID, user2ID, time.Now().UTC(), constraintKey), room)
 		if err != nil {
 			log.Printf("[ERROR] Error creating chat room for users %s and %s: %v", user1ID, user2ID, err)
 			return nil, err
 		}
-		return room, nil
+		// Both users are members, which is what access checks go by
+		_, err = tx.Exec(`INSERT INTO "ChatRoomMember" (room_id, user_id) VALUES ($1, $2), ($1, $3) ON CONFLICT DO NOTHING`, roomID, user1ID, user2ID)
+		if err != nil {
+			log.Printf("[ERROR] Error adding members to chat room %s: %v", roomID, err)
+			return nil, err
+		}
+		return room, tx.Commit()
 	} else if err != nil {
 		log.Printf("[ERROR] Error finding chat room for users %s and %s: %v", user1ID, user2ID, err)
 		return nil, err
@@ -47,8 +78,34 @@ func (r *Repository) FindOrCreateChatRoom(user1ID, user2ID uuid.UUID) (*model.Ch
 	return room, nil
 }
 
-// Create a chat message in a chat room
-func (r *Repository) CreateMessage(roomID, senderID uuid.UUID, content string) (*model.ChatMessage, error) {
+// GetChatRoomById returns a chat room by its ID
+func (r *Repository) GetChatRoomById(roomID uuid.UUID) (*model.ChatRoom, error) {
+	room := &model.ChatRoom{}
+	query := `SELECT ` + chatRoomColumns + ` FROM "ChatRoom" r WHERE r.id = $1`
+	err := scanChatRoom(r.DB.QueryRow(query, roomID), room)
+	if err == sql.ErrNoRows {
+		return nil, db.NewNotFoundError("chat room", roomID.String())
+	}
+	if err != nil {
+		log.Printf("Error getting chat room %s: %v", roomID, err)
+		return nil, db.NewDatabaseError("select", "ChatRoom", err)
+	}
+	return room, nil
+}
+
+// Create a chat message in a chat room. clientMessageID is optional; a
+// sender reusing one gets an AlreadyExistsError and nothing is stored.
+func (r *Repository) CreateMessage(roomID, senderID uuid.UUID, content, clientMessageID string) (*model.ChatMessage, error) {
+	return r.createMessage(roomID, senderID, content, clientMessageID, false)
+}
+
+// CreateAnnouncement stores a message the host broadcasts to the channel of
+// a tournament
+func (r *Repository) CreateAnnouncement(roomID, senderID uuid.UUID, content string) (*model.ChatMessage, error) {
+	return r.createMessage(roomID, senderID, content, "", true)
+}
+
+func (r *Repository) createMessage(roomID, senderID uuid.UUID, content, clientMessageID string, announcement bool) (*model.ChatMessage, error) {
 	tx, err := r.DB.Begin()
 	if err != nil {
 		return nil, err
@@ -59,8 +116,13 @@ func (r *Repository) CreateMessage(roomID, senderID uuid.UUID, content string) (
 	sentAt := time.Now().UTC()
 	msgID := uuid.New().String()
 
-	msgQuery := `INSERT INTO "Messages" (id, chat_room_id, sent_from, content, sent_at) VALUES ($1, $2, $3, $4, $5) RETURNING id, chat_room_id, sent_from, content, sent_at, read_status`
-	err = tx.QueryRow(msgQuery, msgID, roomID, senderID, content, sentAt).Scan(&msg.Id, &msg.ChatRoomId, &msg.SenderId, &msg.Message, &msg.CreatedAt, &msg.Read)
+	msgQuery := `INSERT INTO "Messages" AS m (id, chat_room_id, sent_from, content, sent_at, client_message_id, announcement) VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7)
+	ON CONFLICT (sent_from, client_message_id) WHERE client_message_id IS NOT NULL DO NOTHING
+	RETURNING ` + messageColumns
+	err = scanMessage(tx.QueryRow(msgQuery, msgID, roomID, senderID, content, sentAt, clientMessageID, announcement), msg)
+	if err == sql.ErrNoRows {
+		return nil, db.NewAlreadyExistsError("message", "client_message_id", clientMessageID)
+	}
 	if err != nil {
 		log.Printf("Error creating message in room %s: %v", roomID, err)
 		return nil, err
@@ -72,14 +134,45 @@ func (r *Repository) CreateMessage(roomID, senderID uuid.UUID, content string) (
 		log.Printf("Error updating last_message_at for room %s: %v", roomID, err)
 		return nil, err
 	}
+	// Whoever sends a message has read the room up to it
+	var readAt string
+	if err := tx.QueryRow(markReadQuery, roomID, senderID, sentAt).Scan(&readAt); err != nil && err != sql.ErrNoRows {
+		log.Printf("Error updating last_read_at of user %s in room %s: %v", senderID, roomID, err)
+		return nil, err
+	}
 
 	return msg, tx.Commit()
 }
 
-// GetMessagesForRoom returns messages for a chat room
-func (r *Repository) GetMessagesForRoom(roomID uuid.UUID, limit, offset int) ([]model.ChatMessage, error) {
-	query := `SELECT id, chat_room_id, sent_from, content, sent_at, read_status FROM "Messages" WHERE chat_room_id = $1 ORDER BY sent_at ASC LIMIT $2 OFFSET $3`
-	rows, err := r.DB.Query(query, roomID, limit, offset)
+// GetMessageByClientId returns the message a sender stored under a client
+// generated ID
+func (r *Repository) GetMessageByClientId(senderID uuid.UUID, clientMessageID string) (*model.ChatMessage, error) {
+	msg := &model.ChatMessage{}
+	query := `SELECT ` + messageColumns + ` FROM "Messages" m WHERE m.sent_from = $1 AND m.client_message_id = $2`
+	err := scanMessage(r.DB.QueryRow(query, senderID, clientMessageID), msg)
+	if err == sql.ErrNoRows {
+		return nil, db.NewNotFoundError("message", clientMessageID)
+	}
+	if err != nil {
+		log.Printf("Error getting message %s of user %s: %v", clientMessageID, senderID, err)
+		return nil, db.NewDatabaseError("select", "Messages", err)
+	}
+	return msg, nil
+}
+
+// GetMessagesForRoom returns messages for a chat room, oldest first. Passing
+// the cursor of the last message seen returns only the messages sent after
+// it, so pages do not shift while new messages arrive.
+func (r *Repository) GetMessagesForRoom(roomID uuid.UUID, limit, offset int, after *model.Cursor) ([]model.ChatMessage, error) {
+	where := `m.chat_room_id = $1`
+	args := []any{roomID, limit, offset}
+	if after != nil {
+		where += ` AND ` + afterCursor("m.sent_at", "m.id", 4, false)
+		args = append(args, after.At, after.Id)
+		args[2] = 0
+	}
+	query := `SELECT ` + messageColumns + ` FROM "Messages" m WHERE ` + where + ` ORDER BY m.sent_at ASC, m.id ASC LIMIT $2 OFFSET $3`
+	rows, err := r.DB.Query(query, args...)
 	if err != nil {
 		log.Printf("Error getting messages for room %s: %v", roomID, err)
 		return nil, err
@@ -89,31 +182,45 @@ func (r *Repository) GetMessagesForRoom(roomID uuid.UUID, limit, offset int) ([]
 	var messages []model.ChatMessage
 	for rows.Next() {
 		var msg model.ChatMessage
-		var readStatus bool
-		err := rows.Scan(&msg.Id, &msg.ChatRoomId, &msg.SenderId, &msg.Message, &msg.CreatedAt, &readStatus)
-		if err != nil {
+		if err := scanMessage(rows, &msg); err != nil {
 			log.Printf("Error scanning message row: %v", err)
 			continue
 		}
-		msg.Read = readStatus
 		messages = append(messages, msg)
 	}
 	return messages, nil
 }
 
-// MarkMessagesAsRead marks all unread messages sent by another user in a room as read
-func (r *Repository) MarkMessagesAsRead(roomID, readerID uuid.UUID) error {
-	query := `UPDATE "Messages" SET read_status = TRUE WHERE chat_room_id = $1 AND sent_from != $2 AND read_status = FALSE`
-	_, err := r.DB.Exec(query, roomID, readerID)
+// MarkMessagesAsRead records that a member has read a room up to now and
+// returns the member's last_read_at
+func (r *Repository) MarkMessagesAsRead(roomID, readerID uuid.UUID) (string, error) {
+	var readAt string
+	err := r.DB.QueryRow(markReadQuery, roomID, readerID, time.Now().UTC()).Scan(&readAt)
+	if err == sql.ErrNoRows {
+		return "", db.NewNotFoundError("chat room member", readerID.String())
+	}
 	if err != nil {
 		log.Printf("Error marking messages as read in room %s for user %s: %v", roomID, readerID, err)
+		return "", db.NewDatabaseError("update", "ChatRoomMember", err)
 	}
-	return err
+	return readAt, nil
 }
 
-// GetChatRoomsForUser returns all chat rooms for a given user
+// markReadQuery moves the last_read_at of member $2 of room $1 forward to $3.
+// It never moves it back, so a late request cannot mark read messages unread
+// again.
+const markReadQuery = `UPDATE "ChatRoomMember" SET last_read_at = GREATEST(last_read_at, $3)
+	WHERE room_id = $1 AND user_id = $2 RETURNING last_read_at`
+
+// GetChatRoomsForUser returns all chat rooms a user is a member of, with the
+// number of messages the user has not read in each
 func (r *Repository) GetChatRoomsForUser(userID uuid.UUID) ([]model.ChatRoom, error) {
-	query := `SELECT id, user1, user2, created_at, last_message_at FROM "ChatRoom" WHERE user1 = $1 OR user2 = $1 ORDER BY last_message_at DESC, created_at DESC`
+	query := `SELECT ` + chatRoomColumns + `,
+		(SELECT COUNT(*) FROM "Messages" msg WHERE msg.chat_room_id = r.id AND msg.sent_from != m.user_id
+			AND (m.last_read_at IS NULL OR msg.sent_at > m.last_read_at))
+	FROM "ChatRoom" r
+	JOIN "ChatRoomMember" m ON m.room_id = r.id
+	WHERE m.user_id = $1 ORDER BY r.last_message_at DESC, r.created_at DESC`
 	rows, err := r.DB.Query(query, userID)
 	if err != nil {
 		log.Printf("Error getting chat rooms for user %s: %v", userID, err)
@@ -124,7 +231,7 @@ func (r *Repository) GetChatRoomsForUser(userID uuid.UUID) ([]model.ChatRoom, er
 	var rooms []model.ChatRoom
 	for rows.Next() {
 		var room model.ChatRoom
-		err := rows.Scan(&room.Id, &room.User1, &room.User2, &room.CreatedAt, &room.LastMessageAt)
+		err := scanChatRoom(rows, &room, &room.UnreadCount)
 		if err != nil {
 			log.Printf("Error scanning chat room row: %v", err)
 			continue
@@ -136,7 +243,7 @@ func (r *Repository) GetChatRoomsForUser(userID uuid.UUID) ([]model.ChatRoom, er
 
 // ADDITIONAL FIX: Add authorization check
 func (r *Repository) IsUserInChatRoom(roomID, userID uuid.UUID) (bool, error) {
-	query := `SELECT 1 FROM "ChatRoom" WHERE id = $1 AND (user1 = $2 OR user2 = $2)`
+	query := `SELECT 1 FROM "ChatRoomMember" WHERE room_id = $1 AND user_id = $2`
 	var exists int
 	err := r.DB.QueryRow(query, roomID, userID).Scan(&exists)
 	if err == sql.ErrNoRows {
@@ -148,6 +255,32 @@ func (r *Repository) IsUserInChatRoom(roomID, userID uuid.UUID) (bool, error) {
 	return true, nil
 }
 
+// GetChatContacts returns the users who share a chat room with a user
+func (r *Repository) GetChatContacts(userID uuid.UUID) ([]string, error) {
+	query := `SELECT DISTINCT other.user_id FROM "ChatRoomMember" me
+	JOIN "ChatRoomMember" other ON other.room_id = me.room_id
+	WHERE me.user_id = $1 AND other.user_id <> $1`
+	rows, err := r.DB.Query(query, userID)
+	if err != nil {
+		log.Printf("Error getting chat contacts of user %s: %v", userID, err)
+		return nil, db.NewDatabaseError("select", "ChatRoomMember", err)
+	}
+	defer rows.Close()
+
+	var contacts []string
+	for rows.Next() {
+		var contact string
+		if err := rows.Scan(&contact); err != nil {
+			return nil, db.NewDatabaseError("scan", "ChatRoomMember", err)
+		}
+		contacts = append(contacts, contact)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, db.NewDatabaseError("select", "ChatRoomMember", err)
+	}
+	return contacts, nil
+}
+
 // GetUserSnsEndpointArn returns the SNS endpoint ARN for a user
 func (r *Repository) GetUserSnsEndpointArn(userID uuid.UUID) (string, error) {
 	var arn string
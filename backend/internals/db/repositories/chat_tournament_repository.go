@@ -0,0 +1,61 @@
+package repositories
+
+import (
+	"database/sql"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/model"
+)
+
+// EnsureTournamentChatRoom returns the channel of a tournament, creating it
+// on first use as a group room named after the tournament and owned by its
+// host
+func (r *Repository) EnsureTournamentChatRoom(tournament *model.Tournament) (*model.ChatRoom, error) {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return nil, db.NewDatabaseError("begin", "ChatRoom", err)
+	}
+	defer tx.Rollback()
+
+	room := &model.ChatRoom{}
+	query := `INSERT INTO "ChatRoom" AS r (id, is_group, name, avatar_url, tournament_id, created_at)
+	VALUES ($1, TRUE, LEFT($2, 100), $3, $4, $5)
+	ON CONFLICT (tournament_id) WHERE tournament_id IS NOT NULL DO NOTHING
+	RETURNING ` + chatRoomColumns
+	err = scanChatRoom(tx.QueryRow(query, uuid.New(), tournament.Title, tournament.BannerUrl, tournament.Id, time.Now().UTC()), room)
+	if err == sql.ErrNoRows {
+		return r.GetTournamentChatRoom(tournament.Id)
+	}
+	if err != nil {
+		log.Printf("Error creating chat room of tournament %s: %v", tournament.Id, err)
+		return nil, db.NewDatabaseError("insert", "ChatRoom", err)
+	}
+
+	_, err = tx.Exec(`INSERT INTO "ChatRoomMember" (room_id, user_id, role) VALUES ($1, $2, 'owner')`, room.Id, tournament.HostId)
+	if err != nil {
+		log.Printf("Error adding host to chat room of tournament %s: %v", tournament.Id, err)
+		return nil, db.NewDatabaseError("insert", "ChatRoomMember", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, db.NewDatabaseError("commit", "ChatRoom", err)
+	}
+	return room, nil
+}
+
+// GetTournamentChatRoom returns the channel of a tournament
+func (r *Repository) GetTournamentChatRoom(tournamentID string) (*model.ChatRoom, error) {
+	room := &model.ChatRoom{}
+	query := `SELECT ` + chatRoomColumns + ` FROM "ChatRoom" r WHERE r.tournament_id = $1`
+	err := scanChatRoom(r.DB.QueryRow(query, tournamentID), room)
+	if err == sql.ErrNoRows {
+		return nil, db.NewNotFoundError("tournament chat room", tournamentID)
+	}
+	if err != nil {
+		log.Printf("Error getting chat room of tournament %s: %v", tournamentID, err)
+		return nil, db.NewDatabaseError("select", "ChatRoom", err)
+	}
+	return room, nil
+}
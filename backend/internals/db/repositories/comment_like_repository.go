@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"database/sql"
+	"log"
+	"sort"
+
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/model"
+)
+
+// LikeComment adds a like to a comment and increments the comment's
+// like_count in a single transaction.
+func (repo *Repository) LikeComment(commentID, userID string) error {
+	tx, err := repo.DB.Begin()
+	if err != nil {
+		return db.NewDatabaseError("begin", "transaction", err)
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	if err := tx.QueryRow(`SELECT EXISTS (SELECT 1 FROM "Comment" WHERE id = $1)`, commentID).Scan(&exists); err != nil {
+		log.Printf("Error checking comment %s: %v", commentID, err)
+		return db.NewDatabaseError("select", "Comment", err)
+	}
+	if !exists {
+		return db.NewNotFoundError("comment", commentID)
+	}
+
+	res, err := tx.Exec(`INSERT INTO "comment_likes" (comment_id, user_id) VALUES ($1, $2)
+	ON CONFLICT DO NOTHING`, commentID, userID)
+	if err != nil {
+		log.Printf("Error creating comment like: %v", err)
+		return db.NewDatabaseError("insert", "comment_likes", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return db.NewAlreadyExistsError("comment like", "comment_id and user_id combination", commentID+" + "+userID)
+	}
+
+	if _, err := tx.Exec(`UPDATE "Comment" SET like_count = like_count + 1 WHERE id = $1`, commentID); err != nil {
+		log.Printf("Error incrementing comment like count: %v", err)
+		return db.NewDatabaseError("update", "Comment", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return db.NewDatabaseError("commit", "transaction", err)
+	}
+	return nil
+}
+
+// UnlikeComment removes a like from a comment and decrements the comment's
+// like_count. Removing a like that does not exist is a no-op.
+func (repo *Repository) UnlikeComment(commentID, userID string) error {
+	tx, err := repo.DB.Begin()
+	if err != nil {
+		return db.NewDatabaseError("begin", "transaction", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(`DELETE FROM "comment_likes" WHERE comment_id = $1 AND user_id = $2`, commentID, userID)
+	if err != nil {
+		log.Printf("Error deleting comment like: %v", err)
+		return db.NewDatabaseError("delete", "comment_likes", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil
+	}
+
+	if _, err := tx.Exec(`UPDATE "Comment" SET like_count = like_count - 1 WHERE id = $1`, commentID); err != nil {
+		log.Printf("Error decrementing comment like count: %v", err)
+		return db.NewDatabaseError("update", "Comment", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return db.NewDatabaseError("commit", "transaction", err)
+	}
+	return nil
+}
+
+// sortComments puts top-level comments, read oldest first, in the requested
+// order. Top comments with the same number of likes stay oldest first.
+func sortComments(comments []model.CommentResponse, sortBy model.CommentSort) {
+	switch sortBy {
+	case model.CommentSortNewest:
+		for i, j := 0, len(comments)-1; i < j; i, j = i+1, j-1 {
+			comments[i], comments[j] = comments[j], comments[i]
+		}
+	case model.CommentSortTop:
+		sort.SliceStable(comments, func(i, j int) bool {
+			return comments[i].LikeCount > comments[j].LikeCount
+		})
+	}
+}
+
+// nullableID binds an optional user ID, such as the viewer of a public
+// endpoint, so that an anonymous viewer matches no rows
+func nullableID(id string) sql.NullString {
+	return sql.NullString{String: id, Valid: id != ""}
+}
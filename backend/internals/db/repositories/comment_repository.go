@@ -3,6 +3,7 @@ package repositories
 import (
 	"database/sql"
 	"log"
+	"time"
 
 	"sportsin_backend/internals/db"
 	"sportsin_backend/internals/model"
@@ -40,11 +41,14 @@ func (r *Repository) CreateComment(userId, postId, content string, parentId *str
 		}
 	}
 
+	// CreatedAt has no time zone; store UTC like posts, which the feed and
+	// the keyset cursors compare it against
+	now := time.Now().UTC()
 	err := r.DB.QueryRow(`
-		INSERT INTO "Comment" (userid, postid, parentid, content)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO "Comment" (userid, postid, parentid, content, createdat, updatedat)
+		VALUES ($1, $2, $3, $4, $5, $5)
 		RETURNING id, createdat, updatedat
-	`, userId, postId, parentId, content).Scan(&comment.Id, &comment.CreatedAt, &comment.UpdatedAt)
+	`, userId, postId, parentId, content, now).Scan(&comment.Id, &comment.CreatedAt, &comment.UpdatedAt)
 	if err != nil {
 		log.Printf("ERROR: failed to insert comment: %v", err)
 		return nil, db.NewDatabaseError("insert", "comments", err)
@@ -52,19 +56,37 @@ func (r *Repository) CreateComment(userId, postId, content string, parentId *str
 	return comment, nil
 }
 
-func (r *Repository) GetCommentsByPostId(postId string, limit, offset int) ([]model.CommentResponse, error) {
+// GetCommentsByPostId returns the top-level comments of a post in the given
+// order, each with its latest reply, its like count and whether userId liked
+// it. A page starts after the cursor when one is given and at offset
+// otherwise; the top order has no cursor.
+func (r *Repository) GetCommentsByPostId(postId string, limit, offset int, after *model.Cursor, sortBy model.CommentSort, userId string) ([]model.CommentResponse, error) {
 	if postId == "" {
 		return nil, db.NewValidationError("post_id", "post_id cannot be empty")
 	}
+	if after != nil && sortBy == model.CommentSortTop {
+		return nil, db.NewValidationError("cursor", "cursor is not supported when sorting by top")
+	}
 
 	// Query all comments for the post ordered by creation date
 	// We don't apply limit/offset here because we need all comments to build the nested structure
+	where := `c.postid = $1 AND ` + r.visibleClause(model.ReportTargetComment, "c.id")
+	args := []any{postId, nullableID(userId)}
+	if after != nil {
+		// Replies are kept so that the top-level comments past the cursor
+		// still get their latest reply
+		where += ` AND (c.parentid IS NOT NULL OR ` + afterCursor("c.createdat", "c.id", 3, sortBy == model.CommentSortNewest) + `)`
+		args = append(args, after.At, after.Id)
+		offset = 0
+	}
 	rows, err := r.DB.Query(`
-		SELECT id, userid, postid, parentid, content, createdat, updatedat
-		FROM "Comment" 
-		WHERE postid = $1
-		ORDER BY createdat ASC
-	`, postId)
+		SELECT c.id, c.userid, c.postid, c.parentid, c.content, c.createdat, c.updatedat,
+			COALESCE(c.like_count, 0),
+			EXISTS (SELECT 1 FROM "comment_likes" cl WHERE cl.comment_id = c.id AND cl.user_id = $2::uuid)
+		FROM "Comment" c
+		WHERE `+where+`
+		ORDER BY c.createdat ASC, c.id ASC
+	`, args...)
 	if err != nil {
 		log.Printf("ERROR: failed to query comments: %v", err)
 		return nil, db.NewDatabaseError("select", "comments", err)
@@ -74,11 +96,15 @@ func (r *Repository) GetCommentsByPostId(postId string, limit, offset int) ([]mo
 	var allComments []model.Comment
 	commentMap := make(map[string]*model.Comment)
 	replyMap := make(map[string][]model.Comment) // Map parent_id to its replies
+	likeCounts := make(map[string]int)
+	userLiked := make(map[string]bool)
 
 	// Read all comments and store them in maps
 	for rows.Next() {
 		var comment model.Comment
 		var parentId sql.NullString
+		var likeCount int
+		var liked bool
 
 		err := rows.Scan(
 			&comment.Id,
@@ -88,6 +114,8 @@ func (r *Repository) GetCommentsByPostId(postId string, limit, offset int) ([]mo
 			&comment.Content,
 			&comment.CreatedAt,
 			&comment.UpdatedAt,
+			&likeCount,
+			&liked,
 		)
 		if err != nil {
 			log.Printf("ERROR: failed to scan comment: %v", err)
@@ -101,6 +129,8 @@ func (r *Repository) GetCommentsByPostId(postId string, limit, offset int) ([]mo
 
 		// Store in map for quick lookup
 		commentMap[comment.Id] = &comment
+		likeCounts[comment.Id] = likeCount
+		userLiked[comment.Id] = liked
 		allComments = append(allComments, comment)
 
 		// Group replies by parent_id
@@ -124,6 +154,8 @@ func (r *Repository) GetCommentsByPostId(postId string, limit, offset int) ([]mo
 				Replies:         []model.Comment{},
 				ReplyCount:      0,
 				TotalReplyCount: 0,
+				LikeCount:       likeCounts[comment.Id],
+				UserLiked:       userLiked[comment.Id],
 			}
 
 			// Get replies for this comment
@@ -142,6 +174,8 @@ func (r *Repository) GetCommentsByPostId(postId string, limit, offset int) ([]mo
 		}
 	}
 
+	sortComments(commentResponses, sortBy)
+
 	// Apply pagination to top-level comments only
 	if offset >= len(commentResponses) {
 		return []model.CommentResponse{}, nil
@@ -159,7 +193,9 @@ func (r *Repository) GetCommentsByPostId(postId string, limit, offset int) ([]mo
 	return commentResponses, nil
 }
 
-func (r *Repository) GetCommentById(commentId string, replyLimit, replyOffset int) (*model.CommentResponse, error) {
+// GetCommentById returns a comment with a page of its replies. userId is
+// the viewer whose like is reported in UserLiked and may be empty.
+func (r *Repository) GetCommentById(commentId string, replyLimit, replyOffset int, userId string) (*model.CommentResponse, error) {
 	if commentId == "" {
 		return nil, db.NewValidationError("comment_id", "comment_id cannot be empty")
 	}
@@ -167,12 +203,16 @@ func (r *Repository) GetCommentById(commentId string, replyLimit, replyOffset in
 	// First, get the main comment
 	var comment model.Comment
 	var parentId sql.NullString
+	var likeCount int
+	var userLiked bool
 
 	err := r.DB.QueryRow(`
-		SELECT id, userid, postid, parentid, content, createdat, updatedat
-		FROM "Comment" 
+		SELECT id, userid, postid, parentid, content, createdat, updatedat,
+			COALESCE(like_count, 0),
+			EXISTS (SELECT 1 FROM "comment_likes" cl WHERE cl.comment_id = c.id AND cl.user_id = $2::uuid)
+		FROM "Comment" c
 		WHERE id = $1
-	`, commentId).Scan(
+	`, commentId, nullableID(userId)).Scan(
 		&comment.Id,
 		&comment.UserId,
 		&comment.PostId,
@@ -180,6 +220,8 @@ func (r *Repository) GetCommentById(commentId string, replyLimit, replyOffset in
 		&comment.Content,
 		&comment.CreatedAt,
 		&comment.UpdatedAt,
+		&likeCount,
+		&userLiked,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -260,6 +302,8 @@ func (r *Repository) GetCommentById(commentId string, replyLimit, replyOffset in
 		Replies:         replies,
 		ReplyCount:      len(replies),
 		TotalReplyCount: totalReplyCount,
+		LikeCount:       likeCount,
+		UserLiked:       userLiked,
 	}
 
 	return commentResponse, nil
@@ -302,7 +346,7 @@ func (repo *Repository) UpdateComment(id, content string) error {
 	return nil
 }
 
-func (r *Repository) DeleteComment(id string) error {
+func (r *Repository) DeleteComment(id string, action *model.AdminAction) error {
 	if id == "" {
 		return db.NewValidationError("comment", "id cannot be empty")
 	}
@@ -330,6 +374,10 @@ func (r *Repository) DeleteComment(id string) error {
 		return db.NewNotFoundError("comment", id)
 	}
 
+	if err := insertAdminAction(tx, action); err != nil {
+		return err
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		log.Printf("ERROR: failed to commit transaction: %v", err)
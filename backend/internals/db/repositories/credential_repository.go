@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"database/sql"
+	"log"
+	"time"
+
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/model"
+)
+
+func (repo *Repository) CreateCredential(credential *model.Credential) error {
+	_, err := repo.DB.Exec(`INSERT INTO "Credential" (user_id, email, role, password_hash, confirmed, code, code_expires_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		credential.UserId, credential.Email, credential.Role, credential.PasswordHash,
+		credential.Confirmed, credential.Code, nullTime(credential.CodeExpiresAt))
+	if err != nil {
+		log.Printf("ERROR: failed to create credential: %v", err)
+		if db.IsUniqueConstraintError(err, "email") {
+			return db.NewAlreadyExistsError("credential", "email", credential.Email)
+		}
+		return db.NewDatabaseError("insert", "Credential", err)
+	}
+	return nil
+}
+
+func (repo *Repository) GetCredentialByEmail(email string) (*model.Credential, error) {
+	var credential model.Credential
+	var codeExpiresAt sql.NullTime
+	err := repo.DB.QueryRow(`SELECT user_id, email, role, password_hash, confirmed, code, code_expires_at, created_at, updated_at
+	FROM "Credential" WHERE email = $1`, email).Scan(
+		&credential.UserId, &credential.Email, &credential.Role, &credential.PasswordHash, &credential.Confirmed,
+		&credential.Code, &codeExpiresAt, &credential.CreatedAt, &credential.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, db.NewNotFoundError("credential", email)
+		}
+		log.Printf("ERROR: failed to get credential by email: %v", err)
+		return nil, db.NewDatabaseError("select", "Credential", err)
+	}
+	if codeExpiresAt.Valid {
+		credential.CodeExpiresAt = codeExpiresAt.Time
+	}
+
+	return &credential, nil
+}
+
+func (repo *Repository) UpdateCredential(credential *model.Credential) error {
+	result, err := repo.DB.Exec(`UPDATE "Credential"
+	SET password_hash = $1, confirmed = $2, code = $3, code_expires_at = $4, updated_at = CURRENT_TIMESTAMP
+	WHERE user_id = $5`,
+		credential.PasswordHash, credential.Confirmed, credential.Code, nullTime(credential.CodeExpiresAt), credential.UserId)
+	if err != nil {
+		log.Printf("ERROR: failed to update credential: %v", err)
+		return db.NewDatabaseError("update", "Credential", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return db.NewDatabaseError("update", "Credential", err)
+	}
+	if rowsAffected == 0 {
+		return db.NewNotFoundError("credential", credential.UserId)
+	}
+	return nil
+}
+
+// nullTime stores the zero time as NULL
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
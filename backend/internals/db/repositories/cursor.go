@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"strconv"
+)
+
+// afterCursor returns the condition keeping the rows that follow a cursor
+// in a list ordered by (at, id), newest first when desc. The cursor's At
+// and Id are bound to $n and $n+1.
+func afterCursor(at, id string, n int, desc bool) string {
+	op := ">"
+	if desc {
+		op = "<"
+	}
+	return "(" + at + ", " + id + ") " + op + " ($" + strconv.Itoa(n) + "::timestamp, $" + strconv.Itoa(n+1) + "::uuid)"
+}
@@ -1,8 +1,18 @@
 package repositories
 
-import "database/sql"
+import (
+	"database/sql"
+
+	"sportsin_backend/internals/store"
+)
 
 type Repository struct {
 	DB *sql.DB
+
+	// ReportHideThreshold is the number of reports that hides a post or
+	// comment from the feed until a moderator dismisses them. Zero turns
+	// auto-hiding off.
+	ReportHideThreshold int
 }
 
+var _ store.Store = (*Repository)(nil)
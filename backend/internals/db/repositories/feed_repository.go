@@ -0,0 +1,82 @@
+package repositories
+
+import (
+	"log"
+
+	"github.com/lib/pq"
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/model"
+)
+
+// GetFeedCandidates returns the ranking signals of the posts taken for
+// each signal of the query, counting engagement as of query.Until. Posts
+// hidden by reports are left out.
+func (repo *Repository) GetFeedCandidates(viewerID string, query *model.FeedQuery) ([]model.FeedCandidate, error) {
+	rows, err := repo.DB.Query(`
+		WITH recent AS (
+			SELECT p.id, p.user_id, p.created_at,
+				(SELECT COUNT(*) FROM "post_likes" l WHERE l.post_id = p.id AND l.created_at <= $3) AS like_count,
+				(SELECT COUNT(*) FROM "Comment" c WHERE c.postid = p.id AND c.createdat <= $3) AS comment_count,
+				p.user_id = $1 OR EXISTS (
+					SELECT 1 FROM "Follow" f WHERE f.follower_id = $1 AND f.following_id = p.user_id
+				) AS from_followed,
+				EXISTS (
+					SELECT 1 FROM "UserSkill" mine
+					INNER JOIN "UserSkill" theirs ON theirs.sport_id = mine.sport_id
+					WHERE mine.user_id = $1 AND theirs.user_id = p.user_id
+				) AS shares_sport
+			FROM "Post" p
+			WHERE p.created_at > $2 AND p.created_at <= $3 AND `+repo.visibleClause(model.ReportTargetPost, "p.id")+`
+		)
+		SELECT r.id, r.user_id, r.created_at, r.like_count, r.comment_count,
+			(SELECT COUNT(*) FROM "Comment" c WHERE c.postid = r.id AND c.createdat > $4 AND c.createdat <= $3),
+			r.from_followed, r.shares_sport
+		FROM recent r
+		WHERE r.id IN (
+			(SELECT id FROM recent WHERE from_followed ORDER BY created_at DESC, id DESC LIMIT $5)
+			UNION
+			(SELECT id FROM recent WHERE shares_sport ORDER BY created_at DESC, id DESC LIMIT $5)
+			UNION
+			(SELECT id FROM recent ORDER BY like_count + comment_count DESC, created_at DESC, id DESC LIMIT $5)
+		)`,
+		viewerID, query.Since, query.Until, query.CommentsSince, query.PerSignal)
+	if err != nil {
+		log.Printf("ERROR: failed to get feed candidates: %v", err)
+		return nil, db.NewDatabaseError("select", "Post", err)
+	}
+	defer rows.Close()
+
+	candidates := []model.FeedCandidate{}
+	for rows.Next() {
+		var candidate model.FeedCandidate
+		if err := rows.Scan(&candidate.PostId, &candidate.UserId, &candidate.CreatedAt, &candidate.LikeCount,
+			&candidate.CommentCount, &candidate.RecentComments, &candidate.FromFollowed, &candidate.SharesSport); err != nil {
+			log.Printf("ERROR: failed to scan feed candidate: %v", err)
+			return nil, db.NewDatabaseError("scan", "Post", err)
+		}
+		candidates = append(candidates, candidate)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, db.NewDatabaseError("select", "Post", err)
+	}
+
+	return candidates, nil
+}
+
+// GetPostsWithCommentsByIds loads the given posts in no particular order.
+// Posts that no longer exist are skipped.
+func (repo *Repository) GetPostsWithCommentsByIds(postIds []string, userId string) ([]model.PostWithComment, error) {
+	if len(postIds) == 0 {
+		return []model.PostWithComment{}, nil
+	}
+
+	query := repo.postWithCommentQuery("$2", `p.id = ANY($1::uuid[])`, ``)
+	rows, err := repo.DB.Query(query, pq.Array(postIds), userId)
+	if err != nil {
+		log.Printf("Critical error getting posts by IDs with comments: %v", err)
+		return nil, db.NewDatabaseError("select", "Post", err)
+	}
+	defer rows.Close()
+
+	return scanPostsWithComments(rows), nil
+}
@@ -0,0 +1,165 @@
+package repositories
+
+import (
+	"database/sql"
+	"log"
+
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/model"
+)
+
+// followUserColumns selects a FollowUser for the user aliased u, with the
+// profile aliased ud. Callers add the is_mutual and followed_at columns.
+const followUserColumns = `u.id, COALESCE(ud.username, u.username), COALESCE(ud.name, ''), ud.profile_pic, u.role`
+
+func (repo *Repository) FollowUser(followerID, followingID string) error {
+	if followerID == followingID {
+		return db.NewValidationError("following_id", "users cannot follow themselves")
+	}
+	if err := repo.ensureUserExists(followingID); err != nil {
+		return err
+	}
+
+	result, err := repo.DB.Exec(`INSERT INTO "Follow" (follower_id, following_id) VALUES ($1, $2)
+	ON CONFLICT (follower_id, following_id) DO NOTHING`, followerID, followingID)
+	if err != nil {
+		log.Printf("ERROR: failed to follow user: %v", err)
+		return db.NewDatabaseError("insert", "Follow", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return db.NewAlreadyExistsError("follow", "follower_id and following_id combination", followerID+" + "+followingID)
+	}
+	return nil
+}
+
+func (repo *Repository) UnfollowUser(followerID, followingID string) error {
+	result, err := repo.DB.Exec(`DELETE FROM "Follow" WHERE follower_id = $1 AND following_id = $2`, followerID, followingID)
+	if err != nil {
+		log.Printf("ERROR: failed to unfollow user: %v", err)
+		return db.NewDatabaseError("delete", "Follow", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return db.NewNotFoundError("follow", followingID)
+	}
+	return nil
+}
+
+// GetFollowers returns the users following userID, most recent first
+func (repo *Repository) GetFollowers(userID string, limit, offset int) ([]model.FollowUser, error) {
+	if err := repo.ensureUserExists(userID); err != nil {
+		return nil, err
+	}
+	return repo.queryFollowUsers(`SELECT `+followUserColumns+`,
+		EXISTS (SELECT 1 FROM "Follow" b WHERE b.follower_id = $1 AND b.following_id = f.follower_id),
+		f.created_at
+	FROM "Follow" f
+	INNER JOIN "User" u ON u.id = f.follower_id
+	LEFT JOIN "UserDetails" ud ON ud.id = u.id
+	WHERE f.following_id = $1
+	ORDER BY f.created_at DESC, u.id
+	LIMIT $2 OFFSET $3`, userID, limit, offset)
+}
+
+// GetFollowing returns the users userID follows, most recent first
+func (repo *Repository) GetFollowing(userID string, limit, offset int) ([]model.FollowUser, error) {
+	if err := repo.ensureUserExists(userID); err != nil {
+		return nil, err
+	}
+	return repo.queryFollowUsers(`SELECT `+followUserColumns+`,
+		EXISTS (SELECT 1 FROM "Follow" b WHERE b.follower_id = f.following_id AND b.following_id = $1),
+		f.created_at
+	FROM "Follow" f
+	INNER JOIN "User" u ON u.id = f.following_id
+	LEFT JOIN "UserDetails" ud ON ud.id = u.id
+	WHERE f.follower_id = $1
+	ORDER BY f.created_at DESC, u.id
+	LIMIT $2 OFFSET $3`, userID, limit, offset)
+}
+
+// GetMutualFollows returns the users that follow userID back, ordered by
+// when the follow became mutual, most recent first
+func (repo *Repository) GetMutualFollows(userID string, limit, offset int) ([]model.FollowUser, error) {
+	if err := repo.ensureUserExists(userID); err != nil {
+		return nil, err
+	}
+	return repo.queryFollowUsers(`SELECT `+followUserColumns+`, TRUE, GREATEST(f.created_at, b.created_at) AS mutual_at
+	FROM "Follow" f
+	INNER JOIN "Follow" b ON b.follower_id = f.following_id AND b.following_id = f.follower_id
+	INNER JOIN "User" u ON u.id = f.following_id
+	LEFT JOIN "UserDetails" ud ON ud.id = u.id
+	WHERE f.follower_id = $1
+	ORDER BY mutual_at DESC, u.id
+	LIMIT $2 OFFSET $3`, userID, limit, offset)
+}
+
+// GetFollowStats counts the followers of userID and the users it follows.
+// When viewerID is set and is another user, the relation between the two is
+// filled in as well.
+func (repo *Repository) GetFollowStats(userID, viewerID string) (*model.FollowStats, error) {
+	var stats model.FollowStats
+	err := repo.DB.QueryRow(`SELECT
+		(SELECT COUNT(*) FROM "Follow" WHERE following_id = $1),
+		(SELECT COUNT(*) FROM "Follow" WHERE follower_id = $1)`, userID,
+	).Scan(&stats.FollowersCount, &stats.FollowingCount)
+	if err != nil {
+		log.Printf("ERROR: failed to count follows: %v", err)
+		return nil, db.NewDatabaseError("select", "Follow", err)
+	}
+
+	if viewerID == "" || viewerID == userID {
+		return &stats, nil
+	}
+
+	var isFollowing, followsYou bool
+	err = repo.DB.QueryRow(`SELECT
+		EXISTS (SELECT 1 FROM "Follow" WHERE follower_id = $2 AND following_id = $1),
+		EXISTS (SELECT 1 FROM "Follow" WHERE follower_id = $1 AND following_id = $2)`, userID, viewerID,
+	).Scan(&isFollowing, &followsYou)
+	if err != nil {
+		log.Printf("ERROR: failed to check follow relation: %v", err)
+		return nil, db.NewDatabaseError("select", "Follow", err)
+	}
+	isMutual := isFollowing && followsYou
+	stats.IsFollowing = &isFollowing
+	stats.FollowsYou = &followsYou
+	stats.IsMutual = &isMutual
+
+	return &stats, nil
+}
+
+func (repo *Repository) queryFollowUsers(query string, args ...any) ([]model.FollowUser, error) {
+	rows, err := repo.DB.Query(query, args...)
+	if err != nil {
+		log.Printf("ERROR: failed to get follows: %v", err)
+		return nil, db.NewDatabaseError("select", "Follow", err)
+	}
+	defer rows.Close()
+
+	users := []model.FollowUser{}
+	for rows.Next() {
+		var user model.FollowUser
+		if err := rows.Scan(&user.UserId, &user.Username, &user.Name, &user.ProfilePicture, &user.Role,
+			&user.IsMutual, &user.FollowedAt); err != nil {
+			log.Printf("ERROR: failed to scan follow: %v", err)
+			return nil, db.NewDatabaseError("scan", "Follow", err)
+		}
+		users = append(users, user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, db.NewDatabaseError("select", "Follow", err)
+	}
+
+	return users, nil
+}
+
+func (repo *Repository) ensureUserExists(userID string) error {
+	var exists int
+	err := repo.DB.QueryRow(`SELECT 1 FROM "User" WHERE id = $1`, userID).Scan(&exists)
+	if err == sql.ErrNoRows {
+		return db.NewNotFoundError("user", userID)
+	}
+	if err != nil {
+		return db.NewDatabaseError("select", "User", err)
+	}
+	return nil
+}
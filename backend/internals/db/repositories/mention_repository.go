@@ -0,0 +1,155 @@
+package repositories
+
+import (
+	"database/sql"
+	"log"
+
+	"github.com/lib/pq"
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/model"
+)
+
+// SetPostMentions replaces the users mentioned by a post with those of
+// usernames that exist, leaving out the author. It returns the users that
+// were not mentioned by the post before, who are the ones to notify.
+func (repo *Repository) SetPostMentions(postId, authorId string, usernames []string) ([]model.MentionedUser, error) {
+	tx, err := repo.DB.Begin()
+	if err != nil {
+		return nil, db.NewDatabaseError("begin", "transaction", err)
+	}
+	defer tx.Rollback()
+
+	users, err := resolveMentions(tx, authorId, usernames)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(users))
+	for _, u := range users {
+		ids = append(ids, u.UserId)
+	}
+
+	_, err = tx.Exec(`DELETE FROM "Mention"
+	WHERE post_id = $1 AND comment_id IS NULL AND mentioned_user_id <> ALL($2::uuid[])`, postId, pq.Array(ids))
+	if err != nil {
+		log.Printf("ERROR: failed to clear mentions of post %s: %v", postId, err)
+		return nil, db.NewDatabaseError("delete", "Mention", err)
+	}
+
+	added, err := insertMentions(tx, postId, nil, authorId, users)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, db.NewDatabaseError("commit", "transaction", err)
+	}
+	return added, nil
+}
+
+// AddCommentMentions records the users of usernames mentioned by a comment,
+// leaving out the author, and returns those that were recorded.
+func (repo *Repository) AddCommentMentions(commentId, postId, authorId string, usernames []string) ([]model.MentionedUser, error) {
+	tx, err := repo.DB.Begin()
+	if err != nil {
+		return nil, db.NewDatabaseError("begin", "transaction", err)
+	}
+	defer tx.Rollback()
+
+	users, err := resolveMentions(tx, authorId, usernames)
+	if err != nil {
+		return nil, err
+	}
+	added, err := insertMentions(tx, postId, &commentId, authorId, users)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, db.NewDatabaseError("commit", "transaction", err)
+	}
+	return added, nil
+}
+
+// GetPostsMentioningUser returns the posts whose content mentions a user,
+// newest first, in the shape of GetAllPostsWithComments
+func (repo *Repository) GetPostsMentioningUser(userId string, limit, offset int, after *model.Cursor) ([]model.PostWithComment, error) {
+	if userId == "" {
+		return nil, db.ErrUserIDMissing
+	}
+	if limit <= 0 {
+		return nil, db.ErrInvalidLimit
+	}
+	if offset < 0 {
+		return nil, db.ErrInvalidOffset
+	}
+
+	where := repo.visibleClause(model.ReportTargetPost, "p.id") + ` AND EXISTS (
+			SELECT 1 FROM "Mention" m
+			WHERE m.post_id = p.id AND m.comment_id IS NULL AND m.mentioned_user_id = $3
+		)`
+	args := []any{limit, offset, userId}
+	if after != nil {
+		where += ` AND ` + afterCursor("p.created_at", "p.id", 4, true)
+		args = append(args, after.At, after.Id)
+		args[1] = 0
+	}
+	query := repo.postWithCommentQuery("$3", where,
+		`ORDER BY p.created_at DESC, p.id DESC LIMIT $1 OFFSET $2`)
+
+	rows, err := repo.DB.Query(query, args...)
+	if err != nil {
+		log.Printf("ERROR: failed to get posts mentioning user %s: %v", userId, err)
+		return nil, db.NewDatabaseError("select", "Post", err)
+	}
+	defer rows.Close()
+
+	return scanPostsWithComments(rows), nil
+}
+
+// resolveMentions looks up the users behind usernames, leaving out the
+// author and usernames nobody has
+func resolveMentions(tx *sql.Tx, authorId string, usernames []string) ([]model.MentionedUser, error) {
+	users := []model.MentionedUser{}
+	if len(usernames) == 0 {
+		return users, nil
+	}
+
+	rows, err := tx.Query(`SELECT ud.id, ud.username, COALESCE(u.sns_endpoint_arn, '')
+	FROM "UserDetails" ud
+	INNER JOIN "User" u ON u.id = ud.id
+	WHERE ud.username = ANY($1::text[]) AND ud.id <> $2`, pq.Array(usernames), authorId)
+	if err != nil {
+		log.Printf("ERROR: failed to resolve mentioned usernames: %v", err)
+		return nil, db.NewDatabaseError("select", "UserDetails", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var u model.MentionedUser
+		if err := rows.Scan(&u.UserId, &u.Username, &u.SnsEndpointArn); err != nil {
+			return nil, db.NewDatabaseError("scan", "UserDetails", err)
+		}
+		users = append(users, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, db.NewDatabaseError("select", "UserDetails", err)
+	}
+	return users, nil
+}
+
+// insertMentions records users as mentioned and returns those that were not
+// already
+func insertMentions(tx *sql.Tx, postId string, commentId *string, authorId string, users []model.MentionedUser) ([]model.MentionedUser, error) {
+	added := []model.MentionedUser{}
+	for _, u := range users {
+		result, err := tx.Exec(`INSERT INTO "Mention" (post_id, comment_id, mentioned_user_id, author_id)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT DO NOTHING`, postId, commentId, u.UserId, authorId)
+		if err != nil {
+			log.Printf("ERROR: failed to record mention of %s: %v", u.UserId, err)
+			return nil, db.NewDatabaseError("insert", "Mention", err)
+		}
+		if n, _ := result.RowsAffected(); n > 0 {
+			added = append(added, u)
+		}
+	}
+	return added, nil
+}
@@ -147,515 +147,93 @@ func (r *Repository) UpdateOpening(opening *model.OpeningDetails) error {
 	return nil
 }
 
-func (r *Repository) DeleteOpening(openingID string) error {
+func (r *Repository) DeleteOpening(openingID string, actor model.AuditActor) error {
 	tx, err := r.DB.Begin()
 	if err != nil {
 		return db.NewDatabaseError("begin", "transaction", err)
 	}
+	defer tx.Rollback()
 
-	defer func() {
-		if err != nil {
-			tx.Rollback()
-		} else {
-			err = tx.Commit()
-			if err != nil {
-				err = db.NewDatabaseError("Failed to commit transaction", "transaction", err)
-			}
-		}
-	}()
+	query := `DELETE FROM "Opening" o WHERE id = $1 RETURNING row_to_json(o)`
 
-	query := `DELETE FROM "Opening" WHERE id = $1`
-	_, err = tx.Exec(query, openingID)
-	if err != nil {
+	var before []byte
+	if err := tx.QueryRow(query, openingID).Scan(&before); err != nil {
+		if err == sql.ErrNoRows {
+			return db.NewNotFoundError("opening", openingID)
+		}
 		return db.NewDatabaseError("delete", "opening", err)
 	}
 
+	if err := insertAuditEvent(tx, actor, model.AuditOpeningDeleted, "opening", openingID, before, nil); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return db.NewDatabaseError("Failed to commit transaction", "transaction", err)
+	}
 	return nil
 }
 
-func (r *Repository) UpdateOpeningStatus(openingID string, status model.OpeningStatus) error {
+func (r *Repository) UpdateOpeningStatus(openingID string, status model.OpeningStatus, action *model.AdminAction) error {
 	tx, err := r.DB.Begin()
 	if err != nil {
 		return db.NewDatabaseError("begin", "transaction", err)
 	}
-
-	defer func() {
-		if err != nil {
-			tx.Rollback()
-		} else {
-			err = tx.Commit()
-			if err != nil {
-				err = db.NewDatabaseError("Failed to commit transaction", "transaction", err)
-			}
-		}
-	}()
+	defer tx.Rollback()
 
 	query := `UPDATE "Opening" SET status = $1 WHERE id = $2`
-	_, err = tx.Exec(query, status, openingID)
-	if err != nil {
+	if _, err := tx.Exec(query, status, openingID); err != nil {
 		return db.NewDatabaseError("update", "opening status", err)
 	}
 
+	if err := insertAdminAction(tx, action); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return db.NewDatabaseError("Failed to commit transaction", "transaction", err)
+	}
 	return nil
 }
 
 func (r *Repository) GetOpeningByID(openingID string, playerID *string) (*model.OpeningDetails, error) {
-	var query string
-	var args []any
-
-	if playerID != nil {
-		query = `SELECT o.id, o.sport_id, o.recruiter_id, o.company_name, o.title, o.description, o.status, 
-					o.position, o.min_age, o.max_age, o.min_level, o.min_salary, o.max_salary, o.country_restriction, 
-					o.address_id, o.stats, s.name AS sport_name, a.country, a.state, a.city, a.street, a.building, a.postal_code,
-					CASE WHEN app.id IS NOT NULL THEN true ELSE false END AS applied,
-					app.status AS application_status
-					FROM "Opening" o 
-					JOIN "Sports" s ON o.sport_id = s.id 
-					JOIN "SAddress" a ON o.address_id = a.id 
-					LEFT JOIN "Application" app ON o.id = app.opening_id AND app.player_id = $2
-					WHERE o.id = $1`
-		args = []any{openingID, *playerID}
-	} else {
-		query = `SELECT o.id, o.sport_id, o.recruiter_id, o.company_name, o.title, o.description, o.status, 
-					o.position, o.min_age, o.max_age, o.min_level, o.min_salary, o.max_salary, o.country_restriction, 
-					o.address_id, o.stats, s.name AS sport_name, a.country, a.state, a.city, a.street, a.building, a.postal_code,
-					false AS applied,
-					NULL AS application_status
-					FROM "Opening" o 
-					JOIN "Sports" s ON o.sport_id = s.id 
-					JOIN "SAddress" a ON o.address_id = a.id 
-					WHERE o.id = $1`
-		args = []any{openingID}
-	}
-
-	row := r.DB.QueryRow(query, args...)
-	openingDetails := &model.OpeningDetails{
-		Opening: &model.Opening{},
-		Address: &model.SAddress{},
+	from, args := openingJoins(playerID, []any{openingID})
+	openingDetails, err := scanOpeningDetails(r.DB.QueryRow(`SELECT `+openingColumns+from+` WHERE o.id = $1`, args...))
+	if err == sql.ErrNoRows {
+		return nil, db.NewNotFoundError("opening", openingID)
 	}
-
-	var statsJSON sql.NullString
-	var applied bool
-	var applicationStatusStr sql.NullString
-	err := row.Scan(
-		&openingDetails.Opening.Id,
-		&openingDetails.Opening.SportID,
-		&openingDetails.Opening.RecruiterID,
-		&openingDetails.Opening.CompanyName,
-		&openingDetails.Opening.Title,
-		&openingDetails.Opening.Description,
-		&openingDetails.Opening.Status,
-		&openingDetails.Opening.Position,
-		&openingDetails.Opening.MinAge,
-		&openingDetails.Opening.MaxAge,
-		&openingDetails.Opening.MinLevel,
-		&openingDetails.Opening.MinSalary,
-		&openingDetails.Opening.MaxSalary,
-		&openingDetails.Opening.CountryRestriction,
-		&openingDetails.Opening.AddressID,
-		&statsJSON,
-		&openingDetails.SportName,
-		&openingDetails.Address.Country,
-		&openingDetails.Address.State,
-		&openingDetails.Address.City,
-		&openingDetails.Address.Street,
-		&openingDetails.Address.Building,
-		&openingDetails.Address.PostalCode,
-		&applied,
-		&applicationStatusStr,
-	)
-
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, db.NewNotFoundError("opening", openingID)
-		}
-		return nil, db.NewDatabaseError("query", "get opening by ID", err)
-	}
-
-	// Set application fields
-	openingDetails.Applied = applied
-	if applicationStatusStr.Valid {
-		appStatus := model.ApplicationStatus(applicationStatusStr.String)
-		openingDetails.ApplicationStatus = &appStatus
+		return nil, err
 	}
-
-	// Unmarshal the JSONB stats if present
-	if statsJSON.Valid && len(statsJSON.String) > 0 {
-		if err := json.Unmarshal([]byte(statsJSON.String), &openingDetails.Opening.Stats); err != nil {
-			return nil, db.NewDatabaseError("unmarshal", "stats", err)
-		}
-	}
-
 	return openingDetails, nil
 }
 
-func (r *Repository) GetOpeningsByRecruiterID(recruiterID string, limit, offset int, playerID *string) ([]*model.OpeningDetails, error) {
-	var query string
-	var args []any
-
-	if playerID != nil {
-		query = `SELECT o.id, o.sport_id, o.recruiter_id, o.company_name, o.title, o.description, o.status, 
-					o.position, o.min_age, o.max_age, o.min_level, o.min_salary, o.max_salary, o.country_restriction, 
-					o.address_id, o.stats, s.name AS sport_name, a.country, a.state, a.city, a.street, a.building, a.postal_code,
-					CASE WHEN app.id IS NOT NULL THEN true ELSE false END AS applied,
-					app.status AS application_status
-					FROM "Opening" o 
-					JOIN "Sports" s ON o.sport_id = s.id 
-					JOIN "SAddress" a ON o.address_id = a.id 
-					LEFT JOIN "Application" app ON o.id = app.opening_id AND app.player_id = $4
-					WHERE o.recruiter_id = $1
-					ORDER BY o.created_at DESC
-					LIMIT $2 OFFSET $3`
-		args = []any{recruiterID, limit, offset, *playerID}
-	} else {
-		query = `SELECT o.id, o.sport_id, o.recruiter_id, o.company_name, o.title, o.description, o.status, 
-					o.position, o.min_age, o.max_age, o.min_level, o.min_salary, o.max_salary, o.country_restriction, 
-					o.address_id, o.stats, s.name AS sport_name, a.country, a.state, a.city, a.street, a.building, a.postal_code,
-					false AS applied,
-					NULL AS application_status
-					FROM "Opening" o 
-					JOIN "Sports" s ON o.sport_id = s.id 
-					JOIN "SAddress" a ON o.address_id = a.id 
-					WHERE o.recruiter_id = $1
-					ORDER BY o.created_at DESC
-					LIMIT $2 OFFSET $3`
-		args = []any{recruiterID, limit, offset}
-	}
-
-	rows, err := r.DB.Query(query, args...)
-	if err != nil {
-		return nil, db.NewDatabaseError("query", "get openings by recruiter ID", err)
-	}
-	defer rows.Close()
-
-	var openings []*model.OpeningDetails
-	for rows.Next() {
-		openingDetails := &model.OpeningDetails{
-			Opening: &model.Opening{},
-			Address: &model.SAddress{},
-		}
-
-		var statsJSON sql.NullString
-		var applied bool
-		var applicationStatusStr sql.NullString
-		err := rows.Scan(
-			&openingDetails.Opening.Id,
-			&openingDetails.Opening.SportID,
-			&openingDetails.Opening.RecruiterID,
-			&openingDetails.Opening.CompanyName,
-			&openingDetails.Opening.Title,
-			&openingDetails.Opening.Description,
-			&openingDetails.Opening.Status,
-			&openingDetails.Opening.Position,
-			&openingDetails.Opening.MinAge,
-			&openingDetails.Opening.MaxAge,
-			&openingDetails.Opening.MinLevel,
-			&openingDetails.Opening.MinSalary,
-			&openingDetails.Opening.MaxSalary,
-			&openingDetails.Opening.CountryRestriction,
-			&openingDetails.Opening.AddressID,
-			&statsJSON,
-			&openingDetails.SportName,
-			&openingDetails.Address.Country,
-			&openingDetails.Address.State,
-			&openingDetails.Address.City,
-			&openingDetails.Address.Street,
-			&openingDetails.Address.Building,
-			&openingDetails.Address.PostalCode,
-			&applied,
-			&applicationStatusStr,
-		)
-
-		if err != nil {
-			return nil, db.NewDatabaseError("scan", "get openings by recruiter ID", err)
-		}
-
-		// Set application fields
-		openingDetails.Applied = applied
-		if applicationStatusStr.Valid {
-			appStatus := model.ApplicationStatus(applicationStatusStr.String)
-			openingDetails.ApplicationStatus = &appStatus
-		}
-
-		// Unmarshal the JSONB stats if present
-		if statsJSON.Valid && len(statsJSON.String) > 0 {
-			if err := json.Unmarshal([]byte(statsJSON.String), &openingDetails.Opening.Stats); err != nil {
-				return nil, db.NewDatabaseError("unmarshal", "stats", err)
-			}
-		}
-
-		openings = append(openings, openingDetails)
-	}
-
-	if err = rows.Err(); err != nil {
-		return nil, db.NewDatabaseError("rows", "get openings by recruiter ID", err)
-	}
-	return openings, nil
+// GetOpeningsByRecruiterID returns the openings posted by a recruiter, newest
+// first
+func (r *Repository) GetOpeningsByRecruiterID(recruiterID string, limit, offset int, after *model.Cursor, playerID *string) ([]*model.OpeningDetails, error) {
+	return r.listOpenings([]string{"o.recruiter_id = $1"}, []any{recruiterID}, limit, offset, after, playerID, "get openings by recruiter ID")
 }
 
-func (r *Repository) GetAllOpenings(limit, offset int, playerID *string) ([]*model.OpeningDetails, error) {
-	var query string
-	var args []any
-
-	if playerID != nil {
-		query = `SELECT o.id, o.sport_id, o.recruiter_id, o.company_name, o.title, o.description, o.status, 
-					o.position, o.min_age, o.max_age, o.min_level, o.min_salary, o.max_salary, o.country_restriction, 
-					o.address_id, o.stats, s.name AS sport_name, a.country, a.state, a.city, a.street, a.building, a.postal_code,
-					CASE WHEN app.id IS NOT NULL THEN true ELSE false END AS applied,
-					app.status AS application_status
-					FROM "Opening" o 
-					JOIN "Sports" s ON o.sport_id = s.id 
-					JOIN "SAddress" a ON o.address_id = a.id 
-					LEFT JOIN "Application" app ON o.id = app.opening_id AND app.player_id = $3
-					ORDER BY o.created_at DESC 
-					LIMIT $1 OFFSET $2`
-		args = []any{limit, offset, *playerID}
-	} else {
-		query = `SELECT o.id, o.sport_id, o.recruiter_id, o.company_name, o.title, o.description, o.status, 
-					o.position, o.min_age, o.max_age, o.min_level, o.min_salary, o.max_salary, o.country_restriction, 
-					o.address_id, o.stats, s.name AS sport_name, a.country, a.state, a.city, a.street, a.building, a.postal_code,
-					false AS applied,
-					NULL AS application_status
-					FROM "Opening" o 
-					JOIN "Sports" s ON o.sport_id = s.id 
-					JOIN "SAddress" a ON o.address_id = a.id 
-					ORDER BY o.created_at DESC 
-					LIMIT $1 OFFSET $2`
-		args = []any{limit, offset}
-	}
-
-	rows, err := r.DB.Query(query, args...)
-	if err != nil {
-		return nil, db.NewDatabaseError("query", "get all openings", err)
-	}
-	defer rows.Close()
-
-	var openings []*model.OpeningDetails
-	for rows.Next() {
-		openingDetails := &model.OpeningDetails{
-			Opening: &model.Opening{},
-			Address: &model.SAddress{},
-		}
-
-		var statsJSON sql.NullString
-		var applied bool
-		var applicationStatusStr sql.NullString
-		err := rows.Scan(
-			&openingDetails.Opening.Id,
-			&openingDetails.Opening.SportID,
-			&openingDetails.Opening.RecruiterID,
-			&openingDetails.Opening.CompanyName,
-			&openingDetails.Opening.Title,
-			&openingDetails.Opening.Description,
-			&openingDetails.Opening.Status,
-			&openingDetails.Opening.Position,
-			&openingDetails.Opening.MinAge,
-			&openingDetails.Opening.MaxAge,
-			&openingDetails.Opening.MinLevel,
-			&openingDetails.Opening.MinSalary,
-			&openingDetails.Opening.MaxSalary,
-			&openingDetails.Opening.CountryRestriction,
-			&openingDetails.Opening.AddressID,
-			&statsJSON,
-			&openingDetails.SportName,
-			&openingDetails.Address.Country,
-			&openingDetails.Address.State,
-			&openingDetails.Address.City,
-			&openingDetails.Address.Street,
-			&openingDetails.Address.Building,
-			&openingDetails.Address.PostalCode,
-			&applied,
-			&applicationStatusStr,
-		)
-
-		if err != nil {
-			return nil, db.NewDatabaseError("scan", "get all openings", err)
-		}
-
-		// Set application fields
-		openingDetails.Applied = applied
-		if applicationStatusStr.Valid {
-			appStatus := model.ApplicationStatus(applicationStatusStr.String)
-			openingDetails.ApplicationStatus = &appStatus
-		}
-
-		// Unmarshal the JSONB stats if present
-		if statsJSON.Valid && len(statsJSON.String) > 0 {
-			if err := json.Unmarshal([]byte(statsJSON.String), &openingDetails.Opening.Stats); err != nil {
-				return nil, db.NewDatabaseError("unmarshal", "stats", err)
-			}
-		}
-
-		openings = append(openings, openingDetails)
-	}
-
-	if err = rows.Err(); err != nil {
-		return nil, db.NewDatabaseError("rows", "get all openings", err)
-	}
-	return openings, nil
+// GetAllOpenings returns every opening, newest first
+func (r *Repository) GetAllOpenings(limit, offset int, after *model.Cursor, playerID *string) ([]*model.OpeningDetails, error) {
+	return r.listOpenings(nil, nil, limit, offset, after, playerID, "get all openings")
 }
 
-func (r *Repository) GetOpeningsBySport(sportName string, limit, offset int, playerID *string) ([]*model.OpeningDetails, error) {
-	var query string
-	var args []any
-
-	if playerID != nil {
-		query = `SELECT o.id, o.sport_id, o.recruiter_id, o.company_name, o.title, o.description, o.status, 
-					o.position, o.min_age, o.max_age, o.min_level, o.min_salary, o.max_salary, o.country_restriction, 
-					o.address_id, o.stats, s.name AS sport_name, a.country, a.state, a.city, a.street, a.building, a.postal_code,
-					CASE WHEN app.id IS NOT NULL THEN true ELSE false END AS applied,
-					app.status AS application_status
-					FROM "Opening" o 
-					JOIN "Sports" s ON o.sport_id = s.id 
-					JOIN "SAddress" a ON o.address_id = a.id 
-					LEFT JOIN "Application" app ON o.id = app.opening_id AND app.player_id = $4
-					WHERE s.name = $1
-					ORDER BY o.created_at DESC
-					LIMIT $2 OFFSET $3`
-		args = []any{sportName, limit, offset, *playerID}
-	} else {
-		query = `SELECT o.id, o.sport_id, o.recruiter_id, o.company_name, o.title, o.description, o.status, 
-					o.position, o.min_age, o.max_age, o.min_level, o.min_salary, o.max_salary, o.country_restriction, 
-					o.address_id, o.stats, s.name AS sport_name, a.country, a.state, a.city, a.street, a.building, a.postal_code,
-					false AS applied,
-					NULL AS application_status
-					FROM "Opening" o 
-					JOIN "Sports" s ON o.sport_id = s.id 
-					JOIN "SAddress" a ON o.address_id = a.id 
-					WHERE s.name = $1
-					ORDER BY o.created_at DESC
-					LIMIT $2 OFFSET $3`
-		args = []any{sportName, limit, offset}
-	}
-
-	rows, err := r.DB.Query(query, args...)
-	if err != nil {
-		return nil, db.NewDatabaseError("query", "get openings by sport", err)
-	}
-	defer rows.Close()
-
-	var openings []*model.OpeningDetails
-	for rows.Next() {
-		openingDetails := &model.OpeningDetails{
-			Opening: &model.Opening{},
-			Address: &model.SAddress{},
-		}
-
-		var statsJSON sql.NullString
-		var applied bool
-		var applicationStatusStr sql.NullString
-		err := rows.Scan(
-			&openingDetails.Opening.Id,
-			&openingDetails.Opening.SportID,
-			&openingDetails.Opening.RecruiterID,
-			&openingDetails.Opening.CompanyName,
-			&openingDetails.Opening.Title,
-			&openingDetails.Opening.Description,
-			&openingDetails.Opening.Status,
-			&openingDetails.Opening.Position,
-			&openingDetails.Opening.MinAge,
-			&openingDetails.Opening.MaxAge,
-			&openingDetails.Opening.MinLevel,
-			&openingDetails.Opening.MinSalary,
-			&openingDetails.Opening.MaxSalary,
-			&openingDetails.Opening.CountryRestriction,
-			&openingDetails.Opening.AddressID,
-			&statsJSON,
-			&openingDetails.SportName,
-			&openingDetails.Address.Country,
-			&openingDetails.Address.State,
-			&openingDetails.Address.City,
-			&openingDetails.Address.Street,
-			&openingDetails.Address.Building,
-			&openingDetails.Address.PostalCode,
-			&applied,
-			&applicationStatusStr,
-		)
-
-		if err != nil {
-			return nil, db.NewDatabaseError("scan", "get openings by sport", err)
-		}
-
-		// Set application fields
-		openingDetails.Applied = applied
-		if applicationStatusStr.Valid {
-			appStatus := model.ApplicationStatus(applicationStatusStr.String)
-			openingDetails.ApplicationStatus = &appStatus
-		}
-
-		// Unmarshal the JSONB stats if present
-		if statsJSON.Valid && len(statsJSON.String) > 0 {
-			if err := json.Unmarshal([]byte(statsJSON.String), &openingDetails.Opening.Stats); err != nil {
-				return nil, db.NewDatabaseError("unmarshal", "stats", err)
-			}
-		}
-
-		openings = append(openings, openingDetails)
-	}
-
-	if err = rows.Err(); err != nil {
-		return nil, db.NewDatabaseError("rows", "get openings by sport", err)
-	}
-	return openings, nil
+// GetOpeningsBySport returns the openings for a sport, newest first
+func (r *Repository) GetOpeningsBySport(sportName string, limit, offset int, after *model.Cursor, playerID *string) ([]*model.OpeningDetails, error) {
+	return r.listOpenings([]string{"s.name = $1"}, []any{sportName}, limit, offset, after, playerID, "get openings by sport")
 }
 
-// OpeningFilter represents the filter criteria for searching openings
-type OpeningFilter struct {
-	SportName          *string              `json:"sport_name,omitempty"`
-	Status             *model.OpeningStatus `json:"status,omitempty"`
-	MinAge             *int                 `json:"min_age,omitempty"`
-	MaxAge             *int                 `json:"max_age,omitempty"`
-	MinSalary          *int                 `json:"min_salary,omitempty"`
-	MaxSalary          *int                 `json:"max_salary,omitempty"`
-	CountryRestriction *string              `json:"country_restriction,omitempty"`
-	Country            *string              `json:"country,omitempty"`
-	State              *string              `json:"state,omitempty"`
-	City               *string              `json:"city,omitempty"`
-	CompanyName        *string              `json:"company_name,omitempty"`
-	Position           *string              `json:"position,omitempty"`
-	Applied            *bool                `json:"applied,omitempty"`
-}
-
-func (r *Repository) GetOpeningsByFilter(filter *OpeningFilter, limit, offset int, playerID *string) ([]*model.OpeningDetails, error) {
+func (r *Repository) GetOpeningsByFilter(filter *model.OpeningFilter, limit, offset int, after *model.Cursor, playerID *string) ([]*model.OpeningDetails, error) {
 	// If Applied filter is used but no playerID is provided, return error
 	if filter.Applied != nil && playerID == nil {
 		return nil, db.NewValidationError("authentication", "Authentication required to filter by applied status")
 	}
 
-	// Build the base query
-	var baseQuery string
-	if playerID != nil {
-		baseQuery = `SELECT o.id, o.sport_id, o.recruiter_id, o.company_name, o.title, o.description, o.status, 
-					o.position, o.min_age, o.max_age, o.min_level, o.min_salary, o.max_salary, o.country_restriction, 
-					o.address_id, o.stats, s.name AS sport_name, a.country, a.state, a.city, a.street, a.building, a.postal_code,
-					CASE WHEN app.id IS NOT NULL THEN true ELSE false END AS applied,
-					app.status AS application_status
-					FROM "Opening" o 
-					JOIN "Sports" s ON o.sport_id = s.id 
-					JOIN "SAddress" a ON o.address_id = a.id
-					LEFT JOIN "Application" app ON o.id = app.opening_id AND app.player_id = $` + strconv.Itoa(1)
-	} else {
-		baseQuery = `SELECT o.id, o.sport_id, o.recruiter_id, o.company_name, o.title, o.description, o.status, 
-					o.position, o.min_age, o.max_age, o.min_level, o.min_salary, o.max_salary, o.country_restriction, 
-					o.address_id, o.stats, s.name AS sport_name, a.country, a.state, a.city, a.street, a.building, a.postal_code,
-					false AS applied,
-					NULL AS application_status
-					FROM "Opening" o 
-					JOIN "Sports" s ON o.sport_id = s.id 
-					JOIN "SAddress" a ON o.address_id = a.id`
-	}
-
 	// Build WHERE conditions dynamically
 	var conditions []string
 	var args []any
 	argIndex := 1
 
-	// If playerID is provided, add it to args first
-	if playerID != nil {
-		args = append(args, *playerID)
-		argIndex++
-	}
-
 	if filter.SportName != nil {
 		conditions = append(conditions, "s.name = $"+strconv.Itoa(argIndex))
 		args = append(args, *filter.SportName)
@@ -738,83 +316,137 @@ func (r *Repository) GetOpeningsByFilter(filter *OpeningFilter, limit, offset in
 		}
 	}
 
-	// Construct the final query
-	query := baseQuery
+	return r.listOpenings(conditions, args, limit, offset, after, playerID, "get openings by filter")
+}
+
+// openingColumns selects an OpeningDetails for the opening aliased o, its
+// sport s and its address a. The applied and saved columns come from
+// openingJoins.
+const openingColumns = `o.id, o.sport_id, o.recruiter_id, o.company_name, o.title, o.description, o.status,
+	o.position, o.min_age, o.max_age, o.min_level, o.min_salary, o.max_salary, o.country_restriction,
+	o.address_id, o.stats, o.created_at, o.updated_at, s.name AS sport_name,
+	a.country, a.state, a.city, a.street, a.building, a.postal_code`
+
+// openingJoins returns the applied, application_status and saved columns
+// and the FROM clause of an opening query. When playerID is set it is
+// appended to args and the player's application and bookmark are looked up.
+func openingJoins(playerID *string, args []any) (string, []any) {
+	if playerID == nil {
+		return `, false AS applied, NULL AS application_status, false AS saved
+		FROM "Opening" o
+		JOIN "Sports" s ON o.sport_id = s.id
+		JOIN "SAddress" a ON o.address_id = a.id`, args
+	}
+	args = append(args, *playerID)
+	player := strconv.Itoa(len(args))
+	return `, CASE WHEN app.id IS NOT NULL THEN true ELSE false END AS applied, app.status AS application_status,
+		EXISTS (
+			SELECT 1 FROM "SavedItem" si
+			WHERE si.item_type = 'opening' AND si.item_id = o.id AND si.user_id = $` + player + `
+		) AS saved
+		FROM "Opening" o
+		JOIN "Sports" s ON o.sport_id = s.id
+		JOIN "SAddress" a ON o.address_id = a.id
+		LEFT JOIN "Application" app ON o.id = app.opening_id AND app.player_id = $` + player, args
+}
+
+// listOpenings returns the openings matching conditions, whose placeholders
+// are bound to args, newest first. The page starts after the cursor when
+// one is given and at offset otherwise. op names the query in errors.
+func (r *Repository) listOpenings(conditions []string, args []any, limit, offset int, after *model.Cursor, playerID *string, op string) ([]*model.OpeningDetails, error) {
+	from, args := openingJoins(playerID, args)
+	if after != nil {
+		conditions = append(conditions, afterCursor("o.created_at", "o.id", len(args)+1, true))
+		args = append(args, after.At, after.Id)
+		offset = 0
+	}
+
+	query := `SELECT ` + openingColumns + from
 	if len(conditions) > 0 {
 		query += " WHERE " + strings.Join(conditions, " AND ")
 	}
-	query += " ORDER BY o.created_at DESC LIMIT $" + strconv.Itoa(argIndex) + " OFFSET $" + strconv.Itoa(argIndex+1)
-
-	// Add limit and offset to args
+	query += " ORDER BY o.created_at DESC, o.id DESC LIMIT $" + strconv.Itoa(len(args)+1) + " OFFSET $" + strconv.Itoa(len(args)+2)
 	args = append(args, limit, offset)
 
 	rows, err := r.DB.Query(query, args...)
 	if err != nil {
-		return nil, db.NewDatabaseError("query", "get openings by filter", err)
+		return nil, db.NewDatabaseError("query", op, err)
 	}
 	defer rows.Close()
 
 	var openings []*model.OpeningDetails
 	for rows.Next() {
-		openingDetails := &model.OpeningDetails{
-			Opening: &model.Opening{},
-			Address: &model.SAddress{},
-		}
-
-		var statsJSON sql.NullString
-		var applied bool
-		var applicationStatusStr sql.NullString
-		err := rows.Scan(
-			&openingDetails.Opening.Id,
-			&openingDetails.Opening.SportID,
-			&openingDetails.Opening.RecruiterID,
-			&openingDetails.Opening.CompanyName,
-			&openingDetails.Opening.Title,
-			&openingDetails.Opening.Description,
-			&openingDetails.Opening.Status,
-			&openingDetails.Opening.Position,
-			&openingDetails.Opening.MinAge,
-			&openingDetails.Opening.MaxAge,
-			&openingDetails.Opening.MinLevel,
-			&openingDetails.Opening.MinSalary,
-			&openingDetails.Opening.MaxSalary,
-			&openingDetails.Opening.CountryRestriction,
-			&openingDetails.Opening.AddressID,
-			&statsJSON,
-			&openingDetails.SportName,
-			&openingDetails.Address.Country,
-			&openingDetails.Address.State,
-			&openingDetails.Address.City,
-			&openingDetails.Address.Street,
-			&openingDetails.Address.Building,
-			&openingDetails.Address.PostalCode,
-			&applied,
-			&applicationStatusStr,
-		)
-
+		openingDetails, err := scanOpeningDetails(rows)
 		if err != nil {
-			return nil, db.NewDatabaseError("scan", "get openings by filter", err)
+			return nil, err
 		}
+		openings = append(openings, openingDetails)
+	}
 
-		// Set application fields
-		openingDetails.Applied = applied
-		if applicationStatusStr.Valid {
-			appStatus := model.ApplicationStatus(applicationStatusStr.String)
-			openingDetails.ApplicationStatus = &appStatus
-		}
+	if err = rows.Err(); err != nil {
+		return nil, db.NewDatabaseError("rows", op, err)
+	}
+	return openings, nil
+}
 
-		// Unmarshal the JSONB stats if present
-		if statsJSON.Valid && len(statsJSON.String) > 0 {
-			if err := json.Unmarshal([]byte(statsJSON.String), &openingDetails.Opening.Stats); err != nil {
-				return nil, db.NewDatabaseError("unmarshal", "stats", err)
-			}
+// scanOpeningDetails reads a row selected with openingColumns and
+// openingJoins.
+func scanOpeningDetails(row interface{ Scan(dest ...any) error }) (*model.OpeningDetails, error) {
+	openingDetails := &model.OpeningDetails{
+		Opening: &model.Opening{},
+		Address: &model.SAddress{},
+	}
+
+	var statsJSON sql.NullString
+	var applicationStatusStr sql.NullString
+	err := row.Scan(
+		&openingDetails.Opening.Id,
+		&openingDetails.Opening.SportID,
+		&openingDetails.Opening.RecruiterID,
+		&openingDetails.Opening.CompanyName,
+		&openingDetails.Opening.Title,
+		&openingDetails.Opening.Description,
+		&openingDetails.Opening.Status,
+		&openingDetails.Opening.Position,
+		&openingDetails.Opening.MinAge,
+		&openingDetails.Opening.MaxAge,
+		&openingDetails.Opening.MinLevel,
+		&openingDetails.Opening.MinSalary,
+		&openingDetails.Opening.MaxSalary,
+		&openingDetails.Opening.CountryRestriction,
+		&openingDetails.Opening.AddressID,
+		&statsJSON,
+		&openingDetails.Opening.CreatedAt,
+		&openingDetails.Opening.UpdatedAt,
+		&openingDetails.SportName,
+		&openingDetails.Address.Country,
+		&openingDetails.Address.State,
+		&openingDetails.Address.City,
+		&openingDetails.Address.Street,
+		&openingDetails.Address.Building,
+		&openingDetails.Address.PostalCode,
+		&openingDetails.Applied,
+		&applicationStatusStr,
+		&openingDetails.Saved,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err
 		}
+		return nil, db.NewDatabaseError("scan", "Opening", err)
+	}
 
-		openings = append(openings, openingDetails)
+	if applicationStatusStr.Valid {
+		appStatus := model.ApplicationStatus(applicationStatusStr.String)
+		openingDetails.ApplicationStatus = &appStatus
 	}
 
-	if err = rows.Err(); err != nil {
-		return nil, db.NewDatabaseError("rows", "get openings by filter", err)
+	// Unmarshal the JSONB stats if present
+	if statsJSON.Valid && len(statsJSON.String) > 0 {
+		if err := json.Unmarshal([]byte(statsJSON.String), &openingDetails.Opening.Stats); err != nil {
+			return nil, db.NewDatabaseError("unmarshal", "stats", err)
+		}
 	}
-	return openings, nil
+
+	return openingDetails, nil
 }
@@ -8,13 +8,29 @@ import (
 	"sportsin_backend/internals/model"
 )
 
-// CreatePostImage creates a new post image record in the database
+// postMediaObject builds the JSON of a row of "PostImages" aliased pi, in
+// the shape of model.PostImage
+const postMediaObject = `json_build_object('id', pi.id, 'post_id', pi.post_id, 'image_url', pi.image_url,
+						'media_type', pi.media_type, 'status', pi.status, 'thumbnail_url', pi.thumbnail_url,
+						'duration_seconds', pi.duration_seconds, 'processing_error', pi.processing_error)`
+
+const postMediaColumns = `id, post_id, image_url, media_type, status, thumbnail_url, duration_seconds, processing_error`
+
+// CreatePostImage creates a new post image record in the database. Media
+// type and status default to a ready image.
 func (repo *Repository) CreatePostImage(postImage *model.PostImage) error {
-	query := `INSERT INTO "PostImages" (id, post_id, image_url)
-			  VALUES (gen_random_uuid(), $1, $2)
+	if postImage.MediaType == "" {
+		postImage.MediaType = model.PostMediaImage
+	}
+	if postImage.Status == "" {
+		postImage.Status = model.MediaStatusReady
+	}
+
+	query := `INSERT INTO "PostImages" (id, post_id, image_url, media_type, status)
+			  VALUES (gen_random_uuid(), $1, $2, $3, $4)
 			  RETURNING id`
 
-	err := repo.DB.QueryRow(query, postImage.PostId, postImage.ImageUrl).Scan(&postImage.Id)
+	err := repo.DB.QueryRow(query, postImage.PostId, postImage.ImageUrl, postImage.MediaType, postImage.Status).Scan(&postImage.Id)
 	if err != nil {
 		log.Printf("Error creating post image: %v", err)
 		return db.NewDatabaseError("insert", "PostImages", err)
@@ -25,7 +41,7 @@ func (repo *Repository) CreatePostImage(postImage *model.PostImage) error {
 
 func (repo *Repository) GetImagesByPostId(postId string) ([]model.PostImage, error) {
 	var images []model.PostImage
-	query := `SELECT id, post_id, image_url FROM "PostImages" WHERE post_id = $1 ORDER BY id`
+	query := `SELECT ` + postMediaColumns + ` FROM "PostImages" WHERE post_id = $1 ORDER BY id`
 
 	rows, err := repo.DB.Query(query, postId)
 	if err != nil {
@@ -35,8 +51,7 @@ func (repo *Repository) GetImagesByPostId(postId string) ([]model.PostImage, err
 	defer rows.Close()
 
 	for rows.Next() {
-		var image model.PostImage
-		err := rows.Scan(&image.Id, &image.PostId, &image.ImageUrl)
+		image, err := scanPostMedia(rows)
 		if err != nil {
 			log.Printf("Error scanning post image: %v", err)
 			continue
@@ -47,9 +62,13 @@ func (repo *Repository) GetImagesByPostId(postId string) ([]model.PostImage, err
 	return images, nil
 }
 
+// DeleteImagesByPostId deletes the media of a post and returns the URLs of
+// the files to remove from storage, video thumbnails included
 func (repo *Repository) DeleteImagesByPostId(postId string) ([]string, error) {
 	var imageUrls []string
-	selectQuery := `SELECT image_url FROM "PostImages" WHERE post_id = $1`
+	selectQuery := `SELECT image_url FROM "PostImages" WHERE post_id = $1
+			  UNION ALL
+			  SELECT thumbnail_url FROM "PostImages" WHERE post_id = $1 AND thumbnail_url IS NOT NULL`
 
 	rows, err := repo.DB.Query(selectQuery, postId)
 	if err != nil {
@@ -93,3 +112,70 @@ func (repo *Repository) DeletePostImage(imageId string) (string, error) {
 
 	return imageUrl, nil
 }
+
+// GetUnprocessedPostMedia returns the videos still waiting for the media
+// worker, oldest first, so that jobs lost in a restart can be queued again
+func (repo *Repository) GetUnprocessedPostMedia() ([]model.PostImage, error) {
+	rows, err := repo.DB.Query(`SELECT ` + postMediaColumns + ` FROM "PostImages"
+	WHERE status IN ('pending', 'processing') ORDER BY id`)
+	if err != nil {
+		log.Printf("Error getting unprocessed post media: %v", err)
+		return nil, db.NewDatabaseError("select", "PostImages", err)
+	}
+	defer rows.Close()
+
+	media := []model.PostImage{}
+	for rows.Next() {
+		m, err := scanPostMedia(rows)
+		if err != nil {
+			return nil, db.NewDatabaseError("scan", "PostImages", err)
+		}
+		media = append(media, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, db.NewDatabaseError("select", "PostImages", err)
+	}
+	return media, nil
+}
+
+// UpdatePostMediaStatus records how far the processing of a video has got.
+// processingError is kept for the failed status and cleared otherwise.
+func (repo *Repository) UpdatePostMediaStatus(mediaId string, status model.MediaStatus, processingError *string) error {
+	result, err := repo.DB.Exec(`UPDATE "PostImages" SET status = $2, processing_error = $3 WHERE id = $1`,
+		mediaId, status, processingError)
+	if err != nil {
+		log.Printf("Error updating status of post media %s: %v", mediaId, err)
+		return db.NewDatabaseError("update", "PostImages", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return db.NewNotFoundError("post media", mediaId)
+	}
+	return nil
+}
+
+// CompletePostMedia stores the thumbnail and duration of a processed video
+// and marks it ready
+func (repo *Repository) CompletePostMedia(media *model.PostImage) error {
+	media.Status = model.MediaStatusReady
+	media.ProcessingError = nil
+
+	result, err := repo.DB.Exec(`UPDATE "PostImages"
+	SET status = $2, thumbnail_url = $3, duration_seconds = $4, processing_error = NULL
+	WHERE id = $1`, media.Id, media.Status, media.ThumbnailUrl, media.DurationSeconds)
+	if err != nil {
+		log.Printf("Error completing post media %s: %v", media.Id, err)
+		return db.NewDatabaseError("update", "PostImages", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return db.NewNotFoundError("post media", media.Id)
+	}
+	return nil
+}
+
+// scanPostMedia reads a row selected with postMediaColumns
+func scanPostMedia(row interface{ Scan(dest ...any) error }) (model.PostImage, error) {
+	var m model.PostImage
+	err := row.Scan(&m.Id, &m.PostId, &m.ImageUrl, &m.MediaType, &m.Status,
+		&m.ThumbnailUrl, &m.DurationSeconds, &m.ProcessingError)
+	return m, err
+}
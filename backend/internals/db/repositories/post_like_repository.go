@@ -2,18 +2,20 @@ package repositories
 
 import (
 	"database/sql"
-	"fmt"
 	"log"
 
 	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/model"
 )
 
-// LikePost adds a like to a post and increments the post's like_count.
+// ReactToPost sets the user's reaction to a post. A user who already
+// reacted differently has their reaction changed; a new reaction also
+// increments the post's like_count, which counts reactions of every type.
 // It performs these operations in a single database transaction.
-func (repo *Repository) LikePost(postID, userID string) error {
+func (repo *Repository) ReactToPost(postID, userID string, reaction model.ReactionType) error {
 	tx, err := repo.DB.Begin()
 	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
+		return db.NewDatabaseError("begin", "transaction", err)
 	}
 	// Defer a rollback. If the transaction is committed, this is a no-op.
 	defer func() {
@@ -22,30 +24,54 @@ func (repo *Repository) LikePost(postID, userID string) error {
 		}
 	}()
 
-	// 1. Insert a record into the post_likes table.
-	_, err = tx.Exec(`INSERT INTO "post_likes" (post_id, user_id) VALUES ($1, $2)`, postID, userID)
+	var exists bool
+	if err := tx.QueryRow(`SELECT EXISTS (SELECT 1 FROM "Post" WHERE id = $1)`, postID).Scan(&exists); err != nil {
+		log.Printf("Error checking post %s: %v", postID, err)
+		return db.NewDatabaseError("select", "Post", err)
+	}
+	if !exists {
+		return db.NewNotFoundError("post", postID)
+	}
+
+	// 1. Insert or change the reaction. No row comes back when the user
+	// already left this very reaction, and xmax is 0 only for a new row.
+	var inserted bool
+	err = tx.QueryRow(`
+		INSERT INTO "post_likes" (post_id, user_id, reaction) VALUES ($1, $2, $3)
+		ON CONFLICT (post_id, user_id) DO UPDATE SET reaction = EXCLUDED.reaction
+		WHERE "post_likes".reaction <> EXCLUDED.reaction
+		RETURNING (xmax = 0)
+	`, postID, userID, reaction).Scan(&inserted)
+	if err == sql.ErrNoRows {
+		return db.NewAlreadyExistsError("post reaction", "post_id and user_id combination", postID+" + "+userID)
+	}
 	if err != nil {
-		log.Printf("Error creating post like: %v", err)
+		log.Printf("Error saving post reaction: %v", err)
 		return db.NewDatabaseError("insert", "post_likes", err)
 	}
 
-	// 2. Increment the like_count in the Post table.
-	_, err = tx.Exec(`UPDATE "Post" SET like_count = like_count + 1 WHERE id = $1`, postID)
-	if err != nil {
-		log.Printf("Error incrementing post like count: %v", err)
-		return fmt.Errorf("failed to increment post like count: %w", err)
+	// 2. Increment the like_count in the Post table for a new reaction.
+	if inserted {
+		if _, err := tx.Exec(`UPDATE "Post" SET like_count = like_count + 1 WHERE id = $1`, postID); err != nil {
+			log.Printf("Error incrementing post like count: %v", err)
+			return db.NewDatabaseError("update", "Post", err)
+		}
 	}
 
 	// Commit the transaction.
-	return tx.Commit()
+	if err := tx.Commit(); err != nil {
+		return db.NewDatabaseError("commit", "transaction", err)
+	}
+	return nil
 }
 
-// UnlikePost removes a like from a post and decrements the post's like_count.
-// It performs these operations in a single database transaction.
-func (repo *Repository) UnlikePost(postID, userID string) error {
+// RemovePostReaction removes the user's reaction to a post, whatever its
+// type, and decrements the post's like_count. Removing a reaction that does
+// not exist is a no-op.
+func (repo *Repository) RemovePostReaction(postID, userID string) error {
 	tx, err := repo.DB.Begin()
 	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
+		return db.NewDatabaseError("begin", "transaction", err)
 	}
 	// Defer a rollback. If the transaction is committed, this is a no-op.
 	defer func() {
@@ -55,19 +81,24 @@ func (repo *Repository) UnlikePost(postID, userID string) error {
 	}()
 
 	// 1. Delete the record from the post_likes table.
-	_, err = tx.Exec(`DELETE FROM "post_likes" WHERE post_id = $1 AND user_id = $2`, postID, userID)
+	res, err := tx.Exec(`DELETE FROM "post_likes" WHERE post_id = $1 AND user_id = $2`, postID, userID)
 	if err != nil {
-		log.Printf("Error deleting post like: %v", err)
-		return fmt.Errorf("failed to delete post like: %w", err)
+		log.Printf("Error deleting post reaction: %v", err)
+		return db.NewDatabaseError("delete", "post_likes", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil
 	}
 
 	// 2. Decrement the like_count in the Post table.
-	_, err = tx.Exec(`UPDATE "Post" SET like_count = like_count - 1 WHERE id = $1`, postID)
-	if err != nil {
+	if _, err := tx.Exec(`UPDATE "Post" SET like_count = like_count - 1 WHERE id = $1`, postID); err != nil {
 		log.Printf("Error decrementing post like count: %v", err)
-		return fmt.Errorf("failed to decrement post like count: %w", err)
+		return db.NewDatabaseError("update", "Post", err)
 	}
 
 	// Commit the transaction.
-	return tx.Commit()
+	if err := tx.Commit(); err != nil {
+		return db.NewDatabaseError("commit", "transaction", err)
+	}
+	return nil
 }
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/lib/pq"
 	"sportsin_backend/internals/db"
 	"sportsin_backend/internals/model"
 )
@@ -25,12 +26,20 @@ func (repo *Repository) CreatePost(post *model.Post) error {
 		return db.ErrContentEmpty
 	}
 
-	query := `INSERT INTO "Post" (id, user_id, created_at, updated_at, content, tags)
-			  VALUES (gen_random_uuid(), $1, $2, $3, $4, $5)
+	tx, err := repo.DB.Begin()
+	if err != nil {
+		return db.NewDatabaseError("begin", "transaction", err)
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO "Post" (id, user_id, created_at, updated_at, content)
+			  VALUES (gen_random_uuid(), $1, $2, $3, $4)
 			  RETURNING id, created_at, updated_at`
 
-	now := time.Now()
-	err := repo.DB.QueryRow(query, post.UserId, now, now, post.Content, post.Tags).Scan(
+	// created_at has no time zone and is compared with UTC times by the
+	// feed and the keyset cursors, so every write stores UTC
+	now := time.Now().UTC()
+	err = tx.QueryRow(query, post.UserId, now, now, post.Content).Scan(
 		&post.Id, &post.CreatedAt, &post.UpdatedAt)
 
 	if err != nil {
@@ -44,6 +53,14 @@ func (repo *Repository) CreatePost(post *model.Post) error {
 		log.Printf("Critical error creating post for user %s: %v", post.UserId, err)
 		return db.NewDatabaseError("insert", "Post", err)
 	}
+	post.Kind = model.PostKindPost
+
+	if err := setPostTags(tx, post.Id, post.Tags); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return db.NewDatabaseError("commit", "transaction", err)
+	}
 
 	return nil
 }
@@ -58,24 +75,28 @@ func (repo *Repository) GetPostById(postId string) (*model.Post, error) {
 	query := `
 		SELECT 
 			p.id, p.user_id, p.created_at, p.updated_at, p.content, 
-			COALESCE(p.tags, '') as tags, COALESCE(p.like_count, 0) as like_count,
+			` + postTagsColumn + `, COALESCE(p.like_count, 0) as like_count,
 			COALESCE(
 				array_agg(
 					CASE WHEN pi.id IS NOT NULL 
-					THEN json_build_object('id', pi.id, 'post_id', pi.post_id, 'image_url', pi.image_url)
+					THEN ` + postMediaObject + `
 					END
 				) FILTER (WHERE pi.id IS NOT NULL), 
 				'{}'
-			) as images
+			) as images,
+			` + postShareColumns + `
 		FROM "Post" p
 		LEFT JOIN "PostImages" pi ON p.id = pi.post_id
+		` + postShareJoin + `
 		WHERE p.id = $1
-		GROUP BY p.id, p.user_id, p.created_at, p.updated_at, p.content, p.tags, p.like_count`
+		GROUP BY p.id, p.user_id, p.created_at, p.updated_at, p.content, p.like_count, op.id`
 
 	var imagesJSON string
+	var share postShare
 	err := repo.DB.QueryRow(query, postId).Scan(
 		&post.Id, &post.UserId, &post.CreatedAt, &post.UpdatedAt,
-		&post.Content, &post.Tags, &post.LikeCount, &imagesJSON)
+		&post.Content, pq.Array(&post.Tags), &post.LikeCount, &imagesJSON,
+		&share.kind, &share.repostOfId, &share.userId, &share.content, &share.createdAt, &post.ShareCount)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -84,6 +105,7 @@ func (repo *Repository) GetPostById(postId string) (*model.Post, error) {
 		log.Printf("Critical error getting post by ID %s: %v", postId, err)
 		return nil, db.NewDatabaseError("select", "Post", err)
 	}
+	share.apply(&post)
 
 	// Parse images JSON
 	var images []model.PostImage
@@ -100,7 +122,8 @@ func (repo *Repository) GetPostById(postId string) (*model.Post, error) {
 }
 
 // GetPostsByUserId retrieves all posts by a specific user with images
-func (repo *Repository) GetPostsByUserId(userId string, limit, offset int) ([]model.Post, error) {
+// ordered newest first, starting after the cursor when one is given
+func (repo *Repository) GetPostsByUserId(userId string, limit, offset int, after *model.Cursor) ([]model.Post, error) {
 	if userId == "" {
 		return nil, db.ErrUserIDMissing
 	}
@@ -112,26 +135,35 @@ func (repo *Repository) GetPostsByUserId(userId string, limit, offset int) ([]mo
 	}
 
 	var posts []model.Post
+	where := `p.user_id = $1`
+	args := []any{userId, limit, offset}
+	if after != nil {
+		where += ` AND ` + afterCursor("p.created_at", "p.id", 4, true)
+		args = append(args, after.At, after.Id)
+		args[2] = 0
+	}
 	query := `
 		SELECT 
 			p.id, p.user_id, p.created_at, p.updated_at, p.content, 
-			COALESCE(p.tags, '') as tags, COALESCE(p.like_count, 0) as like_count,
+			` + postTagsColumn + `, COALESCE(p.like_count, 0) as like_count,
 			COALESCE(
 				array_agg(
 					CASE WHEN pi.id IS NOT NULL 
-					THEN json_build_object('id', pi.id, 'post_id', pi.post_id, 'image_url', pi.image_url)
+					THEN ` + postMediaObject + `
 					END
 				) FILTER (WHERE pi.id IS NOT NULL), 
 				'{}'
-			) as images
+			) as images,
+			` + postShareColumns + `
 		FROM "Post" p
 		LEFT JOIN "PostImages" pi ON p.id = pi.post_id
-		WHERE p.user_id = $1
-		GROUP BY p.id, p.user_id, p.created_at, p.updated_at, p.content, p.tags, p.like_count
-		ORDER BY p.created_at DESC 
+		` + postShareJoin + `
+		WHERE ` + where + `
+		GROUP BY p.id, p.user_id, p.created_at, p.updated_at, p.content, p.like_count, op.id
+		ORDER BY p.created_at DESC, p.id DESC
 		LIMIT $2 OFFSET $3`
 
-	rows, err := repo.DB.Query(query, userId, limit, offset)
+	rows, err := repo.DB.Query(query, args...)
 	if err != nil {
 		log.Printf("Critical error getting posts by user ID %s: %v", userId, err)
 		return nil, db.NewDatabaseError("select", "Post", err)
@@ -141,14 +173,17 @@ func (repo *Repository) GetPostsByUserId(userId string, limit, offset int) ([]mo
 	for rows.Next() {
 		var post model.Post
 		var imagesJSON string
+		var share postShare
 
 		err := rows.Scan(
 			&post.Id, &post.UserId, &post.CreatedAt, &post.UpdatedAt,
-			&post.Content, &post.Tags, &post.LikeCount, &imagesJSON)
+			&post.Content, pq.Array(&post.Tags), &post.LikeCount, &imagesJSON,
+			&share.kind, &share.repostOfId, &share.userId, &share.content, &share.createdAt, &post.ShareCount)
 		if err != nil {
 			log.Printf("Critical error scanning post data: %v", err)
 			continue
 		}
+		share.apply(&post)
 
 		// Parse images JSON
 		var images []model.PostImage
@@ -168,7 +203,8 @@ func (repo *Repository) GetPostsByUserId(userId string, limit, offset int) ([]mo
 }
 
 // GetAllPosts retrieves all posts with pagination and images
-func (repo *Repository) GetAllPosts(limit, offset int) ([]model.Post, error) {
+// ordered newest first, starting after the cursor when one is given
+func (repo *Repository) GetAllPosts(limit, offset int, after *model.Cursor) ([]model.Post, error) {
 	if limit <= 0 {
 		return nil, db.ErrInvalidLimit
 	}
@@ -177,25 +213,35 @@ func (repo *Repository) GetAllPosts(limit, offset int) ([]model.Post, error) {
 	}
 
 	var posts []model.Post
+	where := `TRUE`
+	args := []any{limit, offset}
+	if after != nil {
+		where = afterCursor("p.created_at", "p.id", 3, true)
+		args = append(args, after.At, after.Id)
+		args[1] = 0
+	}
 	query := `
 		SELECT 
 			p.id, p.user_id, p.created_at, p.updated_at, p.content, 
-			COALESCE(p.tags, '') as tags, COALESCE(p.like_count, 0) as like_count,
+			` + postTagsColumn + `, COALESCE(p.like_count, 0) as like_count,
 			COALESCE(
 				array_agg(
 					CASE WHEN pi.id IS NOT NULL 
-					THEN json_build_object('id', pi.id, 'post_id', pi.post_id, 'image_url', pi.image_url)
+					THEN ` + postMediaObject + `
 					END
 				) FILTER (WHERE pi.id IS NOT NULL), 
 				'{}'
-			) as images
+			) as images,
+			` + postShareColumns + `
 		FROM "Post" p
 		LEFT JOIN "PostImages" pi ON p.id = pi.post_id
-		GROUP BY p.id, p.user_id, p.created_at, p.updated_at, p.content, p.tags, p.like_count
-		ORDER BY p.created_at DESC 
+		` + postShareJoin + `
+		WHERE ` + where + `
+		GROUP BY p.id, p.user_id, p.created_at, p.updated_at, p.content, p.like_count, op.id
+		ORDER BY p.created_at DESC, p.id DESC
 		LIMIT $1 OFFSET $2`
 
-	rows, err := repo.DB.Query(query, limit, offset)
+	rows, err := repo.DB.Query(query, args...)
 	if err != nil {
 		log.Printf("Critical error getting all posts: %v", err)
 		return nil, db.NewDatabaseError("select", "Post", err)
@@ -205,14 +251,17 @@ func (repo *Repository) GetAllPosts(limit, offset int) ([]model.Post, error) {
 	for rows.Next() {
 		var post model.Post
 		var imagesJSON string
+		var share postShare
 
 		err := rows.Scan(
 			&post.Id, &post.UserId, &post.CreatedAt, &post.UpdatedAt,
-			&post.Content, &post.Tags, &post.LikeCount, &imagesJSON)
+			&post.Content, pq.Array(&post.Tags), &post.LikeCount, &imagesJSON,
+			&share.kind, &share.repostOfId, &share.userId, &share.content, &share.createdAt, &post.ShareCount)
 		if err != nil {
 			log.Printf("Critical error scanning post data: %v", err)
 			continue
 		}
+		share.apply(&post)
 
 		// Parse images JSON
 		var images []model.PostImage
@@ -246,12 +295,20 @@ func (repo *Repository) UpdatePost(post *model.Post) error {
 		return db.ErrContentEmpty
 	}
 
-	query := `UPDATE "Post" SET content = $1, tags = $2, updated_at = $3 
-			  WHERE id = $4 AND user_id = $5
+	tx, err := repo.DB.Begin()
+	if err != nil {
+		return db.NewDatabaseError("begin", "transaction", err)
+	}
+	defer tx.Rollback()
+
+	// A plain repost given content of its own becomes a quote
+	query := `UPDATE "Post" SET content = $1, updated_at = $2,
+			  kind = CASE WHEN kind = 'repost' THEN 'quote' ELSE kind END
+			  WHERE id = $3 AND user_id = $4
 			  RETURNING updated_at`
 
-	now := time.Now()
-	err := repo.DB.QueryRow(query, post.Content, post.Tags, now, post.Id, post.UserId).Scan(&post.UpdatedAt)
+	now := time.Now().UTC()
+	err = tx.QueryRow(query, post.Content, now, post.Id, post.UserId).Scan(&post.UpdatedAt)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -261,37 +318,93 @@ func (repo *Repository) UpdatePost(post *model.Post) error {
 		return db.NewDatabaseError("update", "Post", err)
 	}
 
+	if err := setPostTags(tx, post.Id, post.Tags); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return db.NewDatabaseError("commit", "transaction", err)
+	}
+
 	return nil
 }
 
-// DeletePost deletes a post from the database
-func (repo *Repository) DeletePost(postId, userId string) error {
+// DeletePost deletes a post and its media rows from the database. Plain
+// reposts of it go with it, while quotes of it are kept as tombstones. It
+// returns the URLs of the removed media, which the caller deletes from
+// storage once the post is gone.
+func (repo *Repository) DeletePost(postId, userId string, action *model.AdminAction) ([]string, error) {
 	if postId == "" {
-		return db.NewValidationError("post_id", "post ID is required")
+		return nil, db.NewValidationError("post_id", "post ID is required")
 	}
 	if userId == "" {
-		return db.ErrUserIDMissing
+		return nil, db.ErrUserIDMissing
+	}
+
+	tx, err := repo.DB.Begin()
+	if err != nil {
+		return nil, db.NewDatabaseError("begin", "transaction", err)
+	}
+	defer tx.Rollback()
+
+	// Only the author's post has media to remove; the ownership check below
+	// rolls this back for anyone else
+	rows, err := tx.Query(`DELETE FROM "PostImages" WHERE post_id = $1 RETURNING image_url, thumbnail_url`, postId)
+	if err != nil {
+		log.Printf("Critical error deleting media of post %s: %v", postId, err)
+		return nil, db.NewDatabaseError("delete", "PostImages", err)
+	}
+	var mediaUrls []string
+	for rows.Next() {
+		var imageUrl string
+		var thumbnailUrl sql.NullString
+		if err := rows.Scan(&imageUrl, &thumbnailUrl); err != nil {
+			rows.Close()
+			return nil, db.NewDatabaseError("scan", "PostImages", err)
+		}
+		mediaUrls = append(mediaUrls, imageUrl)
+		if thumbnailUrl.Valid {
+			mediaUrls = append(mediaUrls, thumbnailUrl.String)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, db.NewDatabaseError("delete", "PostImages", err)
+	}
+
+	// Plain reposts have nothing of their own to show once the original is
+	// gone. Quotes lose their repost_of_id to the foreign key instead.
+	if _, err := tx.Exec(`DELETE FROM "Post" WHERE kind = 'repost' AND repost_of_id = $1`, postId); err != nil {
+		log.Printf("Critical error deleting reposts of post %s: %v", postId, err)
+		return nil, db.NewDatabaseError("delete", "Post", err)
 	}
 
 	query := `DELETE FROM "Post" WHERE id = $1 AND user_id = $2`
 
-	result, err := repo.DB.Exec(query, postId, userId)
+	result, err := tx.Exec(query, postId, userId)
 	if err != nil {
 		log.Printf("Critical error deleting post %s: %v", postId, err)
-		return db.NewDatabaseError("delete", "Post", err)
+		return nil, db.NewDatabaseError("delete", "Post", err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
 		log.Printf("Critical error getting rows affected for post deletion %s: %v", postId, err)
-		return db.NewDatabaseError("delete", "Post", err)
+		return nil, db.NewDatabaseError("delete", "Post", err)
 	}
 
 	if rowsAffected == 0 {
-		return db.NewAuthorizationError("delete", "post", userId)
+		return nil, db.NewAuthorizationError("delete", "post", userId)
 	}
 
-	return nil
+	if err := insertAdminAction(tx, action); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, db.NewDatabaseError("commit", "transaction", err)
+	}
+
+	return mediaUrls, nil
 }
 
 // CheckPostOwnership verifies if a user owns a specific post
@@ -316,7 +429,8 @@ func (repo *Repository) CheckPostOwnership(postId, userId string) (bool, error)
 }
 
 // GetAllPostsWithComments returns all posts with their latest comment and total comment count
-func (repo *Repository) GetAllPostsWithComments(limit, offset int, userId string) ([]model.PostWithComment, error) {
+// ordered newest first, starting after the cursor when one is given
+func (repo *Repository) GetAllPostsWithComments(limit, offset int, after *model.Cursor, userId string) ([]model.PostWithComment, error) {
 	if limit <= 0 {
 		return nil, db.ErrInvalidLimit
 	}
@@ -324,113 +438,29 @@ func (repo *Repository) GetAllPostsWithComments(limit, offset int, userId string
 		return nil, db.ErrInvalidOffset
 	}
 
-	var postsWithComments []model.PostWithComment
-	query := `
-		SELECT 
-			p.id, p.user_id, p.created_at, p.updated_at, p.content, 
-			COALESCE(p.tags, '') as tags, COALESCE(p.like_count, 0) as like_count,
-			COALESCE(
-				json_agg(
-					json_build_object('id', pi.id, 'post_id', pi.post_id, 'image_url', pi.image_url)
-				) FILTER (WHERE pi.id IS NOT NULL), 
-				'[]'
-			) as images,
-			-- Latest comment subquery
-			lc.id as latest_comment_id,
-			lc.userid as latest_comment_user_id,
-			lc.postid as latest_comment_post_id,
-			lc.parentid as latest_comment_parent_id,
-			lc.content as latest_comment_content,
-			lc.createdat as latest_comment_created_at,
-			lc.updatedat as latest_comment_updated_at,
-			-- Total comment count
-			COALESCE(cc.total_comments, 0) as total_comments,
-			-- Check if user liked this post
-			CASE WHEN pl.user_id IS NOT NULL THEN true ELSE false END as user_liked
-		FROM "Post" p
-		LEFT JOIN "PostImages" pi ON p.id = pi.post_id
-		LEFT JOIN LATERAL (
-			SELECT id, userid, postid, parentid, content, createdat, updatedat
-			FROM "Comment" c
-			WHERE c.postid = p.id AND c.parentid IS NULL
-			ORDER BY c.createdat DESC
-			LIMIT 1
-		) lc ON true
-		LEFT JOIN (
-			SELECT postid, COUNT(*) as total_comments
-			FROM "Comment"
-			GROUP BY postid
-		) cc ON cc.postid = p.id
-		LEFT JOIN "post_likes" pl ON p.id = pl.post_id AND pl.user_id = $3
-		GROUP BY p.id, p.user_id, p.created_at, p.updated_at, p.content, p.tags, p.like_count,
-				 lc.id, lc.userid, lc.postid, lc.parentid, lc.content, lc.createdat, lc.updatedat,
-				 cc.total_comments, pl.user_id
-		ORDER BY p.created_at DESC 
-		LIMIT $1 OFFSET $2`
+	where := repo.visibleClause(model.ReportTargetPost, "p.id")
+	args := []any{limit, offset, userId}
+	if after != nil {
+		where += ` AND ` + afterCursor("p.created_at", "p.id", 4, true)
+		args = append(args, after.At, after.Id)
+		args[1] = 0
+	}
+	query := repo.postWithCommentQuery("$3", where,
+		`ORDER BY p.created_at DESC, p.id DESC LIMIT $1 OFFSET $2`)
 
-	rows, err := repo.DB.Query(query, limit, offset, userId)
+	rows, err := repo.DB.Query(query, args...)
 	if err != nil {
 		log.Printf("Critical error getting all posts with comments: %v", err)
 		return nil, db.NewDatabaseError("select", "Post", err)
 	}
 	defer rows.Close()
 
-	for rows.Next() {
-		var postWithComment model.PostWithComment
-		var imagesJSON string
-		var latestCommentID, latestCommentUserID, latestCommentPostID sql.NullString
-		var latestCommentParentID sql.NullString
-		var latestCommentContent, latestCommentCreatedAt, latestCommentUpdatedAt sql.NullString
-
-		err := rows.Scan(
-			&postWithComment.Id, &postWithComment.UserId, &postWithComment.CreatedAt, &postWithComment.UpdatedAt,
-			&postWithComment.Content, &postWithComment.Tags, &postWithComment.LikeCount, &imagesJSON,
-			&latestCommentID, &latestCommentUserID, &latestCommentPostID, &latestCommentParentID,
-			&latestCommentContent, &latestCommentCreatedAt, &latestCommentUpdatedAt,
-			&postWithComment.TotalComments, &postWithComment.UserLiked)
-		if err != nil {
-			log.Printf("Critical error scanning post with comment data: %v", err)
-			continue
-		}
-
-		// Parse images JSON
-		var images []model.PostImage
-		if imagesJSON != "[]" && imagesJSON != "" {
-			err = json.Unmarshal([]byte(imagesJSON), &images)
-			if err != nil {
-				log.Printf("Error unmarshaling images JSON for post %s: %v", postWithComment.Id, err)
-				images = []model.PostImage{} // fallback to empty slice
-			}
-		}
-		postWithComment.Images = images
-
-		// Handle latest comment if it exists
-		if latestCommentID.Valid {
-			latestComment := &model.Comment{
-				Id:        latestCommentID.String,
-				UserId:    latestCommentUserID.String,
-				PostId:    latestCommentPostID.String,
-				Content:   latestCommentContent.String,
-				CreatedAt: latestCommentCreatedAt.String,
-				UpdatedAt: latestCommentUpdatedAt.String,
-			}
-
-			// Handle nullable parent_id
-			if latestCommentParentID.Valid {
-				latestComment.ParentId = &latestCommentParentID.String
-			}
-
-			postWithComment.LatestComment = latestComment
-		}
-
-		postsWithComments = append(postsWithComments, postWithComment)
-	}
-
-	return postsWithComments, nil
+	return scanPostsWithComments(rows), nil
 }
 
 // GetAllPostsByUserIdWithComments returns all posts by a specific user with their latest comment and total comment count
-func (repo *Repository) GetAllPostsByUserIdWithComments(targetUserId string, limit, offset int, currentUserId string) ([]model.PostWithComment, error) {
+// ordered newest first, starting after the cursor when one is given
+func (repo *Repository) GetAllPostsByUserIdWithComments(targetUserId string, limit, offset int, after *model.Cursor, currentUserId string) ([]model.PostWithComment, error) {
 	if targetUserId == "" {
 		return nil, db.ErrUserIDMissing
 	}
@@ -441,14 +471,38 @@ func (repo *Repository) GetAllPostsByUserIdWithComments(targetUserId string, lim
 		return nil, db.ErrInvalidOffset
 	}
 
-	var postsWithComments []model.PostWithComment
-	query := `
+	where := `p.user_id = $1`
+	args := []any{targetUserId, limit, offset, currentUserId}
+	if after != nil {
+		where += ` AND ` + afterCursor("p.created_at", "p.id", 5, true)
+		args = append(args, after.At, after.Id)
+		args[2] = 0
+	}
+	query := repo.postWithCommentQuery("$4", where,
+		`ORDER BY p.created_at DESC, p.id DESC LIMIT $2 OFFSET $3`)
+
+	rows, err := repo.DB.Query(query, args...)
+	if err != nil {
+		log.Printf("Critical error getting posts by user ID %s with comments: %v", targetUserId, err)
+		return nil, db.NewDatabaseError("select", "Post", err)
+	}
+	defer rows.Close()
+
+	return scanPostsWithComments(rows), nil
+}
+
+// postWithCommentQuery selects posts together with their images, latest
+// top-level comment, comment count and whether the user bound to likedBy
+// (a placeholder such as "$3") liked and saved them. where filters the
+// posts and tail holds the ORDER BY and LIMIT clauses.
+func (repo *Repository) postWithCommentQuery(likedBy, where, tail string) string {
+	return `
 		SELECT 
 			p.id, p.user_id, p.created_at, p.updated_at, p.content, 
-			COALESCE(p.tags, '') as tags, COALESCE(p.like_count, 0) as like_count,
+			` + postTagsColumn + `, COALESCE(p.like_count, 0) as like_count,
 			COALESCE(
 				json_agg(
-					json_build_object('id', pi.id, 'post_id', pi.post_id, 'image_url', pi.image_url)
+					` + postMediaObject + `
 				) FILTER (WHERE pi.id IS NOT NULL), 
 				'[]'
 			) as images,
@@ -462,14 +516,29 @@ func (repo *Repository) GetAllPostsByUserIdWithComments(targetUserId string, lim
 			lc.updatedat as latest_comment_updated_at,
 			-- Total comment count
 			COALESCE(cc.total_comments, 0) as total_comments,
-			-- Check if current user liked this post
-			CASE WHEN pl.user_id IS NOT NULL THEN true ELSE false END as user_liked
+			-- Check if user liked this post
+			CASE WHEN pl.user_id IS NOT NULL THEN true ELSE false END as user_liked,
+			pl.reaction as user_reaction,
+			-- Reaction counts by type
+			COALESCE((
+				SELECT json_object_agg(r.reaction, r.total)
+				FROM (
+					SELECT reaction, COUNT(*) AS total FROM "post_likes"
+					WHERE post_id = p.id GROUP BY reaction
+				) r
+			), '{}') as reactions,
+			EXISTS (
+				SELECT 1 FROM "SavedItem" si
+				WHERE si.item_type = 'post' AND si.item_id = p.id AND si.user_id = ` + likedBy + `
+			) as saved,
+			` + postShareColumns + `
 		FROM "Post" p
 		LEFT JOIN "PostImages" pi ON p.id = pi.post_id
+		` + postShareJoin + `
 		LEFT JOIN LATERAL (
 			SELECT id, userid, postid, parentid, content, createdat, updatedat
 			FROM "Comment" c
-			WHERE c.postid = p.id AND c.parentid IS NULL
+			WHERE c.postid = p.id AND c.parentid IS NULL AND ` + repo.visibleClause(model.ReportTargetComment, "c.id") + `
 			ORDER BY c.createdat DESC
 			LIMIT 1
 		) lc ON true
@@ -478,38 +547,49 @@ func (repo *Repository) GetAllPostsByUserIdWithComments(targetUserId string, lim
 			FROM "Comment"
 			GROUP BY postid
 		) cc ON cc.postid = p.id
-		LEFT JOIN "post_likes" pl ON p.id = pl.post_id AND pl.user_id = $4
-		WHERE p.user_id = $1
-		GROUP BY p.id, p.user_id, p.created_at, p.updated_at, p.content, p.tags, p.like_count,
+		LEFT JOIN "post_likes" pl ON p.id = pl.post_id AND pl.user_id = ` + likedBy + `
+		WHERE ` + where + `
+		GROUP BY p.id, p.user_id, p.created_at, p.updated_at, p.content, p.like_count,
 				 lc.id, lc.userid, lc.postid, lc.parentid, lc.content, lc.createdat, lc.updatedat,
-				 cc.total_comments, pl.user_id
-		ORDER BY p.created_at DESC 
-		LIMIT $2 OFFSET $3`
-
-	rows, err := repo.DB.Query(query, targetUserId, limit, offset, currentUserId)
-	if err != nil {
-		log.Printf("Critical error getting posts by user ID %s with comments: %v", targetUserId, err)
-		return nil, db.NewDatabaseError("select", "Post", err)
-	}
-	defer rows.Close()
+				 cc.total_comments, pl.user_id, pl.reaction, op.id
+		` + tail
+}
 
+// scanPostsWithComments reads the rows of a postWithCommentQuery. Rows that
+// fail to scan are logged and skipped.
+func scanPostsWithComments(rows *sql.Rows) []model.PostWithComment {
+	var postsWithComments []model.PostWithComment
 	for rows.Next() {
 		var postWithComment model.PostWithComment
 		var imagesJSON string
 		var latestCommentID, latestCommentUserID, latestCommentPostID sql.NullString
 		var latestCommentParentID sql.NullString
 		var latestCommentContent, latestCommentCreatedAt, latestCommentUpdatedAt sql.NullString
+		var userReaction sql.NullString
+		var reactionsJSON string
+		var share postShare
 
 		err := rows.Scan(
 			&postWithComment.Id, &postWithComment.UserId, &postWithComment.CreatedAt, &postWithComment.UpdatedAt,
-			&postWithComment.Content, &postWithComment.Tags, &postWithComment.LikeCount, &imagesJSON,
+			&postWithComment.Content, pq.Array(&postWithComment.Tags), &postWithComment.LikeCount, &imagesJSON,
 			&latestCommentID, &latestCommentUserID, &latestCommentPostID, &latestCommentParentID,
 			&latestCommentContent, &latestCommentCreatedAt, &latestCommentUpdatedAt,
-			&postWithComment.TotalComments, &postWithComment.UserLiked)
+			&postWithComment.TotalComments, &postWithComment.UserLiked, &userReaction, &reactionsJSON, &postWithComment.Saved,
+			&share.kind, &share.repostOfId, &share.userId, &share.content, &share.createdAt, &postWithComment.ShareCount)
 		if err != nil {
 			log.Printf("Critical error scanning post with comment data: %v", err)
 			continue
 		}
+		share.apply(&postWithComment.Post)
+
+		postWithComment.Reactions = map[model.ReactionType]int{}
+		if err := json.Unmarshal([]byte(reactionsJSON), &postWithComment.Reactions); err != nil {
+			log.Printf("Error unmarshaling reactions JSON for post %s: %v", postWithComment.Id, err)
+		}
+		if userReaction.Valid {
+			reaction := model.ReactionType(userReaction.String)
+			postWithComment.UserReaction = &reaction
+		}
 
 		// Parse images JSON
 		var images []model.PostImage
@@ -544,18 +624,5 @@ func (repo *Repository) GetAllPostsByUserIdWithComments(targetUserId string, lim
 		postsWithComments = append(postsWithComments, postWithComment)
 	}
 
-	return postsWithComments, nil
-}
-
-// Helper function to convert comma-separated string to slice
-func StringToTags(tagString string) []string {
-	if tagString == "" {
-		return []string{}
-	}
-	return strings.Split(tagString, ",")
-}
-
-// Helper function to convert slice to comma-separated string
-func TagsToString(tags []string) string {
-	return strings.Join(tags, ",")
+	return postsWithComments
 }
@@ -0,0 +1,180 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/model"
+)
+
+// reportTargetQueries check that a reported target exists. Chat messages can
+// only be reported by someone in the room they were sent to.
+var reportTargetQueries = map[model.ReportTargetType]string{
+	model.ReportTargetPost:    `SELECT 1 FROM "Post" WHERE id = $1`,
+	model.ReportTargetComment: `SELECT 1 FROM "Comment" WHERE id = $1`,
+	model.ReportTargetProfile: `SELECT 1 FROM "User" WHERE id = $1`,
+	model.ReportTargetChatMessage: `SELECT 1 FROM "Messages" m JOIN "ChatRoomMember" cm ON cm.room_id = m.chat_room_id
+		WHERE m.id = $1 AND cm.user_id = $2`,
+}
+
+func (repo *Repository) CreateReport(report *model.Report) error {
+	query, ok := reportTargetQueries[report.TargetType]
+	if !ok {
+		return db.NewValidationError("target_type", "unsupported report target type")
+	}
+
+	args := []any{report.TargetId}
+	if report.TargetType == model.ReportTargetChatMessage {
+		args = append(args, report.ReporterId)
+	}
+	var exists int
+	if err := repo.DB.QueryRow(query, args...).Scan(&exists); err != nil {
+		if err == sql.ErrNoRows {
+			return db.NewNotFoundError(string(report.TargetType), report.TargetId)
+		}
+		log.Printf("ERROR: failed to look up report target: %v", err)
+		return db.NewDatabaseError("select", string(report.TargetType), err)
+	}
+
+	err := repo.DB.QueryRow(`INSERT INTO "Report" (reporter_id, target_type, target_id, reason, details)
+	VALUES ($1, $2, $3, $4, $5)
+	ON CONFLICT (reporter_id, target_type, target_id) DO NOTHING
+	RETURNING id, status, created_at`,
+		report.ReporterId, report.TargetType, report.TargetId, report.Reason, report.Details,
+	).Scan(&report.Id, &report.Status, &report.CreatedAt)
+	if err == sql.ErrNoRows {
+		return db.NewAlreadyExistsError("report", "reporter_id and target combination", report.ReporterId+" + "+report.TargetId)
+	}
+	if err != nil {
+		log.Printf("ERROR: failed to create report: %v", err)
+		return db.NewDatabaseError("insert", "Report", err)
+	}
+	return nil
+}
+
+func (repo *Repository) GetReportById(id string) (*model.Report, error) {
+	var report model.Report
+	err := repo.DB.QueryRow(`SELECT id, reporter_id, target_type, target_id, reason, details, status,
+	resolved_by, resolved_at, created_at FROM "Report" WHERE id = $1`, id).Scan(
+		&report.Id, &report.ReporterId, &report.TargetType, &report.TargetId, &report.Reason,
+		&report.Details, &report.Status, &report.ResolvedBy, &report.ResolvedAt, &report.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, db.NewNotFoundError("report", id)
+	}
+	if err != nil {
+		log.Printf("ERROR: failed to get report: %v", err)
+		return nil, db.NewDatabaseError("select", "Report", err)
+	}
+	return &report, nil
+}
+
+// GetReports returns the moderation queue, oldest report first
+func (repo *Repository) GetReports(filter *model.ReportFilter, limit, offset int) ([]model.Report, error) {
+	query := `SELECT id, reporter_id, target_type, target_id, reason, details, status,
+	resolved_by, resolved_at, created_at FROM "Report"`
+
+	var conditions []string
+	var args []any
+	argIndex := 1
+
+	if filter.Status != nil {
+		conditions = append(conditions, "status = $"+strconv.Itoa(argIndex))
+		args = append(args, *filter.Status)
+		argIndex++
+	}
+	if filter.TargetType != nil {
+		conditions = append(conditions, "target_type = $"+strconv.Itoa(argIndex))
+		args = append(args, *filter.TargetType)
+		argIndex++
+	}
+	if filter.TargetId != nil {
+		conditions = append(conditions, "target_id = $"+strconv.Itoa(argIndex))
+		args = append(args, *filter.TargetId)
+		argIndex++
+	}
+
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY created_at ASC, id ASC LIMIT $" + strconv.Itoa(argIndex) + " OFFSET $" + strconv.Itoa(argIndex+1)
+	args = append(args, limit, offset)
+
+	rows, err := repo.DB.Query(query, args...)
+	if err != nil {
+		log.Printf("ERROR: failed to get reports: %v", err)
+		return nil, db.NewDatabaseError("select", "Report", err)
+	}
+	defer rows.Close()
+
+	reports := []model.Report{}
+	for rows.Next() {
+		var report model.Report
+		if err := rows.Scan(&report.Id, &report.ReporterId, &report.TargetType, &report.TargetId, &report.Reason,
+			&report.Details, &report.Status, &report.ResolvedBy, &report.ResolvedAt, &report.CreatedAt); err != nil {
+			log.Printf("ERROR: failed to scan report: %v", err)
+			return nil, db.NewDatabaseError("scan", "Report", err)
+		}
+		reports = append(reports, report)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, db.NewDatabaseError("select", "Report", err)
+	}
+
+	return reports, nil
+}
+
+// ResolveReport moves an open report to actioned or dismissed. Every other
+// open report on the same target is resolved with it, since moderators
+// decide on the content rather than on a single flag.
+func (repo *Repository) ResolveReport(id string, status model.ReportStatus, resolverID string, action *model.AdminAction) (*model.Report, error) {
+	if status != model.ReportStatusActioned && status != model.ReportStatusDismissed {
+		return nil, db.NewValidationError("status", "report can only be actioned or dismissed")
+	}
+
+	report, err := repo.GetReportById(id)
+	if err != nil {
+		return nil, err
+	}
+	if report.Status != model.ReportStatusOpen {
+		return nil, db.NewValidationError("status", "report has already been resolved")
+	}
+
+	tx, err := repo.DB.Begin()
+	if err != nil {
+		return nil, db.NewDatabaseError("begin", "transaction", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`UPDATE "Report" SET status = $1, resolved_by = $2, resolved_at = CURRENT_TIMESTAMP
+	WHERE target_type = $3 AND target_id = $4 AND status = 'open'`,
+		status, resolverID, report.TargetType, report.TargetId)
+	if err != nil {
+		log.Printf("ERROR: failed to resolve report: %v", err)
+		return nil, db.NewDatabaseError("update", "Report", err)
+	}
+
+	if err := insertAdminAction(tx, action); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, db.NewDatabaseError("commit", "transaction", err)
+	}
+
+	return repo.GetReportById(id)
+}
+
+// visibleClause is a condition on column that fails once the content has
+// collected ReportHideThreshold reports that were not dismissed. A threshold
+// of zero turns auto-hiding off.
+func (repo *Repository) visibleClause(targetType model.ReportTargetType, column string) string {
+	if repo.ReportHideThreshold <= 0 {
+		return "TRUE"
+	}
+	return fmt.Sprintf(`(SELECT COUNT(*) FROM "Report" rp
+		WHERE rp.target_type = '%s' AND rp.target_id = %s AND rp.status <> 'dismissed') < %d`,
+		targetType, column, repo.ReportHideThreshold)
+}
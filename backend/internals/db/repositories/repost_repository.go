@@ -0,0 +1,133 @@
+package repositories
+
+import (
+	"database/sql"
+	"log"
+	"strings"
+	"time"
+
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/model"
+)
+
+// postShareColumns selects what a post shares, joined through postShareJoin,
+// and how many times it has been shared itself. It goes last in a post
+// select list and is read back with postShare.
+const postShareColumns = `p.kind, p.repost_of_id, op.user_id, op.content, op.created_at,
+			(SELECT COUNT(*) FROM "Post" s WHERE s.repost_of_id = p.id) as share_count`
+
+const postShareJoin = `LEFT JOIN "Post" op ON op.id = p.repost_of_id`
+
+// postShare holds the postShareColumns of a row other than the share count
+type postShare struct {
+	kind       string
+	repostOfId sql.NullString
+	userId     sql.NullString
+	content    sql.NullString
+	createdAt  sql.NullString
+}
+
+// apply sets the kind of post and the post it shares. A share whose
+// original was deleted gets a tombstone.
+func (s postShare) apply(post *model.Post) {
+	post.Kind = model.PostKind(s.kind)
+	if post.Kind == model.PostKindPost {
+		return
+	}
+	if !s.repostOfId.Valid {
+		post.RepostOf = &model.SharedPost{Deleted: true}
+		return
+	}
+	post.RepostOfId = &s.repostOfId.String
+	post.RepostOf = &model.SharedPost{
+		Id:        s.repostOfId.String,
+		UserId:    s.userId.String,
+		Content:   s.content.String,
+		CreatedAt: s.createdAt.String,
+	}
+}
+
+// CreateRepost shares the post post.RepostOfId points at on behalf of
+// post.UserId. Blank content makes a plain repost, anything else a quote.
+// Sharing a plain repost shares its original instead.
+func (repo *Repository) CreateRepost(post *model.Post) error {
+	if post.UserId == "" {
+		return db.ErrUserIDMissing
+	}
+	if post.RepostOfId == nil || *post.RepostOfId == "" {
+		return db.NewValidationError("post_id", "post ID is required")
+	}
+
+	tx, err := repo.DB.Begin()
+	if err != nil {
+		return db.NewDatabaseError("begin", "transaction", err)
+	}
+	defer tx.Rollback()
+
+	var kind string
+	var original sql.NullString
+	err = tx.QueryRow(`SELECT kind, repost_of_id FROM "Post" WHERE id = $1`, *post.RepostOfId).Scan(&kind, &original)
+	if err == sql.ErrNoRows {
+		return db.NewNotFoundError("post", *post.RepostOfId)
+	}
+	if err != nil {
+		log.Printf("Error getting shared post %s: %v", *post.RepostOfId, err)
+		return db.NewDatabaseError("select", "Post", err)
+	}
+	if model.PostKind(kind) == model.PostKindRepost {
+		if !original.Valid {
+			return db.NewValidationError("post_id", "the original post has been deleted")
+		}
+		post.RepostOfId = &original.String
+	}
+
+	post.Content = strings.TrimSpace(post.Content)
+	post.Kind = model.PostKindQuote
+	if post.Content == "" {
+		post.Kind = model.PostKindRepost
+	}
+
+	now := time.Now().UTC()
+	err = tx.QueryRow(`INSERT INTO "Post" (id, user_id, created_at, updated_at, content, kind, repost_of_id)
+			  VALUES (gen_random_uuid(), $1, $2, $3, $4, $5, $6)
+			  RETURNING id, created_at, updated_at`,
+		post.UserId, now, now, post.Content, post.Kind, *post.RepostOfId).Scan(
+		&post.Id, &post.CreatedAt, &post.UpdatedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			return db.NewAlreadyExistsError("repost", "post_id and user_id combination", *post.RepostOfId+" + "+post.UserId)
+		}
+		if strings.Contains(err.Error(), "foreign key constraint") {
+			return db.ErrUserNotFound
+		}
+		log.Printf("Critical error creating repost for user %s: %v", post.UserId, err)
+		return db.NewDatabaseError("insert", "Post", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return db.NewDatabaseError("commit", "transaction", err)
+	}
+	return nil
+}
+
+// DeleteRepost undoes a user's plain repost of a post. Quotes are deleted
+// like any other post.
+func (repo *Repository) DeleteRepost(postId, userId string) error {
+	if postId == "" {
+		return db.NewValidationError("post_id", "post ID is required")
+	}
+	if userId == "" {
+		return db.ErrUserIDMissing
+	}
+
+	result, err := repo.DB.Exec(`DELETE FROM "Post"
+		WHERE kind = 'repost' AND repost_of_id = $1 AND user_id = $2`, postId, userId)
+	if err != nil {
+		log.Printf("Critical error deleting repost of post %s: %v", postId, err)
+		return db.NewDatabaseError("delete", "Post", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return db.NewNotFoundError("repost", postId)
+	}
+	return nil
+}
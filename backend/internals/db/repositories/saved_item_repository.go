@@ -0,0 +1,128 @@
+package repositories
+
+import (
+	"database/sql"
+	"log"
+	"strconv"
+
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/model"
+)
+
+// savedItemTargetQueries check that an item being saved exists
+var savedItemTargetQueries = map[model.SavedItemType]string{
+	model.SavedItemPost:       `SELECT 1 FROM "Post" WHERE id = $1`,
+	model.SavedItemOpening:    `SELECT 1 FROM "Opening" WHERE id = $1`,
+	model.SavedItemTournament: `SELECT 1 FROM "Tournament" WHERE id = $1`,
+}
+
+// savedItemExists keeps the saved items whose target has not been deleted
+// since, as the polymorphic item_id cannot cascade.
+const savedItemExists = `CASE si.item_type
+		WHEN 'post' THEN EXISTS (SELECT 1 FROM "Post" WHERE id = si.item_id)
+		WHEN 'opening' THEN EXISTS (SELECT 1 FROM "Opening" WHERE id = si.item_id)
+		WHEN 'tournament' THEN EXISTS (SELECT 1 FROM "Tournament" WHERE id = si.item_id)
+	END`
+
+// SaveItem bookmarks a post, opening or tournament for a user
+func (repo *Repository) SaveItem(item *model.SavedItem) error {
+	query, ok := savedItemTargetQueries[item.ItemType]
+	if !ok {
+		return db.NewValidationError("item_type", "unsupported saved item type")
+	}
+
+	var exists int
+	if err := repo.DB.QueryRow(query, item.ItemId).Scan(&exists); err != nil {
+		if err == sql.ErrNoRows {
+			return db.NewNotFoundError(string(item.ItemType), item.ItemId)
+		}
+		log.Printf("ERROR: failed to look up saved item: %v", err)
+		return db.NewDatabaseError("select", string(item.ItemType), err)
+	}
+
+	err := repo.DB.QueryRow(`INSERT INTO "SavedItem" (user_id, item_type, item_id)
+	VALUES ($1, $2, $3)
+	ON CONFLICT (user_id, item_type, item_id) DO NOTHING
+	RETURNING id, created_at`,
+		item.UserId, item.ItemType, item.ItemId,
+	).Scan(&item.Id, &item.CreatedAt)
+	if err == sql.ErrNoRows {
+		return db.NewAlreadyExistsError("saved item", "user_id and item combination", item.UserId+" + "+item.ItemId)
+	}
+	if err != nil {
+		log.Printf("ERROR: failed to save item: %v", err)
+		return db.NewDatabaseError("insert", "SavedItem", err)
+	}
+	return nil
+}
+
+// UnsaveItem removes a user's bookmark
+func (repo *Repository) UnsaveItem(userId string, itemType model.SavedItemType, itemId string) error {
+	result, err := repo.DB.Exec(`DELETE FROM "SavedItem"
+	WHERE user_id = $1 AND item_type = $2 AND item_id = $3`, userId, itemType, itemId)
+	if err != nil {
+		log.Printf("ERROR: failed to unsave item: %v", err)
+		return db.NewDatabaseError("delete", "SavedItem", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return db.NewNotFoundError("saved item", itemId)
+	}
+	return nil
+}
+
+// GetSavedItems returns a user's saved items, most recently saved first,
+// optionally of a single type. Items whose target was deleted are left out.
+func (repo *Repository) GetSavedItems(userId string, itemType *model.SavedItemType, limit, offset int, after *model.Cursor) ([]model.SavedItem, error) {
+	if limit <= 0 {
+		return nil, db.ErrInvalidLimit
+	}
+	if offset < 0 {
+		return nil, db.ErrInvalidOffset
+	}
+
+	query := `SELECT si.id, si.user_id, si.item_type, si.item_id, si.created_at
+	FROM "SavedItem" si
+	WHERE si.user_id = $1 AND ` + savedItemExists
+	args := []any{userId}
+	if itemType != nil {
+		args = append(args, *itemType)
+		query += ` AND si.item_type = $` + strconv.Itoa(len(args))
+	}
+	if after != nil {
+		query += ` AND ` + afterCursor("si.created_at", "si.id", len(args)+1, true)
+		args = append(args, after.At, after.Id)
+		offset = 0
+	}
+	query += ` ORDER BY si.created_at DESC, si.id DESC LIMIT $` + strconv.Itoa(len(args)+1) + ` OFFSET $` + strconv.Itoa(len(args)+2)
+	args = append(args, limit, offset)
+
+	rows, err := repo.DB.Query(query, args...)
+	if err != nil {
+		log.Printf("ERROR: failed to get saved items: %v", err)
+		return nil, db.NewDatabaseError("select", "SavedItem", err)
+	}
+	defer rows.Close()
+
+	items := []model.SavedItem{}
+	for rows.Next() {
+		var item model.SavedItem
+		if err := rows.Scan(&item.Id, &item.UserId, &item.ItemType, &item.ItemId, &item.CreatedAt); err != nil {
+			log.Printf("ERROR: failed to scan saved item: %v", err)
+			return nil, db.NewDatabaseError("scan", "SavedItem", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, db.NewDatabaseError("select", "SavedItem", err)
+	}
+	return items, nil
+}
+
+// isItemSaved reports whether a user saved an item. Lookups that fail count
+// as not saved, like the enrollment check of tournament details.
+func (repo *Repository) isItemSaved(userId string, itemType model.SavedItemType, itemId string) bool {
+	var saved bool
+	err := repo.DB.QueryRow(`SELECT EXISTS (SELECT 1 FROM "SavedItem"
+	WHERE user_id = $1 AND item_type = $2 AND item_id = $3)`, userId, itemType, itemId).Scan(&saved)
+	return err == nil && saved
+}
@@ -32,6 +32,9 @@ func (r *Repository) GetSportById(id string) (*model.Sport, error) {
 	var sport model.Sport
 	err := r.DB.QueryRow(`SELECT id, name, description, created_at, updated_at FROM "Sports" WHERE id = $1`, id).Scan(&sport.Id, &sport.Name, &sport.Description, &sport.CreatedAt, &sport.UpdatedAt)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, db.NewNotFoundError("sport", id)
+		}
 		return nil, err
 	}
 
@@ -54,22 +57,123 @@ func (r *Repository) GetSportByName(name string) (*model.Sport, error) {
 	return &sport, nil
 }
 
-func (r *Repository) CreateSport(sport *model.Sport) (string, error) {
+func (r *Repository) CreateSport(sport *model.Sport, action *model.AdminAction) (string, error) {
 	sname, err := sanitizeSportName(sport.Name)
 	if err != nil {
 		return "", err
 	}
 	sport.Name = sname
-	err = r.DB.QueryRow(`INSERT INTO "Sports" (id, name, description) VALUES (gen_random_uuid(), $1, $2) RETURNING id`, sport.Name, sport.Description).Scan(&sport.Id)
+
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return "", db.NewDatabaseError("begin", "Sports", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRow(`INSERT INTO "Sports" (id, name, description) VALUES (gen_random_uuid(), $1, $2) RETURNING id`, sport.Name, sport.Description).Scan(&sport.Id)
 	if err != nil {
 		if db.IsUniqueConstraintError(err, "name") {
 			return "", db.NewAlreadyExistsError("sport", "name", sport.Name)
 		}
 		return "", err
 	}
+
+	if action != nil {
+		action.TargetId = sport.Id
+	}
+	if err := insertAdminAction(tx, action); err != nil {
+		return "", err
+	}
+	if err := tx.Commit(); err != nil {
+		return "", db.NewDatabaseError("commit", "Sports", err)
+	}
 	return sport.Id, nil
 }
 
+func (r *Repository) UpdateSport(sport *model.Sport, action *model.AdminAction) error {
+	sname, err := sanitizeSportName(sport.Name)
+	if err != nil {
+		return err
+	}
+	sport.Name = sname
+
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return db.NewDatabaseError("begin", "Sports", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`UPDATE "Sports" SET name = $1, description = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $3`, sport.Name, sport.Description, sport.Id)
+	if err != nil {
+		if db.IsUniqueConstraintError(err, "name") {
+			return db.NewAlreadyExistsError("sport", "name", sport.Name)
+		}
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return db.NewNotFoundError("sport", sport.Id)
+	}
+
+	if err := insertAdminAction(tx, action); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return db.NewDatabaseError("commit", "Sports", err)
+	}
+	return nil
+}
+
+// DeleteSport deletes a sport nothing refers to any more. Tournaments,
+// openings, achievements and skills would otherwise be deleted with it by
+// their ON DELETE CASCADE, so a sport in use is refused with a
+// ConflictError.
+func (r *Repository) DeleteSport(id string, action *model.AdminAction) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return db.NewDatabaseError("begin", "Sports", err)
+	}
+	defer tx.Rollback()
+
+	// Locking the row keeps new references out until the delete commits
+	var locked string
+	err = tx.QueryRow(`SELECT id FROM "Sports" WHERE id = $1 FOR UPDATE`, id).Scan(&locked)
+	if err == sql.ErrNoRows {
+		return db.NewNotFoundError("sport", id)
+	}
+	if err != nil {
+		return db.NewDatabaseError("select", "Sports", err)
+	}
+
+	var usage model.SportUsage
+	err = tx.QueryRow(`SELECT
+		(SELECT COUNT(*) FROM "Tournament" WHERE sport_id = $1),
+		(SELECT COUNT(*) FROM "Opening" WHERE sport_id = $1),
+		(SELECT COUNT(*) FROM "Achievements" WHERE sport_id = $1),
+		(SELECT COUNT(*) FROM "UserSkill" WHERE sport_id = $1)`, id).
+		Scan(&usage.Tournaments, &usage.Openings, &usage.Achievements, &usage.Skills)
+	if err != nil {
+		return db.NewDatabaseError("select", "Sports", err)
+	}
+	if err := usage.Err(); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM "Sports" WHERE id = $1`, id); err != nil {
+		return db.NewDatabaseError("delete", "Sports", err)
+	}
+	if err := insertAdminAction(tx, action); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return db.NewDatabaseError("commit", "Sports", err)
+	}
+	return nil
+}
+
 func sanitizeSportName(name string) (string, error) {
 	name = strings.Trim(name, " ")
 	name = strings.ReplaceAll(name, " ", "_")
@@ -0,0 +1,125 @@
+package repositories
+
+import (
+	"database/sql"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/model"
+)
+
+// postTagsColumn selects the tag names of the post aliased p
+const postTagsColumn = `ARRAY(
+				SELECT t.name FROM "PostTag" pt INNER JOIN "Tag" t ON t.id = pt.tag_id
+				WHERE pt.post_id = p.id ORDER BY t.name
+			) as tags`
+
+// setPostTags replaces the tags of a post, creating the tags that do not
+// exist yet
+func setPostTags(tx *sql.Tx, postID string, tags []string) error {
+	if _, err := tx.Exec(`DELETE FROM "PostTag" WHERE post_id = $1`, postID); err != nil {
+		log.Printf("ERROR: failed to clear tags of post %s: %v", postID, err)
+		return db.NewDatabaseError("delete", "PostTag", err)
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+
+	if _, err := tx.Exec(`INSERT INTO "Tag" (name) SELECT unnest($1::text[])
+	ON CONFLICT (name) DO NOTHING`, pq.Array(tags)); err != nil {
+		log.Printf("ERROR: failed to create tags: %v", err)
+		return db.NewDatabaseError("insert", "Tag", err)
+	}
+	if _, err := tx.Exec(`INSERT INTO "PostTag" (post_id, tag_id)
+	SELECT $1, id FROM "Tag" WHERE name = ANY($2::text[])`, postID, pq.Array(tags)); err != nil {
+		log.Printf("ERROR: failed to tag post %s: %v", postID, err)
+		return db.NewDatabaseError("insert", "PostTag", err)
+	}
+	return nil
+}
+
+// GetPostsByTag returns the posts carrying a tag, newest first, in the
+// shape of GetAllPostsWithComments. Posts hidden by reports are left out.
+func (repo *Repository) GetPostsByTag(tag string, limit, offset int, after *model.Cursor, userId string) ([]model.PostWithComment, error) {
+	if limit <= 0 {
+		return nil, db.ErrInvalidLimit
+	}
+	if offset < 0 {
+		return nil, db.ErrInvalidOffset
+	}
+
+	where := repo.visibleClause(model.ReportTargetPost, "p.id") + ` AND EXISTS (
+			SELECT 1 FROM "PostTag" pt INNER JOIN "Tag" t ON t.id = pt.tag_id
+			WHERE pt.post_id = p.id AND t.name = $4
+		)`
+	args := []any{limit, offset, userId, tag}
+	if after != nil {
+		where += ` AND ` + afterCursor("p.created_at", "p.id", 5, true)
+		args = append(args, after.At, after.Id)
+		args[1] = 0
+	}
+	query := repo.postWithCommentQuery("$3", where,
+		`ORDER BY p.created_at DESC, p.id DESC LIMIT $1 OFFSET $2`)
+
+	rows, err := repo.DB.Query(query, args...)
+	if err != nil {
+		log.Printf("ERROR: failed to get posts tagged %s: %v", tag, err)
+		return nil, db.NewDatabaseError("select", "Post", err)
+	}
+	defer rows.Close()
+
+	return scanPostsWithComments(rows), nil
+}
+
+// SearchTags returns the tags starting with prefix that are used by at least
+// one post, most used first
+func (repo *Repository) SearchTags(prefix string, limit int) ([]model.Tag, error) {
+	pattern := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(prefix) + "%"
+	return repo.queryTags(`SELECT t.name, COUNT(*)
+	FROM "Tag" t
+	INNER JOIN "PostTag" pt ON pt.tag_id = t.id
+	WHERE t.name LIKE $1
+	GROUP BY t.name
+	ORDER BY COUNT(*) DESC, t.name
+	LIMIT $2`, pattern, limit)
+}
+
+// GetTrendingTags returns the tags used by the most posts created after
+// since. Posts hidden by reports do not count.
+func (repo *Repository) GetTrendingTags(since time.Time, limit int) ([]model.Tag, error) {
+	return repo.queryTags(`SELECT t.name, COUNT(*)
+	FROM "PostTag" pt
+	INNER JOIN "Tag" t ON t.id = pt.tag_id
+	INNER JOIN "Post" p ON p.id = pt.post_id
+	WHERE p.created_at > $1 AND `+repo.visibleClause(model.ReportTargetPost, "p.id")+`
+	GROUP BY t.name
+	ORDER BY COUNT(*) DESC, t.name
+	LIMIT $2`, since, limit)
+}
+
+func (repo *Repository) queryTags(query string, args ...any) ([]model.Tag, error) {
+	rows, err := repo.DB.Query(query, args...)
+	if err != nil {
+		log.Printf("ERROR: failed to get tags: %v", err)
+		return nil, db.NewDatabaseError("select", "Tag", err)
+	}
+	defer rows.Close()
+
+	tags := []model.Tag{}
+	for rows.Next() {
+		var tag model.Tag
+		if err := rows.Scan(&tag.Name, &tag.PostCount); err != nil {
+			log.Printf("ERROR: failed to scan tag: %v", err)
+			return nil, db.NewDatabaseError("scan", "Tag", err)
+		}
+		tags = append(tags, tag)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, db.NewDatabaseError("select", "Tag", err)
+	}
+
+	return tags, nil
+}
@@ -112,27 +112,43 @@ func (repo *Repository) GetUserTournaments(userID string) ([]*model.TounramentPa
 }
 
 // UpdateParticipantStatus updates the status of a tournament participant
-func (repo *Repository) UpdateParticipantStatus(userID, tournamentID string, status model.ParticipationStatus) error {
-	query := `UPDATE "TournamentParticipant" 
-	SET status = $3 
-	WHERE user_id = $1 AND tournament_id = $2`
-
-	result, err := repo.DB.Exec(query, userID, tournamentID, status)
+func (repo *Repository) UpdateParticipantStatus(userID, tournamentID string, status model.ParticipationStatus, actor model.AuditActor) error {
+	tx, err := repo.DB.Begin()
 	if err != nil {
-		log.Printf("ERROR: failed to update participant status: %v", err)
+		log.Printf("ERROR: failed to begin transaction: %v", err)
 		return fmt.Errorf("UpdateParticipantStatus: %w", err)
 	}
+	defer tx.Rollback()
 
-	rowsAffected, err := result.RowsAffected()
+	var participantID string
+	var before, after []byte
+	err = tx.QueryRow(`SELECT id, row_to_json(p) FROM "TournamentParticipant" p 
+	WHERE user_id = $1 AND tournament_id = $2 FOR UPDATE`, userID, tournamentID).Scan(&participantID, &before)
 	if err != nil {
-		log.Printf("ERROR: failed to get rows affected: %v", err)
+		if err == sql.ErrNoRows {
+			return db.ITEM_NOT_FOUND
+		}
+		log.Printf("ERROR: failed to get participant: %v", err)
 		return fmt.Errorf("UpdateParticipantStatus: %w", err)
 	}
 
-	if rowsAffected == 0 {
-		return db.ITEM_NOT_FOUND
+	query := `UPDATE "TournamentParticipant" p 
+	SET status = $2 
+	WHERE id = $1 RETURNING row_to_json(p)`
+
+	if err := tx.QueryRow(query, participantID, status).Scan(&after); err != nil {
+		log.Printf("ERROR: failed to update participant status: %v", err)
+		return fmt.Errorf("UpdateParticipantStatus: %w", err)
 	}
 
+	if err := insertAuditEvent(tx, actor, model.AuditParticipantStatusChanged, "tournament_participant", participantID, before, after); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("ERROR: failed to commit participant status: %v", err)
+		return fmt.Errorf("UpdateParticipantStatus: %w", err)
+	}
 	return nil
 }
 
@@ -143,11 +143,13 @@ func (repo *Repository) GetTournamentDetailsByID(tournamentID string, userID *st
 		return nil, fmt.Errorf("GetTournamentDetailsByID: %w", err)
 	}
 
-	// Check if user is enrolled (if userID is provided)
+	// Check if user is enrolled and saved it (if userID is provided)
 	isEnrolled := false
+	isSaved := false
 	if userID != nil && *userID != "" {
 		_, err := repo.GetParticipantByUserAndTournament(*userID, tournamentID)
 		isEnrolled = (err == nil)
+		isSaved = repo.isItemSaved(*userID, model.SavedItemTournament, tournamentID)
 	}
 
 	tournamentDetails := &model.TournamentDetails{
@@ -155,6 +157,7 @@ func (repo *Repository) GetTournamentDetailsByID(tournamentID string, userID *st
 		HostName:          hostName,
 		Sport:             &sport,
 		IsEnrolled:        isEnrolled,
+		Saved:             isSaved,
 		ParticipantsCount: participantsCount,
 	}
 
@@ -225,11 +228,13 @@ func (repo *Repository) GetAllTournamentDetails(userID *string) ([]*model.Tourna
 			return nil, fmt.Errorf("GetAllTournamentDetails: %w", err)
 		}
 
-		// Check if user is enrolled (if userID is provided)
+		// Check if user is enrolled and saved it (if userID is provided)
 		isEnrolled := false
+		isSaved := false
 		if userID != nil && *userID != "" {
 			_, err := repo.GetParticipantByUserAndTournament(*userID, tournament.Id)
 			isEnrolled = (err == nil)
+			isSaved = repo.isItemSaved(*userID, model.SavedItemTournament, tournament.Id)
 		}
 
 		tournamentDetails := &model.TournamentDetails{
@@ -237,6 +242,7 @@ func (repo *Repository) GetAllTournamentDetails(userID *string) ([]*model.Tourna
 			HostName:          hostName,
 			Sport:             &sport,
 			IsEnrolled:        isEnrolled,
+			Saved:             isSaved,
 			ParticipantsCount: participantsCount,
 		}
 
@@ -316,11 +322,13 @@ func (repo *Repository) GetTournamentDetailsByHostID(hostID string, userID *stri
 			return nil, fmt.Errorf("GetTournamentDetailsByHostID: %w", err)
 		}
 
-		// Check if user is enrolled (if userID is provided)
+		// Check if user is enrolled and saved it (if userID is provided)
 		isEnrolled := false
+		isSaved := false
 		if userID != nil && *userID != "" {
 			_, err := repo.GetParticipantByUserAndTournament(*userID, tournament.Id)
 			isEnrolled = (err == nil)
+			isSaved = repo.isItemSaved(*userID, model.SavedItemTournament, tournament.Id)
 		}
 
 		tournamentDetails := &model.TournamentDetails{
@@ -328,6 +336,7 @@ func (repo *Repository) GetTournamentDetailsByHostID(hostID string, userID *stri
 			HostName:          hostName,
 			Sport:             &sport,
 			IsEnrolled:        isEnrolled,
+			Saved:             isSaved,
 			ParticipantsCount: participantsCount,
 		}
 
@@ -407,11 +416,13 @@ func (repo *Repository) GetTournamentDetailsBySportID(sportID string, userID *st
 			return nil, fmt.Errorf("GetTournamentDetailsBySportID: %w", err)
 		}
 
-		// Check if user is enrolled (if userID is provided)
+		// Check if user is enrolled and saved it (if userID is provided)
 		isEnrolled := false
+		isSaved := false
 		if userID != nil && *userID != "" {
 			_, err := repo.GetParticipantByUserAndTournament(*userID, tournament.Id)
 			isEnrolled = (err == nil)
+			isSaved = repo.isItemSaved(*userID, model.SavedItemTournament, tournament.Id)
 		}
 
 		tournamentDetails := &model.TournamentDetails{
@@ -419,6 +430,7 @@ func (repo *Repository) GetTournamentDetailsBySportID(sportID string, userID *st
 			HostName:          hostName,
 			Sport:             &sport,
 			IsEnrolled:        isEnrolled,
+			Saved:             isSaved,
 			ParticipantsCount: participantsCount,
 		}
 
@@ -434,12 +446,19 @@ func (repo *Repository) GetTournamentDetailsBySportID(sportID string, userID *st
 }
 
 // UpdateTournament updates an existing tournament
-func (repo *Repository) UpdateTournament(tournament *model.Tournament) error {
+func (repo *Repository) UpdateTournament(tournament *model.Tournament, action *model.AdminAction) error {
+	tx, err := repo.DB.Begin()
+	if err != nil {
+		log.Printf("ERROR: failed to begin transaction: %v", err)
+		return fmt.Errorf("UpdateTournament: %w", err)
+	}
+	defer tx.Rollback()
+
 	query := `UPDATE "Tournament" 
 	SET name = $2, description = $3, location = $4, sport_id = $5, min_age = $6, max_age = $7, level = $8, level_location = $9, gender = $10, country_restriction = $11, status = $12, banner_link = $13, updated_at = CURRENT_TIMESTAMP
 	WHERE id = $1`
 
-	result, err := repo.DB.Exec(query,
+	result, err := tx.Exec(query,
 		tournament.Id,
 		tournament.Title,
 		tournament.Description,
@@ -470,29 +489,44 @@ func (repo *Repository) UpdateTournament(tournament *model.Tournament) error {
 		return db.ITEM_NOT_FOUND
 	}
 
+	if err := insertAdminAction(tx, action); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		log.Printf("ERROR: failed to commit transaction: %v", err)
+		return fmt.Errorf("UpdateTournament: %w", err)
+	}
 	return nil
 }
 
 // DeleteTournament deletes a tournament by ID
-func (repo *Repository) DeleteTournament(tournamentID string) error {
-	query := `DELETE FROM "Tournament" WHERE id = $1`
-
-	result, err := repo.DB.Exec(query, tournamentID)
+func (repo *Repository) DeleteTournament(tournamentID string, actor model.AuditActor) error {
+	tx, err := repo.DB.Begin()
 	if err != nil {
-		log.Printf("ERROR: failed to delete tournament: %v", err)
+		log.Printf("ERROR: failed to begin transaction: %v", err)
 		return fmt.Errorf("DeleteTournament: %w", err)
 	}
+	defer tx.Rollback()
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		log.Printf("ERROR: failed to get rows affected: %v", err)
+	query := `DELETE FROM "Tournament" t WHERE id = $1 RETURNING row_to_json(t)`
+
+	var before []byte
+	if err := tx.QueryRow(query, tournamentID).Scan(&before); err != nil {
+		if err == sql.ErrNoRows {
+			return db.ITEM_NOT_FOUND
+		}
+		log.Printf("ERROR: failed to delete tournament: %v", err)
 		return fmt.Errorf("DeleteTournament: %w", err)
 	}
 
-	if rowsAffected == 0 {
-		return db.ITEM_NOT_FOUND
+	if err := insertAuditEvent(tx, actor, model.AuditTournamentDeleted, "tournament", tournamentID, before, nil); err != nil {
+		return err
 	}
 
+	if err := tx.Commit(); err != nil {
+		log.Printf("ERROR: failed to commit tournament deletion: %v", err)
+		return fmt.Errorf("DeleteTournament: %w", err)
+	}
 	return nil
 }
 
@@ -561,11 +595,13 @@ func (repo *Repository) GetTournamentDetailsByStatus(status model.TournamentStat
 			return nil, fmt.Errorf("GetTournamentDetailsByStatus: %w", err)
 		}
 
-		// Check if user is enrolled (if userID is provided)
+		// Check if user is enrolled and saved it (if userID is provided)
 		isEnrolled := false
+		isSaved := false
 		if userID != nil && *userID != "" {
 			_, err := repo.GetParticipantByUserAndTournament(*userID, tournament.Id)
 			isEnrolled = (err == nil)
+			isSaved = repo.isItemSaved(*userID, model.SavedItemTournament, tournament.Id)
 		}
 
 		tournamentDetails := &model.TournamentDetails{
@@ -573,6 +609,7 @@ func (repo *Repository) GetTournamentDetailsByStatus(status model.TournamentStat
 			HostName:          hostName,
 			Sport:             &sport,
 			IsEnrolled:        isEnrolled,
+			Saved:             isSaved,
 			ParticipantsCount: participantsCount,
 		}
 
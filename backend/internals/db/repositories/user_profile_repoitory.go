@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"log"
 	"strconv"
@@ -343,32 +344,33 @@ func (repo *Repository) GetReferalCode(userId string) (string, error) {
 	return referralCode, nil
 }
 
-func (repo *Repository) ReferUser(userId, referalCode string) error {
+func (repo *Repository) ReferUser(userId, referalCode string, actor model.AuditActor) error {
 	tx, err := repo.DB.Begin()
 	if err != nil {
 		log.Printf("ERROR: failed to begin transaction: %v", err)
 		return db.NewDatabaseError("begin_transaction", "User", err)
 	}
-	defer func() {
-		if err != nil {
-			tx.Rollback()
-		} else {
-			tx.Commit()
-		}
-	}()
+	defer tx.Rollback()
+
 	var referalUserId string
-	err = tx.QueryRow(`SELECT id FROM "UserDetails" WHERE referral_code = $1`, referalCode).Scan(&referalUserId)
+	var coinsBefore int
+	err = tx.QueryRow(`SELECT id, COALESCE(coins, 0) FROM "UserDetails" WHERE referral_code = $1 FOR UPDATE`, referalCode).Scan(&referalUserId, &coinsBefore)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return db.NewNotFoundError("user", referalCode)
 		}
 		return db.NewDatabaseError("select", "UserDetails", err)
 	}
-	_, err = tx.Exec(`UPDATE "UserDetails" SET referred_by = $1 WHERE id = $2`, referalUserId, userId)
+	var referredByBefore *string
+	err = tx.QueryRow(`SELECT referred_by FROM "UserDetails" WHERE id = $1 FOR UPDATE`, userId).Scan(&referredByBefore)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return db.NewNotFoundError("user", userId)
 		}
+		return db.NewDatabaseError("select", "UserDetails", err)
+	}
+	_, err = tx.Exec(`UPDATE "UserDetails" SET referred_by = $1 WHERE id = $2`, referalUserId, userId)
+	if err != nil {
 		return db.NewDatabaseError("update", "UserDetails", err)
 	}
 	var referalRewardString string
@@ -385,7 +387,36 @@ func (repo *Repository) ReferUser(userId, referalCode string) error {
 		return db.NewValidationError("referal_reward", "referal_reward")
 	}
 
-	_, err = tx.Exec(`UPDATE "UserDetails" SET coins = coins + $1 WHERE id = $2`, referalReward, referalUserId)
+	var coinsAfter int
+	err = tx.QueryRow(`UPDATE "UserDetails" SET coins = COALESCE(coins, 0) + $1 WHERE id = $2 RETURNING coins`, referalReward, referalUserId).Scan(&coinsAfter)
+	if err != nil {
+		return db.NewDatabaseError("update", "UserDetails", err)
+	}
+
+	before, err := json.Marshal(map[string]any{
+		"referred_by":    referredByBefore,
+		"referrer_id":    referalUserId,
+		"referrer_coins": coinsBefore,
+	})
+	if err != nil {
+		return db.NewDatabaseError("marshal", "AuditEvent", err)
+	}
+	after, err := json.Marshal(map[string]any{
+		"referred_by":    referalUserId,
+		"referrer_id":    referalUserId,
+		"referrer_coins": coinsAfter,
+	})
+	if err != nil {
+		return db.NewDatabaseError("marshal", "AuditEvent", err)
+	}
+	if err := insertAuditEvent(tx, actor, model.AuditReferralRewarded, "referral", userId, before, after); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("ERROR: failed to commit transaction: %v", err)
+		return db.NewDatabaseError("commit_transaction", "User", err)
+	}
 	return nil
 }
 
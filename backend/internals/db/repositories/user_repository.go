@@ -3,6 +3,8 @@ package repositories
 import (
 	"database/sql"
 	"log"
+	"strconv"
+	"strings"
 
 	"sportsin_backend/internals/db"
 	"sportsin_backend/internals/model"
@@ -39,8 +41,8 @@ func (repo *Repository) AddUserDeviceTokenDetails(userID, deviceToken, snsEndpoi
 
 func (repo *Repository) GetUserByID(userID string) (*model.User, error) {
 	var user model.User
-	err := repo.DB.QueryRow(`SELECT id, username, email, role, created_at, updated_at, sns_endpoint_arn, device_token FROM "User" WHERE id = $1`, userID).Scan(
-		&user.Id, &user.Username, &user.Email, &user.Role, &user.CreatedAt, &user.UpdatedAt, &user.SnsEndpointArn, &user.DeviceToken,
+	err := repo.DB.QueryRow(`SELECT id, username, email, role, created_at, updated_at, sns_endpoint_arn, device_token, suspended_at FROM "User" WHERE id = $1`, userID).Scan(
+		&user.Id, &user.Username, &user.Email, &user.Role, &user.CreatedAt, &user.UpdatedAt, &user.SnsEndpointArn, &user.DeviceToken, &user.SuspendedAt,
 	)
 
 	if err != nil {
@@ -56,8 +58,8 @@ func (repo *Repository) GetUserByID(userID string) (*model.User, error) {
 
 func (repo *Repository) GetUserByEmail(email string) (*model.User, error) {
 	var user model.User
-	err := repo.DB.QueryRow(`SELECT id, username, email, role, created_at, updated_at FROM "User" WHERE email = $1`, email).Scan(
-		&user.Id, &user.Username, &user.Email, &user.Role, &user.CreatedAt, &user.UpdatedAt,
+	err := repo.DB.QueryRow(`SELECT id, username, email, role, created_at, updated_at, suspended_at FROM "User" WHERE email = $1`, email).Scan(
+		&user.Id, &user.Username, &user.Email, &user.Role, &user.CreatedAt, &user.UpdatedAt, &user.SuspendedAt,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -69,3 +71,97 @@ func (repo *Repository) GetUserByEmail(email string) (*model.User, error) {
 
 	return &user, nil
 }
+
+func (repo *Repository) ListUsers(filter *model.UserFilter, limit, offset int) ([]model.User, error) {
+	query := `SELECT u.id, u.username, u.email, u.role, u.created_at, u.updated_at, u.suspended_at
+		FROM "User" u
+		LEFT JOIN "UserDetails" d ON u.id = d.id`
+
+	var conditions []string
+	var args []any
+	argIndex := 1
+
+	if filter.Query != nil && *filter.Query != "" {
+		placeholder := "$" + strconv.Itoa(argIndex)
+		conditions = append(conditions, "(u.username ILIKE '%' || "+placeholder+" || '%' OR u.email ILIKE '%' || "+placeholder+
+			" || '%' OR d.username ILIKE '%' || "+placeholder+" || '%' OR d.name ILIKE '%' || "+placeholder+" || '%')")
+		args = append(args, *filter.Query)
+		argIndex++
+	}
+	if filter.Role != nil {
+		conditions = append(conditions, "u.role = $"+strconv.Itoa(argIndex))
+		args = append(args, *filter.Role)
+		argIndex++
+	}
+	if filter.Suspended != nil {
+		if *filter.Suspended {
+			conditions = append(conditions, "u.suspended_at IS NOT NULL")
+		} else {
+			conditions = append(conditions, "u.suspended_at IS NULL")
+		}
+	}
+
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY u.created_at DESC LIMIT $" + strconv.Itoa(argIndex) + " OFFSET $" + strconv.Itoa(argIndex+1)
+	args = append(args, limit, offset)
+
+	rows, err := repo.DB.Query(query, args...)
+	if err != nil {
+		log.Printf("ERROR: failed to list users: %v", err)
+		return nil, db.NewDatabaseError("select", "User", err)
+	}
+	defer rows.Close()
+
+	users := []model.User{}
+	for rows.Next() {
+		var user model.User
+		if err := rows.Scan(&user.Id, &user.Username, &user.Email, &user.Role, &user.CreatedAt, &user.UpdatedAt, &user.SuspendedAt); err != nil {
+			log.Printf("ERROR: failed to scan user: %v", err)
+			return nil, db.NewDatabaseError("scan", "User", err)
+		}
+		users = append(users, user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, db.NewDatabaseError("select", "User", err)
+	}
+
+	return users, nil
+}
+
+// SetUserSuspended marks the account as suspended, or clears the mark
+func (repo *Repository) SetUserSuspended(userID string, suspended bool, action *model.AdminAction) error {
+	query := `UPDATE "User" SET suspended_at = NULL, updated_at = CURRENT_TIMESTAMP WHERE id = $1`
+	if suspended {
+		query = `UPDATE "User" SET suspended_at = COALESCE(suspended_at, CURRENT_TIMESTAMP), updated_at = CURRENT_TIMESTAMP WHERE id = $1`
+	}
+
+	tx, err := repo.DB.Begin()
+	if err != nil {
+		return db.NewDatabaseError("begin", "transaction", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(query, userID)
+	if err != nil {
+		log.Printf("ERROR: failed to update user suspension: %v", err)
+		return db.NewDatabaseError("update", "User", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return db.NewDatabaseError("update", "User", err)
+	}
+	if rowsAffected == 0 {
+		return db.NewNotFoundError("user", userID)
+	}
+
+	if err := insertAdminAction(tx, action); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return db.NewDatabaseError("commit", "transaction", err)
+	}
+	return nil
+}
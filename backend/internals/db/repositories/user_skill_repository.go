@@ -1,3 +1,67 @@
 package repositories
 
-// [TODO] Implement post images repository
+import (
+	"log"
+
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/model"
+)
+
+// AddUserSkill records that the user plays the sport
+func (repo *Repository) AddUserSkill(userID, sportID string) error {
+	if _, err := repo.GetSportById(sportID); err != nil {
+		return err
+	}
+
+	result, err := repo.DB.Exec(`INSERT INTO "UserSkill" (user_id, sport_id) VALUES ($1, $2)
+	ON CONFLICT (user_id, sport_id) DO NOTHING`, userID, sportID)
+	if err != nil {
+		log.Printf("ERROR: failed to add user skill: %v", err)
+		return db.NewDatabaseError("insert", "UserSkill", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return db.NewAlreadyExistsError("user skill", "user_id and sport_id combination", userID+" + "+sportID)
+	}
+	return nil
+}
+
+func (repo *Repository) RemoveUserSkill(userID, sportID string) error {
+	result, err := repo.DB.Exec(`DELETE FROM "UserSkill" WHERE user_id = $1 AND sport_id = $2`, userID, sportID)
+	if err != nil {
+		log.Printf("ERROR: failed to remove user skill: %v", err)
+		return db.NewDatabaseError("delete", "UserSkill", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return db.NewNotFoundError("user skill", sportID)
+	}
+	return nil
+}
+
+// GetUserSkills returns the sports the user plays, by name
+func (repo *Repository) GetUserSkills(userID string) ([]model.Sport, error) {
+	rows, err := repo.DB.Query(`SELECT s.id, s.name, s.description, s.created_at, s.updated_at
+	FROM "UserSkill" us
+	INNER JOIN "Sports" s ON s.id = us.sport_id
+	WHERE us.user_id = $1
+	ORDER BY s.name`, userID)
+	if err != nil {
+		log.Printf("ERROR: failed to get user skills: %v", err)
+		return nil, db.NewDatabaseError("select", "UserSkill", err)
+	}
+	defer rows.Close()
+
+	sports := []model.Sport{}
+	for rows.Next() {
+		var sport model.Sport
+		if err := rows.Scan(&sport.Id, &sport.Name, &sport.Description, &sport.CreatedAt, &sport.UpdatedAt); err != nil {
+			log.Printf("ERROR: failed to scan user skill: %v", err)
+			return nil, db.NewDatabaseError("scan", "UserSkill", err)
+		}
+		sports = append(sports, sport)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, db.NewDatabaseError("select", "UserSkill", err)
+	}
+
+	return sports, nil
+}
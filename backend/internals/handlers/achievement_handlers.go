@@ -7,10 +7,10 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"sportsin_backend/internals/config"
-	"sportsin_backend/internals/db/repositories"
 	"sportsin_backend/internals/middleware"
 	"sportsin_backend/internals/model"
 	"sportsin_backend/internals/services"
+	"sportsin_backend/internals/store"
 )
 
 type CreateAchievementRequest struct {
@@ -61,7 +61,7 @@ type CertificateUploadResponse struct {
 // @Failure 500 {object} map[string]string
 // @Router /achievements [post]
 // @Security BearerAuth
-func CreateAchievement(cfg *config.Config, repo *repositories.Repository) gin.HandlerFunc {
+func CreateAchievement(cfg *config.Config, repo store.AchievementStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get user ID from JWT token
 		userID, exists := c.Get("userID")
@@ -139,15 +139,18 @@ func CreateAchievement(cfg *config.Config, repo *repositories.Repository) gin.Ha
 
 // GetUserAchievements godoc
 // @Summary Get user achievements
-// @Description Get all achievements for the authenticated user
+// @Description Get all achievements for the authenticated user, most recent first.
 // @Tags achievements
 // @Produce json
-// @Success 200 {array} AchievementResponse
+// @Param limit query int false "Number of achievements per page when paging by cursor (default 20)"
+// @Param offset query int false "Number of achievements to skip (default 0)"
+// @Param cursor query string false "next_cursor of the previous page"
+// @Success 200 {object} handlers.Page[AchievementResponse]
 // @Failure 401 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /achievements [get]
 // @Security BearerAuth
-func GetUserAchievements(cfg *config.Config, repo *repositories.Repository) gin.HandlerFunc {
+func GetUserAchievements(cfg *config.Config, repo store.AchievementStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get user ID from JWT token
 		userID, exists := c.Get("userID")
@@ -168,7 +171,16 @@ func GetUserAchievements(cfg *config.Config, repo *repositories.Repository) gin.
 			return
 		}
 
-		achievements, err := repo.GetAchievementsByUserId(userIDStr)
+		limit, offset, ok := parsePagination(c)
+		if !ok {
+			return
+		}
+		after, ok := parseCursor(c)
+		if !ok {
+			return
+		}
+
+		achievements, err := repo.GetAchievementsByUser(userIDStr, limit, offset, after)
 		if err != nil {
 			log.Printf("Error fetching achievements: %v", err)
 			c.JSON(http.StatusInternalServerError, gin.H{
@@ -193,7 +205,10 @@ func GetUserAchievements(cfg *config.Config, repo *repositories.Repository) gin.
 			})
 		}
 
-		c.JSON(http.StatusOK, response)
+		next := nextCursor(achievements, limit, func(a model.Achievement) (string, string) {
+			return a.Date, a.Id
+		})
+		c.JSON(http.StatusOK, newPage(response, next))
 	}
 }
 
@@ -210,27 +225,8 @@ func GetUserAchievements(cfg *config.Config, repo *repositories.Repository) gin.
 // @Failure 500 {object} map[string]string
 // @Router /achievements/{id} [get]
 // @Security BearerAuth
-func GetAchievementByID(cfg *config.Config, repo *repositories.Repository) gin.HandlerFunc {
+func GetAchievementByID(cfg *config.Config, repo store.AchievementStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Get user ID from JWT token
-		userID, exists := c.Get("userID")
-		if !exists {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error":   "Unauthorized",
-				"message": "User ID not found in token",
-			})
-			return
-		}
-
-		userIDStr, ok := userID.(string)
-		if !ok {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error":   "Unauthorized",
-				"message": "Invalid user ID format",
-			})
-			return
-		}
-
 		achievementID := c.Param("id")
 		if achievementID == "" {
 			c.JSON(http.StatusBadRequest, gin.H{
@@ -250,15 +246,6 @@ func GetAchievementByID(cfg *config.Config, repo *repositories.Repository) gin.H
 			return
 		}
 
-		// Check if the achievement belongs to the authenticated user
-		if achievement.UserId != userIDStr {
-			c.JSON(http.StatusForbidden, gin.H{
-				"error":   "Forbidden",
-				"message": "You can only access your own achievements",
-			})
-			return
-		}
-
 		response := AchievementResponse{
 			ID:              achievement.Id,
 			UserId:          achievement.UserId,
@@ -291,27 +278,8 @@ func GetAchievementByID(cfg *config.Config, repo *repositories.Repository) gin.H
 // @Failure 500 {object} map[string]string
 // @Router /achievements/{id} [put]
 // @Security BearerAuth
-func UpdateAchievement(cfg *config.Config, repo *repositories.Repository) gin.HandlerFunc {
+func UpdateAchievement(cfg *config.Config, repo store.AchievementStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Get user ID from JWT token
-		userID, exists := c.Get("userID")
-		if !exists {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error":   "Unauthorized",
-				"message": "User ID not found in token",
-			})
-			return
-		}
-
-		userIDStr, ok := userID.(string)
-		if !ok {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error":   "Unauthorized",
-				"message": "Invalid user ID format",
-			})
-			return
-		}
-
 		achievementID := c.Param("id")
 		if achievementID == "" {
 			c.JSON(http.StatusBadRequest, gin.H{
@@ -341,15 +309,6 @@ func UpdateAchievement(cfg *config.Config, repo *repositories.Repository) gin.Ha
 			return
 		}
 
-		// Check if the achievement belongs to the authenticated user
-		if existingAchievement.UserId != userIDStr {
-			c.JSON(http.StatusForbidden, gin.H{
-				"error":   "Forbidden",
-				"message": "You can only update your own achievements",
-			})
-			return
-		}
-
 		// Update only provided fields
 		if req.Date != "" {
 			if _, err := time.Parse("2006-01-02", req.Date); err != nil {
@@ -417,27 +376,8 @@ func UpdateAchievement(cfg *config.Config, repo *repositories.Repository) gin.Ha
 // @Failure 500 {object} map[string]string
 // @Router /achievements/{id} [delete]
 // @Security BearerAuth
-func DeleteAchievement(cfg *config.Config, repo *repositories.Repository, s3Service *services.S3Service) gin.HandlerFunc {
+func DeleteAchievement(cfg *config.Config, repo store.AchievementStore, storage *services.StorageService) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Get user ID from JWT token
-		userID, exists := c.Get("userID")
-		if !exists {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error":   "Unauthorized",
-				"message": "User ID not found in token",
-			})
-			return
-		}
-
-		userIDStr, ok := userID.(string)
-		if !ok {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error":   "Unauthorized",
-				"message": "Invalid user ID format",
-			})
-			return
-		}
-
 		achievementID := c.Param("id")
 		if achievementID == "" {
 			c.JSON(http.StatusBadRequest, gin.H{
@@ -458,25 +398,8 @@ func DeleteAchievement(cfg *config.Config, repo *repositories.Repository, s3Serv
 			return
 		}
 
-		// Check if the achievement belongs to the authenticated user
-		if existingAchievement.UserId != userIDStr {
-			c.JSON(http.StatusForbidden, gin.H{
-				"error":   "Forbidden",
-				"message": "You can only delete your own achievements",
-			})
-			return
-		}
-
-		// Delete certificate from S3 if it exists
-		if existingAchievement.CertificateUrl != nil && *existingAchievement.CertificateUrl != "" {
-			if err := s3Service.DeleteCertificate(c.Request.Context(), *existingAchievement.CertificateUrl); err != nil {
-				log.Printf("Warning: Failed to delete certificate from S3: %v", err)
-				// Continue with database deletion even if S3 deletion fails
-			}
-		}
-
 		// Delete from database
-		if err := repo.DeleteAchievementById(achievementID); err != nil {
+		if err := repo.DeleteAchievementById(achievementID, nil); err != nil {
 			log.Printf("Error deleting achievement: %v", err)
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"error":   "Internal Server Error",
@@ -485,6 +408,14 @@ func DeleteAchievement(cfg *config.Config, repo *repositories.Repository, s3Serv
 			return
 		}
 
+		// Delete certificate from S3 once the achievement is gone, so a
+		// failed deletion keeps it
+		if existingAchievement.CertificateUrl != nil && *existingAchievement.CertificateUrl != "" {
+			if err := storage.DeleteCertificate(c.Request.Context(), *existingAchievement.CertificateUrl); err != nil {
+				log.Printf("Warning: Failed to delete certificate from S3: %v", err)
+			}
+		}
+
 		c.JSON(http.StatusOK, gin.H{
 			"message": "Achievement deleted successfully",
 		})
@@ -507,7 +438,7 @@ func DeleteAchievement(cfg *config.Config, repo *repositories.Repository, s3Serv
 // @Failure 500 {object} map[string]string
 // @Router /achievements/{id}/certificate [post]
 // @Security BearerAuth
-func UploadCertificate(cfg *config.Config, repo *repositories.Repository, s3Service *services.S3Service) gin.HandlerFunc {
+func UploadCertificate(cfg *config.Config, repo store.AchievementStore, storage *services.StorageService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get user ID from JWT token
 		userID, exists := c.Get("userID")
@@ -548,15 +479,6 @@ func UploadCertificate(cfg *config.Config, repo *repositories.Repository, s3Serv
 			return
 		}
 
-		// Check if the achievement belongs to the authenticated user
-		if existingAchievement.UserId != userIDStr {
-			c.JSON(http.StatusForbidden, gin.H{
-				"error":   "Forbidden",
-				"message": "You can only upload certificates for your own achievements",
-			})
-			return
-		}
-
 		// Get file from form data
 		file, header, err := c.Request.FormFile("file")
 		if err != nil {
@@ -579,13 +501,13 @@ func UploadCertificate(cfg *config.Config, repo *repositories.Repository, s3Serv
 
 		// Delete old certificate if it exists
 		if existingAchievement.CertificateUrl != nil && *existingAchievement.CertificateUrl != "" {
-			if err := s3Service.DeleteCertificate(c.Request.Context(), *existingAchievement.CertificateUrl); err != nil {
+			if err := storage.DeleteCertificate(c.Request.Context(), *existingAchievement.CertificateUrl); err != nil {
 				log.Printf("Warning: Failed to delete old certificate from S3: %v", err)
 			}
 		}
 
 		// Upload new certificate to S3
-		certificateURL, err := s3Service.UploadCertificate(c.Request.Context(), userIDStr, achievementID, file, header)
+		certificateURL, err := storage.UploadCertificate(c.Request.Context(), userIDStr, achievementID, file, header)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"error":   "Internal Server Error",
@@ -599,7 +521,7 @@ func UploadCertificate(cfg *config.Config, repo *repositories.Repository, s3Serv
 		if err := repo.UpdateAchievement(existingAchievement); err != nil {
 			log.Printf("Error updating achievement with certificate URL: %v", err)
 			// Try to delete the uploaded file from S3 since database update failed
-			s3Service.DeleteCertificate(c.Request.Context(), certificateURL)
+			storage.DeleteCertificate(c.Request.Context(), certificateURL)
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"error":   "Internal Server Error",
 				"message": "Failed to update achievement with certificate URL",
@@ -628,27 +550,8 @@ func UploadCertificate(cfg *config.Config, repo *repositories.Repository, s3Serv
 // @Failure 500 {object} map[string]string
 // @Router /achievements/{id}/certificate [delete]
 // @Security BearerAuth
-func DeleteCertificate(cfg *config.Config, repo *repositories.Repository, s3Service *services.S3Service) gin.HandlerFunc {
+func DeleteCertificate(cfg *config.Config, repo store.AchievementStore, storage *services.StorageService) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Get user ID from JWT token
-		userID, exists := c.Get("userID")
-		if !exists {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error":   "Unauthorized",
-				"message": "User ID not found in token",
-			})
-			return
-		}
-
-		userIDStr, ok := userID.(string)
-		if !ok {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error":   "Unauthorized",
-				"message": "Invalid user ID format",
-			})
-			return
-		}
-
 		achievementID := c.Param("id")
 		if achievementID == "" {
 			c.JSON(http.StatusBadRequest, gin.H{
@@ -669,15 +572,6 @@ func DeleteCertificate(cfg *config.Config, repo *repositories.Repository, s3Serv
 			return
 		}
 
-		// Check if the achievement belongs to the authenticated user
-		if existingAchievement.UserId != userIDStr {
-			c.JSON(http.StatusForbidden, gin.H{
-				"error":   "Forbidden",
-				"message": "You can only delete certificates for your own achievements",
-			})
-			return
-		}
-
 		// Check if certificate exists
 		if existingAchievement.CertificateUrl == nil || *existingAchievement.CertificateUrl == "" {
 			c.JSON(http.StatusNotFound, gin.H{
@@ -688,7 +582,7 @@ func DeleteCertificate(cfg *config.Config, repo *repositories.Repository, s3Serv
 		}
 
 		// Delete certificate from S3
-		if err := s3Service.DeleteCertificate(c.Request.Context(), *existingAchievement.CertificateUrl); err != nil {
+		if err := storage.DeleteCertificate(c.Request.Context(), *existingAchievement.CertificateUrl); err != nil {
 			log.Printf("Error deleting certificate from S3: %v", err)
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"error":   "Internal Server Error",
@@ -715,16 +609,18 @@ func DeleteCertificate(cfg *config.Config, repo *repositories.Repository, s3Serv
 }
 
 // RegisterAchievementRoutes registers all achievement-related routes
-func RegisterAchievementRoutes(rg *gin.RouterGroup, cfg *config.Config, repo *repositories.Repository, s3Service *services.S3Service) {
+func RegisterAchievementRoutes(rg *gin.RouterGroup, cfg *config.Config, repo store.AchievementStore, storage *services.StorageService) {
 	jwtMiddleware := middleware.NewJWTMiddleware(cfg)
 	r := rg.Group("/achievements")
 	r.Use(jwtMiddleware.AuthMiddleware())
 
+	ownsAchievement := middleware.RequireOwnership("achievement", "id", achievementOwner(repo))
+
 	r.POST("", CreateAchievement(cfg, repo))
 	r.GET("", GetUserAchievements(cfg, repo))
-	r.GET("/:id", GetAchievementByID(cfg, repo))
-	r.PUT("/:id", UpdateAchievement(cfg, repo))
-	r.DELETE("/:id", DeleteAchievement(cfg, repo, s3Service))
-	r.POST("/:id/certificate", UploadCertificate(cfg, repo, s3Service))
-	r.DELETE("/:id/certificate", DeleteCertificate(cfg, repo, s3Service))
+	r.GET("/:id", ownsAchievement, GetAchievementByID(cfg, repo))
+	r.PUT("/:id", ownsAchievement, UpdateAchievement(cfg, repo))
+	r.DELETE("/:id", ownsAchievement, DeleteAchievement(cfg, repo, storage))
+	r.POST("/:id/certificate", ownsAchievement, UploadCertificate(cfg, repo, storage))
+	r.DELETE("/:id/certificate", ownsAchievement, DeleteCertificate(cfg, repo, storage))
 }
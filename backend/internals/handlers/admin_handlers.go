@@ -0,0 +1,523 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"sportsin_backend/internals/auth"
+	"sportsin_backend/internals/config"
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/middleware"
+	"sportsin_backend/internals/model"
+	"sportsin_backend/internals/services"
+	"sportsin_backend/internals/store"
+)
+
+// AdminActionRequest is the optional body of the moderation endpoints
+type AdminActionRequest struct {
+	Reason *string `json:"reason,omitempty"`
+}
+
+// bindAdminActionRequest reads the optional reason. An empty body is allowed.
+func bindAdminActionRequest(c *gin.Context) (*AdminActionRequest, bool) {
+	var req AdminActionRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+			return nil, false
+		}
+	}
+	return &req, true
+}
+
+// adminAction is the audit trail entry for an action of the signed in admin.
+// It is handed to the store method making the change, which records it in
+// the same transaction, so the action fails if it cannot be recorded.
+func adminAction(c *gin.Context, action model.AdminActionType, targetType, targetID string, reason *string) *model.AdminAction {
+	adminID, _ := middleware.GetUserIDFromContext(c)
+	return &model.AdminAction{
+		AdminId:    adminID,
+		Action:     action,
+		TargetType: targetType,
+		TargetId:   targetID,
+		Reason:     reason,
+	}
+}
+
+// AdminListUsers godoc
+// @Summary      List users
+// @Description  Lists accounts newest first, optionally filtered by a search term, role or suspension state. Paged by limit and offset only. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        q          query     string  false  "Matches email, username or name"
+// @Param        role       query     string  false  "Filter by role" Enums(admin,player,recruiter)
+// @Param        suspended  query     bool    false  "Filter by suspension state"
+// @Param        limit      query     int     false  "Number of users to return (default 20)"
+// @Param        offset     query     int     false  "Number of users to skip (default 0)"
+// @Success      200        {object}  handlers.Page[model.User]
+// @Failure      400        {object}  map[string]string
+// @Failure      401        {object}  map[string]string
+// @Failure      403        {object}  map[string]string
+// @Failure      500        {object}  map[string]string
+// @Router       /admin/users [get]
+func AdminListUsersHandler(repo store.AdminStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit, offset, ok := parsePagination(c)
+		if !ok {
+			return
+		}
+
+		filter := &model.UserFilter{}
+		if q := c.Query("q"); q != "" {
+			filter.Query = &q
+		}
+		if roleStr := c.Query("role"); roleStr != "" {
+			role, err := model.ParseRole(roleStr)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role parameter"})
+				return
+			}
+			filter.Role = &role
+		}
+		if suspendedStr := c.Query("suspended"); suspendedStr != "" {
+			suspended, err := strconv.ParseBool(suspendedStr)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid suspended parameter"})
+				return
+			}
+			filter.Suspended = &suspended
+		}
+
+		users, err := repo.ListUsers(filter, limit, offset)
+		if err != nil {
+			httpErr := db.ToHTTPError(err)
+			c.JSON(httpErr.StatusCode, httpErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, newPage(users, ""))
+	}
+}
+
+// AdminSuspendUser godoc
+// @Summary      Suspend a user
+// @Description  Suspends an account and revokes all of its sessions. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string              true   "User ID"
+// @Param        request  body      AdminActionRequest  false  "Reason for the suspension"
+// @Success      200      {object}  map[string]string
+// @Failure      400      {object}  map[string]string
+// @Failure      401      {object}  map[string]string
+// @Failure      403      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /admin/users/{id}/suspend [post]
+func AdminSuspendUserHandler(repo store.AdminStore, svc auth.Provider, revocations *auth.RevocationList) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.Param("id")
+		req, ok := bindAdminActionRequest(c)
+		if !ok {
+			return
+		}
+
+		if adminID, _ := middleware.GetUserIDFromContext(c); adminID == userID {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "You cannot suspend your own account"})
+			return
+		}
+
+		if err := repo.SetUserSuspended(userID, true, adminAction(c, model.AdminActionSuspendUser, "user", userID, req.Reason)); err != nil {
+			httpErr := db.ToHTTPError(err)
+			c.JSON(httpErr.StatusCode, httpErr)
+			return
+		}
+
+		if err := revocations.SuspendUser(c.Request.Context(), userID); err != nil {
+			log.Printf("ERROR: failed to revoke tokens of suspended user %s: %v", userID, err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke user sessions"})
+			return
+		}
+
+		// The ID tokens are already rejected, so a refresh token that
+		// survives here cannot be used for anything
+		if err := svc.SignOutEverywhere(userID); err != nil {
+			log.Printf("Warning: failed to sign out suspended user %s: %v", userID, err)
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "User suspended successfully"})
+	}
+}
+
+// AdminReinstateUser godoc
+// @Summary      Reinstate a user
+// @Description  Lifts the suspension of an account. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string              true   "User ID"
+// @Param        request  body      AdminActionRequest  false  "Reason for the reinstatement"
+// @Success      200      {object}  map[string]string
+// @Failure      400      {object}  map[string]string
+// @Failure      401      {object}  map[string]string
+// @Failure      403      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /admin/users/{id}/reinstate [post]
+func AdminReinstateUserHandler(repo store.AdminStore, revocations *auth.RevocationList) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.Param("id")
+		req, ok := bindAdminActionRequest(c)
+		if !ok {
+			return
+		}
+
+		if err := repo.SetUserSuspended(userID, false, adminAction(c, model.AdminActionReinstateUser, "user", userID, req.Reason)); err != nil {
+			httpErr := db.ToHTTPError(err)
+			c.JSON(httpErr.StatusCode, httpErr)
+			return
+		}
+
+		if err := revocations.ReinstateUser(c.Request.Context(), userID); err != nil {
+			log.Printf("ERROR: failed to reinstate user %s: %v", userID, err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reinstate user sessions"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "User reinstated successfully"})
+	}
+}
+
+// AdminDeletePost godoc
+// @Summary      Delete any post
+// @Description  Deletes a post and its images regardless of the author. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string              true   "Post ID"
+// @Param        request  body      AdminActionRequest  false  "Reason for the deletion"
+// @Success      200      {object}  map[string]string
+// @Failure      400      {object}  map[string]string
+// @Failure      401      {object}  map[string]string
+// @Failure      403      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /admin/posts/{id} [delete]
+func AdminDeletePostHandler(repo store.PostStore, storage *services.StorageService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		postID := c.Param("id")
+		req, ok := bindAdminActionRequest(c)
+		if !ok {
+			return
+		}
+
+		post, err := repo.GetPostById(postID)
+		if err != nil {
+			httpErr := db.ToHTTPError(err)
+			c.JSON(httpErr.StatusCode, httpErr)
+			return
+		}
+
+		imageUrls, err := repo.DeletePost(postID, post.UserId, adminAction(c, model.AdminActionDeletePost, "post", postID, req.Reason))
+		if err != nil {
+			httpErr := db.ToHTTPError(err)
+			c.JSON(httpErr.StatusCode, httpErr)
+			return
+		}
+		for _, imageUrl := range imageUrls {
+			if err := storage.DeletePostImage(c.Request.Context(), imageUrl); err != nil {
+				log.Printf("Warning: failed to delete post image %s: %v", imageUrl, err)
+			}
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Post deleted successfully"})
+	}
+}
+
+// AdminDeleteComment godoc
+// @Summary      Delete any comment
+// @Description  Deletes a comment and its replies regardless of the author. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string              true   "Comment ID"
+// @Param        request  body      AdminActionRequest  false  "Reason for the deletion"
+// @Success      200      {object}  map[string]string
+// @Failure      400      {object}  map[string]string
+// @Failure      401      {object}  map[string]string
+// @Failure      403      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /admin/comments/{id} [delete]
+func AdminDeleteCommentHandler(repo store.CommentStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		commentID := c.Param("id")
+		req, ok := bindAdminActionRequest(c)
+		if !ok {
+			return
+		}
+
+		if err := repo.DeleteComment(commentID, adminAction(c, model.AdminActionDeleteComment, "comment", commentID, req.Reason)); err != nil {
+			httpErr := db.ToHTTPError(err)
+			c.JSON(httpErr.StatusCode, httpErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Comment deleted successfully"})
+	}
+}
+
+// AdminDeleteAchievement godoc
+// @Summary      Delete any achievement
+// @Description  Deletes an achievement and its certificate regardless of the owner. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string              true   "Achievement ID"
+// @Param        request  body      AdminActionRequest  false  "Reason for the deletion"
+// @Success      200      {object}  map[string]string
+// @Failure      400      {object}  map[string]string
+// @Failure      401      {object}  map[string]string
+// @Failure      403      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /admin/achievements/{id} [delete]
+func AdminDeleteAchievementHandler(repo store.AchievementStore, storage *services.StorageService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		achievementID := c.Param("id")
+		req, ok := bindAdminActionRequest(c)
+		if !ok {
+			return
+		}
+
+		achievement, err := repo.GetAchievementById(achievementID)
+		if err != nil {
+			httpErr := db.ToHTTPError(err)
+			c.JSON(httpErr.StatusCode, httpErr)
+			return
+		}
+
+		if err := repo.DeleteAchievementById(achievementID, adminAction(c, model.AdminActionDeleteAchievement, "achievement", achievementID, req.Reason)); err != nil {
+			httpErr := db.ToHTTPError(err)
+			c.JSON(httpErr.StatusCode, httpErr)
+			return
+		}
+
+		// The certificate goes only once the deletion is committed
+		if achievement.CertificateUrl != nil && *achievement.CertificateUrl != "" {
+			if err := storage.DeleteCertificate(c.Request.Context(), *achievement.CertificateUrl); err != nil {
+				log.Printf("Warning: failed to delete certificate %s: %v", *achievement.CertificateUrl, err)
+			}
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Achievement deleted successfully"})
+	}
+}
+
+// AdminCloseOpening godoc
+// @Summary      Close any opening
+// @Description  Sets an opening's status to closed regardless of the recruiter. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string              true   "Opening ID"
+// @Param        request  body      AdminActionRequest  false  "Reason for closing"
+// @Success      200      {object}  map[string]string
+// @Failure      400      {object}  map[string]string
+// @Failure      401      {object}  map[string]string
+// @Failure      403      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /admin/openings/{id}/close [post]
+func AdminCloseOpeningHandler(repo store.OpeningStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		openingID := c.Param("id")
+		req, ok := bindAdminActionRequest(c)
+		if !ok {
+			return
+		}
+
+		if _, err := repo.GetOpeningByID(openingID, nil); err != nil {
+			httpErr := db.ToHTTPError(err)
+			c.JSON(httpErr.StatusCode, httpErr)
+			return
+		}
+
+		if err := repo.UpdateOpeningStatus(openingID, model.OpeningStatusClosed, adminAction(c, model.AdminActionCloseOpening, "opening", openingID, req.Reason)); err != nil {
+			httpErr := db.ToHTTPError(err)
+			c.JSON(httpErr.StatusCode, httpErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Opening closed successfully"})
+	}
+}
+
+// AdminCloseTournament godoc
+// @Summary      Close any tournament
+// @Description  Cancels a tournament regardless of the host. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string              true   "Tournament ID"
+// @Param        request  body      AdminActionRequest  false  "Reason for closing"
+// @Success      200      {object}  map[string]string
+// @Failure      400      {object}  map[string]string
+// @Failure      401      {object}  map[string]string
+// @Failure      403      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /admin/tournaments/{id}/close [post]
+func AdminCloseTournamentHandler(repo store.TournamentStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tournamentID := c.Param("id")
+		req, ok := bindAdminActionRequest(c)
+		if !ok {
+			return
+		}
+
+		tournament, err := repo.GetTournamentByID(tournamentID)
+		if err != nil {
+			httpErr := db.ToHTTPError(err)
+			c.JSON(httpErr.StatusCode, httpErr)
+			return
+		}
+
+		status := model.Cancelled
+		tournament.Status = &status
+		if err := repo.UpdateTournament(tournament, adminAction(c, model.AdminActionCloseTournament, "tournament", tournamentID, req.Reason)); err != nil {
+			httpErr := db.ToHTTPError(err)
+			c.JSON(httpErr.StatusCode, httpErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Tournament closed successfully"})
+	}
+}
+
+// AdminGetActions godoc
+// @Summary      List admin actions
+// @Description  Returns the audit trail of the admin endpoints, newest first. Paged by limit and offset only. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        limit   query     int  false  "Number of entries to return (default 20)"
+// @Param        offset  query     int  false  "Number of entries to skip (default 0)"
+// @Success      200     {object}  handlers.Page[model.AdminAction]
+// @Failure      400     {object}  map[string]string
+// @Failure      401     {object}  map[string]string
+// @Failure      403     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Router       /admin/actions [get]
+func AdminGetActionsHandler(repo store.AdminStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit, offset, ok := parsePagination(c)
+		if !ok {
+			return
+		}
+
+		actions, err := repo.GetAdminActions(limit, offset)
+		if err != nil {
+			httpErr := db.ToHTTPError(err)
+			c.JSON(httpErr.StatusCode, httpErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, newPage(actions, ""))
+	}
+}
+
+// AdminGetAuditEvents godoc
+// @Summary      List audit events
+// @Description  Returns recorded changes to applications, tournament participants, tournaments, openings and referrals, newest first, as a Page. Pages by limit and offset only; next_cursor is never set. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        actor_id     query     string  false  "Only events caused by this user"
+// @Param        action       query     string  false  "Only events of this action" Enums(application_status_changed,participant_status_changed,tournament_deleted,opening_deleted,referral_rewarded)
+// @Param        entity_type  query     string  false  "Only events on this kind of entity"
+// @Param        entity_id    query     string  false  "Only events on this entity"
+// @Param        limit        query     int     false  "Number of events to return (default 20)"
+// @Param        offset       query     int     false  "Number of events to skip (default 0)"
+// @Success      200          {object}  handlers.Page[model.AuditEvent]
+// @Failure      400          {object}  map[string]string
+// @Failure      401          {object}  map[string]string
+// @Failure      403          {object}  map[string]string
+// @Failure      500          {object}  map[string]string
+// @Router       /admin/audit-events [get]
+func AdminGetAuditEventsHandler(repo store.AuditStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit, offset, ok := parsePagination(c)
+		if !ok {
+			return
+		}
+
+		filter := &model.AuditEventFilter{}
+		if actorID := c.Query("actor_id"); actorID != "" {
+			if _, err := uuid.Parse(actorID); err != nil {
+				httpErr := db.ToHTTPError(db.NewValidationError("actor_id", "actor_id must be a user ID"))
+				c.JSON(httpErr.StatusCode, httpErr)
+				return
+			}
+			filter.ActorId = &actorID
+		}
+		if action := c.Query("action"); action != "" {
+			auditAction := model.AuditAction(action)
+			filter.Action = &auditAction
+		}
+		if entityType := c.Query("entity_type"); entityType != "" {
+			filter.EntityType = &entityType
+		}
+		if entityID := c.Query("entity_id"); entityID != "" {
+			filter.EntityId = &entityID
+		}
+
+		events, err := repo.GetAuditEvents(filter, limit, offset)
+		if err != nil {
+			httpErr := db.ToHTTPError(err)
+			c.JSON(httpErr.StatusCode, httpErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, newPage(events, ""))
+	}
+}
+
+// RegisterAdminRoutes registers the moderation endpoints. Every route
+// requires an admin token.
+func RegisterAdminRoutes(rg *gin.RouterGroup, cfg *config.Config, repo store.Store, svc auth.Provider, revocations *auth.RevocationList, storage *services.StorageService) {
+	r := rg.Group("/admin")
+	r.Use(middleware.NewJWTMiddleware(cfg).AuthMiddleware(), middleware.RequireRole(model.AdminRole))
+
+	r.GET("/users", AdminListUsersHandler(repo))
+	r.POST("/users/:id/suspend", AdminSuspendUserHandler(repo, svc, revocations))
+	r.POST("/users/:id/reinstate", AdminReinstateUserHandler(repo, revocations))
+
+	r.DELETE("/posts/:id", AdminDeletePostHandler(repo, storage))
+	r.DELETE("/comments/:id", AdminDeleteCommentHandler(repo))
+	r.DELETE("/achievements/:id", AdminDeleteAchievementHandler(repo, storage))
+	r.POST("/openings/:id/close", AdminCloseOpeningHandler(repo))
+	r.POST("/tournaments/:id/close", AdminCloseTournamentHandler(repo))
+
+	r.GET("/reports", AdminGetReportsHandler(repo))
+	r.POST("/reports/:id/action", AdminActionReportHandler(repo))
+	r.POST("/reports/:id/dismiss", AdminDismissReportHandler(repo))
+
+	r.POST("/sports", CreateSportHandler(repo))
+	r.PUT("/sports/:id", UpdateSportHandler(repo))
+	r.DELETE("/sports/:id", DeleteSportHandler(repo))
+
+	r.GET("/actions", AdminGetActionsHandler(repo))
+	r.GET("/audit-events", AdminGetAuditEventsHandler(repo))
+}
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+
+	"sportsin_backend/internals/auth"
+	"sportsin_backend/internals/model"
+	"sportsin_backend/internals/store/memory"
+)
+
+func TestSuspensionIsRecordedWithTheChange(t *testing.T) {
+	repo := memory.NewStore()
+	router, svc, revocations := newTestAuth(t, repo)
+	router.POST("/admin/users/:id/suspend", AdminSuspendUserHandler(repo, svc, revocations))
+	router.GET("/admin/actions", AdminGetActionsHandler(repo))
+	adminID, _ := newTestAccount(t, repo, model.AdminRole)
+	playerID, email := newTestAccount(t, repo, model.PlayerRole)
+	session := login(t, router, email)
+	path := "/admin/users/" + playerID + "/suspend"
+
+	// An action that cannot be recorded is not made at all
+	if code := serve(t, router, http.MethodPost, path, "not-a-user-id", nil); code != http.StatusInternalServerError {
+		t.Fatalf("unrecorded suspension: status %d, want %d", code, http.StatusInternalServerError)
+	}
+	if user, err := repo.GetUserByID(playerID); err != nil || user.SuspendedAt != nil {
+		t.Fatalf("player = %+v, %v after a failed suspension, want them active", user, err)
+	}
+	if code := refresh(t, router, session.RefreshToken, &session); code != http.StatusOK {
+		t.Fatalf("refresh after a failed suspension: status %d, want %d", code, http.StatusOK)
+	}
+
+	if code := serve(t, router, http.MethodPost, path, adminID, nil); code != http.StatusOK {
+		t.Fatalf("suspension: status %d, want %d", code, http.StatusOK)
+	}
+	var actions Page[model.AdminAction]
+	if code := serve(t, router, http.MethodGet, "/admin/actions", adminID, &actions); code != http.StatusOK {
+		t.Fatalf("actions: status %d, want %d", code, http.StatusOK)
+	}
+	if len(actions.Items) != 1 || actions.Items[0].AdminId != adminID || actions.Items[0].Action != model.AdminActionSuspendUser || actions.Items[0].TargetId != playerID {
+		t.Errorf("actions = %+v, want only the suspension of %s by %s", actions.Items, playerID, adminID)
+	}
+
+	// Every session of the suspended account ends at once
+	if code := serveJSON(t, router, http.MethodPost, "/auth/logout", session.IDToken, nil, nil); code != http.StatusUnauthorized {
+		t.Errorf("ID token of a suspended account: status %d, want %d", code, http.StatusUnauthorized)
+	}
+	if code := refresh(t, router, session.RefreshToken, nil); code != http.StatusUnauthorized {
+		t.Errorf("refresh token of a suspended account: status %d, want %d", code, http.StatusUnauthorized)
+	}
+}
+
+func TestSuspendedAccountCannotLogInOrRefresh(t *testing.T) {
+	repo := memory.NewStore()
+	router, _, _ := newTestAuth(t, repo)
+	playerID, email := newTestAccount(t, repo, model.PlayerRole)
+	session := login(t, router, email)
+
+	// Suspended in the database alone, as when Redis has lost the entry or
+	// the refresh token outlived the sign out
+	if err := repo.SetUserSuspended(playerID, true, nil); err != nil {
+		t.Fatalf("SetUserSuspended: %v", err)
+	}
+
+	if code := refresh(t, router, session.RefreshToken, nil); code != http.StatusForbidden {
+		t.Errorf("refresh: status %d, want %d", code, http.StatusForbidden)
+	}
+	input := auth.LoginInput{Email: email, Password: testPassword}
+	if code := serveJSON(t, router, http.MethodPost, "/login", "", input, nil); code != http.StatusForbidden {
+		t.Errorf("login: status %d, want %d", code, http.StatusForbidden)
+	}
+	if code := serveJSON(t, router, http.MethodPost, "/auth/logout", session.IDToken, nil, nil); code != http.StatusUnauthorized {
+		t.Errorf("ID token: status %d, want %d", code, http.StatusUnauthorized)
+	}
+}
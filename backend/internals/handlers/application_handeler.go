@@ -7,9 +7,9 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"sportsin_backend/internals/db"
-	"sportsin_backend/internals/db/repositories"
 	"sportsin_backend/internals/middleware"
 	"sportsin_backend/internals/model"
+	"sportsin_backend/internals/store"
 )
 
 type UpdateApplicationStatusRequest struct {
@@ -100,7 +100,7 @@ func toPlayerResponse(applicant *model.Applicant) *ApplicantResponse {
 // @Failure      409          {object}  map[string]string
 // @Failure      500          {object}  map[string]string
 // @Router       /openings/{id}/apply [post]
-func CreateApplicationHandler(repo *repositories.Repository) gin.HandlerFunc {
+func CreateApplicationHandler(repo store.RecruitmentStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get user ID and role from authentication middleware
 		userID, exists := middleware.GetUserIDFromContext(c)
@@ -109,12 +109,6 @@ func CreateApplicationHandler(repo *repositories.Repository) gin.HandlerFunc {
 			return
 		}
 
-		role, roleExists := middleware.GetRoleFromContext(c)
-		if !roleExists || role != "player" {
-			c.JSON(http.StatusForbidden, gin.H{"error": "Only players can create applications"})
-			return
-		}
-
 		// Get opening ID from URL path
 		openingID := c.Param("id")
 		if openingID == "" {
@@ -164,21 +158,8 @@ func CreateApplicationHandler(repo *repositories.Repository) gin.HandlerFunc {
 // @Failure      404         {object}  map[string]string
 // @Failure      500         {object}  map[string]string
 // @Router       /openings/{id}/applicants [get]
-func GetApplicantsByOpeningIDHandler(repo *repositories.Repository) gin.HandlerFunc {
+func GetApplicantsByOpeningIDHandler(repo store.RecruitmentStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Get user ID and role from authentication middleware
-		userID, exists := middleware.GetUserIDFromContext(c)
-		if !exists {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
-			return
-		}
-
-		role, roleExists := middleware.GetRoleFromContext(c)
-		if !roleExists || role != "recruiter" {
-			c.JSON(http.StatusForbidden, gin.H{"error": "Only recruiters can view applicants"})
-			return
-		}
-
 		// Get opening ID from URL
 		openingID := c.Param("id")
 		if openingID == "" {
@@ -186,21 +167,7 @@ func GetApplicantsByOpeningIDHandler(repo *repositories.Repository) gin.HandlerF
 			return
 		}
 
-		// Check if the opening exists and if the recruiter owns it
-		opening, err := repo.GetOpeningByID(openingID, nil)
-		if err != nil {
-			log.Println(err)
-			httpErr := db.ToHTTPError(err)
-			c.JSON(httpErr.StatusCode, httpErr)
-			return
-		}
-
-		// Verify ownership
-		if opening.Opening.RecruiterID != userID {
-			c.JSON(http.StatusForbidden, gin.H{"error": "You can only view applicants for your own openings"})
-			return
-		}
-
+		// Role and opening ownership are enforced by middleware on the route
 		// Get applicants for the opening
 		applicants, err := repo.GetApplicantsByOpeningID(openingID)
 		if err != nil {
@@ -232,21 +199,15 @@ func GetApplicantsByOpeningIDHandler(repo *repositories.Repository) gin.HandlerF
 // @Failure      403  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
 // @Router       /applications/my [get]
-func GetApplicationsByPlayerHandler(repo *repositories.Repository) gin.HandlerFunc {
+func GetApplicationsByPlayerHandler(repo store.RecruitmentStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Get user ID and role from authentication middleware
+		// The player role is enforced by middleware.RequireRole on the route
 		userID, exists := middleware.GetUserIDFromContext(c)
 		if !exists {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
 			return
 		}
 
-		role, roleExists := middleware.GetRoleFromContext(c)
-		if !roleExists || role != "player" {
-			c.JSON(http.StatusForbidden, gin.H{"error": "Only players can view their applications"})
-			return
-		}
-
 		// Get applications from database
 		applications, err := repo.GetApplicationsByPlayerID(userID)
 		if err != nil {
@@ -281,8 +242,8 @@ func GetApplicationsByPlayerHandler(repo *repositories.Repository) gin.HandlerFu
 // @Failure      404            {object}  map[string]string
 // @Failure      500            {object}  map[string]string
 // @Router       /openings/{id}/applications/{application_id}/accept [patch]
-func AcceptApplicationHandler(repo *repositories.Repository) gin.HandlerFunc {
-	return updateApplicationStatusHandler(repo, model.ApplicationStatusAccepted, "accept", "recruiter")
+func AcceptApplicationHandler(repo store.RecruitmentStore) gin.HandlerFunc {
+	return updateApplicationStatusHandler(repo, model.ApplicationStatusAccepted, "accept", model.RecruiterRole)
 }
 
 // RejectApplication godoc
@@ -301,8 +262,8 @@ func AcceptApplicationHandler(repo *repositories.Repository) gin.HandlerFunc {
 // @Failure      404            {object}  map[string]string
 // @Failure      500            {object}  map[string]string
 // @Router       /openings/{id}/applications/{application_id}/reject [patch]
-func RejectApplicationHandler(repo *repositories.Repository) gin.HandlerFunc {
-	return updateApplicationStatusHandler(repo, model.ApplicationStatusRejected, "reject", "recruiter")
+func RejectApplicationHandler(repo store.RecruitmentStore) gin.HandlerFunc {
+	return updateApplicationStatusHandler(repo, model.ApplicationStatusRejected, "reject", model.RecruiterRole)
 }
 
 // WithdrawApplication godoc
@@ -321,12 +282,13 @@ func RejectApplicationHandler(repo *repositories.Repository) gin.HandlerFunc {
 // @Failure      404            {object}  map[string]string
 // @Failure      500            {object}  map[string]string
 // @Router       /openings/{id}/applications/{application_id}/withdraw [patch]
-func WithdrawApplicationHandler(repo *repositories.Repository) gin.HandlerFunc {
-	return updateApplicationStatusHandler(repo, model.ApplicationStatusWithdrawn, "withdraw", "player")
+func WithdrawApplicationHandler(repo store.RecruitmentStore) gin.HandlerFunc {
+	return updateApplicationStatusHandler(repo, model.ApplicationStatusWithdrawn, "withdraw", model.PlayerRole)
 }
 
-// Helper function for updating application status
-func updateApplicationStatusHandler(repo *repositories.Repository, status model.ApplicationStatus, action string, requiredRole string) gin.HandlerFunc {
+// Helper function for updating application status. The route must already
+// require actor's role, and opening ownership for recruiters.
+func updateApplicationStatusHandler(repo store.RecruitmentStore, status model.ApplicationStatus, action string, actor model.Role) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get user ID and role from authentication middleware
 		userID, exists := middleware.GetUserIDFromContext(c)
@@ -335,12 +297,6 @@ func updateApplicationStatusHandler(repo *repositories.Repository, status model.
 			return
 		}
 
-		role, roleExists := middleware.GetRoleFromContext(c)
-		if !roleExists || role != requiredRole {
-			c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions to " + action + " application"})
-			return
-		}
-
 		// Get opening ID and application ID from URL
 		openingID := c.Param("id")
 		playerID := c.Param("applicant_id")
@@ -369,31 +325,12 @@ func updateApplicationStatusHandler(repo *repositories.Repository, status model.
 			return
 		}
 
-		switch requiredRole {
-		case "recruiter":
-			opening, err := repo.GetOpeningByID(openingID, nil)
-			if err != nil {
-				log.Println("Error getting opening:", err)
-				httpErr := db.ToHTTPError(err)
-				c.JSON(httpErr.StatusCode, httpErr)
-				return
-			}
-			if opening.Opening.RecruiterID != userID {
-				c.JSON(http.StatusForbidden, gin.H{"error": "You can only " + action + " applications for your own openings"})
-				return
-			}
-		case "player":
-			if application.PlayerID != userID {
-				c.JSON(http.StatusForbidden, gin.H{"error": "You can only " + action + " your own applications"})
-				return
-			}
-
-			if action != "withdraw" {
-				c.JSON(http.StatusForbidden, gin.H{"error": "You can only " + action + " your own applications"})
-			}
+		if actor == model.PlayerRole && application.PlayerID != userID {
+			c.JSON(http.StatusForbidden, gin.H{"error": "You can only " + action + " your own applications"})
+			return
 		}
 
-		if err := repo.UpdateApplicationStatus(application.Id, status); err != nil {
+		if err := repo.UpdateApplicationStatus(application.Id, status, middleware.GetAuditActorFromContext(c)); err != nil {
 			log.Println("Error updating application status:", err)
 			httpErr := db.ToHTTPError(err)
 			c.JSON(httpErr.StatusCode, httpErr)
@@ -1,21 +1,25 @@
 package handlers
 
 import (
+	"encoding/base64"
 	"errors"
 	"log"
+	"math/big"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"sportsin_backend/internals/auth"
 	"sportsin_backend/internals/config"
 	"sportsin_backend/internals/db"
-	"sportsin_backend/internals/db/repositories"
+	"sportsin_backend/internals/middleware"
 	"sportsin_backend/internals/model"
+	"sportsin_backend/internals/store"
 )
 
 // Signup godoc
 // @Summary      Register a new user account
-// @Description  Creates a new user account in AWS Cognito and stores user information in the database. The user will receive a verification code via email/SMS.
+// @Description  Creates a new user account with the configured auth provider and stores user information in the database. The user will receive a verification code via email/SMS.
 // @Tags         Authentication
 // @Accept       json
 // @Produce      json
@@ -26,7 +30,7 @@ import (
 // @Failure      429          {object} object{error=string}                      "Too many requests, rate limited"
 // @Failure      500          {object} object{error=string}                      "Internal server error"
 // @Router       /signup [post]
-func SignupHandler(svc *auth.CognitoService, repo *repositories.Repository) gin.HandlerFunc {
+func SignupHandler(svc auth.Provider, repo store.UserStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var req auth.SignupInput
 		if err := c.ShouldBindJSON(&req); err != nil {
@@ -39,7 +43,7 @@ func SignupHandler(svc *auth.CognitoService, repo *repositories.Repository) gin.
 			return
 		}
 
-		// First, create user in Cognito
+		// First, create user with the auth provider
 		userId, err := svc.Signup(req)
 		if err != nil {
 			var userExistsErr *auth.UserExistsError
@@ -74,10 +78,20 @@ func SignupHandler(svc *auth.CognitoService, repo *repositories.Repository) gin.
 	}
 }
 
-func RegisterAuthRoutes(rg *gin.RouterGroup, cfg *config.Config, repo *repositories.Repository) {
-	svc := auth.NewCognitoService(cfg)
+const (
+	// codeRequestsPerWindow is the number of code requests one IP can make
+	// per codeRequestWindow
+	codeRequestsPerWindow = 20
+	codeRequestWindow     = 15 * time.Minute
+)
+
+func RegisterAuthRoutes(rg *gin.RouterGroup, cfg *config.Config, repo store.UserStore, svc auth.Provider, revocations *auth.RevocationList, limiter *middleware.RateLimiter) {
 	r := rg.Group("/")
 
+	// Routes that send or check verification and reset codes share one
+	// per-IP limit, on top of the per-account limits of the provider
+	codeLimit := limiter.Limit("auth-code", codeRequestsPerWindow, codeRequestWindow)
+
 	r.POST("/signup", SignupHandler(svc, repo))
 
 	// Verify godoc
@@ -93,7 +107,7 @@ func RegisterAuthRoutes(rg *gin.RouterGroup, cfg *config.Config, repo *repositor
 	// @Failure      429           {object} object{error=string}                   "Too many requests, rate limited"
 	// @Failure      500           {object} object{error=string}                   "Internal server error"
 	// @Router       /verify [post]
-	r.POST("/verify", func(c *gin.Context) {
+	r.POST("/verify", codeLimit, func(c *gin.Context) {
 		var req auth.ConfirmInput
 		if err := c.ShouldBindJSON(&req); err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
@@ -140,7 +154,7 @@ func RegisterAuthRoutes(rg *gin.RouterGroup, cfg *config.Config, repo *repositor
 	// @Failure      429                  {object} object{error=string}                         "Too many requests, rate limited"
 	// @Failure      500                  {object} object{error=string}                         "Internal server error"
 	// @Router       /forgot-password [post]
-	r.POST("/forgot-password", func(c *gin.Context) {
+	r.POST("/forgot-password", codeLimit, func(c *gin.Context) {
 		var req auth.ForgotPasswordInput
 		if err := c.ShouldBindJSON(&req); err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
@@ -181,7 +195,7 @@ func RegisterAuthRoutes(rg *gin.RouterGroup, cfg *config.Config, repo *repositor
 	// @Failure      429                 {object} object{error=string}                        "Too many requests, rate limited"
 	// @Failure      500                 {object} object{error=string}                        "Internal server error"
 	// @Router       /reset-password [post]
-	r.POST("/reset-password", func(c *gin.Context) {
+	r.POST("/reset-password", codeLimit, func(c *gin.Context) {
 		var req auth.ResetPasswordInput
 		if err := c.ShouldBindJSON(&req); err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
@@ -224,10 +238,10 @@ func RegisterAuthRoutes(rg *gin.RouterGroup, cfg *config.Config, repo *repositor
 	// @Accept       json
 	// @Produce      json
 	// @Param        loginInput  body     auth.LoginInput  true  "User email and password"
-	// @Success      200         {object} object{id_token=string}                 "Login successful with JWT token"
+	// @Success      200         {object} auth.Tokens                             "Login successful with ID and refresh tokens"
 	// @Failure      400         {object} object{error=string}                    "Invalid input provided"
 	// @Failure      401         {object} object{error=string}                    "Invalid credentials"
-	// @Failure      403         {object} object{error=string}                    "Account not verified"
+	// @Failure      403         {object} object{error=string}                    "Account not verified or suspended"
 	// @Failure      429         {object} object{error=string}                    "Too many requests, rate limited"
 	// @Failure      500         {object} object{error=string}                    "Internal server error"
 	// @Router       /login [post]
@@ -238,10 +252,11 @@ func RegisterAuthRoutes(rg *gin.RouterGroup, cfg *config.Config, repo *repositor
 			return
 		}
 
-		idToken, err := svc.Login(req)
+		tokens, err := svc.Login(req)
 		if err != nil {
 			var invalidCredentialsError *auth.InvalidCredentialsError
 			var userNotConfirmedErr *auth.UserNotConfirmedError
+			var userSuspendedErr *auth.UserSuspendedError
 			var userNotFoundErr *auth.UserNotFoundError
 			var invalidParamErr *auth.InvalidParameterError
 			var tooManyRequestsErr *auth.TooManyRequestsError
@@ -251,6 +266,8 @@ func RegisterAuthRoutes(rg *gin.RouterGroup, cfg *config.Config, repo *repositor
 				c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid email or password"})
 			case errors.As(err, &userNotConfirmedErr):
 				c.JSON(http.StatusForbidden, gin.H{"error": "Account not verified. Please check your email for verification code"})
+			case errors.As(err, &userSuspendedErr):
+				c.JSON(http.StatusForbidden, gin.H{"error": "Account suspended"})
 			case errors.As(err, &userNotFoundErr):
 				c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid email or password"})
 			case errors.As(err, &invalidParamErr):
@@ -263,9 +280,41 @@ func RegisterAuthRoutes(rg *gin.RouterGroup, cfg *config.Config, repo *repositor
 			}
 			return
 		}
-		c.JSON(http.StatusOK, gin.H{"id_token": idToken})
+		c.JSON(http.StatusOK, tokens)
 	})
 
+	// Refresh godoc
+	// @Summary      Refresh ID token
+	// @Description  Exchanges a refresh token from /login for a new ID token. The local provider also rotates the refresh token, so clients must store the one returned.
+	// @Tags         Authentication
+	// @Accept       json
+	// @Produce      json
+	// @Param        refreshInput  body     auth.RefreshInput  true  "Refresh token"
+	// @Success      200           {object} auth.Tokens                          "New tokens"
+	// @Failure      400           {object} object{error=string}                 "Invalid input provided"
+	// @Failure      401           {object} object{error=string}                 "Invalid or expired refresh token"
+	// @Failure      403           {object} object{error=string}                 "Account suspended"
+	// @Failure      429           {object} object{error=string}                 "Too many requests, rate limited"
+	// @Failure      500           {object} object{error=string}                 "Internal server error"
+	// @Router       /auth/refresh [post]
+	r.POST("/auth/refresh", RefreshHandler(svc))
+
+	// Logout godoc
+	// @Summary      Log out
+	// @Description  Revokes the presented ID token and the given refresh token. With all_sessions set, every session of the user on every device is revoked.
+	// @Tags         Authentication
+	// @Accept       json
+	// @Produce      json
+	// @Param        logoutInput  body     auth.LogoutInput  false  "Refresh token to revoke and whether to sign out everywhere"
+	// @Success      200          {object} object{message=string}               "Logged out"
+	// @Failure      400          {object} object{error=string}                 "Invalid input provided"
+	// @Failure      401          {object} object{error=string}                 "Unauthorized"
+	// @Failure      500          {object} object{error=string}                 "Internal server error"
+	// @Router       /auth/logout [post]
+	// @Security     BearerAuth
+	jwtMiddleware := middleware.NewJWTMiddleware(cfg)
+	r.POST("/auth/logout", jwtMiddleware.AuthMiddleware(), LogoutHandler(svc, revocations))
+
 	// GoogleLoginURL godoc
 	// @Summary      Get Google OAuth login URL
 	// @Description  Returns the Google OAuth login URL for Cognito-hosted authentication flow
@@ -302,7 +351,7 @@ func RegisterAuthRoutes(rg *gin.RouterGroup, cfg *config.Config, repo *repositor
 	// @Failure      429              {object} object{error=string}                "Too many requests, rate limited"
 	// @Failure      500              {object} object{error=string}                "Internal server error"
 	// @Router       /resend-code [post]
-	r.POST("/resend-code", func(c *gin.Context) {
+	r.POST("/resend-code", codeLimit, func(c *gin.Context) {
 		var req auth.ResendCodeInput
 		if err := c.ShouldBindJSON(&req); err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
@@ -331,8 +380,111 @@ func RegisterAuthRoutes(rg *gin.RouterGroup, cfg *config.Config, repo *repositor
 	})
 }
 
+// RefreshHandler exchanges a refresh token for a new ID token
+func RefreshHandler(svc auth.Provider) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req auth.RefreshInput
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+			return
+		}
+
+		tokens, err := svc.Refresh(req.RefreshToken)
+		if err != nil {
+			var invalidCredentialsErr *auth.InvalidCredentialsError
+			var userNotFoundErr *auth.UserNotFoundError
+			var userSuspendedErr *auth.UserSuspendedError
+			var invalidParamErr *auth.InvalidParameterError
+			var tooManyRequestsErr *auth.TooManyRequestsError
+
+			switch {
+			case errors.As(err, &invalidCredentialsErr), errors.As(err, &userNotFoundErr):
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired refresh token"})
+			case errors.As(err, &userSuspendedErr):
+				c.JSON(http.StatusForbidden, gin.H{"error": "Account suspended"})
+			case errors.As(err, &invalidParamErr):
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input provided"})
+			case errors.As(err, &tooManyRequestsErr):
+				c.JSON(http.StatusTooManyRequests, gin.H{"error": "Too many requests. Please try again later"})
+			default:
+				log.Printf("Unexpected refresh error: %v", err)
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+			}
+			return
+		}
+		c.JSON(http.StatusOK, tokens)
+	}
+}
+
+// LogoutHandler revokes the caller's ID token and refresh token, or every
+// session of the caller when all_sessions is set
+func LogoutHandler(svc auth.Provider, revocations *auth.RevocationList) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req auth.LogoutInput
+		if c.Request.ContentLength > 0 {
+			if err := c.ShouldBindJSON(&req); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+				return
+			}
+		}
+
+		userID, ok := middleware.GetUserIDFromContext(c)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found in token"})
+			return
+		}
+		claims, ok := middleware.GetUserClaimsFromContext(c)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims"})
+			return
+		}
+		ctx := c.Request.Context()
+
+		if req.RefreshToken != "" {
+			if err := svc.RevokeRefreshToken(req.RefreshToken); err != nil {
+				var invalidCredentialsErr *auth.InvalidCredentialsError
+				if !errors.As(err, &invalidCredentialsErr) {
+					log.Printf("Unexpected revoke token error: %v", err)
+					c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+					return
+				}
+			}
+		}
+
+		if req.AllSessions {
+			if err := svc.SignOutEverywhere(userID); err != nil {
+				log.Printf("Unexpected global sign out error: %v", err)
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+				return
+			}
+			if err := revocations.RevokeUser(ctx, userID); err != nil {
+				log.Printf("ERROR: failed to revoke sessions for %s: %v", userID, err)
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"message": "Logged out of all sessions"})
+			return
+		}
+
+		if jti, ok := claims["jti"].(string); ok && jti != "" {
+			exp, err := claims.GetExpirationTime()
+			if err != nil || exp == nil {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims"})
+				return
+			}
+			if err := revocations.RevokeToken(ctx, jti, exp.Time); err != nil {
+				log.Printf("ERROR: failed to revoke token for %s: %v", userID, err)
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+				return
+			}
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Logged out successfully"})
+	}
+}
+
 // GoogleLoginURLHandler returns the Cognito-hosted Google login URL
-func GoogleLoginURLHandler(svc *auth.CognitoService) gin.HandlerFunc {
+func GoogleLoginURLHandler(svc auth.Provider) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		url := svc.GetGoogleLoginURL()
 		if url == "" {
@@ -344,7 +496,7 @@ func GoogleLoginURLHandler(svc *auth.CognitoService) gin.HandlerFunc {
 }
 
 // GoogleCallbackHandler handles Cognito redirect after Google login
-func GoogleCallbackHandler(svc *auth.CognitoService, repo *repositories.Repository) gin.HandlerFunc {
+func GoogleCallbackHandler(svc auth.Provider, repo store.UserStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		code := c.Query("code")
 		if code == "" {
@@ -393,3 +545,34 @@ func GoogleCallbackHandler(svc *auth.CognitoService, repo *repositories.Reposito
 		c.JSON(http.StatusOK, gin.H{"id_token": idToken})
 	}
 }
+
+// JWKSHandler godoc
+// @Summary      JSON Web Key Set
+// @Description  Public keys used to verify tokens issued by the local auth provider. Only available when AUTH_PROVIDER=local.
+// @Tags         Authentication
+// @Produce      json
+// @Success      200  {object} middleware.JWKSet
+// @Router       /.well-known/jwks.json [get]
+func JWKSHandler(svc *auth.LocalAuthService) gin.HandlerFunc {
+	kid, publicKey := svc.PublicKey()
+	jwks := middleware.JWKSet{
+		Keys: []middleware.JWK{
+			{
+				Kty: "RSA",
+				Kid: kid,
+				Use: "sig",
+				Alg: "RS256",
+				N:   base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes()),
+			},
+		},
+	}
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, jwks)
+	}
+}
+
+// RegisterJWKSRoutes publishes the signing keys of the local auth provider
+func RegisterJWKSRoutes(rg *gin.RouterGroup, svc *auth.LocalAuthService) {
+	rg.GET("/.well-known/jwks.json", JWKSHandler(svc))
+}
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"sportsin_backend/internals/auth"
+	"sportsin_backend/internals/config"
+	"sportsin_backend/internals/middleware"
+	"sportsin_backend/internals/model"
+	"sportsin_backend/internals/services"
+	"sportsin_backend/internals/store/memory"
+)
+
+const testPassword = "correct horse battery"
+
+// newTestAuth mounts the auth routes with the local provider on the memory
+// store and a fake Redis, and makes AuthMiddleware accept its tokens
+func newTestAuth(t *testing.T, repo *memory.Store) (*gin.Engine, *auth.LocalAuthService, *auth.RevocationList) {
+	t.Helper()
+	cfg := &config.Config{AUTH_PROVIDER: "local", LOCAL_AUTH_ISSUER: "http://auth.test"}
+	rdb := newTestRedis(t)
+	revocations := auth.NewRevocationList(rdb, repo)
+	svc, err := auth.NewLocalAuthService(cfg, repo, repo, revocations, rdb)
+	if err != nil {
+		t.Fatalf("NewLocalAuthService: %v", err)
+	}
+
+	middleware.SetLocalSigningKey(svc.PublicKey())
+	middleware.SetRevocationChecker(revocations)
+	t.Cleanup(func() {
+		middleware.SetLocalSigningKey("", nil)
+		middleware.SetRevocationChecker(nil)
+	})
+
+	router := newTestRouter()
+	RegisterAuthRoutes(router.Group("/"), cfg, repo, svc, revocations, nil)
+	return router, svc, revocations
+}
+
+// newTestAccount creates a confirmed account that logs in with testPassword
+// and returns its ID and email
+func newTestAccount(t *testing.T, repo *memory.Store, role model.Role) (string, string) {
+	t.Helper()
+	userID := uuid.NewString()
+	email := userID + "@example.com"
+	if err := repo.CreateUserOnSignup(userID, email, role); err != nil {
+		t.Fatalf("CreateUserOnSignup: %v", err)
+	}
+	hash, err := services.HashPassword(testPassword)
+	if err != nil {
+		t.Fatalf("HashPassword: %v", err)
+	}
+	err = repo.CreateCredential(&model.Credential{UserId: userID, Email: email, Role: role, PasswordHash: hash, Confirmed: true})
+	if err != nil {
+		t.Fatalf("CreateCredential: %v", err)
+	}
+	return userID, email
+}
+
+// serveJSON sends body as JSON with idToken as bearer token, when they are
+// not empty, and decodes the JSON response into out when it is not nil
+func serveJSON(t *testing.T, router *gin.Engine, method, path, idToken string, body, out any) int {
+	t.Helper()
+	req := newJSONRequest(t, method, path, body)
+	if idToken != "" {
+		req.Header.Set("Authorization", "Bearer "+idToken)
+	}
+	return record(t, router, req, out)
+}
+
+func login(t *testing.T, router *gin.Engine, email string) auth.Tokens {
+	t.Helper()
+	var tokens auth.Tokens
+	input := auth.LoginInput{Email: email, Password: testPassword}
+	if code := serveJSON(t, router, http.MethodPost, "/login", "", input, &tokens); code != http.StatusOK {
+		t.Fatalf("login: status %d, want %d", code, http.StatusOK)
+	}
+	return tokens
+}
+
+func refresh(t *testing.T, router *gin.Engine, refreshToken string, out any) int {
+	t.Helper()
+	return serveJSON(t, router, http.MethodPost, "/auth/refresh", "", auth.RefreshInput{RefreshToken: refreshToken}, out)
+}
+
+func TestRefreshRotatesTheRefreshToken(t *testing.T) {
+	repo := memory.NewStore()
+	router, _, _ := newTestAuth(t, repo)
+	_, email := newTestAccount(t, repo, model.PlayerRole)
+	first := login(t, router, email)
+
+	var second auth.Tokens
+	if code := refresh(t, router, first.RefreshToken, &second); code != http.StatusOK {
+		t.Fatalf("refresh: status %d, want %d", code, http.StatusOK)
+	}
+	if second.IDToken == "" || second.RefreshToken == "" || second.RefreshToken == first.RefreshToken {
+		t.Fatalf("refresh returned %+v, want a new ID token and refresh token", second)
+	}
+
+	// A refresh token is spent by its first use, so a copy replayed later
+	// is rejected
+	if code := refresh(t, router, first.RefreshToken, nil); code != http.StatusUnauthorized {
+		t.Errorf("reused refresh token: status %d, want %d", code, http.StatusUnauthorized)
+	}
+	if code := refresh(t, router, second.RefreshToken, nil); code != http.StatusOK {
+		t.Errorf("rotated refresh token: status %d, want %d", code, http.StatusOK)
+	}
+}
+
+func TestLogoutEverywhereRevokesEverySession(t *testing.T) {
+	repo := memory.NewStore()
+	router, _, _ := newTestAuth(t, repo)
+	_, email := newTestAccount(t, repo, model.PlayerRole)
+	phone, laptop := login(t, router, email), login(t, router, email)
+
+	logout := auth.LogoutInput{AllSessions: true}
+	if code := serveJSON(t, router, http.MethodPost, "/auth/logout", phone.IDToken, logout, nil); code != http.StatusOK {
+		t.Fatalf("logout: status %d, want %d", code, http.StatusOK)
+	}
+
+	for name, tokens := range map[string]auth.Tokens{"phone": phone, "laptop": laptop} {
+		if code := refresh(t, router, tokens.RefreshToken, nil); code != http.StatusUnauthorized {
+			t.Errorf("%s refresh token: status %d, want %d", name, code, http.StatusUnauthorized)
+		}
+		if code := serveJSON(t, router, http.MethodPost, "/auth/logout", tokens.IDToken, nil, nil); code != http.StatusUnauthorized {
+			t.Errorf("%s ID token: status %d, want %d", name, code, http.StatusUnauthorized)
+		}
+	}
+
+	// Logging in again starts a new session
+	again := login(t, router, email)
+	if code := serveJSON(t, router, http.MethodPost, "/auth/logout", again.IDToken, nil, nil); code != http.StatusOK {
+		t.Errorf("new session: status %d, want %d", code, http.StatusOK)
+	}
+}
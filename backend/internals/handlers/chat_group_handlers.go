@@ -0,0 +1,414 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/middleware"
+	"sportsin_backend/internals/model"
+)
+
+// maxGroupChatMembers caps the size of a group chat room
+const maxGroupChatMembers = 256
+
+// maxChatRoomNameLength matches the name column of "ChatRoom"
+const maxChatRoomNameLength = 100
+
+type CreateGroupChatRequest struct {
+	Name      string   `json:"name" binding:"required"`
+	MemberIds []string `json:"member_ids"`
+}
+
+type AddChatMemberRequest struct {
+	UserId string `json:"user_id" binding:"required"`
+}
+
+type UpdateChatMemberRoleRequest struct {
+	Role model.ChatMemberRole `json:"role" binding:"required"`
+}
+
+// CreateGroupRoom creates a group chat room owned by the authenticated user
+// with the given members.
+func (ch *ChatHandler) CreateGroupRoom(c *gin.Context) {
+	userID, ok := chatUserID(c)
+	if !ok {
+		return
+	}
+
+	var req CreateGroupChatRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+	name, ok := chatRoomName(c, req.Name)
+	if !ok {
+		return
+	}
+	if len(req.MemberIds) >= maxGroupChatMembers {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("A group chat can have at most %d members", maxGroupChatMembers)})
+		return
+	}
+	memberIDs := make([]uuid.UUID, 0, len(req.MemberIds))
+	for _, id := range req.MemberIds {
+		memberID, err := uuid.Parse(id)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid member ID format"})
+			return
+		}
+		memberIDs = append(memberIDs, memberID)
+	}
+
+	room := &model.ChatRoom{Name: &name}
+	if err := ch.repo.CreateGroupChatRoom(room, userID, memberIDs); err != nil {
+		httpErr := db.ToHTTPError(err)
+		c.JSON(httpErr.StatusCode, httpErr)
+		return
+	}
+
+	c.JSON(http.StatusCreated, room)
+}
+
+// GetChatRoom returns a chat room the authenticated user is in, with its
+// members.
+func (ch *ChatHandler) GetChatRoom(c *gin.Context) {
+	userID, ok := chatUserID(c)
+	if !ok {
+		return
+	}
+	room, _, ok := ch.chatRoomMember(c, userID)
+	if !ok {
+		return
+	}
+
+	members, err := ch.repo.GetChatRoomMembers(uuid.MustParse(room.Id))
+	if err != nil {
+		httpErr := db.ToHTTPError(err)
+		c.JSON(httpErr.StatusCode, httpErr)
+		return
+	}
+	room.Members = members
+
+	c.JSON(http.StatusOK, room)
+}
+
+// UpdateGroupRoom renames a group chat room and replaces its avatar. It takes
+// multipart form data with an optional name and an optional avatar image,
+// and is open to owners and admins.
+func (ch *ChatHandler) UpdateGroupRoom(c *gin.Context) {
+	userID, ok := chatUserID(c)
+	if !ok {
+		return
+	}
+	room, member, ok := ch.groupRoomMember(c, userID)
+	if !ok {
+		return
+	}
+	if !member.Role.CanManage() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only owners and admins can edit this chat room"})
+		return
+	}
+
+	if raw := c.PostForm("name"); raw != "" {
+		name, ok := chatRoomName(c, raw)
+		if !ok {
+			return
+		}
+		room.Name = &name
+	}
+
+	ctx := context.Background()
+	oldAvatar := room.AvatarUrl
+	if avatarFile, err := c.FormFile("avatar"); err == nil {
+		file, err := avatarFile.Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to open avatar image"})
+			return
+		}
+		defer file.Close()
+
+		avatarID := fmt.Sprintf("avatar_%d", time.Now().UnixNano())
+		avatarURL, err := ch.storage.UploadChatAvatar(ctx, room.Id, avatarID, file, avatarFile)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		room.AvatarUrl = &avatarURL
+	}
+
+	if err := ch.repo.UpdateChatRoom(room); err != nil {
+		if room.AvatarUrl != oldAvatar {
+			ch.storage.DeleteChatAvatar(ctx, *room.AvatarUrl)
+		}
+		httpErr := db.ToHTTPError(err)
+		c.JSON(httpErr.StatusCode, httpErr)
+		return
+	}
+	if oldAvatar != nil && room.AvatarUrl != oldAvatar {
+		if err := ch.storage.DeleteChatAvatar(ctx, *oldAvatar); err != nil {
+			log.Printf("Failed to delete old avatar of chat room %s: %v", room.Id, err)
+		}
+	}
+
+	c.JSON(http.StatusOK, room)
+}
+
+// AddGroupMember invites a user into a group chat room. Owners and admins
+// can invite, except into the channel of a tournament.
+func (ch *ChatHandler) AddGroupMember(c *gin.Context) {
+	userID, ok := chatUserID(c)
+	if !ok {
+		return
+	}
+	room, member, ok := ch.groupRoomMember(c, userID)
+	if !ok {
+		return
+	}
+	if !member.Role.CanManage() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only owners and admins can invite members"})
+		return
+	}
+	if tournamentChannel(c, room) {
+		return
+	}
+
+	var req AddChatMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+	inviteeID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
+		return
+	}
+	roomID := uuid.MustParse(room.Id)
+	members, err := ch.repo.GetChatRoomMembers(roomID)
+	if err != nil {
+		httpErr := db.ToHTTPError(err)
+		c.JSON(httpErr.StatusCode, httpErr)
+		return
+	}
+	if len(members) >= maxGroupChatMembers {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("A group chat can have at most %d members", maxGroupChatMembers)})
+		return
+	}
+
+	added, err := ch.repo.AddChatRoomMember(roomID, inviteeID)
+	if err != nil {
+		httpErr := db.ToHTTPError(err)
+		c.JSON(httpErr.StatusCode, httpErr)
+		return
+	}
+
+	c.JSON(http.StatusCreated, added)
+}
+
+// UpdateGroupMemberRole makes a member an admin or demotes an admin back to
+// member. Only the owner can change roles.
+func (ch *ChatHandler) UpdateGroupMemberRole(c *gin.Context) {
+	userID, ok := chatUserID(c)
+	if !ok {
+		return
+	}
+	room, member, ok := ch.groupRoomMember(c, userID)
+	if !ok {
+		return
+	}
+	if member.Role != model.ChatRoleOwner {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only the owner can change roles"})
+		return
+	}
+
+	targetID, err := uuid.Parse(c.Param("userID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
+		return
+	}
+	var req UpdateChatMemberRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+	if req.Role != model.ChatRoleAdmin && req.Role != model.ChatRoleMember {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Role must be admin or member"})
+		return
+	}
+	if targetID == userID {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "The owner cannot change their own role"})
+		return
+	}
+
+	roomID := uuid.MustParse(room.Id)
+	if err := ch.repo.UpdateChatRoomMemberRole(roomID, targetID, req.Role); err != nil {
+		httpErr := db.ToHTTPError(err)
+		c.JSON(httpErr.StatusCode, httpErr)
+		return
+	}
+	updated, err := ch.repo.GetChatRoomMember(roomID, targetID)
+	if err != nil {
+		httpErr := db.ToHTTPError(err)
+		c.JSON(httpErr.StatusCode, httpErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, updated)
+}
+
+// RemoveGroupMember kicks a member out of a group chat room. The owner can
+// remove anyone, admins can only remove members.
+func (ch *ChatHandler) RemoveGroupMember(c *gin.Context) {
+	userID, ok := chatUserID(c)
+	if !ok {
+		return
+	}
+	room, member, ok := ch.groupRoomMember(c, userID)
+	if !ok {
+		return
+	}
+	if !member.Role.CanManage() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only owners and admins can remove members"})
+		return
+	}
+	if tournamentChannel(c, room) {
+		return
+	}
+
+	targetID, err := uuid.Parse(c.Param("userID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
+		return
+	}
+	if targetID == userID {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Use leave to remove yourself from a chat room"})
+		return
+	}
+	roomID := uuid.MustParse(room.Id)
+	target, err := ch.repo.GetChatRoomMember(roomID, targetID)
+	if err != nil {
+		httpErr := db.ToHTTPError(err)
+		c.JSON(httpErr.StatusCode, httpErr)
+		return
+	}
+	if member.Role != model.ChatRoleOwner && target.Role != model.ChatRoleMember {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Admins can only remove members"})
+		return
+	}
+
+	if err := ch.repo.RemoveChatRoomMember(roomID, targetID); err != nil {
+		httpErr := db.ToHTTPError(err)
+		c.JSON(httpErr.StatusCode, httpErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Member removed successfully"})
+}
+
+// LeaveGroupRoom removes the authenticated user from a group chat room. An
+// owner who leaves hands the room over to the longest standing admin, or
+// member, and the last member to leave deletes it.
+func (ch *ChatHandler) LeaveGroupRoom(c *gin.Context) {
+	userID, ok := chatUserID(c)
+	if !ok {
+		return
+	}
+	room, _, ok := ch.groupRoomMember(c, userID)
+	if !ok {
+		return
+	}
+	if tournamentChannel(c, room) {
+		return
+	}
+
+	if err := ch.repo.RemoveChatRoomMember(uuid.MustParse(room.Id), userID); err != nil {
+		httpErr := db.ToHTTPError(err)
+		c.JSON(httpErr.StatusCode, httpErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Left chat room successfully"})
+}
+
+// chatUserID returns the authenticated user, writing the error response
+// when there is none
+func chatUserID(c *gin.Context) (uuid.UUID, bool) {
+	userIDStr, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return uuid.Nil, false
+	}
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+// chatRoomMember loads the room of the roomID path parameter and the user's
+// membership of it, writing the error response when either is missing
+func (ch *ChatHandler) chatRoomMember(c *gin.Context, userID uuid.UUID) (*model.ChatRoom, *model.ChatRoomMember, bool) {
+	roomID, err := uuid.Parse(c.Param("roomID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid room ID"})
+		return nil, nil, false
+	}
+	room, err := ch.repo.GetChatRoomById(roomID)
+	if err != nil {
+		httpErr := db.ToHTTPError(err)
+		c.JSON(httpErr.StatusCode, httpErr)
+		return nil, nil, false
+	}
+	member, err := ch.repo.GetChatRoomMember(roomID, userID)
+	if db.IsNotFoundError(err) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied to this chat room"})
+		return nil, nil, false
+	}
+	if err != nil {
+		httpErr := db.ToHTTPError(err)
+		c.JSON(httpErr.StatusCode, httpErr)
+		return nil, nil, false
+	}
+	return room, member, true
+}
+
+// groupRoomMember is chatRoomMember for requests that only apply to group
+// rooms
+func (ch *ChatHandler) groupRoomMember(c *gin.Context, userID uuid.UUID) (*model.ChatRoom, *model.ChatRoomMember, bool) {
+	room, member, ok := ch.chatRoomMember(c, userID)
+	if !ok {
+		return nil, nil, false
+	}
+	if !room.IsGroup {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Only group chat rooms have members to manage"})
+		return nil, nil, false
+	}
+	return room, member, true
+}
+
+// tournamentChannel writes the error response for membership changes to the
+// channel of a tournament, whose members follow its accepted participants
+func tournamentChannel(c *gin.Context, room *model.ChatRoom) bool {
+	if room.TournamentId == nil {
+		return false
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": "Members of a tournament chat follow the participants of the tournament"})
+	return true
+}
+
+// chatRoomName validates the name of a group chat room
+func chatRoomName(c *gin.Context, raw string) (string, bool) {
+	name := strings.TrimSpace(raw)
+	if name == "" || len(name) > maxChatRoomNameLength {
+		httpErr := db.ToHTTPError(db.NewValidationError("name", fmt.Sprintf("name must be between 1 and %d characters", maxChatRoomNameLength)))
+		c.JSON(httpErr.StatusCode, httpErr)
+		return "", false
+	}
+	return name, true
+}
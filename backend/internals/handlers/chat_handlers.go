@@ -1,16 +1,18 @@
 package handlers
 
 import (
+	"fmt"
 	"log"
 	"net/http"
-	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 	"sportsin_backend/internals/chat/redis"
-	"sportsin_backend/internals/db/repositories"
-	"sportsin_backend/internals/notifications"
+	"sportsin_backend/internals/model"
+	"sportsin_backend/internals/services"
+	"sportsin_backend/internals/store"
 )
 
 // upgrader is used to upgrade the HTTP connection to a WebSocket connection.
@@ -27,17 +29,17 @@ var upgrader = websocket.Upgrader{
 
 // ChatHandler holds dependencies for handling chat-related requests.
 type ChatHandler struct {
-	hub        *redis.Hub
-	repo       *repositories.Repository
-	snsService *notifications.SNSService
+	hub     *redis.Hub
+	repo    store.ChatStore
+	storage *services.StorageService
 }
 
 // NewChatHandler creates a new ChatHandler.
-func NewChatHandler(h *redis.Hub, r *repositories.Repository, sns *notifications.SNSService) *ChatHandler {
+func NewChatHandler(h *redis.Hub, r store.ChatStore, s *services.StorageService) *ChatHandler {
 	return &ChatHandler{
-		hub:        h,
-		repo:       r,
-		snsService: sns,
+		hub:     h,
+		repo:    r,
+		storage: s,
 	}
 }
 
@@ -71,12 +73,9 @@ func (ch *ChatHandler) ServeWs(c *gin.Context) {
 	}
 
 	// Create a new client for the hub.
-	client := &redis.Client{
-		UserID: userID.String(),
-		Conn:   conn,
-		Send:   make(chan []byte, 256),
-		Hub:    ch.hub,
-	}
+	// Every connection is its own client, so the same user can be
+	// connected from several devices at once.
+	client := redis.NewClient(ch.hub, userID.String(), conn)
 	ch.hub.RegisterClient(client)
 
 	// Set a close handler to perform cleanup when the connection is closed.
@@ -113,7 +112,53 @@ func (ch *ChatHandler) GetChatRooms(c *gin.Context) {
 	c.JSON(http.StatusOK, rooms)
 }
 
-// GetMessages retrieves paginated messages for a specific chat room.
+// maxPresenceUsers caps the users of a single presence request
+const maxPresenceUsers = 100
+
+// GetPresence returns whether users are connected to the chat and when they
+// were last seen. The users are given as comma separated IDs in user_ids.
+func (ch *ChatHandler) GetPresence(c *gin.Context) {
+	if _, ok := chatUserID(c); !ok {
+		return
+	}
+
+	var userIDs []string
+	seen := map[string]bool{}
+	for _, param := range c.QueryArray("user_ids") {
+		for _, raw := range strings.Split(param, ",") {
+			id, err := uuid.Parse(strings.TrimSpace(raw))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
+				return
+			}
+			if !seen[id.String()] {
+				seen[id.String()] = true
+				userIDs = append(userIDs, id.String())
+			}
+		}
+	}
+	if len(userIDs) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "user_ids is required"})
+		return
+	}
+	if len(userIDs) > maxPresenceUsers {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("At most %d users can be requested at once", maxPresenceUsers)})
+		return
+	}
+
+	presence, err := ch.hub.GetPresence(userIDs)
+	if err != nil {
+		log.Printf("Error getting presence: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve presence"})
+		return
+	}
+
+	c.JSON(http.StatusOK, presence)
+}
+
+// GetMessages retrieves paginated messages for a specific chat room, oldest
+// first, as a Page. Newer messages are polled by requesting the last page
+// again with the cursor that fetched it.
 func (ch *ChatHandler) GetMessages(c *gin.Context) {
 	userIDStr, exists := c.Get("userID") // fixed key from "user_id" to "userID"
 	if !exists {
@@ -145,19 +190,16 @@ func (ch *ChatHandler) GetMessages(c *gin.Context) {
 		return
 	}
 
-	// Pagination parameters
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
-	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
-
-	// Validate pagination parameters
-	if limit <= 0 || limit > 100 {
-		limit = 50
+	limit, offset, ok := parsePagination(c)
+	if !ok {
+		return
 	}
-	if offset < 0 {
-		offset = 0
+	after, ok := parseCursor(c)
+	if !ok {
+		return
 	}
 
-	messages, err := ch.repo.GetMessagesForRoom(roomID, limit, offset)
+	messages, err := ch.repo.GetMessagesForRoom(roomID, limit, offset, after)
 	if err != nil {
 		log.Printf("Error getting messages for room %s: %v", roomID, err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve messages"})
@@ -167,15 +209,19 @@ func (ch *ChatHandler) GetMessages(c *gin.Context) {
 	// After fetching, mark these messages as read for the current user.
 	// This is an optimistic update; it runs in the background.
 	go func() {
-		if err := ch.repo.MarkMessagesAsRead(roomID, userID); err != nil {
+		if _, err := ch.repo.MarkMessagesAsRead(roomID, userID); err != nil {
 			log.Printf("Error marking messages as read for user %s in room %s: %v", userID, roomID, err)
 		}
 	}()
 
-	c.JSON(http.StatusOK, messages)
+	next := nextCursor(messages, limit, func(m model.ChatMessage) (string, string) {
+		return m.CreatedAt, m.Id
+	})
+	c.JSON(http.StatusOK, newPage(messages, next))
 }
 
-// MarkRoomAsRead handles the request to mark all messages in a room as read.
+// MarkRoomAsRead handles the request to mark all messages in a room as read
+// and notifies the other members in real time.
 func (ch *ChatHandler) MarkRoomAsRead(c *gin.Context) {
 	userIDStr, exists := c.Get("userID") // fixed key from "user_id" to "userID"
 	if !exists {
@@ -207,70 +253,14 @@ func (ch *ChatHandler) MarkRoomAsRead(c *gin.Context) {
 		return
 	}
 
-	err = ch.repo.MarkMessagesAsRead(roomID, userID)
+	readAt, err := ch.repo.MarkMessagesAsRead(roomID, userID)
 	if err != nil {
 		log.Printf("Error marking messages as read for user %s in room %s: %v", userID, roomID, err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to mark messages as read"})
 		return
 	}
+	// Send the same read receipt as a read frame on the socket
+	ch.hub.PublishReadReceipt(ch.repo, roomID.String(), userID.String(), readAt)
 
-	c.JSON(http.StatusOK, gin.H{"message": "Messages marked as read"})
-}
-
-// SendMessage handles sending a message and notifies the recipient
-func (ch *ChatHandler) SendMessage(c *gin.Context) {
-	userIDStr, exists := c.Get("userID")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
-		return
-	}
-	userID := userIDStr.(string)
-
-	roomIDStr := c.Param("roomID")
-	roomID := roomIDStr
-
-	var req struct {
-		Message string `json:"message"`
-	}
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
-		return
-	}
-
-	msg, err := ch.repo.CreateMessage(uuid.MustParse(roomID), uuid.MustParse(userID), req.Message)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to send message"})
-		return
-	}
-
-	// Determine recipient
-	room, err := ch.repo.FindOrCreateChatRoom(uuid.MustParse(msg.ChatRoomId), uuid.MustParse(userID))
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch chat room"})
-		return
-	}
-	var recipientID string
-	if room.User1 == userID {
-		recipientID = room.User2
-	} else {
-		recipientID = room.User1
-	}
-
-	recipientARN, err := ch.repo.GetUserSnsEndpointArn(uuid.MustParse(recipientID))
-	if err == nil && recipientARN != "" {
-		notification := notifications.Notification{
-			Title: "New Chat Message",
-			Body:  req.Message,
-			Data: map[string]string{
-				"ChatRoomID": msg.ChatRoomId,
-				"SenderID":   userID,
-			},
-			TargetARN: recipientARN,
-			Platform:  "android", // or "ios"
-			Type:      "chat_message",
-		}
-		go ch.snsService.Send(notification)
-	}
-
-	c.JSON(http.StatusOK, msg)
+	c.JSON(http.StatusOK, gin.H{"message": "Messages marked as read", "read_at": readAt})
 }
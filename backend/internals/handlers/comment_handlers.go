@@ -5,16 +5,18 @@ import (
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"sportsin_backend/internals/config"
 	"sportsin_backend/internals/db"
-	"sportsin_backend/internals/db/repositories"
 	"sportsin_backend/internals/middleware"
 	"sportsin_backend/internals/model"
+	"sportsin_backend/internals/services"
+	"sportsin_backend/internals/store"
 )
 
 // CreateComment godoc
 // @Summary      Create a new comment
-// @Description  Creates a new comment on a post, or a reply to an existing comment
+// @Description  Creates a new comment on a post, or a reply to an existing comment. Users mentioned as @username in the content are notified.
 // @Tags         comments
 // @Accept       json
 // @Produce      json
@@ -26,7 +28,7 @@ import (
 // @Failure      404  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
 // @Router       /comments [post]
-func CreateCommentHandler(repo *repositories.Repository) gin.HandlerFunc {
+func CreateCommentHandler(repo store.CommentStore, mentions *services.MentionService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get user ID from authentication middleware
 		userID, exists := middleware.GetUserIDFromContext(c)
@@ -48,6 +50,7 @@ func CreateCommentHandler(repo *repositories.Repository) gin.HandlerFunc {
 			c.JSON(httpErr.StatusCode, httpErr)
 			return
 		}
+		mentions.CommentCreated(comment)
 
 		c.JSON(http.StatusCreated, comment)
 	}
@@ -55,18 +58,20 @@ func CreateCommentHandler(repo *repositories.Repository) gin.HandlerFunc {
 
 // GetCommentsByPostId godoc
 // @Summary      Get comments for a post
-// @Description  Retrieves all top-level comments for a post with their latest replies
+// @Description  Retrieves all top-level comments for a post with their latest replies, like counts and whether the authenticated user liked them. Comments sorted by top are paged by offset only.
 // @Tags         comments
 // @Accept       json
 // @Produce      json
 // @Param        id  path   string  true   "Post ID"
 // @Param        limit    query  int     false  "Number of comments to return (default: 10, max: 50)"
 // @Param        offset   query  int     false  "Number of comments to skip (default: 0)"
-// @Success      200  {array}   model.CommentResponse
+// @Param        cursor   query  string  false  "next_cursor of the previous page"
+// @Param        sort     query  string  false  "Comment order (default: oldest)" Enums(oldest,newest,top)
+// @Success      200  {object}  handlers.Page[model.CommentResponse]
 // @Failure      400  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
 // @Router       /posts/{id}/comments [get]
-func GetCommentsByPostIdHandler(repo *repositories.Repository) gin.HandlerFunc {
+func GetCommentsByPostIdHandler(repo store.CommentStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		postID := c.Param("id")
 		if postID == "" {
@@ -89,21 +94,41 @@ func GetCommentsByPostIdHandler(repo *repositories.Repository) gin.HandlerFunc {
 			offset = 0
 		}
 
+		after, ok := parseCursor(c)
+		if !ok {
+			return
+		}
+
+		sortBy, err := model.ParseCommentSort(c.Query("sort"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid sort parameter"})
+			return
+		}
+
+		// The viewer is optional and only used for user_liked
+		userID, _ := middleware.GetUserIDFromContext(c)
+
 		// Get comments
-		comments, err := repo.GetCommentsByPostId(postID, limit, offset)
+		comments, err := repo.GetCommentsByPostId(postID, limit, offset, after, sortBy, userID)
 		if err != nil {
 			httpErr := db.ToHTTPError(err)
 			c.JSON(httpErr.StatusCode, httpErr)
 			return
 		}
 
-		c.JSON(http.StatusOK, comments)
+		var next string
+		if sortBy != model.CommentSortTop {
+			next = nextCursor(comments, limit, func(r model.CommentResponse) (string, string) {
+				return r.Comment.CreatedAt, r.Comment.Id
+			})
+		}
+		c.JSON(http.StatusOK, newPage(comments, next))
 	}
 }
 
 // GetCommentById godoc
 // @Summary      Get a specific comment with its replies
-// @Description  Retrieves a comment by ID with paginated replies
+// @Description  Retrieves a comment by ID with paginated replies, its like count and whether the authenticated user liked it
 // @Tags         comments
 // @Accept       json
 // @Produce      json
@@ -115,7 +140,7 @@ func GetCommentsByPostIdHandler(repo *repositories.Repository) gin.HandlerFunc {
 // @Failure      404  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
 // @Router       /comments/{id} [get]
-func GetCommentByIdHandler(repo *repositories.Repository) gin.HandlerFunc {
+func GetCommentByIdHandler(repo store.CommentStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		commentID := c.Param("id")
 		if commentID == "" {
@@ -138,8 +163,11 @@ func GetCommentByIdHandler(repo *repositories.Repository) gin.HandlerFunc {
 			replyOffset = 0
 		}
 
+		// The viewer is optional and only used for user_liked
+		userID, _ := middleware.GetUserIDFromContext(c)
+
 		// Get comment with replies
-		commentResponse, err := repo.GetCommentById(commentID, replyLimit, replyOffset)
+		commentResponse, err := repo.GetCommentById(commentID, replyLimit, replyOffset, userID)
 		if err != nil {
 			httpErr := db.ToHTTPError(err)
 			c.JSON(httpErr.StatusCode, httpErr)
@@ -166,7 +194,7 @@ func GetCommentByIdHandler(repo *repositories.Repository) gin.HandlerFunc {
 // @Failure      404  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
 // @Router       /comments/{id} [put]
-func UpdateCommentHandler(repo *repositories.Repository) gin.HandlerFunc {
+func UpdateCommentHandler(repo store.CommentStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get user ID from authentication middleware
 		userID, exists := middleware.GetUserIDFromContext(c)
@@ -189,7 +217,7 @@ func UpdateCommentHandler(repo *repositories.Repository) gin.HandlerFunc {
 		}
 
 		// First, get the comment to check ownership
-		commentResponse, err := repo.GetCommentById(commentID, 0, 0)
+		commentResponse, err := repo.GetCommentById(commentID, 0, 0, userID)
 		if err != nil {
 			httpErr := db.ToHTTPError(err)
 			c.JSON(httpErr.StatusCode, httpErr)
@@ -229,7 +257,7 @@ func UpdateCommentHandler(repo *repositories.Repository) gin.HandlerFunc {
 // @Failure      404  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
 // @Router       /comments/{id} [delete]
-func DeleteCommentHandler(repo *repositories.Repository) gin.HandlerFunc {
+func DeleteCommentHandler(repo store.CommentStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get user ID from authentication middleware
 		userID, exists := middleware.GetUserIDFromContext(c)
@@ -246,7 +274,7 @@ func DeleteCommentHandler(repo *repositories.Repository) gin.HandlerFunc {
 		}
 
 		// First, get the comment to check ownership
-		commentResponse, err := repo.GetCommentById(commentID, 0, 0)
+		commentResponse, err := repo.GetCommentById(commentID, 0, 0, userID)
 		if err != nil {
 			httpErr := db.ToHTTPError(err)
 			c.JSON(httpErr.StatusCode, httpErr)
@@ -260,7 +288,7 @@ func DeleteCommentHandler(repo *repositories.Repository) gin.HandlerFunc {
 		}
 
 		// Delete comment
-		err = repo.DeleteComment(commentID)
+		err = repo.DeleteComment(commentID, nil)
 		if err != nil {
 			httpErr := db.ToHTTPError(err)
 			c.JSON(httpErr.StatusCode, httpErr)
@@ -271,21 +299,99 @@ func DeleteCommentHandler(repo *repositories.Repository) gin.HandlerFunc {
 	}
 }
 
+// LikeComment godoc
+// @Summary      Like a comment
+// @Description  Adds a like to a comment or reply for the authenticated user
+// @Tags         comments
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Comment ID"
+// @Success      200  {object}  map[string]string
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /comments/{id}/like [post]
+func LikeCommentHandler(repo store.CommentStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := middleware.GetUserIDFromContext(c)
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+			return
+		}
+
+		commentID := c.Param("id")
+		if _, err := uuid.Parse(commentID); err != nil {
+			httpErr := db.ToHTTPError(db.NewValidationError("comment_id", "invalid comment ID"))
+			c.JSON(httpErr.StatusCode, httpErr)
+			return
+		}
+
+		if err := repo.LikeComment(commentID, userID); err != nil {
+			httpErr := db.ToHTTPError(err)
+			c.JSON(httpErr.StatusCode, httpErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Comment liked successfully"})
+	}
+}
+
+// UnlikeComment godoc
+// @Summary      Unlike a comment
+// @Description  Removes a like from a comment or reply for the authenticated user
+// @Tags         comments
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Comment ID"
+// @Success      200  {object}  map[string]string
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /comments/{id}/like [delete]
+func UnlikeCommentHandler(repo store.CommentStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := middleware.GetUserIDFromContext(c)
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+			return
+		}
+
+		commentID := c.Param("id")
+		if _, err := uuid.Parse(commentID); err != nil {
+			httpErr := db.ToHTTPError(db.NewValidationError("comment_id", "invalid comment ID"))
+			c.JSON(httpErr.StatusCode, httpErr)
+			return
+		}
+
+		if err := repo.UnlikeComment(commentID, userID); err != nil {
+			httpErr := db.ToHTTPError(err)
+			c.JSON(httpErr.StatusCode, httpErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Comment unliked successfully"})
+	}
+}
+
 // RegisterCommentRoutes registers all comment-related routes
-func RegisterCommentRoutes(rg *gin.RouterGroup, cfg *config.Config, repo *repositories.Repository) {
+func RegisterCommentRoutes(rg *gin.RouterGroup, cfg *config.Config, repo store.CommentStore, mentions *services.MentionService) {
 	// Initialize JWT middleware
 	jwtMiddleware := middleware.NewJWTMiddleware(cfg)
 
 	// Public routes (no authentication required)
-	rg.GET("/posts/:id/comments", GetCommentsByPostIdHandler(repo))
-	rg.GET("/comments/:id", GetCommentByIdHandler(repo))
+	rg.GET("/posts/:id/comments", jwtMiddleware.OptionalAuthMiddleware(), GetCommentsByPostIdHandler(repo))
+	rg.GET("/comments/:id", jwtMiddleware.OptionalAuthMiddleware(), GetCommentByIdHandler(repo))
 
 	// Protected routes (authentication required)
 	protected := rg.Group("/")
 	protected.Use(jwtMiddleware.AuthMiddleware())
 	{
-		protected.POST("/comments", CreateCommentHandler(repo))
+		protected.POST("/comments", CreateCommentHandler(repo, mentions))
 		protected.PUT("/comments/:id", UpdateCommentHandler(repo))
 		protected.DELETE("/comments/:id", DeleteCommentHandler(repo))
+		protected.POST("/comments/:id/like", LikeCommentHandler(repo))
+		protected.DELETE("/comments/:id/like", UnlikeCommentHandler(repo))
 	}
 }
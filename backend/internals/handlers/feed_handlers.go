@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"sportsin_backend/internals/config"
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/middleware"
+	"sportsin_backend/internals/services"
+)
+
+// GetFeed godoc
+// @Summary      Personalized home feed
+// @Description  Returns posts ranked for the authenticated user: posts by followed users and by users who play the same sports rank higher, and likes and recent comments push popular posts up. Pass next_cursor back as cursor to get the following page; posts created after the first page do not shift later pages.
+// @Tags         posts
+// @Produce      json
+// @Security     BearerAuth
+// @Param        cursor  query     string  false  "Cursor returned by the previous page"
+// @Param        limit   query     int     false  "Number of posts to return (default 20, max 100)"
+// @Success      200     {object}  handlers.Page[model.PostWithComment]
+// @Failure      400     {object}  map[string]string
+// @Failure      401     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Router       /feed [get]
+func GetFeedHandler(feed *services.FeedService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := middleware.GetUserIDFromContext(c)
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+			return
+		}
+
+		// The feed is ranked, not listed in order, so offset has no meaning
+		limit, _, ok := parsePagination(c)
+		if !ok {
+			return
+		}
+
+		posts, next, err := feed.GetFeed(userID, c.Query("cursor"), limit)
+		if err != nil {
+			httpErr := db.ToHTTPError(err)
+			c.JSON(httpErr.StatusCode, httpErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, newPage(posts, next))
+	}
+}
+
+// RegisterFeedRoutes registers the personalized home feed
+func RegisterFeedRoutes(rg *gin.RouterGroup, cfg *config.Config, feed *services.FeedService) {
+	jwtMiddleware := middleware.NewJWTMiddleware(cfg)
+
+	rg.GET("/feed", jwtMiddleware.AuthMiddleware(), GetFeedHandler(feed))
+}
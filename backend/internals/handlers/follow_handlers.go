@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"sportsin_backend/internals/config"
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/middleware"
+	"sportsin_backend/internals/model"
+	"sportsin_backend/internals/store"
+)
+
+// FollowUser godoc
+// @Summary      Follow a user
+// @Description  Makes the authenticated user follow another user
+// @Tags         follows
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "User ID to follow"
+// @Success      201  {object}  map[string]string
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /profile/{id}/follow [post]
+func FollowUserHandler(repo store.FollowStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := middleware.GetUserIDFromContext(c)
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+			return
+		}
+
+		targetID := c.Param("id")
+		if _, err := uuid.Parse(targetID); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
+			return
+		}
+
+		if err := repo.FollowUser(userID, targetID); err != nil {
+			httpErr := db.ToHTTPError(err)
+			c.JSON(httpErr.StatusCode, httpErr)
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{"message": "User followed successfully"})
+	}
+}
+
+// UnfollowUser godoc
+// @Summary      Unfollow a user
+// @Description  Removes the authenticated user's follow of another user
+// @Tags         follows
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "User ID to unfollow"
+// @Success      200  {object}  map[string]string
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /profile/{id}/follow [delete]
+func UnfollowUserHandler(repo store.FollowStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := middleware.GetUserIDFromContext(c)
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+			return
+		}
+
+		targetID := c.Param("id")
+		if _, err := uuid.Parse(targetID); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
+			return
+		}
+
+		if err := repo.UnfollowUser(userID, targetID); err != nil {
+			httpErr := db.ToHTTPError(err)
+			c.JSON(httpErr.StatusCode, httpErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "User unfollowed successfully"})
+	}
+}
+
+// GetFollowers godoc
+// @Summary      List followers
+// @Description  Lists the users following a user, most recent first. is_mutual is set for followers the user follows back. Paged by limit and offset only.
+// @Tags         follows
+// @Produce      json
+// @Param        id      path      string  true   "User ID"
+// @Param        limit   query     int     false  "Number of users to return (default 20)"
+// @Param        offset  query     int     false  "Number of users to skip (default 0)"
+// @Success      200     {object}  handlers.Page[model.FollowUser]
+// @Failure      400     {object}  map[string]string
+// @Failure      404     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Router       /profile/{id}/followers [get]
+func GetFollowersHandler(repo store.FollowStore) gin.HandlerFunc {
+	return followListHandler(repo.GetFollowers)
+}
+
+// GetFollowing godoc
+// @Summary      List followed users
+// @Description  Lists the users a user follows, most recent first. is_mutual is set for users who follow back. Paged by limit and offset only.
+// @Tags         follows
+// @Produce      json
+// @Param        id      path      string  true   "User ID"
+// @Param        limit   query     int     false  "Number of users to return (default 20)"
+// @Param        offset  query     int     false  "Number of users to skip (default 0)"
+// @Success      200     {object}  handlers.Page[model.FollowUser]
+// @Failure      400     {object}  map[string]string
+// @Failure      404     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Router       /profile/{id}/following [get]
+func GetFollowingHandler(repo store.FollowStore) gin.HandlerFunc {
+	return followListHandler(repo.GetFollowing)
+}
+
+// GetMutualFollows godoc
+// @Summary      List mutual follows
+// @Description  Lists the users that follow a user and are followed back, most recent first. Paged by limit and offset only.
+// @Tags         follows
+// @Produce      json
+// @Param        id      path      string  true   "User ID"
+// @Param        limit   query     int     false  "Number of users to return (default 20)"
+// @Param        offset  query     int     false  "Number of users to skip (default 0)"
+// @Success      200     {object}  handlers.Page[model.FollowUser]
+// @Failure      400     {object}  map[string]string
+// @Failure      404     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Router       /profile/{id}/mutuals [get]
+func GetMutualFollowsHandler(repo store.FollowStore) gin.HandlerFunc {
+	return followListHandler(repo.GetMutualFollows)
+}
+
+func followListHandler(list func(userID string, limit, offset int) ([]model.FollowUser, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.Param("id")
+		if _, err := uuid.Parse(userID); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
+			return
+		}
+		limit, offset, ok := parsePagination(c)
+		if !ok {
+			return
+		}
+
+		users, err := list(userID, limit, offset)
+		if err != nil {
+			httpErr := db.ToHTTPError(err)
+			c.JSON(httpErr.StatusCode, httpErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, newPage(users, ""))
+	}
+}
+
+// RegisterFollowRoutes registers the follow graph endpoints under /profile
+func RegisterFollowRoutes(rg *gin.RouterGroup, cfg *config.Config, repo store.FollowStore) {
+	jwtMiddleware := middleware.NewJWTMiddleware(cfg)
+	r := rg.Group("/profile")
+
+	r.GET("/:id/followers", GetFollowersHandler(repo))
+	r.GET("/:id/following", GetFollowingHandler(repo))
+	r.GET("/:id/mutuals", GetMutualFollowsHandler(repo))
+
+	protected := r.Group("")
+	protected.Use(jwtMiddleware.AuthMiddleware())
+	protected.POST("/:id/follow", FollowUserHandler(repo))
+	protected.DELETE("/:id/follow", UnfollowUserHandler(repo))
+}
@@ -7,9 +7,9 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"sportsin_backend/internals/config"
-	"sportsin_backend/internals/db/repositories"
 	"sportsin_backend/internals/middleware"
 	"sportsin_backend/internals/services"
+	"sportsin_backend/internals/store"
 )
 
 type ImageUploadResponse struct {
@@ -35,7 +35,7 @@ type ImageURLResponse struct {
 // @Failure 500 {object} map[string]string
 // @Router /image/upload [post]
 // @Security BearerAuth
-func UploadProfilePicture(cfg *config.Config, repo *repositories.Repository, s3Service *services.S3Service) gin.HandlerFunc {
+func UploadProfilePicture(cfg *config.Config, repo store.UserProfileStore, storage *services.StorageService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get user ID from JWT token
 		userID, uexists := c.Get("userID")
@@ -79,7 +79,7 @@ func UploadProfilePicture(cfg *config.Config, repo *repositories.Repository, s3S
 		}
 
 		// Upload to S3
-		imageURL, err := s3Service.UploadProfilePicture(c.Request.Context(), userIDStr, file, header)
+		imageURL, err := storage.UploadProfilePicture(c.Request.Context(), userIDStr, file, header)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"error":   "Internal Server Error",
@@ -120,7 +120,7 @@ func UploadProfilePicture(cfg *config.Config, repo *repositories.Repository, s3S
 // @Failure 500 {object} map[string]string
 // @Router /image [get]
 // @Security BearerAuth
-func GetProfilePictureURL(cfg *config.Config, repo *repositories.Repository, s3Service *services.S3Service) gin.HandlerFunc {
+func GetProfilePictureURL(cfg *config.Config, repo store.UserProfileStore, storage *services.StorageService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get user ID from JWT token
 		userID, exists := c.Get("userID")
@@ -154,7 +154,7 @@ func GetProfilePictureURL(cfg *config.Config, repo *repositories.Repository, s3S
 		}
 
 		// Generate S3 URL
-		imageURL := s3Service.GetProfilePictureURL(userIDStr, extension)
+		imageURL := storage.GetProfilePictureURL(userIDStr, extension)
 
 		c.JSON(http.StatusOK, ImageURLResponse{
 			ImageURL: imageURL,
@@ -174,7 +174,7 @@ func GetProfilePictureURL(cfg *config.Config, repo *repositories.Repository, s3S
 // @Failure 500 {object} map[string]string
 // @Router /image [delete]
 // @Security BearerAuth
-func DeleteProfilePicture(cfg *config.Config, repo *repositories.Repository, s3Service *services.S3Service) gin.HandlerFunc {
+func DeleteProfilePicture(cfg *config.Config, repo store.UserProfileStore, storage *services.StorageService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get user ID from JWT token
 		userID, exists := c.Get("userID")
@@ -202,7 +202,7 @@ func DeleteProfilePicture(cfg *config.Config, repo *repositories.Repository, s3S
 		}
 
 		// Delete from S3
-		err := s3Service.DeleteProfilePicture(c.Request.Context(), userIDStr, extension)
+		err := storage.DeleteProfilePicture(c.Request.Context(), userIDStr, extension)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"error":   "Internal Server Error",
@@ -218,11 +218,11 @@ func DeleteProfilePicture(cfg *config.Config, repo *repositories.Repository, s3S
 }
 
 // RegisterImageRoutes registers all image-related routes
-func RegisterImageRoutes(rg *gin.RouterGroup, cfg *config.Config, repo *repositories.Repository, s3Service *services.S3Service) {
+func RegisterImageRoutes(rg *gin.RouterGroup, cfg *config.Config, repo store.UserProfileStore, storage *services.StorageService) {
 	jwtMiddleware := middleware.NewJWTMiddleware(cfg)
 	r := rg.Group("/image")
 	r.Use(jwtMiddleware.AuthMiddleware())
-	r.POST("/upload", UploadProfilePicture(cfg, repo, s3Service))
-	r.GET("", GetProfilePictureURL(cfg, repo, s3Service))
-	r.DELETE("", DeleteProfilePicture(cfg, repo, s3Service))
+	r.POST("/upload", UploadProfilePicture(cfg, repo, storage))
+	r.GET("", GetProfilePictureURL(cfg, repo, storage))
+	r.DELETE("", DeleteProfilePicture(cfg, repo, storage))
 }
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"sportsin_backend/internals/config"
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/middleware"
+	"sportsin_backend/internals/model"
+	"sportsin_backend/internals/store"
+)
+
+// GetMyMentions godoc
+// @Summary      List posts mentioning me
+// @Description  Returns the posts that mention the authenticated user as @username, newest first
+// @Tags         posts
+// @Produce      json
+// @Security     BearerAuth
+// @Param        limit   query     int     false  "Number of posts to return (default 20)"
+// @Param        offset  query     int     false  "Number of posts to skip (default 0)"
+// @Param        cursor  query     string  false  "Cursor returned by the previous page"
+// @Success      200     {object}  handlers.Page[model.PostWithComment]
+// @Failure      400     {object}  map[string]string
+// @Failure      401     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Router       /my-mentions [get]
+func GetMyMentionsHandler(repo store.MentionStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := middleware.GetUserIDFromContext(c)
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+			return
+		}
+
+		limit, offset, ok := parsePagination(c)
+		if !ok {
+			return
+		}
+		after, ok := parseCursor(c)
+		if !ok {
+			return
+		}
+
+		posts, err := repo.GetPostsMentioningUser(userID, limit, offset, after)
+		if err != nil {
+			httpErr := db.ToHTTPError(err)
+			c.JSON(httpErr.StatusCode, httpErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, newPage(posts, nextCursor(posts, limit, func(p model.PostWithComment) (string, string) {
+			return postCursorKey(p.Post)
+		})))
+	}
+}
+
+// RegisterMentionRoutes registers the list of posts mentioning the
+// authenticated user
+func RegisterMentionRoutes(rg *gin.RouterGroup, cfg *config.Config, repo store.MentionStore) {
+	jwtMiddleware := middleware.NewJWTMiddleware(cfg)
+
+	rg.GET("/my-mentions", jwtMiddleware.AuthMiddleware(), GetMyMentionsHandler(repo))
+}
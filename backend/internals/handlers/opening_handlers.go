@@ -8,9 +8,9 @@ import (
 	"github.com/gin-gonic/gin"
 	"sportsin_backend/internals/config"
 	"sportsin_backend/internals/db"
-	"sportsin_backend/internals/db/repositories"
 	"sportsin_backend/internals/middleware"
 	"sportsin_backend/internals/model"
+	"sportsin_backend/internals/store"
 )
 
 // Request/Response models for Opening API
@@ -77,6 +77,7 @@ type OpeningResponse struct {
 	UpdatedAt          string                   `json:"updated_at"`
 	Applied            bool                     `json:"applied"`
 	ApplicationStatus  *model.ApplicationStatus `json:"application_status,omitempty"`
+	Saved              bool                     `json:"saved"`
 }
 
 // SingleOpeningResponse for swagger documentation
@@ -84,11 +85,6 @@ type SingleOpeningResponse struct {
 	Opening OpeningResponse `json:"opening"`
 }
 
-// MultipleOpeningsResponse for swagger documentation
-type MultipleOpeningsResponse struct {
-	Openings []OpeningResponse `json:"openings"`
-}
-
 type UpdateOpeningStatusRequest struct {
 	Status model.OpeningStatus `json:"status" binding:"required"`
 }
@@ -105,6 +101,11 @@ func toSAddress(req AddressRequest) *model.SAddress {
 	}
 }
 
+// openingCursorKey is the keyset pagination key of an opening
+func openingCursorKey(d *model.OpeningDetails) (string, string) {
+	return d.Opening.CreatedAt, d.Opening.Id
+}
+
 // Helper function to convert OpeningDetails to OpeningResponse
 func toOpeningResponse(openingDetails *model.OpeningDetails) *OpeningResponse {
 	return &OpeningResponse{
@@ -127,6 +128,7 @@ func toOpeningResponse(openingDetails *model.OpeningDetails) *OpeningResponse {
 		UpdatedAt:          openingDetails.Opening.UpdatedAt,
 		Applied:            openingDetails.Applied,
 		ApplicationStatus:  openingDetails.ApplicationStatus,
+		Saved:              openingDetails.Saved,
 	}
 }
 
@@ -144,7 +146,7 @@ func toOpeningResponse(openingDetails *model.OpeningDetails) *OpeningResponse {
 // @Failure      403      {object}  map[string]string
 // @Failure      500      {object}  map[string]string
 // @Router       /openings [post]
-func CreateOpeningHandler(repo *repositories.Repository) gin.HandlerFunc {
+func CreateOpeningHandler(repo store.OpeningStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get user ID from authentication middleware
 		userID, exists := middleware.GetUserIDFromContext(c)
@@ -221,7 +223,7 @@ func CreateOpeningHandler(repo *repositories.Repository) gin.HandlerFunc {
 // @Failure      404      {object}  map[string]string
 // @Failure      500      {object}  map[string]string
 // @Router       /openings/{id} [put]
-func UpdateOpeningHandler(repo *repositories.Repository) gin.HandlerFunc {
+func UpdateOpeningHandler(repo store.OpeningStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get user ID from authentication middleware
 		userID, exists := middleware.GetUserIDFromContext(c)
@@ -245,12 +247,6 @@ func UpdateOpeningHandler(repo *repositories.Repository) gin.HandlerFunc {
 			return
 		}
 
-		// Check if user is the owner of the opening
-		if existingOpening.Opening.RecruiterID != userID {
-			c.JSON(http.StatusForbidden, gin.H{"error": "You can only update your own openings"})
-			return
-		}
-
 		// Parse request
 		var req UpdateOpeningRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
@@ -326,15 +322,8 @@ func UpdateOpeningHandler(repo *repositories.Repository) gin.HandlerFunc {
 // @Failure      404 {object}  map[string]string
 // @Failure      500 {object}  map[string]string
 // @Router       /openings/{id} [delete]
-func DeleteOpeningHandler(repo *repositories.Repository) gin.HandlerFunc {
+func DeleteOpeningHandler(repo store.OpeningStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Get user ID from authentication middleware
-		userID, exists := middleware.GetUserIDFromContext(c)
-		if !exists {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
-			return
-		}
-
 		// Get opening ID from URL
 		openingID := c.Param("id")
 		if openingID == "" {
@@ -342,22 +331,10 @@ func DeleteOpeningHandler(repo *repositories.Repository) gin.HandlerFunc {
 			return
 		}
 
-		// Check if opening exists and get current details
-		existingOpening, err := repo.GetOpeningByID(openingID, nil)
-		if err != nil {
-			httpErr := db.ToHTTPError(err)
-			c.JSON(httpErr.StatusCode, httpErr)
-			return
-		}
-
-		// Check if user is the owner of the opening
-		if existingOpening.Opening.RecruiterID != userID {
-			c.JSON(http.StatusForbidden, gin.H{"error": "You can only delete your own openings"})
-			return
-		}
+		// The opening's existence and ownership are checked by middleware.RequireOwnership
 
 		// Delete opening from database
-		if err := repo.DeleteOpening(openingID); err != nil {
+		if err := repo.DeleteOpening(openingID, middleware.GetAuditActorFromContext(c)); err != nil {
 			httpErr := db.ToHTTPError(err)
 			c.JSON(httpErr.StatusCode, httpErr)
 			return
@@ -383,15 +360,8 @@ func DeleteOpeningHandler(repo *repositories.Repository) gin.HandlerFunc {
 // @Failure      404     {object}  map[string]string
 // @Failure      500     {object}  map[string]string
 // @Router       /openings/{id}/status [patch]
-func UpdateOpeningStatusHandler(repo *repositories.Repository) gin.HandlerFunc {
+func UpdateOpeningStatusHandler(repo store.OpeningStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Get user ID from authentication middleware
-		userID, exists := middleware.GetUserIDFromContext(c)
-		if !exists {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
-			return
-		}
-
 		// Get opening ID from URL
 		openingID := c.Param("id")
 		if openingID == "" {
@@ -399,19 +369,7 @@ func UpdateOpeningStatusHandler(repo *repositories.Repository) gin.HandlerFunc {
 			return
 		}
 
-		// Check if opening exists and get current details
-		existingOpening, err := repo.GetOpeningByID(openingID, nil)
-		if err != nil {
-			httpErr := db.ToHTTPError(err)
-			c.JSON(httpErr.StatusCode, httpErr)
-			return
-		}
-
-		// Check if user is the owner of the opening
-		if existingOpening.Opening.RecruiterID != userID {
-			c.JSON(http.StatusForbidden, gin.H{"error": "You can only update your own openings"})
-			return
-		}
+		// The opening's existence and ownership are checked by middleware.RequireOwnership
 
 		// Parse request
 		var req UpdateOpeningStatusRequest
@@ -427,7 +385,7 @@ func UpdateOpeningStatusHandler(repo *repositories.Repository) gin.HandlerFunc {
 		}
 
 		// Update opening status in database
-		if err := repo.UpdateOpeningStatus(openingID, req.Status); err != nil {
+		if err := repo.UpdateOpeningStatus(openingID, req.Status, nil); err != nil {
 			httpErr := db.ToHTTPError(err)
 			c.JSON(httpErr.StatusCode, httpErr)
 			return
@@ -457,7 +415,7 @@ func UpdateOpeningStatusHandler(repo *repositories.Repository) gin.HandlerFunc {
 // @Failure      404 {object}  map[string]string
 // @Failure      500 {object}  map[string]string
 // @Router       /openings/{id} [get]
-func GetOpeningByIDHandler(repo *repositories.Repository) gin.HandlerFunc {
+func GetOpeningByIDHandler(repo store.OpeningStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get opening ID from URL
 		openingID := c.Param("id")
@@ -495,11 +453,12 @@ func GetOpeningByIDHandler(repo *repositories.Repository) gin.HandlerFunc {
 // @Produce      json
 // @Param        limit   query  int     false  "Number of openings to return (default: 10)"
 // @Param        offset  query  int     false  "Number of openings to skip (default: 0)"
-// @Success      200     {object}  MultipleOpeningsResponse
+// @Param        cursor  query  string  false  "next_cursor of the previous page"
+// @Success      200     {object}  handlers.Page[OpeningResponse]
 // @Failure      400     {object}  map[string]string
 // @Failure      500     {object}  map[string]string
 // @Router       /openings [get]
-func GetAllOpeningsHandler(repo *repositories.Repository) gin.HandlerFunc {
+func GetAllOpeningsHandler(repo store.OpeningStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Parse pagination parameters
 		limitStr := c.DefaultQuery("limit", "10")
@@ -526,8 +485,13 @@ func GetAllOpeningsHandler(repo *repositories.Repository) gin.HandlerFunc {
 			// If user is recruiter, playerID remains nil
 		}
 
+		after, ok := parseCursor(c)
+		if !ok {
+			return
+		}
+
 		// Get openings from database
-		openingDetailsList, err := repo.GetAllOpenings(limit, offset, playerID)
+		openingDetailsList, err := repo.GetAllOpenings(limit, offset, after, playerID)
 		if err != nil {
 			httpErr := db.ToHTTPError(err)
 			c.JSON(httpErr.StatusCode, httpErr)
@@ -541,7 +505,7 @@ func GetAllOpeningsHandler(repo *repositories.Repository) gin.HandlerFunc {
 			responses = append(responses, *toOpeningResponse(openingDetails))
 		}
 
-		c.JSON(http.StatusOK, gin.H{"openings": responses})
+		c.JSON(http.StatusOK, newPage(responses, nextCursor(openingDetailsList, limit, openingCursorKey)))
 	}
 }
 
@@ -554,12 +518,13 @@ func GetAllOpeningsHandler(repo *repositories.Repository) gin.HandlerFunc {
 // @Security     BearerAuth
 // @Param        limit   query  int     false  "Number of openings to return (default: 10)"
 // @Param        offset  query  int     false  "Number of openings to skip (default: 0)"
-// @Success      200     {object}  MultipleOpeningsResponse
+// @Param        cursor  query  string  false  "next_cursor of the previous page"
+// @Success      200     {object}  handlers.Page[OpeningResponse]
 // @Failure      400     {object}  map[string]string
 // @Failure      401     {object}  map[string]string
 // @Failure      500     {object}  map[string]string
 // @Router       /openings/my [get]
-func GetOpeningsByRecruiterHandler(repo *repositories.Repository) gin.HandlerFunc {
+func GetOpeningsByRecruiterHandler(repo store.OpeningStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get user ID from authentication middleware
 		userID, exists := middleware.GetUserIDFromContext(c)
@@ -584,8 +549,13 @@ func GetOpeningsByRecruiterHandler(repo *repositories.Repository) gin.HandlerFun
 			return
 		}
 
+		after, ok := parseCursor(c)
+		if !ok {
+			return
+		}
+
 		// Get openings from database
-		openingDetailsList, err := repo.GetOpeningsByRecruiterID(userID, limit, offset, nil)
+		openingDetailsList, err := repo.GetOpeningsByRecruiterID(userID, limit, offset, after, nil)
 		if err != nil {
 			httpErr := db.ToHTTPError(err)
 			c.JSON(httpErr.StatusCode, httpErr)
@@ -597,7 +567,7 @@ func GetOpeningsByRecruiterHandler(repo *repositories.Repository) gin.HandlerFun
 			responses = append(responses, *toOpeningResponse(openingDetails))
 		}
 
-		c.JSON(http.StatusOK, gin.H{"openings": responses})
+		c.JSON(http.StatusOK, newPage(responses, nextCursor(openingDetailsList, limit, openingCursorKey)))
 	}
 }
 
@@ -610,11 +580,12 @@ func GetOpeningsByRecruiterHandler(repo *repositories.Repository) gin.HandlerFun
 // @Param        sport   path   string  true   "Sport name"
 // @Param        limit   query  int     false  "Number of openings to return (default: 10)"
 // @Param        offset  query  int     false  "Number of openings to skip (default: 0)"
-// @Success      200     {object}  MultipleOpeningsResponse
+// @Param        cursor  query  string  false  "next_cursor of the previous page"
+// @Success      200     {object}  handlers.Page[OpeningResponse]
 // @Failure      400     {object}  map[string]string
 // @Failure      500     {object}  map[string]string
 // @Router       /openings/sport/{sport} [get]
-func GetOpeningsBySportHandler(repo *repositories.Repository) gin.HandlerFunc {
+func GetOpeningsBySportHandler(repo store.OpeningStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get sport name from URL
 		sportName := c.Param("sport")
@@ -648,8 +619,13 @@ func GetOpeningsBySportHandler(repo *repositories.Repository) gin.HandlerFunc {
 			// If user is recruiter, playerID remains nil
 		}
 
+		after, ok := parseCursor(c)
+		if !ok {
+			return
+		}
+
 		// Get openings from database
-		openingDetailsList, err := repo.GetOpeningsBySport(sportName, limit, offset, playerID)
+		openingDetailsList, err := repo.GetOpeningsBySport(sportName, limit, offset, after, playerID)
 		if err != nil {
 			httpErr := db.ToHTTPError(err)
 			c.JSON(httpErr.StatusCode, httpErr)
@@ -662,7 +638,7 @@ func GetOpeningsBySportHandler(repo *repositories.Repository) gin.HandlerFunc {
 			responses = append(responses, *toOpeningResponse(openingDetails))
 		}
 
-		c.JSON(http.StatusOK, gin.H{"openings": responses})
+		c.JSON(http.StatusOK, newPage(responses, nextCursor(openingDetailsList, limit, openingCursorKey)))
 	}
 }
 
@@ -687,11 +663,12 @@ func GetOpeningsBySportHandler(repo *repositories.Repository) gin.HandlerFunc {
 // @Param        applied             query  bool    false  "Filter by application status (requires authentication)"
 // @Param        limit               query  int     false  "Number of openings to return (default: 10)"
 // @Param        offset              query  int     false  "Number of openings to skip (default: 0)"
-// @Success      200                 {object}  MultipleOpeningsResponse
+// @Param        cursor              query  string  false  "next_cursor of the previous page"
+// @Success      200                 {object}  handlers.Page[OpeningResponse]
 // @Failure      400                 {object}  map[string]string
 // @Failure      500                 {object}  map[string]string
 // @Router       /openings/filter [get]
-func GetOpeningsByFilterHandler(repo *repositories.Repository) gin.HandlerFunc {
+func GetOpeningsByFilterHandler(repo store.OpeningStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Parse pagination parameters
 		limitStr := c.DefaultQuery("limit", "10")
@@ -710,7 +687,7 @@ func GetOpeningsByFilterHandler(repo *repositories.Repository) gin.HandlerFunc {
 		}
 
 		// Build filter from query parameters
-		filter := &repositories.OpeningFilter{}
+		filter := &model.OpeningFilter{}
 
 		if sportName := c.Query("sport_name"); sportName != "" {
 			filter.SportName = &sportName
@@ -808,8 +785,13 @@ func GetOpeningsByFilterHandler(repo *repositories.Repository) gin.HandlerFunc {
 			// If user is recruiter, playerID remains nil
 		}
 
+		after, ok := parseCursor(c)
+		if !ok {
+			return
+		}
+
 		// Get openings from database
-		openingDetailsList, err := repo.GetOpeningsByFilter(filter, limit, offset, playerID)
+		openingDetailsList, err := repo.GetOpeningsByFilter(filter, limit, offset, after, playerID)
 		if err != nil {
 			httpErr := db.ToHTTPError(err)
 			c.JSON(httpErr.StatusCode, httpErr)
@@ -821,12 +803,12 @@ func GetOpeningsByFilterHandler(repo *repositories.Repository) gin.HandlerFunc {
 			responses = append(responses, *toOpeningResponse(openingDetails))
 		}
 
-		c.JSON(http.StatusOK, gin.H{"openings": responses})
+		c.JSON(http.StatusOK, newPage(responses, nextCursor(openingDetailsList, limit, openingCursorKey)))
 	}
 }
 
 // RegisterOpeningRoutes registers all opening-related routes
-func RegisterOpeningRoutes(rg *gin.RouterGroup, cfg *config.Config, repo *repositories.Repository) {
+func RegisterOpeningRoutes(rg *gin.RouterGroup, cfg *config.Config, repo store.RecruitmentStore) {
 	jwtMiddleware := middleware.NewJWTMiddleware(cfg)
 	protected := rg.Group("/")
 	protected.Use(jwtMiddleware.AuthMiddleware())
@@ -835,18 +817,23 @@ func RegisterOpeningRoutes(rg *gin.RouterGroup, cfg *config.Config, repo *reposi
 		protected.GET("/openings/:id", GetOpeningByIDHandler(repo))
 		protected.GET("/openings/sport/:sport", GetOpeningsBySportHandler(repo))
 		protected.GET("/openings/filter", GetOpeningsByFilterHandler(repo))
-		protected.POST("/openings", CreateOpeningHandler(repo))
-		protected.PUT("/openings/:id", UpdateOpeningHandler(repo))
-		protected.DELETE("/openings/:id", DeleteOpeningHandler(repo))
-		protected.PATCH("/openings/:id/status", UpdateOpeningStatusHandler(repo))
+		recruiter := middleware.RequireRole(model.RecruiterRole)
+		player := middleware.RequireRole(model.PlayerRole)
+		ownsOpening := middleware.RequireOwnership("opening", "id", openingOwner(repo))
+
+		protected.POST("/openings", recruiter, CreateOpeningHandler(repo))
+		protected.PUT("/openings/:id", recruiter, ownsOpening, UpdateOpeningHandler(repo))
+		protected.DELETE("/openings/:id", recruiter, ownsOpening, DeleteOpeningHandler(repo))
+		protected.PATCH("/openings/:id/status", recruiter, ownsOpening, UpdateOpeningStatusHandler(repo))
 
-		protected.GET("/openings/my", GetOpeningsByRecruiterHandler(repo))
+		protected.GET("/openings/my", recruiter, GetOpeningsByRecruiterHandler(repo))
 
 		// Application routes
-		protected.POST("/openings/:id/apply", CreateApplicationHandler(repo))
-		protected.GET("/openings/:id/applicants", GetApplicantsByOpeningIDHandler(repo))
-		protected.PATCH("/openings/:id/applicants/:applicant_id/accept", AcceptApplicationHandler(repo))
-		protected.PATCH("/openings/:id/applicants/:applicant_id/reject", RejectApplicationHandler(repo))
-		protected.PATCH("/openings/:id/applicants/:applicant_id/withdraw", WithdrawApplicationHandler(repo))
+		protected.POST("/openings/:id/apply", player, CreateApplicationHandler(repo))
+		protected.GET("/applications/my", player, GetApplicationsByPlayerHandler(repo))
+		protected.GET("/openings/:id/applicants", recruiter, ownsOpening, GetApplicantsByOpeningIDHandler(repo))
+		protected.PATCH("/openings/:id/applicants/:applicant_id/accept", recruiter, ownsOpening, AcceptApplicationHandler(repo))
+		protected.PATCH("/openings/:id/applicants/:applicant_id/reject", recruiter, ownsOpening, RejectApplicationHandler(repo))
+		protected.PATCH("/openings/:id/applicants/:applicant_id/withdraw", player, WithdrawApplicationHandler(repo))
 	}
 }
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"sportsin_backend/internals/middleware"
+	"sportsin_backend/internals/store"
+)
+
+// Owner lookups used with middleware.RequireOwnership on routes that modify
+// a single resource.
+
+func postOwner(repo store.PostStore) middleware.OwnerLookup {
+	return func(postID string) (string, error) {
+		post, err := repo.GetPostById(postID)
+		if err != nil {
+			return "", err
+		}
+		return post.UserId, nil
+	}
+}
+
+func tournamentOwner(repo store.TournamentStore) middleware.OwnerLookup {
+	return func(tournamentID string) (string, error) {
+		tournament, err := repo.GetTournamentByID(tournamentID)
+		if err != nil {
+			return "", err
+		}
+		return tournament.HostId, nil
+	}
+}
+
+func openingOwner(repo store.OpeningStore) middleware.OwnerLookup {
+	return func(openingID string) (string, error) {
+		opening, err := repo.GetOpeningByID(openingID, nil)
+		if err != nil {
+			return "", err
+		}
+		return opening.Opening.RecruiterID, nil
+	}
+}
+
+func achievementOwner(repo store.AchievementStore) middleware.OwnerLookup {
+	return func(achievementID string) (string, error) {
+		achievement, err := repo.GetAchievementById(achievementID)
+		if err != nil {
+			return "", err
+		}
+		return achievement.UserId, nil
+	}
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/model"
+)
+
+// Lists are paginated with the limit and offset query parameters, or with
+// an opaque cursor: a request carrying the next_cursor of the previous page
+// gets the items after it and offset is ignored. Every paginated list
+// responds with a Page, whether or not a cursor was sent. Lists that are
+// paged by offset only, like followers and the admin lists, never set
+// next_cursor.
+
+// Page is the body of every paginated list. NextCursor is left out on the
+// last page. Lists that grow at the end, like chat messages, are checked
+// for new items by requesting the last page again with the cursor that
+// fetched it.
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// newPage wraps items, never nil, with the cursor of the next page
+func newPage[T any](items []T, next string) Page[T] {
+	if items == nil {
+		items = []T{}
+	}
+	return Page[T]{Items: items, NextCursor: next}
+}
+
+// parsePagination reads the limit and offset query parameters
+func parsePagination(c *gin.Context) (int, int, bool) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit <= 0 || limit > 100 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit parameter"})
+		return 0, 0, false
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid offset parameter"})
+		return 0, 0, false
+	}
+
+	return limit, offset, true
+}
+
+// parseCursor reads the cursor query parameter, nil when it is missing or
+// empty. ok is false when an error response has been written.
+func parseCursor(c *gin.Context) (after *model.Cursor, ok bool) {
+	raw := c.Query("cursor")
+	if raw == "" {
+		return nil, true
+	}
+	after, err := model.DecodeCursor(raw)
+	if err != nil {
+		httpErr := db.ToHTTPError(err)
+		c.JSON(httpErr.StatusCode, httpErr)
+		return nil, false
+	}
+	return after, true
+}
+
+// nextCursor returns the cursor after the last of items, or "" when the
+// page was not full and so is the last one. key gives an item's sort
+// timestamp and ID.
+func nextCursor[T any](items []T, limit int, key func(T) (string, string)) string {
+	if len(items) == 0 || len(items) < limit {
+		return ""
+	}
+	return model.NewCursor(key(items[len(items)-1])).Encode()
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"sportsin_backend/internals/model"
+	"sportsin_backend/internals/store/memory"
+)
+
+func TestListsWalkByNextCursor(t *testing.T) {
+	repo := memory.NewStore()
+	author := memory.NewTestUser(t, repo)
+	for _, content := range []string{"one", "two", "three"} {
+		memory.NewTestPost(t, repo, author, content)
+	}
+
+	router := newTestRouter()
+	router.GET("/my-posts", GetMyPostsHandler(repo))
+
+	// Without a cursor the first page has the same shape
+	var page Page[model.PostResponse]
+	if code := serve(t, router, http.MethodGet, "/my-posts?limit=2", author, &page); code != http.StatusOK {
+		t.Fatalf("status %d, want %d", code, http.StatusOK)
+	}
+	seen := map[string]bool{}
+	for pages := 1; ; pages++ {
+		for _, p := range page.Items {
+			if seen[p.Id] {
+				t.Errorf("post %s on two pages", p.Id)
+			}
+			seen[p.Id] = true
+		}
+		if page.NextCursor == "" {
+			if pages != 2 {
+				t.Errorf("walked %d pages, want 2", pages)
+			}
+			break
+		}
+		if pages > 3 {
+			t.Fatal("next_cursor never left out")
+		}
+
+		path := "/my-posts?limit=2&cursor=" + url.QueryEscape(page.NextCursor)
+		page = Page[model.PostResponse]{}
+		if code := serve(t, router, http.MethodGet, path, author, &page); code != http.StatusOK {
+			t.Fatalf("status %d, want %d", code, http.StatusOK)
+		}
+	}
+	if len(seen) != 3 {
+		t.Errorf("saw %d posts, want 3", len(seen))
+	}
+
+	if code := serve(t, router, http.MethodGet, "/my-posts?cursor=not-a-cursor", author, nil); code != http.StatusBadRequest {
+		t.Errorf("invalid cursor: status %d, want %d", code, http.StatusBadRequest)
+	}
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"context"
 	"fmt"
+	"mime/multipart"
 	"net/http"
 	"strconv"
 	"time"
@@ -10,15 +11,15 @@ import (
 	"github.com/gin-gonic/gin"
 	"sportsin_backend/internals/config"
 	"sportsin_backend/internals/db"
-	"sportsin_backend/internals/db/repositories"
 	"sportsin_backend/internals/middleware"
 	"sportsin_backend/internals/model"
 	"sportsin_backend/internals/services"
+	"sportsin_backend/internals/store"
 )
 
 // CreatePost godoc
 // @Summary      Create a new post
-// @Description  Creates a new post for the authenticated user with optional images
+// @Description  Creates a new post for the authenticated user with optional images and videos. Users mentioned as @username in the content are notified.
 // @Tags         posts
 // @Accept       multipart/form-data
 // @Produce      json
@@ -26,12 +27,13 @@ import (
 // @Param        content     formData  string  true   "Post content"
 // @Param        tags        formData  string  false  "Comma-separated tags"
 // @Param        images      formData  file    false  "Post images (multiple allowed)"
+// @Param        videos      formData  file    false  "Post videos (multiple allowed). Thumbnails and durations are filled in once processed."
 // @Success      201  {object}  model.PostResponse
 // @Failure      400  {object}  map[string]string
 // @Failure      401  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
 // @Router       /posts [post]
-func CreatePostHandler(repo *repositories.Repository, s3Service *services.S3Service) gin.HandlerFunc {
+func CreatePostHandler(repo store.PostStore, mentions *services.MentionService, storage *services.StorageService, media *services.MediaWorker) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get user ID from authentication middleware
 		userID, exists := middleware.GetUserIDFromContext(c)
@@ -48,13 +50,18 @@ func CreatePostHandler(repo *repositories.Repository, s3Service *services.S3Serv
 			return
 		}
 
-		tagsStr := c.PostForm("tags")
+		tags, err := model.ParseTags(c.PostForm("tags"))
+		if err != nil {
+			httpErr := db.ToHTTPError(err)
+			c.JSON(httpErr.StatusCode, httpErr)
+			return
+		}
 
 		// Create post object
 		post := &model.Post{
 			UserId:  userID,
 			Content: content,
-			Tags:    tagsStr,
+			Tags:    tags,
 		}
 
 		// Create post in database
@@ -63,6 +70,7 @@ func CreatePostHandler(repo *repositories.Repository, s3Service *services.S3Serv
 			c.JSON(httpErr.StatusCode, httpErr)
 			return
 		}
+		mentions.PostSaved(post)
 
 		// Handle image uploads if any
 		var images []model.PostImage
@@ -92,7 +100,7 @@ func CreatePostHandler(repo *repositories.Repository, s3Service *services.S3Serv
 				imageID := fmt.Sprintf("img_%d_%d", i, time.Now().UnixNano())
 
 				// Upload to S3
-				imageURL, err := s3Service.UploadPostImage(ctx, post.Id, imageID, file, fileHeader)
+				imageURL, err := storage.UploadPostImage(ctx, post.Id, imageID, file, fileHeader)
 				if err != nil {
 					errMsg := fmt.Sprintf("Failed to upload image %s to S3: %v", fileHeader.Filename, err)
 					fmt.Printf("ERROR: %s\n", errMsg)
@@ -112,7 +120,7 @@ func CreatePostHandler(repo *repositories.Repository, s3Service *services.S3Serv
 					fmt.Printf("ERROR: %s\n", errMsg)
 					imageErrors = append(imageErrors, errMsg)
 					// Try to delete from S3 if database operation fails
-					s3Service.DeletePostImage(ctx, imageURL)
+					storage.DeletePostImage(ctx, imageURL)
 					continue
 				}
 				fmt.Printf("DEBUG: Successfully created image record in DB with ID: %s\n", postImage.Id)
@@ -120,13 +128,13 @@ func CreatePostHandler(repo *repositories.Repository, s3Service *services.S3Serv
 				images = append(images, *postImage)
 			}
 		}
-
-		// Prepare response
-		var tags []string
-		if tagsStr != "" {
-			tags = repositories.StringToTags(tagsStr)
+		if err == nil {
+			videos, videoErrors := uploadPostVideos(repo, storage, media, post.Id, form)
+			images = append(images, videos...)
+			imageErrors = append(imageErrors, videoErrors...)
 		}
 
+		// Prepare response
 		createdAt, _ := time.Parse("2006-01-02T15:04:05Z07:00", post.CreatedAt)
 		updatedAt, _ := time.Parse("2006-01-02T15:04:05Z07:00", post.UpdatedAt)
 
@@ -146,10 +154,11 @@ func CreatePostHandler(repo *repositories.Repository, s3Service *services.S3Serv
 			Image_url: imageUrl,
 			UserId:    post.UserId,
 			Content:   post.Content,
-			Tags:      tags,
+			Tags:      post.Tags,
 			Images:    images,
 			CreatedAt: createdAt,
 			UpdatedAt: updatedAt,
+			Kind:      post.Kind,
 		}
 
 		// If there were image errors, include them in the response
@@ -175,7 +184,7 @@ func CreatePostHandler(repo *repositories.Repository, s3Service *services.S3Serv
 // @Failure      404  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
 // @Router       /posts/{id} [get]
-func GetPostHandler(repo *repositories.Repository) gin.HandlerFunc {
+func GetPostHandler(repo store.PostStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		postID := c.Param("id")
 		if postID == "" {
@@ -193,23 +202,21 @@ func GetPostHandler(repo *repositories.Repository) gin.HandlerFunc {
 		}
 
 		// Prepare response
-		var tags []string
-		if post.Tags != "" {
-			tags = repositories.StringToTags(post.Tags)
-		}
-
 		createdAt, _ := time.Parse("2006-01-02T15:04:05Z07:00", post.CreatedAt)
 		updatedAt, _ := time.Parse("2006-01-02T15:04:05Z07:00", post.UpdatedAt)
 
 		response := model.PostResponse{
-			Id:        post.Id,
-			UserId:    post.UserId,
-			Content:   post.Content,
-			Tags:      tags,
-			Images:    post.Images,
-			CreatedAt: createdAt,
-			UpdatedAt: updatedAt,
-			LikeCount: post.LikeCount,
+			Id:         post.Id,
+			UserId:     post.UserId,
+			Content:    post.Content,
+			Tags:       post.Tags,
+			Images:     post.Images,
+			CreatedAt:  createdAt,
+			UpdatedAt:  updatedAt,
+			LikeCount:  post.LikeCount,
+			Kind:       post.Kind,
+			RepostOf:   post.RepostOf,
+			ShareCount: post.ShareCount,
 		}
 
 		c.JSON(http.StatusOK, response)
@@ -224,11 +231,12 @@ func GetPostHandler(repo *repositories.Repository) gin.HandlerFunc {
 // @Param        user_id  query     string  false  "User ID to filter posts"
 // @Param        limit    query     int     false  "Number of posts to return (default 10)"
 // @Param        offset   query     int     false  "Number of posts to skip (default 0)"
-// @Success      200  {array}   model.PostResponse
+// @Param        cursor   query     string  false  "next_cursor of the previous page"
+// @Success      200  {object}  handlers.Page[model.PostResponse]
 // @Failure      400  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
 // @Router       /posts [get]
-func GetPostsHandler(repo *repositories.Repository) gin.HandlerFunc {
+func GetPostsHandler(repo store.PostStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		userID := c.Query("user_id")
 
@@ -245,12 +253,17 @@ func GetPostsHandler(repo *repositories.Repository) gin.HandlerFunc {
 			offset = 0
 		}
 
+		after, ok := parseCursor(c)
+		if !ok {
+			return
+		}
+
 		// Get posts with images
 		var posts []model.Post
 		if userID != "" {
-			posts, err = repo.GetPostsByUserId(userID, limit, offset)
+			posts, err = repo.GetPostsByUserId(userID, limit, offset, after)
 		} else {
-			posts, err = repo.GetAllPosts(limit, offset)
+			posts, err = repo.GetAllPosts(limit, offset, after)
 		}
 		if err != nil {
 			httpErr := db.ToHTTPError(err)
@@ -261,44 +274,43 @@ func GetPostsHandler(repo *repositories.Repository) gin.HandlerFunc {
 		// Prepare response
 		var responses []model.PostResponse
 		for _, post := range posts {
-			var tags []string
-			if post.Tags != "" {
-				tags = repositories.StringToTags(post.Tags)
-			}
-
 			createdAt, _ := time.Parse("2006-01-02T15:04:05Z07:00", post.CreatedAt)
 			updatedAt, _ := time.Parse("2006-01-02T15:04:05Z07:00", post.UpdatedAt)
 
 			response := model.PostResponse{
-				Id:        post.Id,
-				UserId:    post.UserId,
-				Content:   post.Content,
-				Tags:      tags,
-				Images:    post.Images,
-				CreatedAt: createdAt,
-				UpdatedAt: updatedAt,
-				LikeCount: post.LikeCount,
+				Id:         post.Id,
+				UserId:     post.UserId,
+				Content:    post.Content,
+				Tags:       post.Tags,
+				Images:     post.Images,
+				CreatedAt:  createdAt,
+				UpdatedAt:  updatedAt,
+				LikeCount:  post.LikeCount,
+				Kind:       post.Kind,
+				RepostOf:   post.RepostOf,
+				ShareCount: post.ShareCount,
 			}
 			responses = append(responses, response)
 		}
 
-		c.JSON(http.StatusOK, responses)
+		c.JSON(http.StatusOK, newPage(responses, nextCursor(posts, limit, postCursorKey)))
 	}
 }
 
 // GetPostsWithCommentsHandler godoc
 // @Summary      Get all posts with comments
-// @Description  Retrieves all posts with their latest comment and total comment count
+// @Description  Retrieves all posts with their latest comment and total comment count.
 // @Tags         posts
 // @Accept       json
 // @Produce      json
 // @Param        limit    query  int     false  "Number of posts to return (default: 10, max: 100)"
 // @Param        offset   query  int     false  "Number of posts to skip (default: 0)"
-// @Success      200  {array}   model.PostWithComment
+// @Param        cursor   query  string  false  "next_cursor of the previous page"
+// @Success      200  {object}  handlers.Page[model.PostWithComment]
 // @Failure      400  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
 // @Router       /posts/with-comments [get]
-func GetPostsWithCommentsHandler(repo *repositories.Repository) gin.HandlerFunc {
+func GetPostsWithCommentsHandler(repo store.PostStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		cUsrId, ok := middleware.GetUserIDFromContext(c)
 		if !ok {
@@ -319,11 +331,16 @@ func GetPostsWithCommentsHandler(repo *repositories.Repository) gin.HandlerFunc
 			offset = 0
 		}
 
+		after, ok := parseCursor(c)
+		if !ok {
+			return
+		}
+
 		var postsWithComments []model.PostWithComment
 		if fUsrId == "" {
-			postsWithComments, err = repo.GetAllPostsWithComments(limit, offset, cUsrId)
+			postsWithComments, err = repo.GetAllPostsWithComments(limit, offset, after, cUsrId)
 		} else {
-			postsWithComments, err = repo.GetAllPostsByUserIdWithComments(fUsrId, limit, offset, cUsrId)
+			postsWithComments, err = repo.GetAllPostsByUserIdWithComments(fUsrId, limit, offset, after, cUsrId)
 		}
 		if err != nil {
 			httpErr := db.ToHTTPError(err)
@@ -331,13 +348,16 @@ func GetPostsWithCommentsHandler(repo *repositories.Repository) gin.HandlerFunc
 			return
 		}
 
-		c.JSON(http.StatusOK, postsWithComments)
+		next := nextCursor(postsWithComments, limit, func(p model.PostWithComment) (string, string) {
+			return postCursorKey(p.Post)
+		})
+		c.JSON(http.StatusOK, newPage(postsWithComments, next))
 	}
 }
 
 // UpdatePost godoc
 // @Summary      Update a post with images
-// @Description  Updates a post owned by the authenticated user with optional new images
+// @Description  Updates a post owned by the authenticated user with optional new images. Users newly mentioned as @username in the content are notified.
 // @Tags         posts
 // @Accept       multipart/form-data
 // @Produce      json
@@ -346,7 +366,8 @@ func GetPostsWithCommentsHandler(repo *repositories.Repository) gin.HandlerFunc
 // @Param        content     formData  string  false  "Post content"
 // @Param        tags        formData  string  false  "Comma-separated tags"
 // @Param        images      formData  file    false  "Post images (multiple allowed)"
-// @Param        replace_images formData string false "Set to 'true' to replace all existing images"
+// @Param        videos      formData  file    false  "Post videos (multiple allowed)"
+// @Param        replace_images formData string false "Set to 'true' to replace all existing images and videos"
 // @Success      200  {object}  model.PostResponse
 // @Failure      400  {object}  map[string]string
 // @Failure      401  {object}  map[string]string
@@ -354,15 +375,8 @@ func GetPostsWithCommentsHandler(repo *repositories.Repository) gin.HandlerFunc
 // @Failure      404  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
 // @Router       /posts/{id} [put]
-func UpdatePostHandler(repo *repositories.Repository, s3Service *services.S3Service) gin.HandlerFunc {
+func UpdatePostHandler(repo store.PostStore, mentions *services.MentionService, storage *services.StorageService, media *services.MediaWorker) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Get user ID from authentication middleware
-		userID, exists := middleware.GetUserIDFromContext(c)
-		if !exists {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
-			return
-		}
-
 		postID := c.Param("id")
 		if postID == "" {
 			httpErr := db.ToHTTPError(db.NewValidationError("post_id", "post ID is required"))
@@ -370,19 +384,7 @@ func UpdatePostHandler(repo *repositories.Repository, s3Service *services.S3Serv
 			return
 		}
 
-		// Check if user owns the post
-		owns, err := repo.CheckPostOwnership(postID, userID)
-		if err != nil {
-			httpErr := db.ToHTTPError(err)
-			c.JSON(httpErr.StatusCode, httpErr)
-			return
-		}
-		if !owns {
-			httpErr := db.ToHTTPError(db.NewAuthorizationError("update", "post", userID))
-			c.JSON(httpErr.StatusCode, httpErr)
-			return
-		}
-
+		// Ownership is enforced by middleware.RequireOwnership on the route
 		// Get existing post
 		post, err := repo.GetPostById(postID)
 		if err != nil {
@@ -401,7 +403,13 @@ func UpdatePostHandler(repo *repositories.Repository, s3Service *services.S3Serv
 			post.Content = content
 		}
 		if tagsStr != "" {
-			post.Tags = tagsStr
+			tags, err := model.ParseTags(tagsStr)
+			if err != nil {
+				httpErr := db.ToHTTPError(err)
+				c.JSON(httpErr.StatusCode, httpErr)
+				return
+			}
+			post.Tags = tags
 		}
 
 		// Update post in database
@@ -410,17 +418,18 @@ func UpdatePostHandler(repo *repositories.Repository, s3Service *services.S3Serv
 			c.JSON(httpErr.StatusCode, httpErr)
 			return
 		}
+		mentions.PostSaved(post)
 
 		// Handle image uploads if any
 		var imageErrors []string
 		form, err := c.MultipartForm()
 		if err != nil {
 			fmt.Printf("DEBUG: Error getting multipart form: %v\n", err)
-		} else if form.File["images"] != nil {
-			fmt.Printf("DEBUG: Found %d new images to process\n", len(form.File["images"]))
+		} else if form.File["images"] != nil || form.File["videos"] != nil {
+			fmt.Printf("DEBUG: Found %d new images and %d new videos to process\n", len(form.File["images"]), len(form.File["videos"]))
 			ctx := context.Background()
 
-			// If replace_images is true, delete existing images first
+			// If replace_images is true, delete existing images and videos first
 			if replaceImages {
 				fmt.Printf("DEBUG: Replacing existing images\n")
 				imageUrls, err := repo.DeleteImagesByPostId(postID)
@@ -431,7 +440,7 @@ func UpdatePostHandler(repo *repositories.Repository, s3Service *services.S3Serv
 				}
 				// Delete images from S3
 				for _, imageUrl := range imageUrls {
-					if err := s3Service.DeletePostImage(ctx, imageUrl); err != nil {
+					if err := storage.DeletePostImage(ctx, imageUrl); err != nil {
 						fmt.Printf("ERROR: Failed to delete image from S3: %v\n", err)
 					}
 				}
@@ -454,7 +463,7 @@ func UpdatePostHandler(repo *repositories.Repository, s3Service *services.S3Serv
 				imageID := fmt.Sprintf("img_%d_%d", i, time.Now().UnixNano())
 
 				// Upload to S3
-				imageURL, err := s3Service.UploadPostImage(ctx, post.Id, imageID, file, fileHeader)
+				imageURL, err := storage.UploadPostImage(ctx, post.Id, imageID, file, fileHeader)
 				if err != nil {
 					errMsg := fmt.Sprintf("Failed to upload image %s to S3: %v", fileHeader.Filename, err)
 					fmt.Printf("ERROR: %s\n", errMsg)
@@ -474,11 +483,14 @@ func UpdatePostHandler(repo *repositories.Repository, s3Service *services.S3Serv
 					fmt.Printf("ERROR: %s\n", errMsg)
 					imageErrors = append(imageErrors, errMsg)
 					// Try to delete from S3 if database operation fails
-					s3Service.DeletePostImage(ctx, imageURL)
+					storage.DeletePostImage(ctx, imageURL)
 					continue
 				}
 				fmt.Printf("DEBUG: Successfully created image record in DB with ID: %s\n", postImage.Id)
 			}
+
+			_, videoErrors := uploadPostVideos(repo, storage, media, post.Id, form)
+			imageErrors = append(imageErrors, videoErrors...)
 		}
 
 		// Get updated post with all images
@@ -490,11 +502,6 @@ func UpdatePostHandler(repo *repositories.Repository, s3Service *services.S3Serv
 		}
 
 		// Prepare response
-		var tags []string
-		if post.Tags != "" {
-			tags = repositories.StringToTags(post.Tags)
-		}
-
 		createdAt, _ := time.Parse("2006-01-02T15:04:05Z07:00", post.CreatedAt)
 		updatedAt, _ := time.Parse("2006-01-02T15:04:05Z07:00", post.UpdatedAt)
 
@@ -505,15 +512,18 @@ func UpdatePostHandler(repo *repositories.Repository, s3Service *services.S3Serv
 		}
 
 		response := model.PostResponse{
-			Id:        post.Id,
-			Image_url: imageUrl,
-			UserId:    post.UserId,
-			Content:   post.Content,
-			Tags:      tags,
-			Images:    post.Images,
-			CreatedAt: createdAt,
-			UpdatedAt: updatedAt,
-			LikeCount: post.LikeCount,
+			Id:         post.Id,
+			Image_url:  imageUrl,
+			UserId:     post.UserId,
+			Content:    post.Content,
+			Tags:       post.Tags,
+			Images:     post.Images,
+			CreatedAt:  createdAt,
+			UpdatedAt:  updatedAt,
+			LikeCount:  post.LikeCount,
+			Kind:       post.Kind,
+			RepostOf:   post.RepostOf,
+			ShareCount: post.ShareCount,
 		}
 
 		// If there were image errors, include them in the response
@@ -542,7 +552,7 @@ func UpdatePostHandler(repo *repositories.Repository, s3Service *services.S3Serv
 // @Failure      404  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
 // @Router       /posts/{id} [delete]
-func DeletePostHandler(repo *repositories.Repository, s3Service *services.S3Service) gin.HandlerFunc {
+func DeletePostHandler(repo store.PostStore, storage *services.StorageService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get user ID from authentication middleware
 		userID, exists := middleware.GetUserIDFromContext(c)
@@ -558,22 +568,10 @@ func DeletePostHandler(repo *repositories.Repository, s3Service *services.S3Serv
 			return
 		}
 
-		// Check if user owns the post
-		owns, err := repo.CheckPostOwnership(postID, userID)
-		if err != nil {
-			httpErr := db.ToHTTPError(err)
-			c.JSON(httpErr.StatusCode, httpErr)
-			return
-		}
-		if !owns {
-			httpErr := db.ToHTTPError(db.NewAuthorizationError("delete", "post", userID))
-			c.JSON(httpErr.StatusCode, httpErr)
-			return
-		}
-
-		// Delete images from S3 and database
-		ctx := context.Background()
-		imageUrls, err := repo.DeleteImagesByPostId(postID)
+		// Ownership is enforced by middleware.RequireOwnership on the route.
+		// Delete the post and its image rows from the database first, so a
+		// failure leaves the images in place
+		imageUrls, err := repo.DeletePost(postID, userID, nil)
 		if err != nil {
 			httpErr := db.ToHTTPError(err)
 			c.JSON(httpErr.StatusCode, httpErr)
@@ -581,37 +579,32 @@ func DeletePostHandler(repo *repositories.Repository, s3Service *services.S3Serv
 		}
 
 		// Delete images from S3
+		ctx := context.Background()
 		for _, imageUrl := range imageUrls {
-			if err := s3Service.DeletePostImage(ctx, imageUrl); err != nil {
-				// Log error but continue with post deletion
+			if err := storage.DeletePostImage(ctx, imageUrl); err != nil {
+				// Log error, the post is already gone
 				fmt.Printf("Failed to delete image from S3: %v\n", err)
 			}
 		}
 
-		// Delete post from database
-		if err := repo.DeletePost(postID, userID); err != nil {
-			httpErr := db.ToHTTPError(err)
-			c.JSON(httpErr.StatusCode, httpErr)
-			return
-		}
-
 		c.JSON(http.StatusOK, gin.H{"message": "Post deleted successfully"})
 	}
 }
 
 // GetMyPosts godoc
 // @Summary      Get current user's posts
-// @Description  Retrieves posts created by the authenticated user
+// @Description  Retrieves posts created by the authenticated user.
 // @Tags         posts
 // @Produce      json
 // @Security     BearerAuth
 // @Param        limit    query     int     false  "Number of posts to return (default 10)"
 // @Param        offset   query     int     false  "Number of posts to skip (default 0)"
-// @Success      200  {array}   model.PostResponse
+// @Param        cursor   query     string  false  "next_cursor of the previous page"
+// @Success      200  {object}  handlers.Page[model.PostResponse]
 // @Failure      401  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
 // @Router       /my-posts [get]
-func GetMyPostsHandler(repo *repositories.Repository) gin.HandlerFunc {
+func GetMyPostsHandler(repo store.PostStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get user ID from authentication middleware
 		userID, exists := middleware.GetUserIDFromContext(c)
@@ -633,8 +626,13 @@ func GetMyPostsHandler(repo *repositories.Repository) gin.HandlerFunc {
 			offset = 0
 		}
 
+		after, ok := parseCursor(c)
+		if !ok {
+			return
+		}
+
 		// Get user's posts with images
-		posts, err := repo.GetPostsByUserId(userID, limit, offset)
+		posts, err := repo.GetPostsByUserId(userID, limit, offset, after)
 		if err != nil {
 			httpErr := db.ToHTTPError(err)
 			c.JSON(httpErr.StatusCode, httpErr)
@@ -644,34 +642,32 @@ func GetMyPostsHandler(repo *repositories.Repository) gin.HandlerFunc {
 		// Prepare response
 		var responses []model.PostResponse
 		for _, post := range posts {
-			var tags []string
-			if post.Tags != "" {
-				tags = repositories.StringToTags(post.Tags)
-			}
-
 			createdAt, _ := time.Parse("2006-01-02T15:04:05Z07:00", post.CreatedAt)
 			updatedAt, _ := time.Parse("2006-01-02T15:04:05Z07:00", post.UpdatedAt)
 
 			response := model.PostResponse{
-				Id:        post.Id,
-				UserId:    post.UserId,
-				Content:   post.Content,
-				Tags:      tags,
-				Images:    post.Images,
-				CreatedAt: createdAt,
-				UpdatedAt: updatedAt,
-				LikeCount: post.LikeCount,
+				Id:         post.Id,
+				UserId:     post.UserId,
+				Content:    post.Content,
+				Tags:       post.Tags,
+				Images:     post.Images,
+				CreatedAt:  createdAt,
+				UpdatedAt:  updatedAt,
+				LikeCount:  post.LikeCount,
+				Kind:       post.Kind,
+				RepostOf:   post.RepostOf,
+				ShareCount: post.ShareCount,
 			}
 			responses = append(responses, response)
 		}
 
-		c.JSON(http.StatusOK, responses)
+		c.JSON(http.StatusOK, newPage(responses, nextCursor(posts, limit, postCursorKey)))
 	}
 }
 
 // LikePost godoc
 // @Summary      Like a post
-// @Description  Adds a like to a specific post for the authenticated user
+// @Description  Adds a like to a specific post for the authenticated user. This is the like reaction of PUT /posts/{id}/reaction and replaces another reaction the user left.
 // @Tags         posts
 // @Produce      json
 // @Security     BearerAuth
@@ -680,9 +676,10 @@ func GetMyPostsHandler(repo *repositories.Repository) gin.HandlerFunc {
 // @Failure      400  {object}  map[string]string
 // @Failure      401  {object}  map[string]string
 // @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
 // @Router       /posts/{id}/like [post]
-func LikePostHandler(repo *repositories.Repository) gin.HandlerFunc {
+func LikePostHandler(repo store.PostStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		userID, exists := middleware.GetUserIDFromContext(c)
 		if !exists {
@@ -697,7 +694,7 @@ func LikePostHandler(repo *repositories.Repository) gin.HandlerFunc {
 			return
 		}
 
-		if err := repo.LikePost(postID, userID); err != nil {
+		if err := repo.ReactToPost(postID, userID, model.ReactionLike); err != nil {
 			httpErr := db.ToHTTPError(err)
 			c.JSON(httpErr.StatusCode, httpErr)
 			return
@@ -709,7 +706,7 @@ func LikePostHandler(repo *repositories.Repository) gin.HandlerFunc {
 
 // UnlikePost godoc
 // @Summary      Unlike a post
-// @Description  Removes a like from a specific post for the authenticated user
+// @Description  Removes the authenticated user's like, or other reaction, from a specific post
 // @Tags         posts
 // @Produce      json
 // @Security     BearerAuth
@@ -720,7 +717,7 @@ func LikePostHandler(repo *repositories.Repository) gin.HandlerFunc {
 // @Failure      404  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
 // @Router       /posts/{id}/like [delete]
-func UnlikePostHandler(repo *repositories.Repository) gin.HandlerFunc {
+func UnlikePostHandler(repo store.PostStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		userID, exists := middleware.GetUserIDFromContext(c)
 		if !exists {
@@ -735,7 +732,7 @@ func UnlikePostHandler(repo *repositories.Repository) gin.HandlerFunc {
 			return
 		}
 
-		if err := repo.UnlikePost(postID, userID); err != nil {
+		if err := repo.RemovePostReaction(postID, userID); err != nil {
 			httpErr := db.ToHTTPError(err)
 			c.JSON(httpErr.StatusCode, httpErr)
 			return
@@ -745,8 +742,200 @@ func UnlikePostHandler(repo *repositories.Repository) gin.HandlerFunc {
 	}
 }
 
+// ReactToPost godoc
+// @Summary      React to a post
+// @Description  Sets the authenticated user's reaction to a post. A user has one reaction per post, so this replaces a previous reaction of another type.
+// @Tags         posts
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id        path      string              true  "Post ID"
+// @Param        reaction  body      model.ReactRequest  true  "Reaction type"
+// @Success      200       {object}  map[string]string
+// @Failure      400       {object}  map[string]string
+// @Failure      401       {object}  map[string]string
+// @Failure      404       {object}  map[string]string
+// @Failure      409       {object}  map[string]string
+// @Failure      500       {object}  map[string]string
+// @Router       /posts/{id}/reaction [put]
+func ReactToPostHandler(repo store.PostStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := middleware.GetUserIDFromContext(c)
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+			return
+		}
+
+		postID := c.Param("id")
+		if postID == "" {
+			httpErr := db.ToHTTPError(db.NewValidationError("post_id", "post ID is required"))
+			c.JSON(httpErr.StatusCode, httpErr)
+			return
+		}
+
+		var req model.ReactRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+			return
+		}
+		reaction, err := model.ParseReactionType(req.Reaction)
+		if err != nil {
+			httpErr := db.ToHTTPError(db.NewValidationError("reaction", "reaction must be one of like, clap, fire or trophy"))
+			c.JSON(httpErr.StatusCode, httpErr)
+			return
+		}
+
+		if err := repo.ReactToPost(postID, userID, reaction); err != nil {
+			httpErr := db.ToHTTPError(err)
+			c.JSON(httpErr.StatusCode, httpErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Reaction saved successfully", "reaction": reaction})
+	}
+}
+
+// RemovePostReaction godoc
+// @Summary      Remove a reaction from a post
+// @Description  Removes the authenticated user's reaction, of any type, from a post
+// @Tags         posts
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Post ID"
+// @Success      200  {object}  map[string]string
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /posts/{id}/reaction [delete]
+func RemovePostReactionHandler(repo store.PostStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := middleware.GetUserIDFromContext(c)
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+			return
+		}
+
+		postID := c.Param("id")
+		if postID == "" {
+			httpErr := db.ToHTTPError(db.NewValidationError("post_id", "post ID is required"))
+			c.JSON(httpErr.StatusCode, httpErr)
+			return
+		}
+
+		if err := repo.RemovePostReaction(postID, userID); err != nil {
+			httpErr := db.ToHTTPError(err)
+			c.JSON(httpErr.StatusCode, httpErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Reaction removed successfully"})
+	}
+}
+
+// RepostPost godoc
+// @Summary      Share a post
+// @Description  Shares a post on the authenticated user's profile and in feeds. Without content this is a plain repost, which a user can make once per post; with content it is a quote. Sharing a plain repost shares its original.
+// @Tags         posts
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path      string               true   "Post ID"
+// @Param        repost  body      model.RepostRequest  false  "Commentary for a quote"
+// @Success      201     {object}  model.Post
+// @Failure      400     {object}  map[string]string
+// @Failure      401     {object}  map[string]string
+// @Failure      404     {object}  map[string]string
+// @Failure      409     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Router       /posts/{id}/repost [post]
+func RepostPostHandler(repo store.PostStore, mentions *services.MentionService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := middleware.GetUserIDFromContext(c)
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+			return
+		}
+
+		postID := c.Param("id")
+		if postID == "" {
+			httpErr := db.ToHTTPError(db.NewValidationError("post_id", "post ID is required"))
+			c.JSON(httpErr.StatusCode, httpErr)
+			return
+		}
+
+		var req model.RepostRequest
+		if c.Request.ContentLength > 0 {
+			if err := c.ShouldBindJSON(&req); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+				return
+			}
+		}
+
+		post := &model.Post{
+			UserId:     userID,
+			Content:    req.Content,
+			RepostOfId: &postID,
+		}
+		if err := repo.CreateRepost(post); err != nil {
+			httpErr := db.ToHTTPError(err)
+			c.JSON(httpErr.StatusCode, httpErr)
+			return
+		}
+		if post.Kind == model.PostKindQuote {
+			mentions.PostSaved(post)
+		}
+
+		created, err := repo.GetPostById(post.Id)
+		if err != nil {
+			httpErr := db.ToHTTPError(err)
+			c.JSON(httpErr.StatusCode, httpErr)
+			return
+		}
+
+		c.JSON(http.StatusCreated, created)
+	}
+}
+
+// UndoRepost godoc
+// @Summary      Undo a repost
+// @Description  Removes the authenticated user's plain repost of a post. Quotes are deleted like any other post.
+// @Tags         posts
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Post ID"
+// @Success      200  {object}  map[string]string
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /posts/{id}/repost [delete]
+func UndoRepostHandler(repo store.PostStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := middleware.GetUserIDFromContext(c)
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+			return
+		}
+
+		postID := c.Param("id")
+		if postID == "" {
+			httpErr := db.ToHTTPError(db.NewValidationError("post_id", "post ID is required"))
+			c.JSON(httpErr.StatusCode, httpErr)
+			return
+		}
+
+		if err := repo.DeleteRepost(postID, userID); err != nil {
+			httpErr := db.ToHTTPError(err)
+			c.JSON(httpErr.StatusCode, httpErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Repost removed successfully"})
+	}
+}
+
 // RegisterPostRoutes registers all post-related routes
-func RegisterPostRoutes(rg *gin.RouterGroup, cfg *config.Config, repo *repositories.Repository, s3Service *services.S3Service) {
+func RegisterPostRoutes(rg *gin.RouterGroup, cfg *config.Config, repo store.PostStore, mentions *services.MentionService, storage *services.StorageService, media *services.MediaWorker) {
 	// Initialize JWT middleware
 	jwtMiddleware := middleware.NewJWTMiddleware(cfg)
 
@@ -762,13 +951,79 @@ func RegisterPostRoutes(rg *gin.RouterGroup, cfg *config.Config, repo *repositor
 	protected.Use(jwtMiddleware.AuthMiddleware())
 	{
 		protected.GET("/posts/with-comments", GetPostsWithCommentsHandler(repo))
-		protected.POST("/posts", CreatePostHandler(repo, s3Service))
-		protected.PUT("/posts/:id", UpdatePostHandler(repo, s3Service))
-		protected.DELETE("/posts/:id", DeletePostHandler(repo, s3Service))
+		protected.POST("/posts", CreatePostHandler(repo, mentions, storage, media))
+		ownsPost := middleware.RequireOwnership("post", "id", postOwner(repo))
+		protected.PUT("/posts/:id", ownsPost, UpdatePostHandler(repo, mentions, storage, media))
+		protected.DELETE("/posts/:id", ownsPost, DeletePostHandler(repo, storage))
 		protected.GET("/my-posts", GetMyPostsHandler(repo))
 
-		// Like and Unlike routes
+		// Reactions, with like and unlike kept for the like reaction
+		protected.PUT("/posts/:id/reaction", ReactToPostHandler(repo))
+		protected.DELETE("/posts/:id/reaction", RemovePostReactionHandler(repo))
 		protected.POST("/posts/:id/like", LikePostHandler(repo))
 		protected.DELETE("/posts/:id/like", UnlikePostHandler(repo))
+
+		protected.POST("/posts/:id/repost", RepostPostHandler(repo, mentions))
+		protected.DELETE("/posts/:id/repost", UndoRepostHandler(repo))
+	}
+}
+
+// uploadPostVideos stores the files of the videos form field as pending
+// media of a post and queues them for processing. Failed uploads are
+// reported in the same way as failed images, as are all videos when the
+// server runs without a media worker.
+func uploadPostVideos(repo store.PostStore, storage *services.StorageService, media *services.MediaWorker, postID string, form *multipart.Form) ([]model.PostImage, []string) {
+	var videos []model.PostImage
+	var videoErrors []string
+	ctx := context.Background()
+
+	if media == nil {
+		for _, fileHeader := range form.File["videos"] {
+			videoErrors = append(videoErrors, fmt.Sprintf("Failed to upload video %s: video uploads are disabled on this server", fileHeader.Filename))
+		}
+		return nil, videoErrors
+	}
+
+	for i, fileHeader := range form.File["videos"] {
+		file, err := fileHeader.Open()
+		if err != nil {
+			errMsg := fmt.Sprintf("Failed to open video file %s: %v", fileHeader.Filename, err)
+			fmt.Printf("ERROR: %s\n", errMsg)
+			videoErrors = append(videoErrors, errMsg)
+			continue
+		}
+		defer file.Close()
+
+		videoID := fmt.Sprintf("vid_%d_%d", i, time.Now().UnixNano())
+		videoURL, err := storage.UploadPostVideo(ctx, postID, videoID, file, fileHeader)
+		if err != nil {
+			errMsg := fmt.Sprintf("Failed to upload video %s: %v", fileHeader.Filename, err)
+			fmt.Printf("ERROR: %s\n", errMsg)
+			videoErrors = append(videoErrors, errMsg)
+			continue
+		}
+
+		video := &model.PostImage{
+			PostId:    postID,
+			ImageUrl:  videoURL,
+			MediaType: model.PostMediaVideo,
+			Status:    model.MediaStatusPending,
+		}
+		if err := repo.CreatePostImage(video); err != nil {
+			errMsg := fmt.Sprintf("Failed to save video %s to database: %v", fileHeader.Filename, err)
+			fmt.Printf("ERROR: %s\n", errMsg)
+			videoErrors = append(videoErrors, errMsg)
+			storage.DeletePostImage(ctx, videoURL)
+			continue
+		}
+
+		media.Enqueue(*video)
+		videos = append(videos, *video)
 	}
+	return videos, videoErrors
+}
+
+// postCursorKey is the keyset pagination key of a post
+func postCursorKey(p model.Post) (string, string) {
+	return p.CreatedAt, p.Id
 }
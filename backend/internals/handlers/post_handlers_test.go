@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"sportsin_backend/internals/middleware"
+	"sportsin_backend/internals/model"
+	"sportsin_backend/internals/services"
+	"sportsin_backend/internals/store/memory"
+)
+
+// testUserHeader carries the ID of the signed in user in tests, in place of
+// the JWT middleware
+const testUserHeader = "X-Test-User"
+
+func newTestRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if userID := c.GetHeader(testUserHeader); userID != "" {
+			c.Set("userID", userID)
+		}
+	})
+	return router
+}
+
+func newTestStorage(t *testing.T) *services.StorageService {
+	t.Helper()
+	blobs, err := services.NewLocalStorage(t.TempDir(), "http://storage.test", "secret")
+	if err != nil {
+		t.Fatalf("NewLocalStorage: %v", err)
+	}
+	return services.NewStorageService(blobs, 10<<20)
+}
+
+// serve sends a request as userID, or anonymously when it is empty, and
+// decodes the JSON response into out when it is not nil
+func serve(t *testing.T, router *gin.Engine, method, path, userID string, out any) int {
+	t.Helper()
+	req := httptest.NewRequest(method, path, nil)
+	if userID != "" {
+		req.Header.Set(testUserHeader, userID)
+	}
+	return record(t, router, req, out)
+}
+
+// newJSONRequest returns a request carrying body as JSON, or no body when it
+// is nil
+func newJSONRequest(t *testing.T, method, path string, body any) *http.Request {
+	t.Helper()
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			t.Fatalf("encoding %v: %v", body, err)
+		}
+	}
+	req := httptest.NewRequest(method, path, bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
+
+// record serves req and decodes a successful JSON response into out when it
+// is not nil
+func record(t *testing.T, router *gin.Engine, req *http.Request, out any) int {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if out != nil && (rec.Code == http.StatusOK || rec.Code == http.StatusCreated) {
+		if err := json.Unmarshal(rec.Body.Bytes(), out); err != nil {
+			t.Fatalf("%s %s: decoding %s: %v", req.Method, req.URL, rec.Body.String(), err)
+		}
+	}
+	return rec.Code
+}
+
+func TestGetPostsWithCommentsHidesReportedPosts(t *testing.T) {
+	repo := memory.NewStore()
+	repo.ReportHideThreshold = 1
+	author := memory.NewTestUser(t, repo)
+	kept := memory.NewTestPost(t, repo, author, "match report")
+	reported := memory.NewTestPost(t, repo, author, "click this link")
+	err := repo.CreateReport(&model.Report{
+		ReporterId: memory.NewTestUser(t, repo),
+		TargetType: model.ReportTargetPost,
+		TargetId:   reported.Id,
+		Reason:     model.ReportReasonSpam,
+	})
+	if err != nil {
+		t.Fatalf("CreateReport: %v", err)
+	}
+
+	router := newTestRouter()
+	router.GET("/posts/with-comments", GetPostsWithCommentsHandler(repo))
+
+	if code := serve(t, router, http.MethodGet, "/posts/with-comments", "", nil); code != http.StatusUnauthorized {
+		t.Errorf("anonymous request: status %d, want %d", code, http.StatusUnauthorized)
+	}
+
+	var page Page[model.PostWithComment]
+	if code := serve(t, router, http.MethodGet, "/posts/with-comments", author, &page); code != http.StatusOK {
+		t.Fatalf("status %d, want %d", code, http.StatusOK)
+	}
+	if len(page.Items) != 1 || page.Items[0].Post.Id != kept.Id {
+		t.Errorf("posts = %v, want only %s", page.Items, kept.Id)
+	}
+	if page.NextCursor != "" {
+		t.Errorf("next_cursor = %q on the last page, want none", page.NextCursor)
+	}
+}
+
+func TestDeletePostRequiresOwnershipAndLeavesTombstones(t *testing.T) {
+	repo := memory.NewStore()
+	author, sharer := memory.NewTestUser(t, repo), memory.NewTestUser(t, repo)
+	post := memory.NewTestPost(t, repo, author, "training moved to 7pm")
+	original := post.Id
+	quote := &model.Post{UserId: sharer, RepostOfId: &original, Content: "noted"}
+	if err := repo.CreateRepost(quote); err != nil {
+		t.Fatalf("CreateRepost: %v", err)
+	}
+
+	router := newTestRouter()
+	router.GET("/posts/:id", GetPostHandler(repo))
+	router.DELETE("/posts/:id", middleware.RequireOwnership("post", "id", postOwner(repo)), DeletePostHandler(repo, newTestStorage(t)))
+
+	if code := serve(t, router, http.MethodDelete, "/posts/"+post.Id, sharer, nil); code != http.StatusForbidden {
+		t.Fatalf("delete by another user: status %d, want %d", code, http.StatusForbidden)
+	}
+	if code := serve(t, router, http.MethodDelete, "/posts/"+post.Id, author, nil); code != http.StatusOK {
+		t.Fatalf("delete by the author: status %d, want %d", code, http.StatusOK)
+	}
+	if code := serve(t, router, http.MethodGet, "/posts/"+post.Id, "", nil); code != http.StatusNotFound {
+		t.Errorf("deleted post: status %d, want %d", code, http.StatusNotFound)
+	}
+
+	var got model.PostResponse
+	if code := serve(t, router, http.MethodGet, "/posts/"+quote.Id, "", &got); code != http.StatusOK {
+		t.Fatalf("quote: status %d, want %d", code, http.StatusOK)
+	}
+	if got.RepostOf == nil || !got.RepostOf.Deleted || got.RepostOf.Id != "" {
+		t.Errorf("quote shares %+v, want a tombstone", got.RepostOf)
+	}
+}
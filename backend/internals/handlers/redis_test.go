@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// fakeRedis speaks just enough RESP2 for the local auth provider, the
+// revocation list and the rate limiter. Keys never expire.
+type fakeRedis struct {
+	mu      sync.Mutex
+	strings map[string]string
+	sets    map[string]map[string]bool
+}
+
+// newTestRedis returns a client of a fake Redis server that lives as long as
+// the test
+func newTestRedis(t *testing.T) *redis.Client {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	f := &fakeRedis{strings: map[string]string{}, sets: map[string]map[string]bool{}}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go f.serve(conn)
+		}
+	}()
+
+	client := redis.NewClient(&redis.Options{Addr: ln.Addr().String(), DisableIdentity: true})
+	t.Cleanup(func() {
+		client.Close()
+		ln.Close()
+	})
+	return client
+}
+
+func (f *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+	r, w := bufio.NewReader(conn), bufio.NewWriter(conn)
+
+	var queued [][]string
+	inMulti := false
+	for {
+		args, err := readCommand(r)
+		if err != nil {
+			return
+		}
+		switch name := strings.ToUpper(args[0]); {
+		case name == "MULTI":
+			inMulti, queued = true, nil
+			w.WriteString("+OK\r\n")
+		case name == "EXEC":
+			fmt.Fprintf(w, "*%d\r\n", len(queued))
+			f.mu.Lock()
+			for _, cmd := range queued {
+				f.exec(w, cmd)
+			}
+			f.mu.Unlock()
+			inMulti, queued = false, nil
+		case inMulti:
+			queued = append(queued, args)
+			w.WriteString("+QUEUED\r\n")
+		default:
+			f.mu.Lock()
+			f.exec(w, args)
+			f.mu.Unlock()
+		}
+		if r.Buffered() == 0 {
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// exec runs one command and writes its reply. The caller must hold f.mu.
+func (f *fakeRedis) exec(w *bufio.Writer, args []string) {
+	key := ""
+	if len(args) > 1 {
+		key = args[1]
+	}
+	switch strings.ToUpper(args[0]) {
+	case "GET", "GETDEL":
+		v, ok := f.strings[key]
+		if !ok {
+			w.WriteString("$-1\r\n")
+			return
+		}
+		if strings.EqualFold(args[0], "GETDEL") {
+			delete(f.strings, key)
+		}
+		fmt.Fprintf(w, "$%d\r\n%s\r\n", len(v), v)
+	case "SET":
+		f.strings[key] = args[2]
+		w.WriteString("+OK\r\n")
+	case "INCR":
+		n, _ := strconv.Atoi(f.strings[key])
+		f.strings[key] = strconv.Itoa(n + 1)
+		fmt.Fprintf(w, ":%d\r\n", n+1)
+	case "DEL", "EXISTS":
+		n := 0
+		for _, k := range args[1:] {
+			_, isString := f.strings[k]
+			_, isSet := f.sets[k]
+			if isString || isSet {
+				n++
+			}
+			if strings.EqualFold(args[0], "DEL") {
+				delete(f.strings, k)
+				delete(f.sets, k)
+			}
+		}
+		fmt.Fprintf(w, ":%d\r\n", n)
+	case "EXPIRE":
+		_, isString := f.strings[key]
+		_, isSet := f.sets[key]
+		if isString || isSet {
+			w.WriteString(":1\r\n")
+		} else {
+			w.WriteString(":0\r\n")
+		}
+	case "SADD":
+		if f.sets[key] == nil {
+			f.sets[key] = map[string]bool{}
+		}
+		n := 0
+		for _, m := range args[2:] {
+			if !f.sets[key][m] {
+				f.sets[key][m] = true
+				n++
+			}
+		}
+		fmt.Fprintf(w, ":%d\r\n", n)
+	case "SREM":
+		n := 0
+		for _, m := range args[2:] {
+			if f.sets[key][m] {
+				delete(f.sets[key], m)
+				n++
+			}
+		}
+		if len(f.sets[key]) == 0 {
+			delete(f.sets, key)
+		}
+		fmt.Fprintf(w, ":%d\r\n", n)
+	case "SMEMBERS":
+		fmt.Fprintf(w, "*%d\r\n", len(f.sets[key]))
+		for m := range f.sets[key] {
+			fmt.Fprintf(w, "$%d\r\n%s\r\n", len(m), m)
+		}
+	default:
+		fmt.Fprintf(w, "-ERR unknown command '%s'\r\n", args[0])
+	}
+}
+
+// readCommand reads one command sent as an array of bulk strings
+func readCommand(r *bufio.Reader) ([]string, error) {
+	n, err := readLength(r, '*')
+	if err != nil {
+		return nil, err
+	}
+	args := make([]string, n)
+	for i := range args {
+		size, err := readLength(r, '$')
+		if err != nil {
+			return nil, err
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		args[i] = string(buf[:size])
+	}
+	if len(args) == 0 {
+		return nil, fmt.Errorf("empty command")
+	}
+	return args, nil
+}
+
+func readLength(r *bufio.Reader, prefix byte) (int, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return 0, err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if len(line) == 0 || line[0] != prefix {
+		return 0, fmt.Errorf("unexpected %q", line)
+	}
+	return strconv.Atoi(line[1:])
+}
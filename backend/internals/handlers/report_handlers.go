@@ -0,0 +1,209 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"sportsin_backend/internals/config"
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/middleware"
+	"sportsin_backend/internals/model"
+	"sportsin_backend/internals/store"
+)
+
+type CreateReportRequest struct {
+	TargetType model.ReportTargetType `json:"target_type" binding:"required"`
+	TargetId   string                 `json:"target_id" binding:"required"`
+	Reason     model.ReportReason     `json:"reason" binding:"required"`
+	Details    *string                `json:"details,omitempty" binding:"omitempty,max=1000"`
+}
+
+// CreateReport godoc
+// @Summary      Report content
+// @Description  Flags a post, comment, chat message or profile for moderation. Each user can report a target once. Posts and comments with enough open reports are hidden until a moderator dismisses them.
+// @Tags         reports
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      CreateReportRequest  true  "Report details"
+// @Success      201      {object}  model.Report
+// @Failure      400      {object}  map[string]string
+// @Failure      401      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      409      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /reports [post]
+func CreateReportHandler(repo store.ReportStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := middleware.GetUserIDFromContext(c)
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+			return
+		}
+
+		var req CreateReportRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+			return
+		}
+		if !req.TargetType.IsValid() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid target type"})
+			return
+		}
+		if !req.Reason.IsValid() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid report reason"})
+			return
+		}
+		if _, err := uuid.Parse(req.TargetId); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid target ID format"})
+			return
+		}
+		if req.TargetType == model.ReportTargetProfile && req.TargetId == userID {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "You cannot report your own profile"})
+			return
+		}
+
+		report := &model.Report{
+			ReporterId: userID,
+			TargetType: req.TargetType,
+			TargetId:   req.TargetId,
+			Reason:     req.Reason,
+			Details:    req.Details,
+		}
+		if err := repo.CreateReport(report); err != nil {
+			httpErr := db.ToHTTPError(err)
+			c.JSON(httpErr.StatusCode, httpErr)
+			return
+		}
+
+		c.JSON(http.StatusCreated, report)
+	}
+}
+
+// AdminGetReports godoc
+// @Summary      Moderation queue
+// @Description  Lists reports oldest first. Only open reports are returned unless another status is asked for. Paged by limit and offset only. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        status       query     string  false  "Report status (default open)" Enums(open,actioned,dismissed)
+// @Param        target_type  query     string  false  "Only reports on this kind of content" Enums(post,comment,chat_message,profile)
+// @Param        target_id    query     string  false  "Only reports on this target"
+// @Param        limit        query     int     false  "Number of reports to return (default 20)"
+// @Param        offset       query     int     false  "Number of reports to skip (default 0)"
+// @Success      200          {object}  handlers.Page[model.Report]
+// @Failure      400          {object}  map[string]string
+// @Failure      401          {object}  map[string]string
+// @Failure      403          {object}  map[string]string
+// @Failure      500          {object}  map[string]string
+// @Router       /admin/reports [get]
+func AdminGetReportsHandler(repo store.ReportStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit, offset, ok := parsePagination(c)
+		if !ok {
+			return
+		}
+
+		status := model.ReportStatus(c.DefaultQuery("status", string(model.ReportStatusOpen)))
+		if !status.IsValid() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status"})
+			return
+		}
+		filter := &model.ReportFilter{Status: &status}
+		if targetType := model.ReportTargetType(c.Query("target_type")); targetType != "" {
+			if !targetType.IsValid() {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid target type"})
+				return
+			}
+			filter.TargetType = &targetType
+		}
+		if targetID := c.Query("target_id"); targetID != "" {
+			if _, err := uuid.Parse(targetID); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid target ID format"})
+				return
+			}
+			filter.TargetId = &targetID
+		}
+
+		reports, err := repo.GetReports(filter, limit, offset)
+		if err != nil {
+			httpErr := db.ToHTTPError(err)
+			c.JSON(httpErr.StatusCode, httpErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, newPage(reports, ""))
+	}
+}
+
+// AdminActionReport godoc
+// @Summary      Action a report
+// @Description  Marks a report and every other open report on the same target as actioned. The content stays hidden; use the delete or suspend endpoints to remove it. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string              true   "Report ID"
+// @Param        request  body      AdminActionRequest  false  "Reason for the decision"
+// @Success      200      {object}  model.Report
+// @Failure      400      {object}  map[string]string
+// @Failure      401      {object}  map[string]string
+// @Failure      403      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /admin/reports/{id}/action [post]
+func AdminActionReportHandler(repo store.ReportStore) gin.HandlerFunc {
+	return resolveReportHandler(repo, model.ReportStatusActioned, model.AdminActionActionReport)
+}
+
+// AdminDismissReport godoc
+// @Summary      Dismiss a report
+// @Description  Marks a report and every other open report on the same target as dismissed, which makes hidden content visible again. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string              true   "Report ID"
+// @Param        request  body      AdminActionRequest  false  "Reason for the decision"
+// @Success      200      {object}  model.Report
+// @Failure      400      {object}  map[string]string
+// @Failure      401      {object}  map[string]string
+// @Failure      403      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /admin/reports/{id}/dismiss [post]
+func AdminDismissReportHandler(repo store.ReportStore) gin.HandlerFunc {
+	return resolveReportHandler(repo, model.ReportStatusDismissed, model.AdminActionDismissReport)
+}
+
+func resolveReportHandler(repo store.ReportStore, status model.ReportStatus, action model.AdminActionType) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		reportID := c.Param("id")
+		if _, err := uuid.Parse(reportID); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid report ID format"})
+			return
+		}
+		req, ok := bindAdminActionRequest(c)
+		if !ok {
+			return
+		}
+
+		adminID, _ := middleware.GetUserIDFromContext(c)
+		report, err := repo.ResolveReport(reportID, status, adminID, adminAction(c, action, "report", reportID, req.Reason))
+		if err != nil {
+			httpErr := db.ToHTTPError(err)
+			c.JSON(httpErr.StatusCode, httpErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, report)
+	}
+}
+
+// RegisterReportRoutes registers the endpoint users flag content with
+func RegisterReportRoutes(rg *gin.RouterGroup, cfg *config.Config, repo store.ReportStore) {
+	jwtMiddleware := middleware.NewJWTMiddleware(cfg)
+
+	rg.POST("/reports", jwtMiddleware.AuthMiddleware(), CreateReportHandler(repo))
+}
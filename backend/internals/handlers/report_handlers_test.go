@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"sportsin_backend/internals/model"
+	"sportsin_backend/internals/store/memory"
+)
+
+// report flags a target as reporterID and returns the status and the report
+func report(t *testing.T, router *gin.Engine, reporterID string, targetType model.ReportTargetType, targetID string) (int, *model.Report) {
+	t.Helper()
+	req := newJSONRequest(t, http.MethodPost, "/reports", CreateReportRequest{
+		TargetType: targetType,
+		TargetId:   targetID,
+		Reason:     model.ReportReasonSpam,
+	})
+	req.Header.Set(testUserHeader, reporterID)
+	var created model.Report
+	code := record(t, router, req, &created)
+	return code, &created
+}
+
+func TestReportedContentIsHiddenUntilDismissed(t *testing.T) {
+	repo := memory.NewStore()
+	repo.ReportHideThreshold = 2
+	author, admin := memory.NewTestUser(t, repo), memory.NewTestUser(t, repo)
+	first, second := memory.NewTestUser(t, repo), memory.NewTestUser(t, repo)
+	post := memory.NewTestPost(t, repo, author, "free tickets, click here")
+	match := memory.NewTestPost(t, repo, author, "match report")
+	comment, err := repo.CreateComment(author, match.Id, "buy followers here", nil)
+	if err != nil {
+		t.Fatalf("CreateComment: %v", err)
+	}
+
+	router := newTestRouter()
+	router.POST("/reports", CreateReportHandler(repo))
+	router.GET("/posts/with-comments", GetPostsWithCommentsHandler(repo))
+	router.GET("/posts/:id/comments", GetCommentsByPostIdHandler(repo))
+	router.POST("/admin/reports/:id/action", AdminActionReportHandler(repo))
+	router.POST("/admin/reports/:id/dismiss", AdminDismissReportHandler(repo))
+
+	postVisible := func() bool {
+		t.Helper()
+		var page Page[model.PostWithComment]
+		if code := serve(t, router, http.MethodGet, "/posts/with-comments", author, &page); code != http.StatusOK {
+			t.Fatalf("posts: status %d, want %d", code, http.StatusOK)
+		}
+		for _, p := range page.Items {
+			if p.Post.Id == post.Id {
+				return true
+			}
+		}
+		return false
+	}
+	commentVisible := func() bool {
+		t.Helper()
+		var page Page[model.CommentResponse]
+		if code := serve(t, router, http.MethodGet, "/posts/"+match.Id+"/comments", "", &page); code != http.StatusOK {
+			t.Fatalf("comments: status %d, want %d", code, http.StatusOK)
+		}
+		return len(page.Items) == 1 && page.Items[0].Comment.Id == comment.Id
+	}
+
+	code, postReport := report(t, router, first, model.ReportTargetPost, post.Id)
+	if code != http.StatusCreated || postReport.Status != model.ReportStatusOpen {
+		t.Fatalf("report: status %d, %+v, want an open report", code, postReport)
+	}
+	if code, _ := report(t, router, first, model.ReportTargetPost, post.Id); code != http.StatusConflict {
+		t.Errorf("second report by the same user: status %d, want %d", code, http.StatusConflict)
+	}
+	if !postVisible() {
+		t.Fatalf("post hidden below the threshold")
+	}
+	if code, _ := report(t, router, second, model.ReportTargetPost, post.Id); code != http.StatusCreated {
+		t.Fatalf("report: status %d, want %d", code, http.StatusCreated)
+	}
+	if postVisible() {
+		t.Errorf("post still listed with %d reports", repo.ReportHideThreshold)
+	}
+
+	code, commentReport := report(t, router, first, model.ReportTargetComment, comment.Id)
+	if code != http.StatusCreated {
+		t.Fatalf("report: status %d, want %d", code, http.StatusCreated)
+	}
+	if code, _ := report(t, router, second, model.ReportTargetComment, comment.Id); code != http.StatusCreated {
+		t.Fatalf("report: status %d, want %d", code, http.StatusCreated)
+	}
+	if commentVisible() {
+		t.Errorf("comment still listed with %d reports", repo.ReportHideThreshold)
+	}
+
+	// Acting on a report keeps the content hidden, dismissing it brings it
+	// back
+	if code := serve(t, router, http.MethodPost, "/admin/reports/"+commentReport.Id+"/action", admin, nil); code != http.StatusOK {
+		t.Fatalf("action: status %d, want %d", code, http.StatusOK)
+	}
+	if commentVisible() {
+		t.Errorf("comment listed after its report was actioned")
+	}
+	if code := serve(t, router, http.MethodPost, "/admin/reports/"+postReport.Id+"/dismiss", admin, nil); code != http.StatusOK {
+		t.Fatalf("dismiss: status %d, want %d", code, http.StatusOK)
+	}
+	if !postVisible() {
+		t.Errorf("post still hidden after its reports were dismissed")
+	}
+}
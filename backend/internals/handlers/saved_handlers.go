@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"sportsin_backend/internals/config"
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/middleware"
+	"sportsin_backend/internals/model"
+	"sportsin_backend/internals/store"
+)
+
+type SaveItemRequest struct {
+	ItemType model.SavedItemType `json:"item_type" binding:"required"`
+	ItemId   string              `json:"item_id" binding:"required"`
+}
+
+// SaveItem godoc
+// @Summary      Save an item
+// @Description  Bookmarks a post, opening or tournament for the authenticated user. Each item can be saved once.
+// @Tags         saved
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      SaveItemRequest  true  "Item to save"
+// @Success      201      {object}  model.SavedItem
+// @Failure      400      {object}  map[string]string
+// @Failure      401      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      409      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /saved [post]
+func SaveItemHandler(repo store.SavedItemStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := middleware.GetUserIDFromContext(c)
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+			return
+		}
+
+		var req SaveItemRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+			return
+		}
+		if !req.ItemType.IsValid() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid item type"})
+			return
+		}
+		if _, err := uuid.Parse(req.ItemId); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid item ID format"})
+			return
+		}
+
+		item := &model.SavedItem{
+			UserId:   userID,
+			ItemType: req.ItemType,
+			ItemId:   req.ItemId,
+		}
+		if err := repo.SaveItem(item); err != nil {
+			httpErr := db.ToHTTPError(err)
+			c.JSON(httpErr.StatusCode, httpErr)
+			return
+		}
+
+		c.JSON(http.StatusCreated, item)
+	}
+}
+
+// UnsaveItem godoc
+// @Summary      Unsave an item
+// @Description  Removes the authenticated user's bookmark on a post, opening or tournament
+// @Tags         saved
+// @Produce      json
+// @Security     BearerAuth
+// @Param        type  path      string  true  "Item type"  Enums(post,opening,tournament)
+// @Param        id    path      string  true  "Item ID"
+// @Success      200   {object}  map[string]string
+// @Failure      400   {object}  map[string]string
+// @Failure      401   {object}  map[string]string
+// @Failure      404   {object}  map[string]string
+// @Failure      500   {object}  map[string]string
+// @Router       /saved/{type}/{id} [delete]
+func UnsaveItemHandler(repo store.SavedItemStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := middleware.GetUserIDFromContext(c)
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+			return
+		}
+
+		itemType := model.SavedItemType(c.Param("type"))
+		if !itemType.IsValid() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid item type"})
+			return
+		}
+		itemID := c.Param("id")
+		if _, err := uuid.Parse(itemID); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid item ID format"})
+			return
+		}
+
+		if err := repo.UnsaveItem(userID, itemType, itemID); err != nil {
+			httpErr := db.ToHTTPError(err)
+			c.JSON(httpErr.StatusCode, httpErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Item unsaved successfully"})
+	}
+}
+
+// GetSavedItems godoc
+// @Summary      List saved items
+// @Description  Returns the authenticated user's saved items, most recently saved first, each with the post, opening or tournament it points at
+// @Tags         saved
+// @Produce      json
+// @Security     BearerAuth
+// @Param        type    query     string  false  "Only return items of this type"  Enums(post,opening,tournament)
+// @Param        limit   query     int     false  "Number of items to return (default 20)"
+// @Param        offset  query     int     false  "Number of items to skip (default 0)"
+// @Param        cursor  query     string  false  "Cursor returned by the previous page"
+// @Success      200     {object}  handlers.Page[model.SavedItem]
+// @Failure      400     {object}  map[string]string
+// @Failure      401     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Router       /saved [get]
+func GetSavedItemsHandler(repo store.SavedStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := middleware.GetUserIDFromContext(c)
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+			return
+		}
+
+		var itemType *model.SavedItemType
+		if raw := c.Query("type"); raw != "" {
+			t := model.SavedItemType(raw)
+			if !t.IsValid() {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid item type"})
+				return
+			}
+			itemType = &t
+		}
+		limit, offset, ok := parsePagination(c)
+		if !ok {
+			return
+		}
+		after, ok := parseCursor(c)
+		if !ok {
+			return
+		}
+
+		items, err := repo.GetSavedItems(userID, itemType, limit, offset, after)
+		if err != nil {
+			httpErr := db.ToHTTPError(err)
+			c.JSON(httpErr.StatusCode, httpErr)
+			return
+		}
+		if err := loadSavedContent(repo, userID, items); err != nil {
+			httpErr := db.ToHTTPError(err)
+			c.JSON(httpErr.StatusCode, httpErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, newPage(items, nextCursor(items, limit, func(item model.SavedItem) (string, string) {
+			return item.CreatedAt, item.Id
+		})))
+	}
+}
+
+// loadSavedContent fills in the post, opening or tournament of each saved
+// item as userID sees it. Posts are loaded in one query; a page holds few
+// enough openings and tournaments to load them one by one.
+func loadSavedContent(repo store.SavedStore, userID string, items []model.SavedItem) error {
+	var postIDs []string
+	for _, item := range items {
+		if item.ItemType == model.SavedItemPost {
+			postIDs = append(postIDs, item.ItemId)
+		}
+	}
+	posts := make(map[string]*model.PostWithComment)
+	if len(postIDs) > 0 {
+		loaded, err := repo.GetPostsWithCommentsByIds(postIDs, userID)
+		if err != nil {
+			return err
+		}
+		for i := range loaded {
+			posts[loaded[i].Id] = &loaded[i]
+		}
+	}
+
+	for i := range items {
+		item := &items[i]
+		switch item.ItemType {
+		case model.SavedItemPost:
+			item.Post = posts[item.ItemId]
+		case model.SavedItemOpening:
+			opening, err := repo.GetOpeningByID(item.ItemId, &userID)
+			if err != nil {
+				log.Printf("ERROR: failed to load saved opening %s: %v", item.ItemId, err)
+				continue
+			}
+			item.Opening = opening
+		case model.SavedItemTournament:
+			tournament, err := repo.GetTournamentDetailsByID(item.ItemId, &userID)
+			if err != nil {
+				log.Printf("ERROR: failed to load saved tournament %s: %v", item.ItemId, err)
+				continue
+			}
+			item.Tournament = tournament
+		}
+	}
+	return nil
+}
+
+// RegisterSavedRoutes registers saving, unsaving and listing bookmarks
+func RegisterSavedRoutes(rg *gin.RouterGroup, cfg *config.Config, repo store.SavedStore) {
+	jwtMiddleware := middleware.NewJWTMiddleware(cfg)
+	r := rg.Group("/saved")
+	r.Use(jwtMiddleware.AuthMiddleware())
+
+	r.POST("", SaveItemHandler(repo))
+	r.GET("", GetSavedItemsHandler(repo))
+	r.DELETE("/:type/:id", UnsaveItemHandler(repo))
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/middleware"
+	"sportsin_backend/internals/store"
+)
+
+type AddUserSkillRequest struct {
+	SportId string `json:"sport_id" binding:"required"`
+}
+
+// GetUserSkills godoc
+// @Summary      List a user's sports
+// @Description  Lists the sports a user plays. These are used to rank the home feed.
+// @Tags         Profile Management
+// @Produce      json
+// @Param        id   path      string  true  "User ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /profile/{id}/skills [get]
+func GetUserSkillsHandler(repo store.UserSkillStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.Param("id")
+		if _, err := uuid.Parse(userID); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
+			return
+		}
+
+		sports, err := repo.GetUserSkills(userID)
+		if err != nil {
+			httpErr := db.ToHTTPError(err)
+			c.JSON(httpErr.StatusCode, httpErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"sports": sports})
+	}
+}
+
+// AddUserSkill godoc
+// @Summary      Add a sport to my profile
+// @Description  Records that the authenticated user plays a sport
+// @Tags         Profile Management
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      AddUserSkillRequest  true  "Sport to add"
+// @Success      201      {object}  map[string]string
+// @Failure      400      {object}  map[string]string
+// @Failure      401      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      409      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /profile/skills [post]
+func AddUserSkillHandler(repo store.UserSkillStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := middleware.GetUserIDFromContext(c)
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+			return
+		}
+
+		var req AddUserSkillRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+			return
+		}
+		if _, err := uuid.Parse(req.SportId); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid sport ID format"})
+			return
+		}
+
+		if err := repo.AddUserSkill(userID, req.SportId); err != nil {
+			httpErr := db.ToHTTPError(err)
+			c.JSON(httpErr.StatusCode, httpErr)
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{"message": "Sport added successfully"})
+	}
+}
+
+// RemoveUserSkill godoc
+// @Summary      Remove a sport from my profile
+// @Description  Removes a sport from the authenticated user's profile
+// @Tags         Profile Management
+// @Produce      json
+// @Security     BearerAuth
+// @Param        sport_id  path      string  true  "Sport ID"
+// @Success      200       {object}  map[string]string
+// @Failure      401       {object}  map[string]string
+// @Failure      404       {object}  map[string]string
+// @Failure      500       {object}  map[string]string
+// @Router       /profile/skills/{sport_id} [delete]
+func RemoveUserSkillHandler(repo store.UserSkillStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := middleware.GetUserIDFromContext(c)
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+			return
+		}
+
+		if err := repo.RemoveUserSkill(userID, c.Param("sport_id")); err != nil {
+			httpErr := db.ToHTTPError(err)
+			c.JSON(httpErr.StatusCode, httpErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Sport removed successfully"})
+	}
+}
@@ -5,11 +5,9 @@ import (
 	"strconv"
 
 	"github.com/gin-gonic/gin"
-	"sportsin_backend/internals/config"
 	"sportsin_backend/internals/db"
-	"sportsin_backend/internals/db/repositories"
-	"sportsin_backend/internals/middleware"
 	"sportsin_backend/internals/model"
+	"sportsin_backend/internals/store"
 )
 
 // GetSports godoc
@@ -23,7 +21,7 @@ import (
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /sports [get]
-func GetSportsHandler(repo *repositories.Repository) gin.HandlerFunc {
+func GetSportsHandler(repo store.SportStore) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		limitStr := ctx.DefaultQuery("limit", "10")
 		limit, err := strconv.Atoi(limitStr)
@@ -75,10 +73,12 @@ type CreateSportRequest struct {
 // @Success 201 {object} model.Sport
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 409 {object} map[string]string
 // @Failure 500 {object} map[string]string
-// @Router /sports [post]
+// @Router /admin/sports [post]
 // @Security BearerAuth
-func CreateSportHandler(repo *repositories.Repository) gin.HandlerFunc {
+func CreateSportHandler(repo store.SportStore) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		var req CreateSportRequest
 		if err := ctx.ShouldBindJSON(&req); err != nil {
@@ -90,7 +90,7 @@ func CreateSportHandler(repo *repositories.Repository) gin.HandlerFunc {
 		var sport model.Sport
 		sport.Name = req.Name
 		sport.Description = req.Description
-		id, err := repo.CreateSport(&sport)
+		id, err := repo.CreateSport(&sport, adminAction(ctx, model.AdminActionCreateSport, "sport", "", nil))
 		if err != nil {
 			httpError := db.ToHTTPError(err)
 			ctx.JSON(httpError.StatusCode, gin.H{
@@ -105,6 +105,100 @@ func CreateSportHandler(repo *repositories.Repository) gin.HandlerFunc {
 	}
 }
 
+type UpdateSportRequest struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+// UpdateSport godoc
+// @Summary Update a sport
+// @Description Rename a sport or change its description. Admin only.
+// @Tags sports
+// @Accept json
+// @Produce json
+// @Param id path string true "Sport ID"
+// @Param sport body UpdateSportRequest true "Fields to update"
+// @Success 200 {object} model.Sport
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/sports/{id} [put]
+// @Security BearerAuth
+func UpdateSportHandler(repo store.SportStore) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req UpdateSportRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid request format",
+			})
+			return
+		}
+		sport, err := repo.GetSportById(ctx.Param("id"))
+		if err != nil {
+			httpError := db.ToHTTPError(err)
+			ctx.JSON(httpError.StatusCode, gin.H{
+				"error": httpError.Message,
+			})
+			return
+		}
+		if req.Name != nil {
+			sport.Name = *req.Name
+		}
+		if req.Description != nil {
+			sport.Description = *req.Description
+		}
+		if err := repo.UpdateSport(sport, adminAction(ctx, model.AdminActionUpdateSport, "sport", sport.Id, nil)); err != nil {
+			httpError := db.ToHTTPError(err)
+			ctx.JSON(httpError.StatusCode, gin.H{
+				"error": httpError.Message,
+			})
+			return
+		}
+		ctx.JSON(http.StatusOK, gin.H{
+			"sport": sport,
+		})
+	}
+}
+
+// DeleteSport godoc
+// @Summary Delete a sport
+// @Description Delete a sport that no tournament, opening, achievement or player skill uses any more. Admin only.
+// @Tags sports
+// @Accept json
+// @Produce json
+// @Param id path string true "Sport ID"
+// @Param request body AdminActionRequest false "Reason for the deletion"
+// @Success 200 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string "The sport is still in use"
+// @Failure 500 {object} map[string]string
+// @Router /admin/sports/{id} [delete]
+// @Security BearerAuth
+func DeleteSportHandler(repo store.SportStore) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		req, ok := bindAdminActionRequest(ctx)
+		if !ok {
+			return
+		}
+		id := ctx.Param("id")
+		if err := repo.DeleteSport(id, adminAction(ctx, model.AdminActionDeleteSport, "sport", id, req.Reason)); err != nil {
+			httpError := db.ToHTTPError(err)
+			ctx.JSON(httpError.StatusCode, gin.H{
+				"error": httpError.Message,
+			})
+			return
+		}
+		ctx.JSON(http.StatusOK, gin.H{
+			"message": "Sport deleted successfully",
+		})
+	}
+}
+
 // GetSportByName godoc
 // @Summary Get a sport by name
 // @Description Get a single sport by its name
@@ -115,7 +209,7 @@ func CreateSportHandler(repo *repositories.Repository) gin.HandlerFunc {
 // @Failure 400 {object} map[string]string
 // @Failure 404 {object} map[string]string
 // @Router /sports/{name} [get]
-func GetSportByNameHandler(repo *repositories.Repository) gin.HandlerFunc {
+func GetSportByNameHandler(repo store.SportStore) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		name := ctx.Param("name")
 		if name == "" {
@@ -139,11 +233,9 @@ func GetSportByNameHandler(repo *repositories.Repository) gin.HandlerFunc {
 
 }
 
-func RegisterSportRoutes(rg *gin.RouterGroup, cfg *config.Config, repo *repositories.Repository) {
+// RegisterSportRoutes registers the public catalogue routes. Changes to the
+// catalogue go through RegisterAdminRoutes.
+func RegisterSportRoutes(rg *gin.RouterGroup, repo store.SportStore) {
 	rg.GET("/sports", GetSportsHandler(repo))
 	rg.GET("/sports/:name", GetSportByNameHandler(repo))
-
-	protected := rg.Group("")
-	protected.Use(middleware.NewJWTMiddleware(cfg).AuthMiddleware())
-	rg.POST("/sports", CreateSportHandler(repo))
 }
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"sportsin_backend/internals/services"
+)
+
+// maxLocalUploadSize caps the body accepted by signed local uploads
+const maxLocalUploadSize = 10 * 1024 * 1024
+
+// LocalUploadHandler godoc
+// @Summary Upload a file to local storage
+// @Description Accepts the raw file body for a URL generated by the local storage backend. Only available when STORAGE_DRIVER=local.
+// @Tags storage
+// @Accept application/octet-stream
+// @Produce json
+// @Param filepath path string true "Object key"
+// @Param expires query int true "Unix expiry time of the signature"
+// @Param signature query string true "Signature generated for the URL"
+// @Success 200 {object} ImageUploadResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /files/{filepath} [put]
+func LocalUploadHandler(local *services.LocalStorage) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := strings.TrimPrefix(c.Param("filepath"), "/")
+
+		err := local.VerifySignature(http.MethodPut, key, c.Query("expires"), c.Query("signature"))
+		if err != nil {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error":   "Forbidden",
+				"message": err.Error(),
+			})
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxLocalUploadSize))
+		if err != nil {
+			var maxErr *http.MaxBytesError
+			if errors.As(err, &maxErr) {
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error":   "Bad Request",
+					"message": "File size must be less than 10MB",
+				})
+				return
+			}
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Bad Request",
+				"message": "Failed to read request body",
+			})
+			return
+		}
+
+		url, err := local.PutObject(c.Request.Context(), key, body, c.ContentType())
+		if err != nil {
+			log.Printf("ERROR: failed to store local upload %s: %v", key, err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Internal Server Error",
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, ImageUploadResponse{
+			ImageURL: url,
+			Message:  "File uploaded successfully",
+		})
+	}
+}
+
+// RegisterLocalStorageRoutes serves files written by the local storage backend
+// and accepts uploads to the signed URLs it hands out
+func RegisterLocalStorageRoutes(rg *gin.RouterGroup, local *services.LocalStorage) {
+	rg.Static(services.LocalFilesRoute, local.Dir())
+	rg.PUT(services.LocalFilesRoute+"/*filepath", LocalUploadHandler(local))
+}
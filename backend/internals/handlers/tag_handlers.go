@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"sportsin_backend/internals/config"
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/middleware"
+	"sportsin_backend/internals/model"
+	"sportsin_backend/internals/store"
+)
+
+const (
+	defaultTrendingWindow = 7 * 24 * time.Hour
+	maxTrendingWindow     = 30 * 24 * time.Hour
+)
+
+// SearchTags godoc
+// @Summary      Autocomplete tags
+// @Description  Returns the tags starting with q that are used by at least one post, most used first. A leading '#' in q is ignored.
+// @Tags         tags
+// @Produce      json
+// @Param        q      query     string  false  "Tag prefix"
+// @Param        limit  query     int     false  "Number of tags to return (default 10, max 50)"
+// @Success      200    {object}  map[string]interface{}
+// @Failure      400    {object}  map[string]string
+// @Failure      500    {object}  map[string]string
+// @Router       /tags [get]
+func SearchTagsHandler(repo store.TagStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit, ok := parseTagLimit(c)
+		if !ok {
+			return
+		}
+		prefix := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(c.Query("q")), "#"))
+
+		tags, err := repo.SearchTags(prefix, limit)
+		if err != nil {
+			httpErr := db.ToHTTPError(err)
+			c.JSON(httpErr.StatusCode, httpErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"tags": tags})
+	}
+}
+
+// GetTrendingTags godoc
+// @Summary      Trending tags
+// @Description  Returns the tags used by the most posts created within the window, most used first
+// @Tags         tags
+// @Produce      json
+// @Param        window  query     string  false  "Time window as a duration such as 24h (default 168h, max 720h)"
+// @Param        limit   query     int     false  "Number of tags to return (default 10, max 50)"
+// @Success      200     {object}  map[string]interface{}
+// @Failure      400     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Router       /tags/trending [get]
+func GetTrendingTagsHandler(repo store.TagStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit, ok := parseTagLimit(c)
+		if !ok {
+			return
+		}
+
+		window := defaultTrendingWindow
+		if raw := c.Query("window"); raw != "" {
+			parsed, err := time.ParseDuration(raw)
+			if err != nil || parsed <= 0 || parsed > maxTrendingWindow {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid window parameter"})
+				return
+			}
+			window = parsed
+		}
+
+		tags, err := repo.GetTrendingTags(time.Now().UTC().Add(-window), limit)
+		if err != nil {
+			httpErr := db.ToHTTPError(err)
+			c.JSON(httpErr.StatusCode, httpErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"tags":   tags,
+			"window": window.String(),
+		})
+	}
+}
+
+// GetPostsByTag godoc
+// @Summary      List posts by tag
+// @Description  Returns the posts carrying a tag, newest first, with their latest comment and whether the authenticated user liked them
+// @Tags         tags
+// @Produce      json
+// @Security     BearerAuth
+// @Param        tag     path      string  true   "Tag, with or without a leading '#'"
+// @Param        limit   query     int     false  "Number of posts to return (default 20)"
+// @Param        offset  query     int     false  "Number of posts to skip (default 0)"
+// @Param        cursor  query     string  false  "Cursor returned by the previous page"
+// @Success      200     {object}  handlers.Page[model.PostWithComment]
+// @Failure      400     {object}  map[string]string
+// @Failure      401     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Router       /tags/{tag}/posts [get]
+func GetPostsByTagHandler(repo store.TagStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := middleware.GetUserIDFromContext(c)
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+			return
+		}
+
+		tag, valid := model.NormalizeTag(c.Param("tag"))
+		if !valid {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tag"})
+			return
+		}
+		limit, offset, ok := parsePagination(c)
+		if !ok {
+			return
+		}
+		after, ok := parseCursor(c)
+		if !ok {
+			return
+		}
+
+		posts, err := repo.GetPostsByTag(tag, limit, offset, after, userID)
+		if err != nil {
+			httpErr := db.ToHTTPError(err)
+			c.JSON(httpErr.StatusCode, httpErr)
+			return
+		}
+
+		next := nextCursor(posts, limit, func(p model.PostWithComment) (string, string) {
+			return postCursorKey(p.Post)
+		})
+		c.JSON(http.StatusOK, newPage(posts, next))
+	}
+}
+
+func parseTagLimit(c *gin.Context) (int, bool) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit <= 0 || limit > 50 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit parameter"})
+		return 0, false
+	}
+	return limit, true
+}
+
+// RegisterTagRoutes registers tag autocomplete, trending tags and the posts
+// of a tag
+func RegisterTagRoutes(rg *gin.RouterGroup, cfg *config.Config, repo store.TagStore) {
+	jwtMiddleware := middleware.NewJWTMiddleware(cfg)
+	r := rg.Group("/tags")
+
+	r.GET("", SearchTagsHandler(repo))
+	r.GET("/trending", GetTrendingTagsHandler(repo))
+	r.GET("/:tag/posts", jwtMiddleware.AuthMiddleware(), GetPostsByTagHandler(repo))
+}
@@ -5,15 +5,16 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"sportsin_backend/internals/config"
 	"sportsin_backend/internals/db"
-	"sportsin_backend/internals/db/repositories"
 	"sportsin_backend/internals/middleware"
 	"sportsin_backend/internals/model"
 	"sportsin_backend/internals/services"
+	"sportsin_backend/internals/store"
 )
 
 type CreateTournamentRequest struct {
@@ -57,6 +58,10 @@ type UpdateParticipantStatusRequest struct {
 	Status model.ParticipationStatus `json:"status" binding:"required" enums:"pending,accepted,rejected"`
 }
 
+type TournamentAnnouncementRequest struct {
+	Content string `json:"content" binding:"required"`
+}
+
 // CreateTournament godoc
 // @Summary      Create a new tournament
 // @Description  Creates a new tournament for the authenticated recruiter (host) with optional banner image
@@ -84,7 +89,7 @@ type UpdateParticipantStatusRequest struct {
 // @Failure      403           {object} object{error=string}      "Only recruiters can create tournaments"
 // @Failure      500           {object} object{error=string}      "Internal server error"
 // @Router       /tournaments [post]
-func CreateTournamentHandler(repo *repositories.Repository, s3Service *services.S3Service) gin.HandlerFunc {
+func CreateTournamentHandler(repo store.TournamentStore, storage *services.StorageService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get user ID from authentication middleware
 		userID, exists := middleware.GetUserIDFromContext(c)
@@ -225,7 +230,7 @@ func CreateTournamentHandler(repo *repositories.Repository, s3Service *services.
 			bannerID := fmt.Sprintf("banner_%d", time.Now().UnixNano())
 
 			// Upload to S3 (we'll need to create this method)
-			bannerURL, err := s3Service.UploadTournamentBanner(ctx, tournament.HostId, bannerID, file, bannerFile)
+			bannerURL, err := storage.UploadTournamentBanner(ctx, tournament.HostId, bannerID, file, bannerFile)
 			if err != nil {
 				c.JSON(http.StatusInternalServerError, gin.H{
 					"error": "Failed to upload banner image",
@@ -240,7 +245,7 @@ func CreateTournamentHandler(repo *repositories.Repository, s3Service *services.
 		if err != nil {
 			if tournament.BannerUrl != nil {
 				ctx := context.Background()
-				s3Service.DeleteTournamentBanner(ctx, *tournament.BannerUrl)
+				storage.DeleteTournamentBanner(ctx, *tournament.BannerUrl)
 			}
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"error": "Failed to create tournament",
@@ -270,7 +275,7 @@ func CreateTournamentHandler(repo *repositories.Repository, s3Service *services.
 // @Failure      401       {object} object{error=string}     "Authentication required"
 // @Failure      500       {object} object{error=string}     "Internal server error"
 // @Router       /tournaments [get]
-func GetTournamentsHandler(repo *repositories.Repository) gin.HandlerFunc {
+func GetTournamentsHandler(repo store.TournamentStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		hostID := c.Query("host_id")
 		sportID := c.Query("sport_id")
@@ -345,7 +350,7 @@ func GetTournamentsHandler(repo *repositories.Repository) gin.HandlerFunc {
 // @Failure      404 {object} object{error=string}    "Tournament not found"
 // @Failure      500 {object} object{error=string}    "Internal server error"
 // @Router       /tournaments/{id} [get]
-func GetTournamentByIDHandler(repo *repositories.Repository) gin.HandlerFunc {
+func GetTournamentByIDHandler(repo store.TournamentStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		tournamentID := c.Param("id")
 
@@ -393,7 +398,7 @@ func GetTournamentByIDHandler(repo *repositories.Repository) gin.HandlerFunc {
 // @Failure      404           {object} object{error=string}      "Tournament not found"
 // @Failure      500           {object} object{error=string}      "Internal server error"
 // @Router       /tournaments/{id} [put]
-func UpdateTournamentHandler(repo *repositories.Repository) gin.HandlerFunc {
+func UpdateTournamentHandler(repo store.TournamentStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		tournamentID := c.Param("id")
 
@@ -405,16 +410,7 @@ func UpdateTournamentHandler(repo *repositories.Repository) gin.HandlerFunc {
 			return
 		}
 
-		// Get user ID from authentication middleware
-		userID, exists := middleware.GetUserIDFromContext(c)
-		if !exists {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error": "User not authenticated",
-			})
-			return
-		}
-
-		// Check if tournament exists and user is the host
+		// Ownership is enforced by middleware.RequireOwnership on the route
 		existingTournament, err := repo.GetTournamentByID(tournamentID)
 		if err != nil {
 			if err == db.ITEM_NOT_FOUND {
@@ -429,13 +425,6 @@ func UpdateTournamentHandler(repo *repositories.Repository) gin.HandlerFunc {
 			return
 		}
 
-		if existingTournament.HostId != userID {
-			c.JSON(http.StatusForbidden, gin.H{
-				"error": "Not authorized to update this tournament",
-			})
-			return
-		}
-
 		// Update only provided fields
 		if req.Title != "" {
 			existingTournament.Title = req.Title
@@ -477,7 +466,7 @@ func UpdateTournamentHandler(repo *repositories.Repository) gin.HandlerFunc {
 			existingTournament.BannerUrl = req.BannerUrl
 		}
 
-		err = repo.UpdateTournament(existingTournament)
+		err = repo.UpdateTournament(existingTournament, nil)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"error": "Failed to update tournament",
@@ -504,42 +493,12 @@ func UpdateTournamentHandler(repo *repositories.Repository) gin.HandlerFunc {
 // @Failure      404           {object} object{error=string}   "Tournament not found"
 // @Failure      500           {object} object{error=string}   "Internal server error"
 // @Router       /tournaments/{id} [delete]
-func DeleteTournamentHandler(repo *repositories.Repository) gin.HandlerFunc {
+func DeleteTournamentHandler(repo store.TournamentStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		tournamentID := c.Param("id")
 
-		// Get user ID from authentication middleware
-		userID, exists := middleware.GetUserIDFromContext(c)
-		if !exists {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error": "User not authenticated",
-			})
-			return
-		}
-
-		// Check if tournament exists and user is the host
-		tournament, err := repo.GetTournamentByID(tournamentID)
-		if err != nil {
-			if err == db.ITEM_NOT_FOUND {
-				c.JSON(http.StatusNotFound, gin.H{
-					"error": "Tournament not found",
-				})
-				return
-			}
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "Failed to retrieve tournament",
-			})
-			return
-		}
-
-		if tournament.HostId != userID {
-			c.JSON(http.StatusForbidden, gin.H{
-				"error": "Not authorized to delete this tournament",
-			})
-			return
-		}
-
-		err = repo.DeleteTournament(tournamentID)
+		// The tournament's existence and ownership are checked by middleware.RequireOwnership
+		err := repo.DeleteTournament(tournamentID, middleware.GetAuditActorFromContext(c))
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"error": "Failed to delete tournament",
@@ -568,7 +527,7 @@ func DeleteTournamentHandler(repo *repositories.Repository) gin.HandlerFunc {
 // @Failure      404           {object} object{error=string}    "Tournament not found"
 // @Failure      500           {object} object{error=string}    "Internal server error"
 // @Router       /tournaments/join [post]
-func JoinTournamentHandler(repo *repositories.Repository) gin.HandlerFunc {
+func JoinTournamentHandler(repo store.TournamentStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		fmt.Printf("DEBUG: JoinTournamentHandler called\n")
 
@@ -660,7 +619,7 @@ func JoinTournamentHandler(repo *repositories.Repository) gin.HandlerFunc {
 
 // LeaveTournament godoc
 // @Summary      Leave tournament
-// @Description  Allows a user to leave a tournament
+// @Description  Allows a user to leave a tournament, which also removes them from its chat channel
 // @Tags         tournaments
 // @Accept       json
 // @Produce      json
@@ -672,7 +631,7 @@ func JoinTournamentHandler(repo *repositories.Repository) gin.HandlerFunc {
 // @Failure      404           {object} object{error=string}   "Tournament or participation not found"
 // @Failure      500           {object} object{error=string}   "Internal server error"
 // @Router       /tournaments/{id}/leave [delete]
-func LeaveTournamentHandler(repo *repositories.Repository) gin.HandlerFunc {
+func LeaveTournamentHandler(repo store.TournamentStore, chats *services.TournamentChatService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		tournamentID := c.Param("id")
 
@@ -698,6 +657,7 @@ func LeaveTournamentHandler(repo *repositories.Repository) gin.HandlerFunc {
 			})
 			return
 		}
+		chats.ParticipantLeft(tournamentID, userID)
 
 		c.JSON(http.StatusOK, gin.H{
 			"message": "Successfully left tournament",
@@ -717,7 +677,7 @@ func LeaveTournamentHandler(repo *repositories.Repository) gin.HandlerFunc {
 // @Failure      404     {object} object{error=string}          "Tournament not found"
 // @Failure      500     {object} object{error=string}          "Internal server error"
 // @Router       /tournaments/{id}/participants [get]
-func GetTournamentParticipantsHandler(repo *repositories.Repository) gin.HandlerFunc {
+func GetTournamentParticipantsHandler(repo store.TournamentStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		tournamentID := c.Param("id")
 		statusStr := c.Query("status")
@@ -747,7 +707,7 @@ func GetTournamentParticipantsHandler(repo *repositories.Repository) gin.Handler
 
 // UpdateParticipantStatus godoc
 // @Summary      Update participant status
-// @Description  Updates the status of a tournament participant (only recruiter host can update)
+// @Description  Updates the status of a tournament participant (only recruiter host can update). Accepted participants are added to the tournament's chat channel and removed again when their status changes.
 // @Tags         tournaments
 // @Accept       json
 // @Produce      json
@@ -762,7 +722,7 @@ func GetTournamentParticipantsHandler(repo *repositories.Repository) gin.Handler
 // @Failure      404           {object} object{error=string}            "Tournament or participant not found"
 // @Failure      500           {object} object{error=string}            "Internal server error"
 // @Router       /tournaments/{id}/participants/status [put]
-func UpdateParticipantStatusHandler(repo *repositories.Repository) gin.HandlerFunc {
+func UpdateParticipantStatusHandler(repo store.TournamentStore, chats *services.TournamentChatService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		tournamentID := c.Param("id")
 
@@ -774,38 +734,8 @@ func UpdateParticipantStatusHandler(repo *repositories.Repository) gin.HandlerFu
 			return
 		}
 
-		// Get user ID from authentication middleware
-		userID, exists := middleware.GetUserIDFromContext(c)
-		if !exists {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error": "User not authenticated",
-			})
-			return
-		}
-
-		// Check if tournament exists and user is the host
-		tournament, err := repo.GetTournamentByID(tournamentID)
-		if err != nil {
-			if err == db.ITEM_NOT_FOUND {
-				c.JSON(http.StatusNotFound, gin.H{
-					"error": "Tournament not found",
-				})
-				return
-			}
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "Failed to retrieve tournament",
-			})
-			return
-		}
-
-		if tournament.HostId != userID {
-			c.JSON(http.StatusForbidden, gin.H{
-				"error": "Not authorized to update participants for this tournament",
-			})
-			return
-		}
-
-		err = repo.UpdateParticipantStatus(req.UserId, tournamentID, req.Status)
+		// The tournament's existence and ownership are checked by middleware.RequireOwnership
+		err := repo.UpdateParticipantStatus(req.UserId, tournamentID, req.Status, middleware.GetAuditActorFromContext(c))
 		if err != nil {
 			if err == db.ITEM_NOT_FOUND {
 				c.JSON(http.StatusNotFound, gin.H{
@@ -818,6 +748,7 @@ func UpdateParticipantStatusHandler(repo *repositories.Repository) gin.HandlerFu
 			})
 			return
 		}
+		chats.ParticipantStatusChanged(tournamentID, req.UserId, req.Status)
 
 		c.JSON(http.StatusOK, gin.H{
 			"message": "Participant status updated successfully",
@@ -838,7 +769,7 @@ func UpdateParticipantStatusHandler(repo *repositories.Repository) gin.HandlerFu
 // @Failure      401           {object} object{error=string}           "Authentication required"
 // @Failure      500           {object} object{error=string}           "Internal server error"
 // @Router       /tournaments/my-tournaments [get]
-func GetUserTournamentsHandler(repo *repositories.Repository) gin.HandlerFunc {
+func GetUserTournamentsHandler(repo store.TournamentStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get user ID from authentication middleware
 		userID, exists := middleware.GetUserIDFromContext(c)
@@ -863,8 +794,65 @@ func GetUserTournamentsHandler(repo *repositories.Repository) gin.HandlerFunc {
 	}
 }
 
+// AnnounceTournament godoc
+// @Summary      Send tournament announcement
+// @Description  Broadcasts an announcement from the host to the tournament's chat channel, which the accepted participants receive as a chat message and a push notification
+// @Tags         tournaments
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        Authorization  header  string                         true  "Bearer JWT token"
+// @Param        id            path    string                         true  "Tournament ID"
+// @Param        request       body    TournamentAnnouncementRequest  true  "Announcement"
+// @Success      201           {object} model.ChatMessage              "Announcement sent"
+// @Failure      400           {object} object{error=string}           "Invalid request"
+// @Failure      401           {object} object{error=string}           "Authentication required"
+// @Failure      403           {object} object{error=string}           "Only the recruiter hosting the tournament can send announcements"
+// @Failure      404           {object} object{error=string}           "Tournament not found"
+// @Failure      500           {object} object{error=string}           "Internal server error"
+// @Router       /tournaments/{id}/announcements [post]
+func AnnounceTournamentHandler(repo store.TournamentStore, chats *services.TournamentChatService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tournamentID := c.Param("id")
+
+		var req TournamentAnnouncementRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid request format: " + err.Error(),
+			})
+			return
+		}
+		content := strings.TrimSpace(req.Content)
+		if content == "" {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Announcement content is required",
+			})
+			return
+		}
+
+		// The tournament's existence and ownership are checked by middleware.RequireOwnership
+		tournament, err := repo.GetTournamentByID(tournamentID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to retrieve tournament",
+			})
+			return
+		}
+
+		msg, err := chats.Announce(tournament, content)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to send announcement",
+			})
+			return
+		}
+
+		c.JSON(http.StatusCreated, msg)
+	}
+}
+
 // RegisterTournamentRoutes registers all tournament-related routes
-func RegisterTournamentRoutes(rg *gin.RouterGroup, cfg *config.Config, repo *repositories.Repository, s3Service *services.S3Service) {
+func RegisterTournamentRoutes(rg *gin.RouterGroup, cfg *config.Config, repo store.TournamentStore, storage *services.StorageService, chats *services.TournamentChatService) {
 	// Initialize JWT middleware
 	jwtMiddleware := middleware.NewJWTMiddleware(cfg)
 
@@ -879,15 +867,19 @@ func RegisterTournamentRoutes(rg *gin.RouterGroup, cfg *config.Config, repo *rep
 		protected.GET("/tournaments", GetTournamentsHandler(repo))
 		protected.GET("/tournaments/:id", GetTournamentByIDHandler(repo))
 
+		recruiter := middleware.RequireRole(model.RecruiterRole)
+		hostsTournament := middleware.RequireOwnership("tournament", "id", tournamentOwner(repo))
+
 		// Tournament management
-		protected.POST("/tournaments", CreateTournamentHandler(repo, s3Service))
-		protected.PUT("/tournaments/:id", UpdateTournamentHandler(repo))
-		protected.DELETE("/tournaments/:id", DeleteTournamentHandler(repo))
+		protected.POST("/tournaments", recruiter, CreateTournamentHandler(repo, storage))
+		protected.PUT("/tournaments/:id", recruiter, hostsTournament, UpdateTournamentHandler(repo))
+		protected.DELETE("/tournaments/:id", recruiter, hostsTournament, DeleteTournamentHandler(repo))
 
 		// Participation management
 		protected.POST("/tournaments/join", JoinTournamentHandler(repo))
-		protected.DELETE("/tournaments/:id/leave", LeaveTournamentHandler(repo))
-		protected.PUT("/tournaments/:id/participants/status", UpdateParticipantStatusHandler(repo))
+		protected.DELETE("/tournaments/:id/leave", LeaveTournamentHandler(repo, chats))
+		protected.PUT("/tournaments/:id/participants/status", recruiter, hostsTournament, UpdateParticipantStatusHandler(repo, chats))
+		protected.POST("/tournaments/:id/announcements", recruiter, hostsTournament, AnnounceTournamentHandler(repo, chats))
 		protected.GET("/tournaments/my-tournaments", GetUserTournamentsHandler(repo))
 	}
 }
@@ -8,9 +8,9 @@ import (
 	"github.com/gin-gonic/gin"
 	"sportsin_backend/internals/config"
 	"sportsin_backend/internals/db"
-	"sportsin_backend/internals/db/repositories"
 	"sportsin_backend/internals/middleware"
 	"sportsin_backend/internals/model"
+	"sportsin_backend/internals/store"
 	"sportsin_backend/internals/utils"
 )
 
@@ -70,7 +70,7 @@ type DeviceTokenRequest struct {
 // @Failure      409                  {object} object{error=string}                   "Profile already exists"
 // @Failure      500                  {object} object{error=string}                   "Internal server error"
 // @Router       /profile [post]
-func CreateProfileHandler(repo *repositories.Repository) gin.HandlerFunc {
+func CreateProfileHandler(repo store.UserProfileStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var req ProfileCreateRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
@@ -235,7 +235,7 @@ func CreateProfileHandler(repo *repositories.Repository) gin.HandlerFunc {
 
 		var referalErr string
 		if req.ReferalCode != "" {
-			if err := repo.ReferUser(userId, req.ReferalCode); err != nil {
+			if err := repo.ReferUser(userId, req.ReferalCode, middleware.GetAuditActorFromContext(c)); err != nil {
 				referalErr = "Failed to refer user"
 			}
 		}
@@ -252,17 +252,17 @@ func CreateProfileHandler(repo *repositories.Repository) gin.HandlerFunc {
 
 // GetProfile godoc
 // @Summary      Get user profile by ID
-// @Description  Retrieves a public user profile by user ID. This endpoint is publicly accessible and returns basic profile information.
+// @Description  Retrieves a public user profile by user ID together with its follower counts. This endpoint is publicly accessible; when called with a token the follow relation to the caller is included.
 // @Tags         Profile Management
 // @Accept       json
 // @Produce      json
 // @Param        id   path      string  true  "User ID (UUID format)"
-// @Success      200  {object}  object{profile=object}     "User profile data"
+// @Success      200  {object}  object{profile=object,follow_stats=model.FollowStats}     "User profile data"
 // @Failure      400  {object}  object{error=string}       "Invalid or missing user ID"
 // @Failure      404  {object}  object{error=string}       "Profile not found"
 // @Failure      500  {object}  object{error=string}       "Internal server error"
 // @Router       /profile/{id} [get]
-func GetProfileHandler(repo *repositories.Repository) gin.HandlerFunc {
+func GetProfileHandler(repo store.ProfileStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		userId := c.Param("id")
 		if userId == "" {
@@ -278,8 +278,18 @@ func GetProfileHandler(repo *repositories.Repository) gin.HandlerFunc {
 			c.JSON(httpErr.StatusCode, httpErr)
 			return
 		}
+
+		viewerId, _ := middleware.GetUserIDFromContext(c)
+		stats, err := repo.GetFollowStats(userId, viewerId)
+		if err != nil {
+			httpErr := db.ToHTTPError(err)
+			c.JSON(httpErr.StatusCode, httpErr)
+			return
+		}
+
 		c.JSON(http.StatusOK, gin.H{
-			"profile": profile,
+			"profile":      profile,
+			"follow_stats": stats,
 		})
 	}
 }
@@ -292,12 +302,12 @@ func GetProfileHandler(repo *repositories.Repository) gin.HandlerFunc {
 // @Produce      json
 // @Security     BearerAuth
 // @Param        Authorization  header  string  true  "Bearer JWT token"
-// @Success      200            {object} object{profile=object}     "Current user's complete profile data"
+// @Success      200            {object} object{profile=object,follow_stats=model.FollowStats}     "Current user's complete profile data"
 // @Failure      401            {object} object{error=string}       "Authentication required"
 // @Failure      404            {object} object{error=string}       "Profile not found"
 // @Failure      500            {object} object{error=string}       "Internal server error"
 // @Router       /profile/me [get]
-func GetMyProfileHandler(repo *repositories.Repository) gin.HandlerFunc {
+func GetMyProfileHandler(repo store.ProfileStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get user email from authenticated context
 		email, exists := middleware.GetEmailFromContext(c)
@@ -323,8 +333,16 @@ func GetMyProfileHandler(repo *repositories.Repository) gin.HandlerFunc {
 			return
 		}
 
+		stats, err := repo.GetFollowStats(user.Id, "")
+		if err != nil {
+			httpErr := db.ToHTTPError(err)
+			c.JSON(httpErr.StatusCode, httpErr)
+			return
+		}
+
 		c.JSON(http.StatusOK, gin.H{
-			"profile": profile,
+			"profile":      profile,
+			"follow_stats": stats,
 		})
 	}
 }
@@ -344,7 +362,7 @@ func GetMyProfileHandler(repo *repositories.Repository) gin.HandlerFunc {
 // @Failure      404                  {object} object{error=string}                   "Profile not found"
 // @Failure      500                  {object} object{error=string}                   "Internal server error"
 // @Router       /profile [put]
-func UpdateProfileHandler(repo *repositories.Repository) gin.HandlerFunc {
+func UpdateProfileHandler(repo store.UserProfileStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get user email from authenticated context
 		email, exists := middleware.GetEmailFromContext(c)
@@ -505,7 +523,7 @@ func UpdateProfileHandler(repo *repositories.Repository) gin.HandlerFunc {
 	}
 }
 
-func GenerateReferalCodeHandler(repo *repositories.Repository) gin.HandlerFunc {
+func GenerateReferalCodeHandler(repo store.UserProfileStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		userId, ok := middleware.GetUserIDFromContext(c)
 		if !ok {
@@ -553,7 +571,7 @@ func GenerateReferalCodeHandler(repo *repositories.Repository) gin.HandlerFunc {
 	}
 }
 
-func RegisterDeviceTokenHandler(repo *repositories.Repository) gin.HandlerFunc {
+func RegisterDeviceTokenHandler(repo store.UserProfileStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var req DeviceTokenRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
@@ -584,7 +602,7 @@ func RegisterDeviceTokenHandler(repo *repositories.Repository) gin.HandlerFunc {
 }
 
 // RegisterProfileRoutes registers all profile-related routes
-func RegisterProfileRoutes(rg *gin.RouterGroup, cfg *config.Config, repo *repositories.Repository) {
+func RegisterProfileRoutes(rg *gin.RouterGroup, cfg *config.Config, repo store.ProfileStore) {
 	jwtMiddleware := middleware.NewJWTMiddleware(cfg)
 	r := rg.Group("/profile")
 
@@ -597,9 +615,12 @@ func RegisterProfileRoutes(rg *gin.RouterGroup, cfg *config.Config, repo *reposi
 	protected.GET("/me", GetMyProfileHandler(repo))
 	protected.GET("/referal", GenerateReferalCodeHandler(repo))
 	protected.POST("/device-token", RegisterDeviceTokenHandler(repo))
+	protected.POST("/skills", AddUserSkillHandler(repo))
+	protected.DELETE("/skills/:sport_id", RemoveUserSkillHandler(repo))
 
 	// Public route for getting profiles by ID
-	r.GET("/:id", GetProfileHandler(repo))
+	r.GET("/:id", jwtMiddleware.OptionalAuthMiddleware(), GetProfileHandler(repo))
+	r.GET("/:id/skills", GetUserSkillsHandler(repo))
 }
 
 // Helper function to validate level values
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"context"
 	"crypto/rsa"
 	"encoding/base64"
 	"encoding/json"
@@ -30,6 +31,37 @@ type JWK struct {
 	Alg string `json:"alg"`
 }
 
+// RevocationChecker reports whether an otherwise valid token has been
+// revoked, e.g. by a logout.
+type RevocationChecker interface {
+	IsRevoked(ctx context.Context, claims jwt.MapClaims) (bool, error)
+}
+
+// revocations is shared by every JWTMiddleware; nil disables the check.
+var revocations RevocationChecker
+
+// SetRevocationChecker makes AuthMiddleware reject revoked tokens. It must be
+// called before the server starts handling requests.
+func SetRevocationChecker(checker RevocationChecker) {
+	revocations = checker
+}
+
+// localKey verifies the tokens of the built-in local provider, which signs
+// them in this process, so its key is handed over directly instead of being
+// fetched from the provider's own JWKS endpoint.
+var localKey struct {
+	kid string
+	key *rsa.PublicKey
+}
+
+// SetLocalSigningKey gives AuthMiddleware the verification key of the local
+// provider. It must be called before the server starts handling requests
+// when AUTH_PROVIDER is local.
+func SetLocalSigningKey(kid string, key *rsa.PublicKey) {
+	localKey.kid = kid
+	localKey.key = key
+}
+
 type JWTMiddleware struct {
 	config   *config.Config
 	jwkCache map[string]*rsa.PublicKey
@@ -42,9 +74,30 @@ func NewJWTMiddleware(cfg *config.Config) *JWTMiddleware {
 	}
 }
 
-func (m *JWTMiddleware) fetchJWKS() (*JWKSet, error) {
-	jwksURL := fmt.Sprintf("https://cognito-idp.%s.amazonaws.com/%s/.well-known/jwks.json",
+// usesLocalAuth reports whether tokens are issued by the built-in local
+// provider instead of Cognito
+func (m *JWTMiddleware) usesLocalAuth() bool {
+	return m.config.AUTH_PROVIDER == "local"
+}
+
+func (m *JWTMiddleware) issuer() string {
+	if m.usesLocalAuth() {
+		return strings.TrimRight(m.config.LOCAL_AUTH_ISSUER, "/")
+	}
+	return fmt.Sprintf("https://cognito-idp.%s.amazonaws.com/%s",
 		m.config.AWS_REGION, m.config.COGNITO_USER_POOL_ID)
+}
+
+func (m *JWTMiddleware) audience() string {
+	if m.usesLocalAuth() {
+		return m.issuer()
+	}
+	return m.config.COGNITO_CLIENT_ID
+}
+
+// fetchJWKS downloads the Cognito user pool's signing keys
+func (m *JWTMiddleware) fetchJWKS() (*JWKSet, error) {
+	jwksURL := m.issuer() + "/.well-known/jwks.json"
 
 	resp, err := http.Get(jwksURL)
 	if err != nil {
@@ -66,6 +119,16 @@ func (m *JWTMiddleware) fetchJWKS() (*JWKSet, error) {
 
 func (m *JWTMiddleware) getPublicKey(kid string) (*rsa.PublicKey, error) {
 	log.Printf("[AUTH] Token header kid: %s", kid)
+	if m.usesLocalAuth() {
+		if localKey.key == nil {
+			return nil, errors.New("local signing key is not configured")
+		}
+		if kid != localKey.kid {
+			log.Printf("[AUTH] kid does not match the local signing key: %s", kid)
+			return nil, errors.New("key not found")
+		}
+		return localKey.key, nil
+	}
 	if key, exists := m.jwkCache[kid]; exists {
 		log.Printf("[AUTH] Found kid in cache: %s", kid)
 		return key, nil
@@ -187,13 +250,12 @@ func (m *JWTMiddleware) validateToken(tokenString string) (jwt.MapClaims, error)
 	}
 
 	if aud, ok := claims["aud"].(string); ok {
-		if aud != m.config.COGNITO_CLIENT_ID {
+		if aud != m.audience() {
 			return nil, errors.New("invalid audience")
 		}
 	}
 
-	expectedIssuer := fmt.Sprintf("https://cognito-idp.%s.amazonaws.com/%s",
-		m.config.AWS_REGION, m.config.COGNITO_USER_POOL_ID)
+	expectedIssuer := m.issuer()
 	if iss, ok := claims["iss"].(string); ok {
 		if iss != expectedIssuer {
 			return nil, errors.New("invalid issuer")
@@ -243,6 +305,22 @@ func (m *JWTMiddleware) AuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
+		if revocations != nil {
+			revoked, err := revocations.IsRevoked(c.Request.Context(), claims)
+			if err != nil {
+				log.Printf("[AUTH] Revocation check failed: %v", err)
+				c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Unable to verify session"})
+				c.Abort()
+				return
+			}
+			if revoked {
+				log.Println("[AUTH] Token has been revoked")
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Token has been revoked"})
+				c.Abort()
+				return
+			}
+		}
+
 		email, ok := claims["email"].(string)
 		if !ok {
 			log.Println("[AUTH] Email not found in token claims")
@@ -278,6 +356,20 @@ func (m *JWTMiddleware) AuthMiddleware() gin.HandlerFunc {
 	}
 }
 
+// OptionalAuthMiddleware authenticates requests that carry an Authorization
+// header and lets anonymous ones through, for public routes that show more
+// to signed-in users. A bad token is still rejected.
+func (m *JWTMiddleware) OptionalAuthMiddleware() gin.HandlerFunc {
+	authenticate := m.AuthMiddleware()
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			c.Next()
+			return
+		}
+		authenticate(c)
+	}
+}
+
 func GetEmailFromContext(c *gin.Context) (string, bool) {
 	email, exists := c.Get("email")
 	if !exists {
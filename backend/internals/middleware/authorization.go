@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/model"
+)
+
+// OwnerLookup returns the user ID that owns the resource with the given ID.
+// A missing resource should be reported as a db.NotFoundError.
+type OwnerLookup func(resourceID string) (string, error)
+
+// RequireRole aborts with 403 unless the authenticated user has one of the
+// given roles. It must run after AuthMiddleware.
+func RequireRole(roles ...model.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, exists := GetRoleFromContext(c)
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Role not found in token"})
+			c.Abort()
+			return
+		}
+
+		for _, allowed := range roles {
+			if model.Role(role) == allowed {
+				c.Next()
+				return
+			}
+		}
+
+		log.Printf("[AUTH] Role %q not allowed for %s %s", role, c.Request.Method, c.FullPath())
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		c.Abort()
+	}
+}
+
+// RequireOwnership aborts unless the authenticated user owns the resource
+// named by the :param path parameter. It must run after AuthMiddleware.
+func RequireOwnership(resource, param string, owner OwnerLookup) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := GetUserIDFromContext(c)
+		if !exists || userID == "" {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+			c.Abort()
+			return
+		}
+
+		resourceID := c.Param(param)
+		if resourceID == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Resource ID is required"})
+			c.Abort()
+			return
+		}
+
+		ownerID, err := owner(resourceID)
+		if err != nil {
+			httpErr := db.ToHTTPError(err)
+			c.JSON(httpErr.StatusCode, httpErr)
+			c.Abort()
+			return
+		}
+
+		if ownerID != userID {
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not own this " + resource})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
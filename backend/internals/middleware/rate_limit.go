@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+)
+
+// RateLimiter counts requests per client IP in fixed windows. Counts are
+// kept in Redis so that every API instance shares them.
+type RateLimiter struct {
+	client *redis.Client
+}
+
+func NewRateLimiter(client *redis.Client) *RateLimiter {
+	return &RateLimiter{client: client}
+}
+
+// Limit allows each client IP limit requests per window to the routes it
+// guards and answers 429 beyond that. Routes sharing a scope share the
+// count. A nil RateLimiter lets every request through, as does a Redis
+// failure, which is logged: the limit protects the routes but must not take
+// them down.
+func (l *RateLimiter) Limit(scope string, limit int, window time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if l == nil {
+			c.Next()
+			return
+		}
+
+		ctx := c.Request.Context()
+		windowStart := time.Now().Truncate(window).Unix()
+		key := "ratelimit:" + scope + ":" + c.ClientIP() + ":" + strconv.FormatInt(windowStart, 10)
+
+		pipe := l.client.TxPipeline()
+		count := pipe.Incr(ctx, key)
+		pipe.Expire(ctx, key, window)
+		if _, err := pipe.Exec(ctx); err != nil {
+			log.Printf("[RATE LIMIT] Failed to count %s request: %v", scope, err)
+			c.Next()
+			return
+		}
+
+		if count.Val() > int64(limit) {
+			retryAfter := time.Until(time.Unix(windowStart, 0).Add(window))
+			c.Header("Retry-After", strconv.Itoa(int(retryAfter.Seconds())+1))
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": "Too many requests. Please try again later"})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
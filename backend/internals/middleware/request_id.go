@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"sportsin_backend/internals/model"
+)
+
+// RequestIDHeader carries the request ID in both directions
+const RequestIDHeader = "X-Request-ID"
+
+// validRequestID limits what a client may pass in, since the value ends up in
+// logs and in the audit trail
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// RequestID tags every request with an ID. An ID sent by the client or a
+// proxy is reused, otherwise a new one is generated. The ID is echoed back in
+// the response headers.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if !validRequestID.MatchString(requestID) {
+			requestID = uuid.New().String()
+		}
+
+		c.Set("requestID", requestID)
+		c.Header(RequestIDHeader, requestID)
+		c.Next()
+	}
+}
+
+func GetRequestIDFromContext(c *gin.Context) (string, bool) {
+	requestID, exists := c.Get("requestID")
+	if !exists {
+		return "", false
+	}
+	requestIDStr, ok := requestID.(string)
+	return requestIDStr, ok
+}
+
+// GetAuditActorFromContext returns the authenticated user and request ID to
+// record with a change
+func GetAuditActorFromContext(c *gin.Context) model.AuditActor {
+	userID, _ := GetUserIDFromContext(c)
+	requestID, _ := GetRequestIDFromContext(c)
+	return model.AuditActor{UserId: userID, RequestId: requestID}
+}
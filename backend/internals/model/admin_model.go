@@ -3,3 +3,31 @@ package model
 type Admin struct {
 	UserDetails
 }
+
+type AdminActionType string
+
+const (
+	AdminActionSuspendUser       AdminActionType = "suspend_user"
+	AdminActionReinstateUser     AdminActionType = "reinstate_user"
+	AdminActionDeletePost        AdminActionType = "delete_post"
+	AdminActionDeleteComment     AdminActionType = "delete_comment"
+	AdminActionDeleteAchievement AdminActionType = "delete_achievement"
+	AdminActionCloseOpening      AdminActionType = "close_opening"
+	AdminActionCloseTournament   AdminActionType = "close_tournament"
+	AdminActionCreateSport       AdminActionType = "create_sport"
+	AdminActionUpdateSport       AdminActionType = "update_sport"
+	AdminActionDeleteSport       AdminActionType = "delete_sport"
+	AdminActionActionReport      AdminActionType = "action_report"
+	AdminActionDismissReport     AdminActionType = "dismiss_report"
+)
+
+// AdminAction is one entry in the audit trail of the /admin endpoints
+type AdminAction struct {
+	Id         string          `json:"id"`
+	AdminId    string          `json:"admin_id"`
+	Action     AdminActionType `json:"action"`
+	TargetType string          `json:"target_type"`
+	TargetId   string          `json:"target_id"`
+	Reason     *string         `json:"reason,omitempty"`
+	CreatedAt  string          `json:"created_at"`
+}
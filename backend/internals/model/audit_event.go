@@ -0,0 +1,41 @@
+package model
+
+import "encoding/json"
+
+type AuditAction string
+
+const (
+	AuditApplicationStatusChanged AuditAction = "application_status_changed"
+	AuditParticipantStatusChanged AuditAction = "participant_status_changed"
+	AuditTournamentDeleted        AuditAction = "tournament_deleted"
+	AuditOpeningDeleted           AuditAction = "opening_deleted"
+	AuditReferralRewarded         AuditAction = "referral_rewarded"
+)
+
+// AuditActor identifies who made a change and the request it was made in
+type AuditActor struct {
+	UserId    string
+	RequestId string
+}
+
+// AuditEvent records a sensitive state change together with the affected
+// row as it was before and after the change
+type AuditEvent struct {
+	Id         string          `json:"id"`
+	ActorId    string          `json:"actor_id"`
+	Action     AuditAction     `json:"action"`
+	EntityType string          `json:"entity_type"`
+	EntityId   string          `json:"entity_id"`
+	Before     json.RawMessage `json:"before,omitempty" swaggertype:"object"`
+	After      json.RawMessage `json:"after,omitempty" swaggertype:"object"`
+	RequestId  string          `json:"request_id,omitempty"`
+	CreatedAt  string          `json:"created_at"`
+}
+
+// AuditEventFilter represents the filter criteria for querying audit events
+type AuditEventFilter struct {
+	ActorId    *string      `json:"actor_id,omitempty"`
+	Action     *AuditAction `json:"action,omitempty"`
+	EntityType *string      `json:"entity_type,omitempty"`
+	EntityId   *string      `json:"entity_id,omitempty"`
+}
@@ -4,6 +4,14 @@ type ChatMessage struct {
 	AppModel
 	ChatRoomId string `json:"chat_room_id"`
 	SenderId   string `json:"sender_id"`
-	Read       bool   `json:"read"`
-	Message    string `json:"message"`
+	// Read is set once every member other than the sender has read the room
+	// past the message
+	Read    bool   `json:"read"`
+	Message string `json:"message"`
+	// ClientMessageId is the ID the sending client generated for the
+	// message, used to store a resent message only once
+	ClientMessageId string `json:"client_message_id,omitempty"`
+	// Announcement is set on the messages a host broadcasts to the channel
+	// of a tournament
+	Announcement bool `json:"announcement,omitempty"`
 }
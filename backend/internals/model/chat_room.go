@@ -4,7 +4,54 @@ import "database/sql"
 
 type ChatRoom struct {
 	AppModel
-	User1         string         `json:"user_1"`          // User ID of the first user in the chat room
-	User2         string         `json:"user_2"`          // User ID of the second user in the chat room
+	User1         string         `json:"user_1"`          // User ID of the first user in a one-to-one chat room, empty for groups
+	User2         string         `json:"user_2"`          // User ID of the second user in a one-to-one chat room, empty for groups
 	LastMessageAt sql.NullString `json:"last_message_at"` // Timestamp of the last message in the chat room
+	IsGroup       bool           `json:"is_group"`
+	Name          *string        `json:"name,omitempty"`
+	AvatarUrl     *string        `json:"avatar_url,omitempty"`
+	// TournamentId is set on the channel of a tournament, whose members
+	// follow the tournament's accepted participants
+	TournamentId *string `json:"tournament_id,omitempty"`
+	// UnreadCount is the number of messages the requesting user has not
+	// read, only set when listing the rooms of a user
+	UnreadCount int `json:"unread_count"`
+	// Members is only loaded when a single room is requested
+	Members []ChatRoomMember `json:"members,omitempty"`
+}
+
+// ChatMemberRole is what a member may do in a group chat room. Owners can do
+// everything, admins can invite, remove members and edit the room, and
+// members can only chat and leave. Both users of a one-to-one room are
+// members.
+type ChatMemberRole string
+
+const (
+	ChatRoleOwner  ChatMemberRole = "owner"
+	ChatRoleAdmin  ChatMemberRole = "admin"
+	ChatRoleMember ChatMemberRole = "member"
+)
+
+func (r ChatMemberRole) IsValid() bool {
+	switch r {
+	case ChatRoleOwner, ChatRoleAdmin, ChatRoleMember:
+		return true
+	}
+	return false
+}
+
+// CanManage reports whether the role can invite, remove members and edit the
+// room
+func (r ChatMemberRole) CanManage() bool {
+	return r == ChatRoleOwner || r == ChatRoleAdmin
+}
+
+type ChatRoomMember struct {
+	RoomId   string         `json:"room_id"`
+	UserId   string         `json:"user_id"`
+	Role     ChatMemberRole `json:"role"`
+	JoinedAt string         `json:"joined_at"`
+	// LastReadAt is when the member last read the room, nil until they
+	// first do. Messages sent after it are unread for them.
+	LastReadAt *string `json:"last_read_at,omitempty"`
 }
@@ -1,5 +1,7 @@
 package model
 
+import "fmt"
+
 type Comment struct {
 	Id        string  `json:"id"`
 	UserId    string  `json:"user_id"`
@@ -15,6 +17,33 @@ type CommentResponse struct {
 	Replies         []Comment `json:"replies,omitempty"`
 	ReplyCount      int       `json:"reply_count"`
 	TotalReplyCount int       `json:"total_reply_count"`
+	LikeCount       int       `json:"like_count"`
+	UserLiked       bool      `json:"user_liked"`
+}
+
+// CommentSort is the order of the top-level comments of a post
+type CommentSort string
+
+const (
+	// CommentSortOldest lists comments in the order they were written
+	CommentSortOldest CommentSort = "oldest"
+	// CommentSortNewest lists the most recent comments first
+	CommentSortNewest CommentSort = "newest"
+	// CommentSortTop lists the most liked comments first
+	CommentSortTop CommentSort = "top"
+)
+
+func ParseCommentSort(sort string) (CommentSort, error) {
+	switch sort {
+	case "", "oldest":
+		return CommentSortOldest, nil
+	case "newest":
+		return CommentSortNewest, nil
+	case "top":
+		return CommentSortTop, nil
+	}
+
+	return "", fmt.Errorf("invalid comment sort: %s", sort)
 }
 
 type CreateCommentRequest struct {
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// Credential is the login record kept by the local auth provider. Cognito
+// users never get one.
+type Credential struct {
+	UserId        string    `json:"user_id"`
+	Email         string    `json:"email"`
+	Role          Role      `json:"role"`
+	PasswordHash  string    `json:"-"`
+	Confirmed     bool      `json:"confirmed"`
+	Code          string    `json:"-"` // pending verification or reset code
+	CodeExpiresAt time.Time `json:"-"`
+	CreatedAt     string    `json:"created_at"`
+	UpdatedAt     string    `json:"updated_at"`
+}
@@ -0,0 +1,55 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"sportsin_backend/internals/db"
+)
+
+// Cursor marks the last row of a page in a list ordered by a timestamp and
+// then by ID. At is the row's sort timestamp: created_at for most lists,
+// sent_at for chat messages and the date of an achievement. Clients only
+// see it encoded, as an opaque string.
+type Cursor struct {
+	At string `json:"a"`
+	Id string `json:"i"`
+}
+
+// NewCursor returns the cursor positioned after the row (at, id)
+func NewCursor(at, id string) *Cursor {
+	return &Cursor{At: at, Id: id}
+}
+
+// Encode returns the opaque form of the cursor handed to clients
+func (c *Cursor) Encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// DecodeCursor parses a cursor produced by Encode. Cursors that were not are
+// rejected with a validation error rather than reaching the database.
+func DecodeCursor(cursor string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, db.NewValidationError("cursor", "invalid cursor")
+	}
+	var decoded Cursor
+	if err := json.Unmarshal(raw, &decoded); err != nil || !validCursorTime(decoded.At) {
+		return nil, db.NewValidationError("cursor", "invalid cursor")
+	}
+	if _, err := uuid.Parse(decoded.Id); err != nil {
+		return nil, db.NewValidationError("cursor", "invalid cursor")
+	}
+	return &decoded, nil
+}
+
+func validCursorTime(at string) bool {
+	if _, err := time.Parse(time.RFC3339Nano, at); err == nil {
+		return true
+	}
+	_, err := time.Parse(time.DateOnly, at)
+	return err == nil
+}
@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+// FeedCandidate carries the ranking signals of one post for one viewer.
+// Engagement is counted as of FeedQuery.Until.
+type FeedCandidate struct {
+	PostId         string
+	UserId         string
+	CreatedAt      time.Time
+	LikeCount      int // reactions made by Until
+	CommentCount   int
+	RecentComments int  // comments made inside the velocity window
+	FromFollowed   bool // the viewer follows the author, or is the author
+	SharesSport    bool // the author plays one of the viewer's sports
+}
+
+// FeedQuery bounds the posts considered for a feed. Of the posts created in
+// (Since, Until], up to PerSignal are taken for each signal: the newest by
+// followed authors, the newest by authors sharing a sport, and the most
+// reacted to and commented on by Until. Comments made in
+// (CommentsSince, Until] count towards the comment velocity.
+type FeedQuery struct {
+	Since         time.Time
+	Until         time.Time
+	CommentsSince time.Time
+	PerSignal     int
+}
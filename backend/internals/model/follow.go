@@ -0,0 +1,31 @@
+package model
+
+// Follow is a one-way follow from FollowerId to FollowingId. It corresponds
+// to the "Follow" table.
+type Follow struct {
+	FollowerId  string `json:"follower_id"`
+	FollowingId string `json:"following_id"`
+	CreatedAt   string `json:"created_at"`
+}
+
+// FollowUser is an entry in a followers or following list. IsMutual is set
+// when the owner of the list and this user follow each other.
+type FollowUser struct {
+	UserId         string  `json:"user_id"`
+	Username       string  `json:"username"`
+	Name           string  `json:"name"`
+	ProfilePicture *string `json:"profile_picture,omitempty"`
+	Role           Role    `json:"role"`
+	IsMutual       bool    `json:"is_mutual"`
+	FollowedAt     string  `json:"followed_at"`
+}
+
+// FollowStats are the follower counts shown on a profile. The relation
+// flags describe the viewer and are left out for anonymous requests.
+type FollowStats struct {
+	FollowersCount int   `json:"followers_count"`
+	FollowingCount int   `json:"following_count"`
+	IsFollowing    *bool `json:"is_following,omitempty"`
+	FollowsYou     *bool `json:"follows_you,omitempty"`
+	IsMutual       *bool `json:"is_mutual,omitempty"`
+}
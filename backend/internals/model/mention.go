@@ -0,0 +1,53 @@
+package model
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Mention records that a post, or a comment on it when CommentId is set,
+// mentions a user by @username. It corresponds to the "Mention" table.
+type Mention struct {
+	Id              string  `json:"id"`
+	PostId          string  `json:"post_id"`
+	CommentId       *string `json:"comment_id,omitempty"`
+	MentionedUserId string  `json:"mentioned_user_id"`
+	AuthorId        string  `json:"author_id"`
+	CreatedAt       string  `json:"created_at"`
+}
+
+// MentionedUser is a user newly mentioned by a post or comment, with the
+// push endpoint used to notify them.
+type MentionedUser struct {
+	UserId         string `json:"user_id"`
+	Username       string `json:"username"`
+	SnsEndpointArn string `json:"-"`
+}
+
+// MaxMentions caps the number of users a single post or comment can
+// mention. Further mentions are ignored.
+const MaxMentions = 20
+
+// mentionPattern matches @username at the start of the text or after a
+// character that cannot be part of a username, so e-mail addresses are not
+// taken for mentions.
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w.@])@([\w.]+)`)
+
+// ParseMentions returns the usernames mentioned in content, in order of
+// first appearance and without duplicates.
+func ParseMentions(content string) []string {
+	usernames := []string{}
+	seen := make(map[string]bool)
+	for _, match := range mentionPattern.FindAllStringSubmatch(content, -1) {
+		username := strings.TrimRight(match[1], ".")
+		if username == "" || seen[username] {
+			continue
+		}
+		seen[username] = true
+		usernames = append(usernames, username)
+		if len(usernames) == MaxMentions {
+			break
+		}
+	}
+	return usernames
+}
@@ -25,3 +25,20 @@ const (
 	OpeningStatusOpen   OpeningStatus = "open"
 	OpeningStatusClosed OpeningStatus = "closed"
 )
+
+// OpeningFilter represents the filter criteria for searching openings
+type OpeningFilter struct {
+	SportName          *string        `json:"sport_name,omitempty"`
+	Status             *OpeningStatus `json:"status,omitempty"`
+	MinAge             *int           `json:"min_age,omitempty"`
+	MaxAge             *int           `json:"max_age,omitempty"`
+	MinSalary          *int           `json:"min_salary,omitempty"`
+	MaxSalary          *int           `json:"max_salary,omitempty"`
+	CountryRestriction *string        `json:"country_restriction,omitempty"`
+	Country            *string        `json:"country,omitempty"`
+	State              *string        `json:"state,omitempty"`
+	City               *string        `json:"city,omitempty"`
+	CompanyName        *string        `json:"company_name,omitempty"`
+	Position           *string        `json:"position,omitempty"`
+	Applied            *bool          `json:"applied,omitempty"`
+}
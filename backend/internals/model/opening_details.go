@@ -7,4 +7,5 @@ type OpeningDetails struct {
 	Address           *SAddress          `json:"address"`
 	Applied           bool               `json:"applied"`
 	ApplicationStatus *ApplicationStatus `json:"application_status,omitempty"`
+	Saved             bool               `json:"saved"`
 }
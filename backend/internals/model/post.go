@@ -4,19 +4,57 @@ import "time"
 
 type Post struct {
 	AppModel
-	UserId    string      `json:"user_id"`
-	Content   string      `json:"content"`
-	Tags      string      `json:"tags"` // Store as comma-separated string to match DB
-	LikeCount int         `json:"like_count"`
-	Images    []PostImage `json:"images,omitempty"`
+	UserId     string      `json:"user_id"`
+	Content    string      `json:"content"`
+	Tags       []string    `json:"tags"`
+	LikeCount  int         `json:"like_count"`
+	Images     []PostImage `json:"images,omitempty"`
+	Kind       PostKind    `json:"kind"`
+	RepostOfId *string     `json:"repost_of_id,omitempty"`
+	RepostOf   *SharedPost `json:"repost_of,omitempty"`
+	ShareCount int         `json:"share_count"`
 }
 
+// PostKind tells an original post from a share of another post
+type PostKind string
+
+const (
+	PostKindPost PostKind = "post"
+	// PostKindRepost is a plain share, with no content of its own
+	PostKindRepost PostKind = "repost"
+	// PostKindQuote is a share with the sharer's commentary as content
+	PostKindQuote PostKind = "quote"
+)
+
+// SharedPost is the post shown inside a repost or quote. When the original
+// has been deleted only Deleted is set, leaving a tombstone.
+type SharedPost struct {
+	Id        string `json:"id,omitempty"`
+	UserId    string `json:"user_id,omitempty"`
+	Content   string `json:"content,omitempty"`
+	CreatedAt string `json:"created_at,omitempty"`
+	Deleted   bool   `json:"deleted"`
+}
+
+// RepostRequest is the body of a request to share a post. Leaving Content
+// empty makes a plain repost, otherwise a quote.
+type RepostRequest struct {
+	Content string `json:"content,omitempty"`
+}
+
+// PostWithComment is a post as listed in feeds. LikeCount counts every
+// reaction and Reactions breaks it down by type. UserLiked is set when the
+// viewer reacted in any way, UserReaction says how, and Saved is set when
+// the viewer bookmarked the post.
 type PostWithComment struct {
 	Post
-	LatestComment *Comment    `json:"latest_comment,omitempty"`
-	TotalComments int         `json:"total_comments"`
-	UserLiked     bool        `json:"user_liked"`
-	Images        []PostImage `json:"images,omitempty"`
+	LatestComment *Comment             `json:"latest_comment,omitempty"`
+	TotalComments int                  `json:"total_comments"`
+	UserLiked     bool                 `json:"user_liked"`
+	Reactions     map[ReactionType]int `json:"reactions"`
+	UserReaction  *ReactionType        `json:"user_reaction,omitempty"`
+	Saved         bool                 `json:"saved"`
+	Images        []PostImage          `json:"images,omitempty"`
 }
 
 type PostWithCommentsResponse struct {
@@ -35,13 +73,16 @@ type UpdatePostRequest struct {
 }
 
 type PostResponse struct {
-	Id        string      `json:"id"`
-	UserId    string      `json:"user_id"`
-	Content   string      `json:"content"`
-	Tags      []string    `json:"tags"`
-	Images    []PostImage `json:"images,omitempty"`
-	CreatedAt time.Time   `json:"created_at"`
-	UpdatedAt time.Time   `json:"updated_at"`
-	Image_url string      `json:"image_url,omitempty"` // Added for image URL in response
-	LikeCount int         `json:"like_count"`
+	Id         string      `json:"id"`
+	UserId     string      `json:"user_id"`
+	Content    string      `json:"content"`
+	Tags       []string    `json:"tags"`
+	Images     []PostImage `json:"images,omitempty"`
+	CreatedAt  time.Time   `json:"created_at"`
+	UpdatedAt  time.Time   `json:"updated_at"`
+	Image_url  string      `json:"image_url,omitempty"` // Added for image URL in response
+	LikeCount  int         `json:"like_count"`
+	Kind       PostKind    `json:"kind"`
+	RepostOf   *SharedPost `json:"repost_of,omitempty"`
+	ShareCount int         `json:"share_count"`
 }
@@ -1,7 +1,34 @@
 package model
 
+// PostMediaType tells a photo from a video attached to a post
+type PostMediaType string
+
+const (
+	PostMediaImage PostMediaType = "image"
+	PostMediaVideo PostMediaType = "video"
+)
+
+// MediaStatus is how far the processing of an uploaded video has got.
+// Images are ready as soon as they are uploaded.
+type MediaStatus string
+
+const (
+	MediaStatusPending    MediaStatus = "pending"
+	MediaStatusProcessing MediaStatus = "processing"
+	MediaStatusReady      MediaStatus = "ready"
+	MediaStatusFailed     MediaStatus = "failed"
+)
+
+// PostImage is a photo or video attached to a post. Videos start out
+// pending and become ready once the media worker has made their thumbnail
+// and checked their duration, or failed with ProcessingError saying why.
 type PostImage struct {
 	AppModel
-	PostId   string `json:"post_id"`
-	ImageUrl string `json:"image_url"`
+	PostId          string        `json:"post_id"`
+	ImageUrl        string        `json:"image_url"`
+	MediaType       PostMediaType `json:"media_type"`
+	Status          MediaStatus   `json:"status"`
+	ThumbnailUrl    *string       `json:"thumbnail_url,omitempty"`
+	DurationSeconds *float64      `json:"duration_seconds,omitempty"`
+	ProcessingError *string       `json:"processing_error,omitempty"`
 }
@@ -0,0 +1,17 @@
+package model
+
+// PresenceStatus is whether a user is connected to the chat
+type PresenceStatus string
+
+const (
+	PresenceOnline  PresenceStatus = "online"
+	PresenceOffline PresenceStatus = "offline"
+)
+
+// UserPresence is whether a user is connected to the chat, from any device
+// and backend instance, and when they last were
+type UserPresence struct {
+	UserID   string `json:"user_id"`
+	Online   bool   `json:"online"`
+	LastSeen string `json:"last_seen,omitempty"`
+}
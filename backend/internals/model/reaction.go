@@ -0,0 +1,34 @@
+package model
+
+import "fmt"
+
+// ReactionType is the kind of reaction a user leaves on a post. A user has
+// at most one reaction per post; a plain like is the ReactionLike reaction.
+type ReactionType string
+
+const (
+	ReactionLike   ReactionType = "like"
+	ReactionClap   ReactionType = "clap"
+	ReactionFire   ReactionType = "fire"
+	ReactionTrophy ReactionType = "trophy"
+)
+
+func ParseReactionType(reaction string) (ReactionType, error) {
+	switch reaction {
+	case "like":
+		return ReactionLike, nil
+	case "clap":
+		return ReactionClap, nil
+	case "fire":
+		return ReactionFire, nil
+	case "trophy":
+		return ReactionTrophy, nil
+	}
+
+	return "", fmt.Errorf("invalid reaction: %s", reaction)
+}
+
+// ReactRequest is the body of a request to react to a post
+type ReactRequest struct {
+	Reaction string `json:"reaction" binding:"required" example:"fire" enums:"like,clap,fire,trophy"`
+}
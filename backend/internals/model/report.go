@@ -0,0 +1,78 @@
+package model
+
+type ReportTargetType string
+
+const (
+	ReportTargetPost        ReportTargetType = "post"
+	ReportTargetComment     ReportTargetType = "comment"
+	ReportTargetChatMessage ReportTargetType = "chat_message"
+	ReportTargetProfile     ReportTargetType = "profile"
+)
+
+func (t ReportTargetType) IsValid() bool {
+	switch t {
+	case ReportTargetPost, ReportTargetComment, ReportTargetChatMessage, ReportTargetProfile:
+		return true
+	}
+	return false
+}
+
+type ReportReason string
+
+const (
+	ReportReasonSpam           ReportReason = "spam"
+	ReportReasonHarassment     ReportReason = "harassment"
+	ReportReasonHateSpeech     ReportReason = "hate_speech"
+	ReportReasonViolence       ReportReason = "violence"
+	ReportReasonNudity         ReportReason = "nudity"
+	ReportReasonImpersonation  ReportReason = "impersonation"
+	ReportReasonMisinformation ReportReason = "misinformation"
+	ReportReasonOther          ReportReason = "other"
+)
+
+func (r ReportReason) IsValid() bool {
+	switch r {
+	case ReportReasonSpam, ReportReasonHarassment, ReportReasonHateSpeech, ReportReasonViolence,
+		ReportReasonNudity, ReportReasonImpersonation, ReportReasonMisinformation, ReportReasonOther:
+		return true
+	}
+	return false
+}
+
+type ReportStatus string
+
+const (
+	ReportStatusOpen      ReportStatus = "open"
+	ReportStatusActioned  ReportStatus = "actioned"
+	ReportStatusDismissed ReportStatus = "dismissed"
+)
+
+func (s ReportStatus) IsValid() bool {
+	switch s {
+	case ReportStatusOpen, ReportStatusActioned, ReportStatusDismissed:
+		return true
+	}
+	return false
+}
+
+// Report is a user's flag on a post, comment, chat message or profile.
+// Reports that are not dismissed count towards hiding the content.
+type Report struct {
+	Id         string           `json:"id"`
+	ReporterId string           `json:"reporter_id"`
+	TargetType ReportTargetType `json:"target_type"`
+	TargetId   string           `json:"target_id"`
+	Reason     ReportReason     `json:"reason"`
+	Details    *string          `json:"details,omitempty"`
+	Status     ReportStatus     `json:"status"`
+	ResolvedBy *string          `json:"resolved_by,omitempty"`
+	ResolvedAt *string          `json:"resolved_at,omitempty"`
+	CreatedAt  string           `json:"created_at"`
+}
+
+// ReportFilter represents the filter criteria for the moderation queue
+type ReportFilter struct {
+	Status     *ReportStatus     `json:"status,omitempty"`
+	TargetType *ReportTargetType `json:"target_type,omitempty"`
+	TargetId   *string           `json:"target_id,omitempty"`
+}
@@ -0,0 +1,30 @@
+package model
+
+type SavedItemType string
+
+const (
+	SavedItemPost       SavedItemType = "post"
+	SavedItemOpening    SavedItemType = "opening"
+	SavedItemTournament SavedItemType = "tournament"
+)
+
+func (t SavedItemType) IsValid() bool {
+	switch t {
+	case SavedItemPost, SavedItemOpening, SavedItemTournament:
+		return true
+	}
+	return false
+}
+
+// SavedItem is a post, opening or tournament a user bookmarked. When listed,
+// the field matching ItemType holds the saved content as the viewer sees it.
+type SavedItem struct {
+	Id         string             `json:"id"`
+	UserId     string             `json:"user_id"`
+	ItemType   SavedItemType      `json:"item_type"`
+	ItemId     string             `json:"item_id"`
+	CreatedAt  string             `json:"created_at"`
+	Post       *PostWithComment   `json:"post,omitempty"`
+	Opening    *OpeningDetails    `json:"opening,omitempty"`
+	Tournament *TournamentDetails `json:"tournament,omitempty"`
+}
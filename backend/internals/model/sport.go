@@ -1,7 +1,45 @@
 package model
 
+import (
+	"fmt"
+	"strings"
+
+	"sportsin_backend/internals/db"
+)
+
 type Sport struct {
 	AppModel
 	Name        string `json:"name"`
 	Description string `json:"description"`
 }
+
+// SportUsage counts the rows that refer to a sport
+type SportUsage struct {
+	Tournaments  int
+	Openings     int
+	Achievements int
+	Skills       int
+}
+
+// Err returns the ConflictError refusing to delete a sport with this usage,
+// or nil when nothing uses it
+func (u SportUsage) Err() error {
+	var uses []string
+	for _, c := range []struct {
+		n    int
+		what string
+	}{
+		{u.Tournaments, "tournament(s)"},
+		{u.Openings, "opening(s)"},
+		{u.Achievements, "achievement(s)"},
+		{u.Skills, "player skill(s)"},
+	} {
+		if c.n > 0 {
+			uses = append(uses, fmt.Sprintf("%d %s", c.n, c.what))
+		}
+	}
+	if len(uses) == 0 {
+		return nil
+	}
+	return db.NewConflictError("sport", "it is still used by "+strings.Join(uses, ", "))
+}
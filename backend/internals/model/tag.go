@@ -0,0 +1,62 @@
+package model
+
+import (
+	"strings"
+	"unicode"
+
+	"sportsin_backend/internals/db"
+)
+
+// Tag is a hashtag with the number of posts using it. Depending on the
+// query the count covers every post or only those of a time window.
+type Tag struct {
+	Name      string `json:"name"`
+	PostCount int    `json:"post_count"`
+}
+
+const (
+	// MaxTagLength is the longest tag name accepted, in characters
+	MaxTagLength = 50
+	// MaxTagsPerPost caps the number of tags on one post
+	MaxTagsPerPost = 10
+)
+
+// NormalizeTag returns the stored form of a tag: without a leading '#' and
+// lower-cased. ok is false when the tag is empty, too long or holds
+// anything but letters, digits and underscores.
+func NormalizeTag(tag string) (string, bool) {
+	tag = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+	if tag == "" || len([]rune(tag)) > MaxTagLength {
+		return "", false
+	}
+	for _, r := range tag {
+		if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return "", false
+		}
+	}
+	return tag, true
+}
+
+// ParseTags splits the comma-separated tags sent with a post and normalizes
+// them, dropping empty entries and duplicates.
+func ParseTags(raw string) ([]string, error) {
+	tags := []string{}
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(raw, ",") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		tag, ok := NormalizeTag(part)
+		if !ok {
+			return nil, db.NewValidationError("tags", "invalid tag \""+strings.TrimSpace(part)+"\": tags may only contain letters, digits and underscores")
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	if len(tags) > MaxTagsPerPost {
+		return nil, db.NewValidationError("tags", "a post can have at most 10 tags")
+	}
+	return tags, nil
+}
@@ -24,4 +24,5 @@ type TournamentDetails struct {
 	Sport             *Sport      `json:"sport"`
 	IsEnrolled        bool        `json:"is_enrolled"`
 	ParticipantsCount int         `json:"participants_count"`
+	Saved             bool        `json:"saved"`
 }
@@ -2,9 +2,17 @@ package model
 
 type User struct {
 	AppModel
-	Username       string `json:"username"`
-	Email          string `json:"email"`
-	Role           Role   `json:"role"`
-	SnsEndpointArn string `json:"sns_endpoint_arn"`
-	DeviceToken    string `json:"device_token"`
+	Username       string  `json:"username"`
+	Email          string  `json:"email"`
+	Role           Role    `json:"role"`
+	SnsEndpointArn string  `json:"sns_endpoint_arn"`
+	DeviceToken    string  `json:"device_token"`
+	SuspendedAt    *string `json:"suspended_at,omitempty"`
+}
+
+// UserFilter represents the filter criteria used when listing accounts
+type UserFilter struct {
+	Query     *string `json:"query,omitempty"`
+	Role      *Role   `json:"role,omitempty"`
+	Suspended *bool   `json:"suspended,omitempty"`
 }
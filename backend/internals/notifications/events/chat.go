@@ -16,7 +16,7 @@ type ChatMessageEvent struct {
 }
 
 // SendChatMessageNotification triggers a push notification for a chat message to a user
-func SendChatMessageNotification(snsService *notifications.SNSService, event ChatMessageEvent) error {
+func SendChatMessageNotification(notifier notifications.Notifier, event ChatMessageEvent) error {
 	notification := notifications.Notification{
 		Title:     "New Chat Message",
 		Body:      event.Message,
@@ -28,11 +28,11 @@ func SendChatMessageNotification(snsService *notifications.SNSService, event Cha
 			"sender_id":    event.SenderID,
 		},
 	}
-	return snsService.Send(notification)
+	return notifier.Send(notification)
 }
 
 // SendChatMessageToTopic broadcasts a chat message notification to all subscribers of the topic
-func SendChatMessageToTopic(snsService *notifications.SNSService, cfg *config.Config, event ChatMessageEvent) error {
+func SendChatMessageToTopic(notifier notifications.TopicNotifier, cfg *config.Config, event ChatMessageEvent) error {
 	notification := notifications.Notification{
 		Title:    "New Chat Message",
 		Body:     event.Message,
@@ -43,5 +43,5 @@ func SendChatMessageToTopic(snsService *notifications.SNSService, cfg *config.Co
 			"sender_id":    event.SenderID,
 		},
 	}
-	return snsService.SendToTopic(notification, cfg.AWS_TOPIC_ARN)
+	return notifier.SendToTopic(notification, cfg.AWS_TOPIC_ARN)
 }
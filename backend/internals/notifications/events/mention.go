@@ -0,0 +1,38 @@
+package event
+
+import (
+	"sportsin_backend/internals/notifications"
+)
+
+// MentionEvent represents a user being mentioned by @username in a post or
+// a comment. CommentID is empty for mentions in a post.
+type MentionEvent struct {
+	RecipientARN string
+	PostID       string
+	CommentID    string
+	AuthorID     string
+	Content      string
+	Platform     string // "android" or "ios"
+}
+
+// SendMentionNotification triggers a push notification to the mentioned user
+func SendMentionNotification(notifier notifications.Notifier, event MentionEvent) error {
+	title := "You were mentioned in a post"
+	data := map[string]string{
+		"post_id":   event.PostID,
+		"author_id": event.AuthorID,
+	}
+	if event.CommentID != "" {
+		title = "You were mentioned in a comment"
+		data["comment_id"] = event.CommentID
+	}
+	notification := notifications.Notification{
+		Title:     title,
+		Body:      event.Content,
+		TargetARN: event.RecipientARN,
+		Platform:  event.Platform,
+		Type:      "mention",
+		Data:      data,
+	}
+	return notifier.Send(notification)
+}
@@ -0,0 +1,35 @@
+package event
+
+import (
+	"sportsin_backend/internals/notifications"
+)
+
+// TournamentAnnouncementEvent represents an announcement the host of a
+// tournament broadcast to its channel
+type TournamentAnnouncementEvent struct {
+	RecipientARN string
+	TournamentID string
+	ChatRoomID   string
+	MessageID    string
+	Title        string // Title of the tournament
+	Message      string
+	Platform     string // "android" or "ios"
+}
+
+// SendTournamentAnnouncementNotification triggers a push notification of an
+// announcement to a participant
+func SendTournamentAnnouncementNotification(notifier notifications.Notifier, event TournamentAnnouncementEvent) error {
+	notification := notifications.Notification{
+		Title:     event.Title,
+		Body:      event.Message,
+		TargetARN: event.RecipientARN,
+		Platform:  event.Platform,
+		Type:      "tournament_announcement",
+		Data: map[string]string{
+			"tournament_id": event.TournamentID,
+			"chat_room_id":  event.ChatRoomID,
+			"message_id":    event.MessageID,
+		},
+	}
+	return notifier.Send(notification)
+}
@@ -0,0 +1,45 @@
+package notifications
+
+import (
+	"log/slog"
+)
+
+// LogNotifier writes notifications to a structured logger instead of
+// delivering them. Useful for local development where there is no SNS.
+type LogNotifier struct {
+	logger *slog.Logger
+}
+
+var _ TopicNotifier = (*LogNotifier)(nil)
+
+// NewLogNotifier creates a LogNotifier. A nil logger uses slog.Default().
+func NewLogNotifier(logger *slog.Logger) *LogNotifier {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &LogNotifier{logger: logger}
+}
+
+func (l *LogNotifier) Send(notification Notification) error {
+	l.logger.Info("push notification",
+		slog.String("type", notification.Type),
+		slog.String("target", notification.TargetARN),
+		slog.String("platform", notification.Platform),
+		slog.String("title", notification.Title),
+		slog.String("body", notification.Body),
+		slog.Any("data", notification.Data),
+	)
+	return nil
+}
+
+func (l *LogNotifier) SendToTopic(notification Notification, topicARN string) error {
+	l.logger.Info("topic notification",
+		slog.String("type", notification.Type),
+		slog.String("topic", topicARN),
+		slog.String("platform", notification.Platform),
+		slog.String("title", notification.Title),
+		slog.String("body", notification.Body),
+		slog.Any("data", notification.Data),
+	)
+	return nil
+}
@@ -14,6 +14,8 @@ type SNSService struct {
 	platformAppARNMap map[string]string
 }
 
+var _ TopicNotifier = (*SNSService)(nil)
+
 // Only keep the Android-specific constructor
 func NewSNSService(region string, androidARN string) *SNSService {
 	client := sns.New(sns.Options{
@@ -1,14 +1,21 @@
 package notifications
 
 type Notification struct {
-	Title     string
-	Body      string
-	Data      map[string]string //extra payload for bigger messages
-	TargetARN string            //SNS ARN endpoint
-	Platform  string            // ios or Android
-	Type      string
+	Title     string            `json:"title"`
+	Body      string            `json:"body"`
+	Data      map[string]string `json:"data,omitempty"`       //extra payload for bigger messages
+	TargetARN string            `json:"target_arn,omitempty"` //SNS ARN endpoint
+	Platform  string            `json:"platform,omitempty"`   // ios or Android
+	Type      string            `json:"type"`
 }
 
+// Notifier delivers a push notification to a single device.
 type Notifier interface {
-	Send(notification Notification)
+	Send(notification Notification) error
+}
+
+// TopicNotifier is a Notifier that can also broadcast to every subscriber of a topic.
+type TopicNotifier interface {
+	Notifier
+	SendToTopic(notification Notification, topicARN string) error
 }
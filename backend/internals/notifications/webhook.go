@@ -0,0 +1,62 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// WebhookNotifier POSTs each Notification as JSON to an HTTP endpoint, so
+// notifications can be received by a local service instead of AWS SNS.
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+var _ TopicNotifier = (*WebhookNotifier)(nil)
+
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{
+		url: url,
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+func (w *WebhookNotifier) Send(notification Notification) error {
+	return w.post(notification)
+}
+
+// SendToTopic posts the notification with the topic in place of the device target
+func (w *WebhookNotifier) SendToTopic(notification Notification, topicARN string) error {
+	notification.TargetARN = topicARN
+	return w.post(notification)
+}
+
+func (w *WebhookNotifier) post(notification Notification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(context.TODO(), http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to deliver webhook notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"math"
+	"sort"
+	"time"
+
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/model"
+	"sportsin_backend/internals/store"
+)
+
+// Ranking weights. A post's base affinity grows when the viewer follows the
+// author or shares a sport with them, engagement is added on a log scale so
+// a few viral posts do not drown everything else, and the sum decays with
+// age the way Hacker News ranks stories.
+const (
+	feedBaseAffinity    = 1.0
+	feedFollowWeight    = 3.0
+	feedSportWeight     = 1.5
+	feedLikeWeight      = 1.0
+	feedCommentWeight   = 0.5
+	feedVelocityWeight  = 2.0
+	feedAgeOffsetHours  = 2.0
+	feedGravity         = 1.5
+	defaultFeedWindow   = 14 * 24 * time.Hour
+	defaultVelocitySpan = 6 * time.Hour
+	defaultPerSignal    = 200
+)
+
+// FeedService ranks the home feed from posts by followed users, by users
+// sharing the viewer's sports, and popular posts. Pages are keyed by a
+// cursor that pins the ranking time. Candidates and their engagement are
+// taken as of that time, so neither new posts nor new reactions and
+// comments made while the user is scrolling shift later pages. Only
+// reactions withdrawn in the meantime can still move a post.
+type FeedService struct {
+	repo store.FeedStore
+
+	// Window is how far back posts are considered
+	Window time.Duration
+	// VelocitySpan is the recent period whose comments boost a post
+	VelocitySpan time.Duration
+	// PerSignal caps the posts taken for each ranking signal per request
+	PerSignal int
+}
+
+func NewFeedService(repo store.FeedStore) *FeedService {
+	return &FeedService{
+		repo:         repo,
+		Window:       defaultFeedWindow,
+		VelocitySpan: defaultVelocitySpan,
+		PerSignal:    defaultPerSignal,
+	}
+}
+
+// feedCursor is the position after the last post of a page
+type feedCursor struct {
+	AsOf   int64   `json:"t"`
+	Score  float64 `json:"s"`
+	PostId string  `json:"p"`
+}
+
+type rankedPost struct {
+	id    string
+	score float64
+}
+
+// GetFeed returns the posts of the page after cursor, or of the first page
+// when cursor is empty, and the cursor of the next page, empty on the last
+// one.
+func (s *FeedService) GetFeed(viewerID, cursor string, limit int) ([]model.PostWithComment, string, error) {
+	var after *feedCursor
+	asOf := time.Now().UTC()
+	if cursor != "" {
+		decoded, err := decodeFeedCursor(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		after = decoded
+		asOf = time.Unix(0, decoded.AsOf).UTC()
+	}
+
+	candidates, err := s.repo.GetFeedCandidates(viewerID, &model.FeedQuery{
+		Since:         asOf.Add(-s.Window),
+		Until:         asOf,
+		CommentsSince: asOf.Add(-s.VelocitySpan),
+		PerSignal:     s.PerSignal,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	ranked := make([]rankedPost, 0, len(candidates))
+	for _, candidate := range candidates {
+		ranked = append(ranked, rankedPost{id: candidate.PostId, score: feedScore(candidate, asOf)})
+	}
+	sort.Slice(ranked, func(i, j int) bool { return rankedBefore(ranked[i], ranked[j]) })
+
+	start := 0
+	if after != nil {
+		start = sort.Search(len(ranked), func(i int) bool {
+			return rankedBefore(rankedPost{id: after.PostId, score: after.Score}, ranked[i])
+		})
+	}
+	end := min(start+limit, len(ranked))
+	pageIDs := make([]string, 0, end-start)
+	for _, r := range ranked[start:end] {
+		pageIDs = append(pageIDs, r.id)
+	}
+
+	posts, err := s.repo.GetPostsWithCommentsByIds(pageIDs, viewerID)
+	if err != nil {
+		return nil, "", err
+	}
+	byID := make(map[string]model.PostWithComment, len(posts))
+	for _, p := range posts {
+		byID[p.Id] = p
+	}
+
+	page := make([]model.PostWithComment, 0, len(pageIDs))
+	for _, id := range pageIDs {
+		if p, ok := byID[id]; ok {
+			page = append(page, p)
+		}
+	}
+	next := ""
+	if end < len(ranked) {
+		last := ranked[end-1]
+		next = encodeFeedCursor(feedCursor{AsOf: asOf.UnixNano(), Score: last.score, PostId: last.id})
+	}
+	return page, next, nil
+}
+
+// feedScore is evaluated at asOf rather than the current time so that a
+// post keeps its rank across the pages of one cursor.
+func feedScore(candidate model.FeedCandidate, asOf time.Time) float64 {
+	affinity := feedBaseAffinity
+	if candidate.FromFollowed {
+		affinity += feedFollowWeight
+	}
+	if candidate.SharesSport {
+		affinity += feedSportWeight
+	}
+
+	engagement := feedLikeWeight*math.Log1p(float64(candidate.LikeCount)) +
+		feedCommentWeight*math.Log1p(float64(candidate.CommentCount)) +
+		feedVelocityWeight*math.Log1p(float64(candidate.RecentComments))
+
+	ageHours := math.Max(asOf.Sub(candidate.CreatedAt).Hours(), 0)
+	return (affinity + engagement) / math.Pow(ageHours+feedAgeOffsetHours, feedGravity)
+}
+
+// rankedBefore orders by score, highest first, with the post ID breaking
+// ties so that the order is total.
+func rankedBefore(a, b rankedPost) bool {
+	if a.score != b.score {
+		return a.score > b.score
+	}
+	return a.id > b.id
+}
+
+func encodeFeedCursor(cursor feedCursor) string {
+	raw, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeFeedCursor(cursor string) (*feedCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, db.NewValidationError("cursor", "invalid feed cursor")
+	}
+	var decoded feedCursor
+	if err := json.Unmarshal(raw, &decoded); err != nil || decoded.AsOf == 0 || decoded.PostId == "" {
+		return nil, db.NewValidationError("cursor", "invalid feed cursor")
+	}
+	return &decoded, nil
+}
@@ -0,0 +1,110 @@
+package services_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/google/uuid"
+	"sportsin_backend/internals/model"
+	"sportsin_backend/internals/services"
+	"sportsin_backend/internals/store/memory"
+)
+
+func react(t *testing.T, repo *memory.Store, postID string, times int) {
+	t.Helper()
+	for i := 0; i < times; i++ {
+		if err := repo.ReactToPost(postID, uuid.NewString(), model.ReactionLike); err != nil {
+			t.Fatalf("ReactToPost: %v", err)
+		}
+	}
+}
+
+// walkFeed returns the post IDs of every page, calling between after each
+// page but the last
+func walkFeed(t *testing.T, feed *services.FeedService, viewerID string, limit int, between func()) []string {
+	t.Helper()
+	var ids []string
+	cursor := ""
+	for pages := 0; pages < 20; pages++ {
+		posts, next, err := feed.GetFeed(viewerID, cursor, limit)
+		if err != nil {
+			t.Fatalf("GetFeed: %v", err)
+		}
+		for _, p := range posts {
+			ids = append(ids, p.Id)
+		}
+		if next == "" {
+			return ids
+		}
+		cursor = next
+		between()
+	}
+	t.Fatal("feed did not end")
+	return nil
+}
+
+func TestFeedTakesPopularPostsBeyondTheNewest(t *testing.T) {
+	repo := memory.NewStore()
+	viewer, followed, stranger := memory.NewTestUser(t, repo), memory.NewTestUser(t, repo), memory.NewTestUser(t, repo)
+	if err := repo.FollowUser(viewer, followed); err != nil {
+		t.Fatalf("FollowUser: %v", err)
+	}
+
+	popular := memory.NewTestPost(t, repo, stranger, "training update").Id
+	react(t, repo, popular, 3)
+	for i := 0; i < 3; i++ {
+		memory.NewTestPost(t, repo, stranger, "training update")
+	}
+	memory.NewTestPost(t, repo, followed, "training update")
+	second := memory.NewTestPost(t, repo, followed, "training update").Id
+	newest := memory.NewTestPost(t, repo, followed, "training update").Id
+
+	feed := services.NewFeedService(repo)
+	feed.PerSignal = 2
+	got := walkFeed(t, feed, viewer, 10, func() {})
+
+	// The two newest followed posts, then the most engaged post and the
+	// newest of the rest, which is already in
+	want := []string{popular, second, newest}
+	sort.Strings(got)
+	sort.Strings(want)
+	if len(got) != len(want) {
+		t.Fatalf("feed = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("feed = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestFeedPagesIgnoreReactionsAfterTheCursor(t *testing.T) {
+	repo := memory.NewStore()
+	viewer, followed := memory.NewTestUser(t, repo), memory.NewTestUser(t, repo)
+	if err := repo.FollowUser(viewer, followed); err != nil {
+		t.Fatalf("FollowUser: %v", err)
+	}
+	var posts []string
+	for i := 0; i < 5; i++ {
+		posts = append(posts, memory.NewTestPost(t, repo, followed, "training update").Id)
+	}
+
+	// The oldest post is last on the first page's ranking. Reactions to it
+	// while the user scrolls must not lift it above the cursor.
+	got := walkFeed(t, services.NewFeedService(repo), viewer, 2, func() {
+		react(t, repo, posts[0], 5)
+	})
+
+	seen := make(map[string]bool)
+	for _, id := range got {
+		if seen[id] {
+			t.Errorf("post %s shown twice", id)
+		}
+		seen[id] = true
+	}
+	for _, id := range posts {
+		if !seen[id] {
+			t.Errorf("post %s never shown", id)
+		}
+	}
+}
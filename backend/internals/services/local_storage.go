@@ -0,0 +1,206 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LocalFilesRoute is the path under which LocalStorage objects are served.
+const LocalFilesRoute = "/files"
+
+var (
+	ErrInvalidSignature = errors.New("invalid or missing signature")
+	ErrSignatureExpired = errors.New("signature has expired")
+)
+
+// LocalStorage is a BlobStore that keeps objects on the local filesystem.
+// It is meant for development and tests where there is no S3 bucket; files
+// are served back through the LocalFilesRoute static route and uploads via
+// PresignPutObject are authorised by an HMAC signature instead of IAM.
+type LocalStorage struct {
+	baseDir string
+	baseURL string
+	secret  []byte
+}
+
+var _ BlobStore = (*LocalStorage)(nil)
+
+func NewLocalStorage(baseDir, baseURL, secret string) (*LocalStorage, error) {
+	if baseDir == "" {
+		return nil, fmt.Errorf("local storage directory is required")
+	}
+	if secret == "" {
+		return nil, fmt.Errorf("local storage signing secret is required")
+	}
+	if err := os.MkdirAll(baseDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create local storage directory: %w", err)
+	}
+
+	return &LocalStorage{
+		baseDir: baseDir,
+		baseURL: strings.TrimRight(baseURL, "/"),
+		secret:  []byte(secret),
+	}, nil
+}
+
+// Dir returns the directory objects are written to
+func (s *LocalStorage) Dir() string {
+	return s.baseDir
+}
+
+// PutObject writes an object to disk and returns its public URL
+func (s *LocalStorage) PutObject(ctx context.Context, key string, body []byte, contentType string) (string, error) {
+	filePath, err := s.pathFor(key)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		return "", fmt.Errorf("failed to create directory for %s: %w", key, err)
+	}
+	if err := os.WriteFile(filePath, body, 0o644); err != nil {
+		return "", fmt.Errorf("failed to write file to local storage: %w", err)
+	}
+
+	return s.ObjectURL(key), nil
+}
+
+// GetObject reads an object from disk
+func (s *LocalStorage) GetObject(ctx context.Context, key string) ([]byte, error) {
+	filePath, err := s.pathFor(key)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file from local storage: %w", err)
+	}
+
+	return body, nil
+}
+
+// DeleteObject deletes a single object from disk
+func (s *LocalStorage) DeleteObject(ctx context.Context, key string) error {
+	filePath, err := s.pathFor(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete object from local storage: %w", err)
+	}
+
+	return nil
+}
+
+// DeletePrefix deletes all objects under a key prefix. Keys are paths, so a
+// prefix ending in "/" removes the whole directory.
+func (s *LocalStorage) DeletePrefix(ctx context.Context, prefix string) error {
+	dir, err := s.pathFor(strings.TrimSuffix(prefix, "/"))
+	if err != nil {
+		return err
+	}
+
+	if strings.HasSuffix(prefix, "/") {
+		if err := os.RemoveAll(dir); err != nil {
+			return fmt.Errorf("failed to delete objects for prefix %s: %w", prefix, err)
+		}
+		return nil
+	}
+
+	matches, err := filepath.Glob(dir + "*")
+	if err != nil {
+		return fmt.Errorf("failed to list objects for prefix %s: %w", prefix, err)
+	}
+	for _, match := range matches {
+		if err := os.RemoveAll(match); err != nil {
+			return fmt.Errorf("failed to delete object %s: %w", match, err)
+		}
+	}
+
+	return nil
+}
+
+// ObjectURL returns the public URL of an object
+func (s *LocalStorage) ObjectURL(key string) string {
+	return s.baseURL + LocalFilesRoute + "/" + key
+}
+
+// KeyFromURL extracts the object key from a URL returned by ObjectURL
+func (s *LocalStorage) KeyFromURL(objectURL string) (string, error) {
+	expectedPrefix := s.baseURL + LocalFilesRoute + "/"
+	if !strings.HasPrefix(objectURL, expectedPrefix) {
+		return "", fmt.Errorf("invalid local storage URL format")
+	}
+
+	key := strings.TrimPrefix(objectURL, expectedPrefix)
+	if i := strings.IndexByte(key, '?'); i >= 0 {
+		key = key[:i]
+	}
+	if key == "" {
+		return "", fmt.Errorf("empty object key")
+	}
+
+	return key, nil
+}
+
+// PresignPutObject returns a signed URL that accepts a PUT of key until duration elapses
+func (s *LocalStorage) PresignPutObject(ctx context.Context, key string, contentType string, duration time.Duration) (string, error) {
+	if _, err := s.pathFor(key); err != nil {
+		return "", err
+	}
+
+	expires := time.Now().Add(duration).Unix()
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expires, 10))
+	query.Set("signature", s.sign("PUT", key, expires))
+
+	return s.ObjectURL(key) + "?" + query.Encode(), nil
+}
+
+// VerifySignature checks the expires and signature query parameters of a
+// request for key against what PresignPutObject would have produced.
+func (s *LocalStorage) VerifySignature(method, key, expiresParam, signature string) error {
+	expires, err := strconv.ParseInt(expiresParam, 10, 64)
+	if err != nil || signature == "" {
+		return ErrInvalidSignature
+	}
+
+	expected := s.sign(method, key, expires)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return ErrInvalidSignature
+	}
+	if time.Now().Unix() > expires {
+		return ErrSignatureExpired
+	}
+
+	return nil
+}
+
+func (s *LocalStorage) sign(method, key string, expires int64) string {
+	mac := hmac.New(sha256.New, s.secret)
+	fmt.Fprintf(mac, "%s\n%s\n%d", method, key, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// pathFor maps a key onto the filesystem, rejecting keys that would escape baseDir
+func (s *LocalStorage) pathFor(key string) (string, error) {
+	cleaned := path.Clean("/" + key)
+	if cleaned == "/" || strings.Contains(key, "\\") {
+		return "", fmt.Errorf("invalid object key %q", key)
+	}
+
+	return filepath.Join(s.baseDir, filepath.FromSlash(strings.TrimPrefix(cleaned, "/"))), nil
+}
@@ -0,0 +1,182 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"sportsin_backend/internals/model"
+	"sportsin_backend/internals/store"
+)
+
+// mediaQueueSize is how many videos can wait for a free worker. Videos
+// uploaded while the queue is full stay pending until the next rescan.
+const mediaQueueSize = 100
+
+// mediaJobTimeout bounds the processing of a single video
+const mediaJobTimeout = 5 * time.Minute
+
+// mediaRescanPeriod is how often the worker looks for videos that were left
+// pending because the queue was full
+const mediaRescanPeriod = time.Minute
+
+// MediaWorker processes uploaded post videos in the background. Each video
+// is downloaded, measured and given a thumbnail, and its row moves from
+// pending through processing to ready, or to failed when it cannot be read
+// or runs longer than the duration limit. The status lives on the row, so
+// videos still pending after a restart, or dropped from a full queue, are
+// queued again by a rescan.
+type MediaWorker struct {
+	repo        store.MediaStore
+	storage     *StorageService
+	processor   VideoProcessor
+	maxDuration time.Duration
+	jobs        chan model.PostImage
+	// overflowed is set when Enqueue left a video pending
+	overflowed atomic.Bool
+}
+
+func NewMediaWorker(repo store.MediaStore, storage *StorageService, processor VideoProcessor, maxDuration time.Duration) *MediaWorker {
+	return &MediaWorker{
+		repo:        repo,
+		storage:     storage,
+		processor:   processor,
+		maxDuration: maxDuration,
+		jobs:        make(chan model.PostImage, mediaQueueSize),
+	}
+}
+
+// Start runs workers goroutines until ctx is cancelled, queues the videos
+// left unprocessed by a previous run and rescans for videos that did not
+// fit in the queue
+func (w *MediaWorker) Start(ctx context.Context, workers int) {
+	for i := 0; i < workers; i++ {
+		go w.run(ctx)
+	}
+	go w.rescan(ctx)
+}
+
+// Enqueue queues a pending video for processing without blocking the
+// request that uploaded it. When the queue is full the video stays pending
+// and is picked up by the next rescan.
+func (w *MediaWorker) Enqueue(media model.PostImage) {
+	select {
+	case w.jobs <- media:
+	default:
+		w.overflowed.Store(true)
+		log.Printf("Media queue is full, post video %s stays pending until the next rescan", media.Id)
+	}
+}
+
+// rescan feeds the queue from the database, blocking while it is full: once
+// at start with every video a previous run left unprocessed, then with the
+// pending videos Enqueue could not queue, after the queue has drained
+func (w *MediaWorker) rescan(ctx context.Context) {
+	if !w.feed(ctx, false) {
+		return
+	}
+	ticker := time.NewTicker(mediaRescanPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if len(w.jobs) == 0 && w.overflowed.Swap(false) {
+				if !w.feed(ctx, true) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// feed queues the unprocessed videos, only the pending ones when
+// pendingOnly is set, and reports false when ctx was cancelled meanwhile
+func (w *MediaWorker) feed(ctx context.Context, pendingOnly bool) bool {
+	unprocessed, err := w.repo.GetUnprocessedPostMedia()
+	if err != nil {
+		log.Printf("ERROR: failed to load unprocessed post media: %v", err)
+		return true
+	}
+	if len(unprocessed) > 0 {
+		log.Printf("Queueing %d unprocessed post video(s)", len(unprocessed))
+	}
+	for _, media := range unprocessed {
+		if pendingOnly && media.Status != model.MediaStatusPending {
+			continue
+		}
+		select {
+		case w.jobs <- media:
+		case <-ctx.Done():
+			return false
+		}
+	}
+	return true
+}
+
+func (w *MediaWorker) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case media := <-w.jobs:
+			jobCtx, cancel := context.WithTimeout(ctx, mediaJobTimeout)
+			w.Process(jobCtx, media)
+			cancel()
+		}
+	}
+}
+
+// Process runs a single job to completion and records its outcome on the
+// media row. It is what the workers call for each queued video.
+func (w *MediaWorker) Process(ctx context.Context, media model.PostImage) {
+	if err := w.repo.UpdatePostMediaStatus(media.Id, model.MediaStatusProcessing, nil); err != nil {
+		// The post was most likely deleted while the video was queued
+		log.Printf("ERROR: failed to start processing post media %s: %v", media.Id, err)
+		return
+	}
+
+	if err := w.process(ctx, &media); err != nil {
+		log.Printf("ERROR: failed to process post media %s: %v", media.Id, err)
+		reason := err.Error()
+		if err := w.repo.UpdatePostMediaStatus(media.Id, model.MediaStatusFailed, &reason); err != nil {
+			log.Printf("ERROR: failed to mark post media %s as failed: %v", media.Id, err)
+		}
+		return
+	}
+	log.Printf("Processed post video %s", media.Id)
+}
+
+func (w *MediaWorker) process(ctx context.Context, media *model.PostImage) error {
+	video, err := w.storage.GetPostMedia(ctx, media.ImageUrl)
+	if err != nil {
+		return fmt.Errorf("failed to download video: %w", err)
+	}
+
+	processed, err := w.processor.Process(ctx, video, filepath.Ext(media.ImageUrl))
+	if err != nil {
+		return err
+	}
+	if processed.Duration > w.maxDuration {
+		return fmt.Errorf("video is too long. The maximum duration is %s", w.maxDuration)
+	}
+
+	thumbnailURL, err := w.storage.UploadVideoThumbnail(ctx, media.PostId, media.Id, processed.Thumbnail, processed.ThumbnailType)
+	if err != nil {
+		return fmt.Errorf("failed to upload thumbnail: %w", err)
+	}
+
+	seconds := processed.Duration.Seconds()
+	media.ThumbnailUrl = &thumbnailURL
+	media.DurationSeconds = &seconds
+	if err := w.repo.CompletePostMedia(media); err != nil {
+		// Do not leave the thumbnail of a deleted post behind
+		w.storage.DeletePostImage(ctx, thumbnailURL)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package services_test
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"sportsin_backend/internals/model"
+	"sportsin_backend/internals/services"
+	"sportsin_backend/internals/store/memory"
+)
+
+// newMediaWorker returns a worker whose stub processor reports every video as
+// lasting duration, against a memory store and local storage
+func newMediaWorker(t *testing.T, duration time.Duration) (*services.MediaWorker, *memory.Store, *services.LocalStorage) {
+	t.Helper()
+	blobs, err := services.NewLocalStorage(t.TempDir(), "http://storage.test", "secret")
+	if err != nil {
+		t.Fatalf("NewLocalStorage: %v", err)
+	}
+	repo := memory.NewStore()
+	storage := services.NewStorageService(blobs, 10<<20)
+	worker := services.NewMediaWorker(repo, storage, services.NewStubVideoProcessor(duration), time.Minute)
+	return worker, repo, blobs
+}
+
+// createVideo stores a pending video on a new post and returns its row
+func createVideo(t *testing.T, repo *memory.Store, blobs *services.LocalStorage) (*model.Post, model.PostImage) {
+	t.Helper()
+	post := memory.NewTestPost(t, repo, memory.NewTestUser(t, repo), "match highlights")
+	videoURL, err := blobs.PutObject(context.Background(), "posts/"+post.Id+"/vid.mp4", []byte("not really a video"), "video/mp4")
+	if err != nil {
+		t.Fatalf("PutObject: %v", err)
+	}
+	video := &model.PostImage{
+		PostId:    post.Id,
+		ImageUrl:  videoURL,
+		MediaType: model.PostMediaVideo,
+		Status:    model.MediaStatusPending,
+	}
+	if err := repo.CreatePostImage(video); err != nil {
+		t.Fatalf("CreatePostImage: %v", err)
+	}
+	return post, *video
+}
+
+func postVideo(t *testing.T, repo *memory.Store, postID string) model.PostImage {
+	t.Helper()
+	media, err := repo.GetImagesByPostId(postID)
+	if err != nil || len(media) != 1 {
+		t.Fatalf("GetImagesByPostId = %v, %v; want one video", media, err)
+	}
+	return media[0]
+}
+
+func TestMediaWorkerProcessReady(t *testing.T) {
+	worker, repo, blobs := newMediaWorker(t, 30*time.Second)
+	post, video := createVideo(t, repo, blobs)
+
+	worker.Process(context.Background(), video)
+
+	got := postVideo(t, repo, post.Id)
+	if got.Status != model.MediaStatusReady {
+		t.Fatalf("status = %s, want %s (error %v)", got.Status, model.MediaStatusReady, got.ProcessingError)
+	}
+	if got.DurationSeconds == nil || *got.DurationSeconds != 30 {
+		t.Errorf("duration = %v, want 30", got.DurationSeconds)
+	}
+	if got.ThumbnailUrl == nil {
+		t.Fatal("thumbnail was not stored")
+	}
+	key, err := blobs.KeyFromURL(*got.ThumbnailUrl)
+	if err != nil {
+		t.Fatalf("KeyFromURL: %v", err)
+	}
+	if _, err := blobs.GetObject(context.Background(), key); err != nil {
+		t.Errorf("thumbnail object is missing: %v", err)
+	}
+}
+
+func TestMediaWorkerProcessTooLong(t *testing.T) {
+	worker, repo, blobs := newMediaWorker(t, 2*time.Minute)
+	post, video := createVideo(t, repo, blobs)
+
+	worker.Process(context.Background(), video)
+
+	got := postVideo(t, repo, post.Id)
+	if got.Status != model.MediaStatusFailed {
+		t.Fatalf("status = %s, want %s", got.Status, model.MediaStatusFailed)
+	}
+	if got.ProcessingError == nil || *got.ProcessingError == "" {
+		t.Error("processing error was not recorded")
+	}
+	if got.ThumbnailUrl != nil {
+		t.Errorf("thumbnail = %s, want none", *got.ThumbnailUrl)
+	}
+}
+
+func TestMediaWorkerProcessDeletedPost(t *testing.T) {
+	worker, repo, blobs := newMediaWorker(t, 30*time.Second)
+	post, video := createVideo(t, repo, blobs)
+	if _, err := repo.DeletePost(post.Id, post.UserId, nil); err != nil {
+		t.Fatalf("DeletePost: %v", err)
+	}
+
+	worker.Process(context.Background(), video)
+
+	if media, _ := repo.GetImagesByPostId(post.Id); len(media) != 0 {
+		t.Errorf("media of the deleted post = %v, want none", media)
+	}
+	unprocessed, err := repo.GetUnprocessedPostMedia()
+	if err != nil {
+		t.Fatalf("GetUnprocessedPostMedia: %v", err)
+	}
+	if len(unprocessed) != 0 {
+		t.Errorf("unprocessed media = %v, want none", unprocessed)
+	}
+}
+
+// deletingProcessor deletes the post of the video while it is processed, as
+// its author could while the worker is busy
+type deletingProcessor struct {
+	repo *memory.Store
+	post *model.Post
+	services.StubVideoProcessor
+}
+
+func (p *deletingProcessor) Process(ctx context.Context, video []byte, ext string) (*services.ProcessedVideo, error) {
+	if _, err := p.repo.DeletePost(p.post.Id, p.post.UserId, nil); err != nil {
+		return nil, err
+	}
+	return p.StubVideoProcessor.Process(ctx, video, ext)
+}
+
+func TestMediaWorkerProcessPostDeletedWhileProcessing(t *testing.T) {
+	blobs, err := services.NewLocalStorage(t.TempDir(), "http://storage.test", "secret")
+	if err != nil {
+		t.Fatalf("NewLocalStorage: %v", err)
+	}
+	repo := memory.NewStore()
+	post, video := createVideo(t, repo, blobs)
+	processor := &deletingProcessor{repo: repo, post: post, StubVideoProcessor: services.StubVideoProcessor{Duration: time.Second}}
+	worker := services.NewMediaWorker(repo, services.NewStorageService(blobs, 10<<20), processor, time.Minute)
+
+	worker.Process(context.Background(), video)
+
+	// The thumbnail of the deleted post must not be left behind
+	filepath.WalkDir(blobs.Dir(), func(path string, d fs.DirEntry, err error) error {
+		if err == nil && strings.Contains(d.Name(), "_thumb") {
+			t.Errorf("thumbnail %s was left behind", path)
+		}
+		return nil
+	})
+}
+
+func TestMediaWorkerEnqueueFullQueue(t *testing.T) {
+	worker, repo, blobs := newMediaWorker(t, time.Second)
+	_, video := createVideo(t, repo, blobs)
+
+	// No workers are running, so the queue fills up and the rest must be
+	// left pending instead of blocking the uploading request
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 500; i++ {
+			worker.Enqueue(video)
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Enqueue blocked on a full queue")
+	}
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"log"
+
+	"sportsin_backend/internals/model"
+	"sportsin_backend/internals/notifications"
+	event "sportsin_backend/internals/notifications/events"
+	"sportsin_backend/internals/store"
+)
+
+// mentionPreviewLength is the number of characters of the post or comment
+// shown in a mention notification
+const mentionPreviewLength = 100
+
+// MentionService records the @username mentions of posts and comments and
+// notifies the mentioned users. Mentions are secondary to the content they
+// appear in, so failures are logged rather than returned.
+type MentionService struct {
+	repo     store.MentionStore
+	notifier notifications.Notifier
+}
+
+func NewMentionService(repo store.MentionStore, notifier notifications.Notifier) *MentionService {
+	return &MentionService{repo: repo, notifier: notifier}
+}
+
+// PostSaved updates the mentions of a created or edited post. Users already
+// mentioned before an edit are not notified again.
+func (s *MentionService) PostSaved(post *model.Post) {
+	users, err := s.repo.SetPostMentions(post.Id, post.UserId, model.ParseMentions(post.Content))
+	if err != nil {
+		log.Printf("ERROR: failed to record mentions of post %s: %v", post.Id, err)
+		return
+	}
+	s.notify(users, event.MentionEvent{
+		PostID:   post.Id,
+		AuthorID: post.UserId,
+		Content:  truncate(post.Content, mentionPreviewLength),
+	})
+}
+
+// CommentCreated records the mentions of a new comment
+func (s *MentionService) CommentCreated(comment *model.Comment) {
+	users, err := s.repo.AddCommentMentions(comment.Id, comment.PostId, comment.UserId, model.ParseMentions(comment.Content))
+	if err != nil {
+		log.Printf("ERROR: failed to record mentions of comment %s: %v", comment.Id, err)
+		return
+	}
+	s.notify(users, event.MentionEvent{
+		PostID:    comment.PostId,
+		CommentID: comment.Id,
+		AuthorID:  comment.UserId,
+		Content:   truncate(comment.Content, mentionPreviewLength),
+	})
+}
+
+func (s *MentionService) notify(users []model.MentionedUser, mention event.MentionEvent) {
+	for _, u := range users {
+		if u.SnsEndpointArn == "" {
+			continue
+		}
+		mention.RecipientARN = u.SnsEndpointArn
+		mention.Platform = "android"
+		go func(mention event.MentionEvent) {
+			if err := event.SendMentionNotification(s.notifier, mention); err != nil {
+				log.Printf("ERROR: failed to notify %s of mention: %v", u.UserId, err)
+			}
+		}(mention)
+	}
+}
@@ -5,8 +5,6 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"mime/multipart"
-	"path/filepath"
 	"strings"
 	"time"
 
@@ -15,12 +13,15 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 )
 
+// S3Service is the BlobStore backed by an AWS S3 bucket.
 type S3Service struct {
 	client     *s3.Client
 	bucketName string
 	region     string
 }
 
+var _ BlobStore = (*S3Service)(nil)
+
 func NewS3Service(bucketName, region string) (*S3Service, error) {
 	cfg, err := config.LoadDefaultConfig(context.TODO(), config.WithRegion(region))
 	if err != nil {
@@ -36,148 +37,56 @@ func NewS3Service(bucketName, region string) (*S3Service, error) {
 	}, nil
 }
 
-// UploadProfilePicture uploads a profile picture to S3 using the Cognito user ID as the key
-func (s *S3Service) UploadProfilePicture(ctx context.Context, userID string, file multipart.File, header *multipart.FileHeader) (string, error) {
-	// Validate file type
-	if !s.isValidImageType(header.Filename) {
-		return "", fmt.Errorf("invalid file type. Only JPEG, PNG, and GIF files are allowed")
-	}
-
-	// Read file content
-	fileContent, err := io.ReadAll(file)
-	if err != nil {
-		return "", fmt.Errorf("failed to read file: %w", err)
-	}
-
-	file.Seek(0, 0)
-
-	fileExtension := filepath.Ext(header.Filename)
-	s3Key := fmt.Sprintf("profile-pictures/%s%s", userID, fileExtension)
-
-	// Upload to S3
-	_, err = s.client.PutObject(ctx, &s3.PutObjectInput{
+// PutObject uploads an object to the bucket and returns its public URL
+func (s *S3Service) PutObject(ctx context.Context, key string, body []byte, contentType string) (string, error) {
+	_, err := s.client.PutObject(ctx, &s3.PutObjectInput{
 		Bucket:      aws.String(s.bucketName),
-		Key:         aws.String(s3Key),
-		Body:        bytes.NewReader(fileContent),
-		ContentType: aws.String(s.getContentType(fileExtension)),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(body),
+		ContentType: aws.String(contentType),
 		// ACL:         types.ObjectCannedACLPublicRead,
 	})
-
 	if err != nil {
 		return "", fmt.Errorf("failed to upload file to S3: %w", err)
 	}
 
-	// Generate and return the public URL
-	imageURL := fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", s.bucketName, s.region, s3Key)
-	return imageURL, nil
-}
-
-// GetProfilePictureURL returns the S3 URL for a user's profile picture
-func (s *S3Service) GetProfilePictureURL(userID string, fileExtension string) string {
-	if fileExtension == "" {
-		fileExtension = ".jpg" // default extension
-	}
-	s3Key := fmt.Sprintf("profile-pictures/%s%s", userID, fileExtension)
-	return fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", s.bucketName, s.region, s3Key)
+	return s.ObjectURL(key), nil
 }
 
-// DeleteProfilePicture deletes a user's profile picture from S3
-func (s *S3Service) DeleteProfilePicture(ctx context.Context, userID string, fileExtension string) error {
-	s3Key := fmt.Sprintf("profile-pictures/%s%s", userID, fileExtension)
-
-	_, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+// GetObject downloads an object from the bucket
+func (s *S3Service) GetObject(ctx context.Context, key string) ([]byte, error) {
+	result, err := s.client.GetObject(ctx, &s3.GetObjectInput{
 		Bucket: aws.String(s.bucketName),
-		Key:    aws.String(s3Key),
+		Key:    aws.String(key),
 	})
-
-	if err != nil {
-		return fmt.Errorf("failed to delete profile picture from S3: %w", err)
-	}
-
-	return nil
-}
-
-// GeneratePresignedURL generates a presigned URL for uploading profile pictures
-func (s *S3Service) GeneratePresignedURL(ctx context.Context, userID string, fileExtension string, duration time.Duration) (string, error) {
-	s3Key := fmt.Sprintf("profile-pictures/%s%s", userID, fileExtension)
-
-	presignClient := s3.NewPresignClient(s.client)
-
-	request, err := presignClient.PresignPutObject(ctx, &s3.PutObjectInput{
-		Bucket:      aws.String(s.bucketName),
-		Key:         aws.String(s3Key),
-		ContentType: aws.String(s.getContentType(fileExtension)),
-		// ACL:         types.ObjectCannedACLPublicRead, // Removed for Bucket Owner Enforced
-	}, func(opts *s3.PresignOptions) {
-		opts.Expires = duration
-	})
-
 	if err != nil {
-		return "", fmt.Errorf("failed to generate presigned URL: %w", err)
-	}
-
-	return request.URL, nil
-}
-
-// UploadPostImage uploads a post image to S3 in the posts folder
-func (s *S3Service) UploadPostImage(ctx context.Context, postID, imageID string, file multipart.File, header *multipart.FileHeader) (string, error) {
-	// Validate file type
-	if !s.isValidImageType(header.Filename) {
-		return "", fmt.Errorf("invalid file type. Only JPEG, PNG, and GIF files are allowed")
-	}
-
-	// Read file content
-	fileContent, err := io.ReadAll(file)
-	if err != nil {
-		return "", fmt.Errorf("failed to read file: %w", err)
+		return nil, fmt.Errorf("failed to download file from S3: %w", err)
 	}
+	defer result.Body.Close()
 
-	file.Seek(0, 0)
-
-	fileExtension := filepath.Ext(header.Filename)
-	s3Key := fmt.Sprintf("posts/%s/%s%s", postID, imageID, fileExtension)
-
-	// Upload to S3
-	_, err = s.client.PutObject(ctx, &s3.PutObjectInput{
-		Bucket:      aws.String(s.bucketName),
-		Key:         aws.String(s3Key),
-		Body:        bytes.NewReader(fileContent),
-		ContentType: aws.String(s.getContentType(fileExtension)),
-	})
-
+	body, err := io.ReadAll(result.Body)
 	if err != nil {
-		return "", fmt.Errorf("failed to upload post image to S3: %w", err)
+		return nil, fmt.Errorf("failed to read file from S3: %w", err)
 	}
 
-	// Generate and return the public URL
-	imageURL := fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", s.bucketName, s.region, s3Key)
-	return imageURL, nil
+	return body, nil
 }
 
-// DeletePostImage deletes a post image from S3
-func (s *S3Service) DeletePostImage(ctx context.Context, imageURL string) error {
-	// Extract S3 key from URL
-	s3Key, err := s.extractS3KeyFromURL(imageURL)
-	if err != nil {
-		return fmt.Errorf("failed to extract S3 key from URL: %w", err)
-	}
-
-	_, err = s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+// DeleteObject deletes a single object from the bucket
+func (s *S3Service) DeleteObject(ctx context.Context, key string) error {
+	_, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
 		Bucket: aws.String(s.bucketName),
-		Key:    aws.String(s3Key),
+		Key:    aws.String(key),
 	})
-
 	if err != nil {
-		return fmt.Errorf("failed to delete post image from S3: %w", err)
+		return fmt.Errorf("failed to delete object from S3: %w", err)
 	}
 
 	return nil
 }
 
-// DeletePostFolder deletes all images in a post folder
-func (s *S3Service) DeletePostFolder(ctx context.Context, postID string) error {
-	prefix := fmt.Sprintf("posts/%s/", postID)
-
+// DeletePrefix deletes all objects under a key prefix
+func (s *S3Service) DeletePrefix(ctx context.Context, prefix string) error {
 	// List objects with the prefix
 	listInput := &s3.ListObjectsV2Input{
 		Bucket: aws.String(s.bucketName),
@@ -186,7 +95,7 @@ func (s *S3Service) DeletePostFolder(ctx context.Context, postID string) error {
 
 	result, err := s.client.ListObjectsV2(ctx, listInput)
 	if err != nil {
-		return fmt.Errorf("failed to list objects for post %s: %w", postID, err)
+		return fmt.Errorf("failed to list objects for prefix %s: %w", prefix, err)
 	}
 
 	// Delete each object
@@ -203,118 +112,13 @@ func (s *S3Service) DeletePostFolder(ctx context.Context, postID string) error {
 	return nil
 }
 
-// UploadTournamentBanner uploads a tournament banner to S3 in the tournaments folder
-func (s *S3Service) UploadTournamentBanner(ctx context.Context, hostID, bannerID string, file multipart.File, header *multipart.FileHeader) (string, error) {
-	// Validate file type
-	if !s.isValidImageType(header.Filename) {
-		return "", fmt.Errorf("invalid file type. Only JPEG, PNG, and GIF files are allowed")
-	}
-
-	// Read file content
-	fileContent, err := io.ReadAll(file)
-	if err != nil {
-		return "", fmt.Errorf("failed to read file: %w", err)
-	}
-
-	file.Seek(0, 0)
-
-	fileExtension := filepath.Ext(header.Filename)
-	s3Key := fmt.Sprintf("tournaments/%s/%s%s", hostID, bannerID, fileExtension)
-
-	// Upload to S3
-	_, err = s.client.PutObject(ctx, &s3.PutObjectInput{
-		Bucket:      aws.String(s.bucketName),
-		Key:         aws.String(s3Key),
-		Body:        bytes.NewReader(fileContent),
-		ContentType: aws.String(s.getContentType(fileExtension)),
-	})
-
-	if err != nil {
-		return "", fmt.Errorf("failed to upload tournament banner to S3: %w", err)
-	}
-
-	// Generate and return the public URL
-	imageURL := fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", s.bucketName, s.region, s3Key)
-	return imageURL, nil
-}
-
-// DeleteTournamentBanner deletes a tournament banner from S3
-func (s *S3Service) DeleteTournamentBanner(ctx context.Context, imageURL string) error {
-	// Extract S3 key from URL
-	s3Key, err := s.extractS3KeyFromURL(imageURL)
-	if err != nil {
-		return fmt.Errorf("failed to extract S3 key from URL: %w", err)
-	}
-
-	_, err = s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
-		Bucket: aws.String(s.bucketName),
-		Key:    aws.String(s3Key),
-	})
-
-	if err != nil {
-		return fmt.Errorf("failed to delete tournament banner from S3: %w", err)
-	}
-
-	return nil
+// ObjectURL returns the public URL of an object in the bucket
+func (s *S3Service) ObjectURL(key string) string {
+	return fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", s.bucketName, s.region, key)
 }
 
-// UploadCertificate uploads an achievement certificate to S3 in the certificates folder
-func (s *S3Service) UploadCertificate(ctx context.Context, userID, achievementID string, file multipart.File, header *multipart.FileHeader) (string, error) {
-	// Validate file type - allow PDF and images for certificates
-	if !s.isValidCertificateType(header.Filename) {
-		return "", fmt.Errorf("invalid file type. Only PDF, JPEG, PNG, and GIF files are allowed for certificates")
-	}
-
-	// Read file content
-	fileContent, err := io.ReadAll(file)
-	if err != nil {
-		return "", fmt.Errorf("failed to read file: %w", err)
-	}
-
-	file.Seek(0, 0)
-
-	fileExtension := filepath.Ext(header.Filename)
-	s3Key := fmt.Sprintf("certificates/%s/%s%s", userID, achievementID, fileExtension)
-
-	// Upload to S3
-	_, err = s.client.PutObject(ctx, &s3.PutObjectInput{
-		Bucket:      aws.String(s.bucketName),
-		Key:         aws.String(s3Key),
-		Body:        bytes.NewReader(fileContent),
-		ContentType: aws.String(s.getCertificateContentType(fileExtension)),
-	})
-
-	if err != nil {
-		return "", fmt.Errorf("failed to upload certificate to S3: %w", err)
-	}
-
-	// Generate and return the public URL
-	imageURL := fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", s.bucketName, s.region, s3Key)
-	return imageURL, nil
-}
-
-// DeleteCertificate deletes a certificate from S3
-func (s *S3Service) DeleteCertificate(ctx context.Context, imageURL string) error {
-	// Extract S3 key from URL
-	s3Key, err := s.extractS3KeyFromURL(imageURL)
-	if err != nil {
-		return fmt.Errorf("failed to extract S3 key from URL: %w", err)
-	}
-
-	_, err = s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
-		Bucket: aws.String(s.bucketName),
-		Key:    aws.String(s3Key),
-	})
-
-	if err != nil {
-		return fmt.Errorf("failed to delete certificate from S3: %w", err)
-	}
-
-	return nil
-}
-
-// extractS3KeyFromURL extracts the S3 key from a full S3 URL
-func (s *S3Service) extractS3KeyFromURL(imageURL string) (string, error) {
+// KeyFromURL extracts the S3 key from a full S3 URL
+func (s *S3Service) KeyFromURL(imageURL string) (string, error) {
 	expectedPrefix := fmt.Sprintf("https://%s.s3.%s.amazonaws.com/", s.bucketName, s.region)
 	if !strings.HasPrefix(imageURL, expectedPrefix) {
 		return "", fmt.Errorf("invalid S3 URL format")
@@ -328,58 +132,22 @@ func (s *S3Service) extractS3KeyFromURL(imageURL string) (string, error) {
 	return s3Key, nil
 }
 
-// isValidImageType checks if the file type is a valid image type
-func (s *S3Service) isValidImageType(filename string) bool {
-	ext := strings.ToLower(filepath.Ext(filename))
-	validTypes := []string{".jpg", ".jpeg", ".png", ".gif"}
-
-	for _, validType := range validTypes {
-		if ext == validType {
-			return true
-		}
-	}
-	return false
-}
-
-// getContentType returns the appropriate content type for the file extension
-func (s *S3Service) getContentType(extension string) string {
-	switch strings.ToLower(extension) {
-	case ".jpg", ".jpeg":
-		return "image/jpeg"
-	case ".png":
-		return "image/png"
-	case ".gif":
-		return "image/gif"
-	default:
-		return "application/octet-stream"
-	}
-}
+// PresignPutObject generates a presigned URL for uploading an object
+func (s *S3Service) PresignPutObject(ctx context.Context, key string, contentType string, duration time.Duration) (string, error) {
+	presignClient := s3.NewPresignClient(s.client)
 
-// isValidCertificateType checks if the file type is valid for certificates (images + PDF)
-func (s *S3Service) isValidCertificateType(filename string) bool {
-	ext := strings.ToLower(filepath.Ext(filename))
-	validTypes := []string{".jpg", ".jpeg", ".png", ".gif", ".pdf"}
+	request, err := presignClient.PresignPutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(s.bucketName),
+		Key:         aws.String(key),
+		ContentType: aws.String(contentType),
+		// ACL:         types.ObjectCannedACLPublicRead, // Removed for Bucket Owner Enforced
+	}, func(opts *s3.PresignOptions) {
+		opts.Expires = duration
+	})
 
-	for _, validType := range validTypes {
-		if ext == validType {
-			return true
-		}
+	if err != nil {
+		return "", fmt.Errorf("failed to generate presigned URL: %w", err)
 	}
-	return false
-}
 
-// getCertificateContentType returns the appropriate content type for certificate files
-func (s *S3Service) getCertificateContentType(extension string) string {
-	switch strings.ToLower(extension) {
-	case ".jpg", ".jpeg":
-		return "image/jpeg"
-	case ".png":
-		return "image/png"
-	case ".gif":
-		return "image/gif"
-	case ".pdf":
-		return "application/pdf"
-	default:
-		return "application/octet-stream"
-	}
+	return request.URL, nil
 }
@@ -1 +1,36 @@
 package services
+
+import (
+	"context"
+	"time"
+)
+
+// BlobStore is the object storage backend used for user uploads. Keys are
+// slash separated paths such as "posts/<post id>/<image id>.png"; the URLs
+// it returns are what gets persisted in the database.
+type BlobStore interface {
+	// PutObject stores body under key and returns its public URL.
+	PutObject(ctx context.Context, key string, body []byte, contentType string) (string, error)
+	// GetObject reads back the body of an object.
+	GetObject(ctx context.Context, key string) ([]byte, error)
+	// DeleteObject removes a single object.
+	DeleteObject(ctx context.Context, key string) error
+	// DeletePrefix removes every object whose key starts with prefix.
+	DeletePrefix(ctx context.Context, prefix string) error
+	// ObjectURL returns the public URL for key without checking that it exists.
+	ObjectURL(key string) string
+	// KeyFromURL is the inverse of ObjectURL.
+	KeyFromURL(url string) (string, error)
+	// PresignPutObject returns a URL that allows uploading key for duration.
+	PresignPutObject(ctx context.Context, key string, contentType string, duration time.Duration) (string, error)
+}
+
+// truncate shortens content to at most length characters for a notification,
+// marking where it was cut with an ellipsis
+func truncate(content string, length int) string {
+	runes := []rune(content)
+	if len(runes) <= length {
+		return content
+	}
+	return string(runes[:length]) + "…"
+}
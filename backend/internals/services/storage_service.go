@@ -0,0 +1,297 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// StorageService knows where each kind of upload lives and which file types
+// it accepts. The bytes themselves go to the configured BlobStore.
+type StorageService struct {
+	blobs         BlobStore
+	maxVideoBytes int64
+}
+
+// NewStorageService returns a StorageService that rejects post videos
+// larger than maxVideoBytes
+func NewStorageService(blobs BlobStore, maxVideoBytes int64) *StorageService {
+	return &StorageService{blobs: blobs, maxVideoBytes: maxVideoBytes}
+}
+
+// UploadProfilePicture uploads a profile picture using the Cognito user ID as the key
+func (s *StorageService) UploadProfilePicture(ctx context.Context, userID string, file multipart.File, header *multipart.FileHeader) (string, error) {
+	// Validate file type
+	if !s.isValidImageType(header.Filename) {
+		return "", fmt.Errorf("invalid file type. Only JPEG, PNG, and GIF files are allowed")
+	}
+
+	fileExtension := filepath.Ext(header.Filename)
+	key := fmt.Sprintf("profile-pictures/%s%s", userID, fileExtension)
+
+	return s.upload(ctx, key, file, s.getContentType(fileExtension))
+}
+
+// GetProfilePictureURL returns the URL for a user's profile picture
+func (s *StorageService) GetProfilePictureURL(userID string, fileExtension string) string {
+	if fileExtension == "" {
+		fileExtension = ".jpg" // default extension
+	}
+	return s.blobs.ObjectURL(fmt.Sprintf("profile-pictures/%s%s", userID, fileExtension))
+}
+
+// DeleteProfilePicture deletes a user's profile picture
+func (s *StorageService) DeleteProfilePicture(ctx context.Context, userID string, fileExtension string) error {
+	key := fmt.Sprintf("profile-pictures/%s%s", userID, fileExtension)
+	if err := s.blobs.DeleteObject(ctx, key); err != nil {
+		return fmt.Errorf("failed to delete profile picture: %w", err)
+	}
+	return nil
+}
+
+// GeneratePresignedURL generates a presigned URL for uploading profile pictures
+func (s *StorageService) GeneratePresignedURL(ctx context.Context, userID string, fileExtension string, duration time.Duration) (string, error) {
+	key := fmt.Sprintf("profile-pictures/%s%s", userID, fileExtension)
+	return s.blobs.PresignPutObject(ctx, key, s.getContentType(fileExtension), duration)
+}
+
+// UploadPostImage uploads a post image in the posts folder
+func (s *StorageService) UploadPostImage(ctx context.Context, postID, imageID string, file multipart.File, header *multipart.FileHeader) (string, error) {
+	// Validate file type
+	if !s.isValidImageType(header.Filename) {
+		return "", fmt.Errorf("invalid file type. Only JPEG, PNG, and GIF files are allowed")
+	}
+
+	fileExtension := filepath.Ext(header.Filename)
+	key := fmt.Sprintf("posts/%s/%s%s", postID, imageID, fileExtension)
+
+	return s.upload(ctx, key, file, s.getContentType(fileExtension))
+}
+
+// UploadPostVideo uploads a post video in the posts folder. Its duration is
+// only known once the media worker has processed it.
+func (s *StorageService) UploadPostVideo(ctx context.Context, postID, videoID string, file multipart.File, header *multipart.FileHeader) (string, error) {
+	// Validate file type and size
+	if !s.isValidVideoType(header.Filename) {
+		return "", fmt.Errorf("invalid file type. Only MP4, MOV and WEBM files are allowed")
+	}
+	if header.Size > s.maxVideoBytes {
+		return "", fmt.Errorf("video is too large. The maximum size is %d MB", s.maxVideoBytes>>20)
+	}
+
+	fileExtension := filepath.Ext(header.Filename)
+	key := fmt.Sprintf("posts/%s/%s%s", postID, videoID, fileExtension)
+
+	return s.upload(ctx, key, file, s.getVideoContentType(fileExtension))
+}
+
+// GetPostMedia downloads an uploaded post image or video by its URL
+func (s *StorageService) GetPostMedia(ctx context.Context, mediaURL string) ([]byte, error) {
+	key, err := s.blobs.KeyFromURL(mediaURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract key from URL: %w", err)
+	}
+	return s.blobs.GetObject(ctx, key)
+}
+
+// UploadVideoThumbnail stores the thumbnail made for a post video next to it
+func (s *StorageService) UploadVideoThumbnail(ctx context.Context, postID, mediaID string, thumbnail []byte, contentType string) (string, error) {
+	key := fmt.Sprintf("posts/%s/%s_thumb%s", postID, mediaID, s.extensionFor(contentType))
+	return s.blobs.PutObject(ctx, key, thumbnail, contentType)
+}
+
+// DeletePostImage deletes a post image by its URL
+func (s *StorageService) DeletePostImage(ctx context.Context, imageURL string) error {
+	if err := s.deleteByURL(ctx, imageURL); err != nil {
+		return fmt.Errorf("failed to delete post image: %w", err)
+	}
+	return nil
+}
+
+// DeletePostFolder deletes all images in a post folder
+func (s *StorageService) DeletePostFolder(ctx context.Context, postID string) error {
+	return s.blobs.DeletePrefix(ctx, fmt.Sprintf("posts/%s/", postID))
+}
+
+// UploadTournamentBanner uploads a tournament banner in the tournaments folder
+func (s *StorageService) UploadTournamentBanner(ctx context.Context, hostID, bannerID string, file multipart.File, header *multipart.FileHeader) (string, error) {
+	// Validate file type
+	if !s.isValidImageType(header.Filename) {
+		return "", fmt.Errorf("invalid file type. Only JPEG, PNG, and GIF files are allowed")
+	}
+
+	fileExtension := filepath.Ext(header.Filename)
+	key := fmt.Sprintf("tournaments/%s/%s%s", hostID, bannerID, fileExtension)
+
+	return s.upload(ctx, key, file, s.getContentType(fileExtension))
+}
+
+// DeleteTournamentBanner deletes a tournament banner by its URL
+func (s *StorageService) DeleteTournamentBanner(ctx context.Context, imageURL string) error {
+	if err := s.deleteByURL(ctx, imageURL); err != nil {
+		return fmt.Errorf("failed to delete tournament banner: %w", err)
+	}
+	return nil
+}
+
+// UploadChatAvatar uploads the avatar of a group chat room
+func (s *StorageService) UploadChatAvatar(ctx context.Context, roomID, avatarID string, file multipart.File, header *multipart.FileHeader) (string, error) {
+	// Validate file type
+	if !s.isValidImageType(header.Filename) {
+		return "", fmt.Errorf("invalid file type. Only JPEG, PNG, and GIF files are allowed")
+	}
+
+	fileExtension := filepath.Ext(header.Filename)
+	key := fmt.Sprintf("chats/%s/%s%s", roomID, avatarID, fileExtension)
+
+	return s.upload(ctx, key, file, s.getContentType(fileExtension))
+}
+
+// DeleteChatAvatar deletes a group chat room avatar by its URL
+func (s *StorageService) DeleteChatAvatar(ctx context.Context, imageURL string) error {
+	if err := s.deleteByURL(ctx, imageURL); err != nil {
+		return fmt.Errorf("failed to delete chat avatar: %w", err)
+	}
+	return nil
+}
+
+// UploadCertificate uploads an achievement certificate in the certificates folder
+func (s *StorageService) UploadCertificate(ctx context.Context, userID, achievementID string, file multipart.File, header *multipart.FileHeader) (string, error) {
+	// Validate file type - allow PDF and images for certificates
+	if !s.isValidCertificateType(header.Filename) {
+		return "", fmt.Errorf("invalid file type. Only PDF, JPEG, PNG, and GIF files are allowed for certificates")
+	}
+
+	fileExtension := filepath.Ext(header.Filename)
+	key := fmt.Sprintf("certificates/%s/%s%s", userID, achievementID, fileExtension)
+
+	return s.upload(ctx, key, file, s.getCertificateContentType(fileExtension))
+}
+
+// DeleteCertificate deletes a certificate by its URL
+func (s *StorageService) DeleteCertificate(ctx context.Context, imageURL string) error {
+	if err := s.deleteByURL(ctx, imageURL); err != nil {
+		return fmt.Errorf("failed to delete certificate: %w", err)
+	}
+	return nil
+}
+
+// upload reads the multipart file and stores it under key
+func (s *StorageService) upload(ctx context.Context, key string, file multipart.File, contentType string) (string, error) {
+	// Read file content
+	fileContent, err := io.ReadAll(file)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+
+	file.Seek(0, 0)
+
+	return s.blobs.PutObject(ctx, key, fileContent, contentType)
+}
+
+func (s *StorageService) deleteByURL(ctx context.Context, url string) error {
+	key, err := s.blobs.KeyFromURL(url)
+	if err != nil {
+		return fmt.Errorf("failed to extract key from URL: %w", err)
+	}
+	return s.blobs.DeleteObject(ctx, key)
+}
+
+// isValidImageType checks if the file type is a valid image type
+func (s *StorageService) isValidImageType(filename string) bool {
+	ext := strings.ToLower(filepath.Ext(filename))
+	validTypes := []string{".jpg", ".jpeg", ".png", ".gif"}
+
+	for _, validType := range validTypes {
+		if ext == validType {
+			return true
+		}
+	}
+	return false
+}
+
+// getContentType returns the appropriate content type for the file extension
+func (s *StorageService) getContentType(extension string) string {
+	switch strings.ToLower(extension) {
+	case ".jpg", ".jpeg":
+		return "image/jpeg"
+	case ".png":
+		return "image/png"
+	case ".gif":
+		return "image/gif"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// isValidVideoType checks if the file type is a valid video type
+func (s *StorageService) isValidVideoType(filename string) bool {
+	ext := strings.ToLower(filepath.Ext(filename))
+	validTypes := []string{".mp4", ".mov", ".webm"}
+
+	for _, validType := range validTypes {
+		if ext == validType {
+			return true
+		}
+	}
+	return false
+}
+
+// getVideoContentType returns the appropriate content type for video files
+func (s *StorageService) getVideoContentType(extension string) string {
+	switch strings.ToLower(extension) {
+	case ".mp4":
+		return "video/mp4"
+	case ".mov":
+		return "video/quicktime"
+	case ".webm":
+		return "video/webm"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// extensionFor is the inverse of getContentType
+func (s *StorageService) extensionFor(contentType string) string {
+	switch contentType {
+	case "image/png":
+		return ".png"
+	case "image/gif":
+		return ".gif"
+	default:
+		return ".jpg"
+	}
+}
+
+// isValidCertificateType checks if the file type is valid for certificates (images + PDF)
+func (s *StorageService) isValidCertificateType(filename string) bool {
+	ext := strings.ToLower(filepath.Ext(filename))
+	validTypes := []string{".jpg", ".jpeg", ".png", ".gif", ".pdf"}
+
+	for _, validType := range validTypes {
+		if ext == validType {
+			return true
+		}
+	}
+	return false
+}
+
+// getCertificateContentType returns the appropriate content type for certificate files
+func (s *StorageService) getCertificateContentType(extension string) string {
+	switch strings.ToLower(extension) {
+	case ".jpg", ".jpeg":
+		return "image/jpeg"
+	case ".png":
+		return "image/png"
+	case ".gif":
+		return "image/gif"
+	case ".pdf":
+		return "application/pdf"
+	default:
+		return "application/octet-stream"
+	}
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"log"
+
+	"github.com/google/uuid"
+	"sportsin_backend/internals/chat/redis"
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/model"
+	"sportsin_backend/internals/notifications"
+	event "sportsin_backend/internals/notifications/events"
+	"sportsin_backend/internals/store"
+)
+
+// announcementPreviewLength is the number of characters of an announcement
+// shown in its push notification
+const announcementPreviewLength = 140
+
+// TournamentChatService keeps the channel of each tournament, a group chat
+// room owned by the host, in step with the tournament's accepted
+// participants, and broadcasts the host's announcements to it. The channel
+// is secondary to participation, so membership failures are logged rather
+// than returned.
+type TournamentChatService struct {
+	repo     store.TournamentChatStore
+	hub      *redis.Hub
+	notifier notifications.Notifier
+}
+
+func NewTournamentChatService(repo store.TournamentChatStore, hub *redis.Hub, notifier notifications.Notifier) *TournamentChatService {
+	return &TournamentChatService{repo: repo, hub: hub, notifier: notifier}
+}
+
+// ParticipantStatusChanged adds an accepted participant to the channel and
+// removes one who is no longer accepted
+func (s *TournamentChatService) ParticipantStatusChanged(tournamentID, userID string, status model.ParticipationStatus) {
+	if status == model.Accepted {
+		s.addMember(tournamentID, userID)
+	} else {
+		s.removeMember(tournamentID, userID)
+	}
+}
+
+// ParticipantLeft removes a participant who left the tournament from the
+// channel
+func (s *TournamentChatService) ParticipantLeft(tournamentID, userID string) {
+	s.removeMember(tournamentID, userID)
+}
+
+// Announce stores an announcement of the host in the channel, creating it
+// when no participant has been accepted yet, delivers it to the members
+// connected to the chat and sends it to the other members as a push
+// notification
+func (s *TournamentChatService) Announce(tournament *model.Tournament, content string) (*model.ChatMessage, error) {
+	room, err := s.repo.EnsureTournamentChatRoom(tournament)
+	if err != nil {
+		return nil, err
+	}
+	roomID := uuid.MustParse(room.Id)
+	msg, err := s.repo.CreateAnnouncement(roomID, uuid.MustParse(tournament.HostId), content)
+	if err != nil {
+		return nil, err
+	}
+	s.hub.PublishAnnouncement(s.repo, msg)
+
+	members, err := s.repo.GetChatRoomMembers(roomID)
+	if err != nil {
+		log.Printf("ERROR: failed to load members of chat room %s: %v", room.Id, err)
+		return msg, nil
+	}
+	announcement := event.TournamentAnnouncementEvent{
+		TournamentID: tournament.Id,
+		ChatRoomID:   room.Id,
+		MessageID:    msg.Id,
+		Title:        tournament.Title,
+		Message:      truncate(content, announcementPreviewLength),
+		Platform:     "android",
+	}
+	for _, m := range members {
+		if m.UserId == tournament.HostId {
+			continue
+		}
+		arn, err := s.repo.GetUserSnsEndpointArn(uuid.MustParse(m.UserId))
+		if err != nil || arn == "" {
+			continue
+		}
+		announcement.RecipientARN = arn
+		go func(userID string, announcement event.TournamentAnnouncementEvent) {
+			if err := event.SendTournamentAnnouncementNotification(s.notifier, announcement); err != nil {
+				log.Printf("ERROR: failed to notify %s of announcement: %v", userID, err)
+			}
+		}(m.UserId, announcement)
+	}
+	return msg, nil
+}
+
+func (s *TournamentChatService) addMember(tournamentID, userID string) {
+	memberID, err := uuid.Parse(userID)
+	if err != nil {
+		return
+	}
+	tournament, err := s.repo.GetTournamentByID(tournamentID)
+	if err != nil {
+		log.Printf("ERROR: failed to load tournament %s for its chat: %v", tournamentID, err)
+		return
+	}
+	room, err := s.repo.EnsureTournamentChatRoom(tournament)
+	if err != nil {
+		log.Printf("ERROR: failed to create chat room of tournament %s: %v", tournamentID, err)
+		return
+	}
+	if userID == tournament.HostId {
+		return
+	}
+	_, err = s.repo.AddChatRoomMember(uuid.MustParse(room.Id), memberID)
+	if err != nil && !db.IsAlreadyExistsError(err) {
+		log.Printf("ERROR: failed to add %s to chat room of tournament %s: %v", userID, tournamentID, err)
+	}
+}
+
+func (s *TournamentChatService) removeMember(tournamentID, userID string) {
+	memberID, err := uuid.Parse(userID)
+	if err != nil {
+		return
+	}
+	room, err := s.repo.GetTournamentChatRoom(tournamentID)
+	if db.IsNotFoundError(err) {
+		return
+	}
+	if err != nil {
+		log.Printf("ERROR: failed to load chat room of tournament %s: %v", tournamentID, err)
+		return
+	}
+	roomID := uuid.MustParse(room.Id)
+	// The host owns the channel whatever happens to their own participation
+	member, err := s.repo.GetChatRoomMember(roomID, memberID)
+	if err != nil || member.Role == model.ChatRoleOwner {
+		return
+	}
+	if err := s.repo.RemoveChatRoomMember(roomID, memberID); err != nil && !db.IsNotFoundError(err) {
+		log.Printf("ERROR: failed to remove %s from chat room of tournament %s: %v", userID, tournamentID, err)
+	}
+}
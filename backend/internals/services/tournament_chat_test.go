@@ -0,0 +1,93 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"sportsin_backend/internals/model"
+	"sportsin_backend/internals/services"
+	"sportsin_backend/internals/store/memory"
+)
+
+// channelRoles returns the roles of the members of a tournament's channel,
+// or nil when it has none
+func channelRoles(t *testing.T, repo *memory.Store, tournamentID string) map[string]model.ChatMemberRole {
+	t.Helper()
+	room, err := repo.GetTournamentChatRoom(tournamentID)
+	if err != nil {
+		return nil
+	}
+	members, err := repo.GetChatRoomMembers(uuid.MustParse(room.Id))
+	if err != nil {
+		t.Fatalf("GetChatRoomMembers: %v", err)
+	}
+	roles := make(map[string]model.ChatMemberRole)
+	for _, m := range members {
+		roles[m.UserId] = m.Role
+	}
+	return roles
+}
+
+func newTournamentChat(t *testing.T) (*services.TournamentChatService, *memory.Store, *model.Tournament) {
+	t.Helper()
+	repo := memory.NewStore()
+	tournament := &model.Tournament{HostId: memory.NewTestUser(t, repo), Title: "Summer cup"}
+	if err := repo.CreateTournament(tournament); err != nil {
+		t.Fatalf("CreateTournament: %v", err)
+	}
+	// Membership changes never publish or notify
+	return services.NewTournamentChatService(repo, nil, nil), repo, tournament
+}
+
+func TestTournamentChatFollowsAcceptedParticipants(t *testing.T) {
+	chat, repo, tournament := newTournamentChat(t)
+	accepted, rejected, left := memory.NewTestUser(t, repo), memory.NewTestUser(t, repo), memory.NewTestUser(t, repo)
+
+	chat.ParticipantStatusChanged(tournament.Id, rejected, model.Rejected)
+	if roles := channelRoles(t, repo, tournament.Id); roles != nil {
+		t.Fatalf("channel = %v after a rejection, want none", roles)
+	}
+
+	chat.ParticipantStatusChanged(tournament.Id, accepted, model.Accepted)
+	chat.ParticipantStatusChanged(tournament.Id, left, model.Accepted)
+	chat.ParticipantStatusChanged(tournament.Id, rejected, model.Accepted)
+	roles := channelRoles(t, repo, tournament.Id)
+	if len(roles) != 4 || roles[tournament.HostId] != model.ChatRoleOwner || roles[accepted] != model.ChatRoleMember {
+		t.Fatalf("channel = %v, want the host as owner and three members", roles)
+	}
+
+	// Accepting twice keeps a single membership
+	chat.ParticipantStatusChanged(tournament.Id, accepted, model.Accepted)
+	if roles := channelRoles(t, repo, tournament.Id); len(roles) != 4 {
+		t.Errorf("channel = %v after accepting twice, want four members", roles)
+	}
+
+	chat.ParticipantStatusChanged(tournament.Id, rejected, model.Rejected)
+	chat.ParticipantLeft(tournament.Id, left)
+	roles = channelRoles(t, repo, tournament.Id)
+	if _, ok := roles[rejected]; ok {
+		t.Errorf("rejected participant is still in the channel")
+	}
+	if _, ok := roles[left]; ok {
+		t.Errorf("participant who left is still in the channel")
+	}
+	if len(roles) != 2 || roles[accepted] != model.ChatRoleMember {
+		t.Errorf("channel = %v, want the host and %s", roles, accepted)
+	}
+}
+
+func TestTournamentChatHostStaysOwner(t *testing.T) {
+	chat, repo, tournament := newTournamentChat(t)
+	player := memory.NewTestUser(t, repo)
+	chat.ParticipantStatusChanged(tournament.Id, player, model.Accepted)
+
+	// The host's own participation never changes the channel's owner
+	chat.ParticipantStatusChanged(tournament.Id, tournament.HostId, model.Accepted)
+	chat.ParticipantStatusChanged(tournament.Id, tournament.HostId, model.Rejected)
+	chat.ParticipantLeft(tournament.Id, tournament.HostId)
+
+	roles := channelRoles(t, repo, tournament.Id)
+	if len(roles) != 2 || roles[tournament.HostId] != model.ChatRoleOwner || roles[player] != model.ChatRoleMember {
+		t.Errorf("channel = %v, want the host as owner and %s as member", roles, player)
+	}
+}
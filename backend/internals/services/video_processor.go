@@ -0,0 +1,125 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ProcessedVideo is what the media worker learns from an uploaded video
+type ProcessedVideo struct {
+	Thumbnail     []byte
+	ThumbnailType string
+	Duration      time.Duration
+}
+
+// VideoProcessor reads the duration of a video and renders a thumbnail for
+// it. ext is the file extension of the upload, such as ".mp4".
+type VideoProcessor interface {
+	Process(ctx context.Context, video []byte, ext string) (*ProcessedVideo, error)
+}
+
+// FFmpegVideoProcessor runs the ffprobe and ffmpeg binaries found on PATH
+type FFmpegVideoProcessor struct{}
+
+var _ VideoProcessor = (*FFmpegVideoProcessor)(nil)
+
+func NewFFmpegVideoProcessor() (*FFmpegVideoProcessor, error) {
+	for _, bin := range []string{"ffprobe", "ffmpeg"} {
+		if _, err := exec.LookPath(bin); err != nil {
+			return nil, fmt.Errorf("%s not found on PATH: %w", bin, err)
+		}
+	}
+	return &FFmpegVideoProcessor{}, nil
+}
+
+// Process writes the video to a temporary file, probes its duration and
+// grabs a JPEG frame one second in, or the first frame of shorter clips
+func (p *FFmpegVideoProcessor) Process(ctx context.Context, video []byte, ext string) (*ProcessedVideo, error) {
+	dir, err := os.MkdirTemp("", "post-video-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	input := filepath.Join(dir, "input"+ext)
+	if err := os.WriteFile(input, video, 0o600); err != nil {
+		return nil, fmt.Errorf("failed to write video to disk: %w", err)
+	}
+
+	out, err := runCommand(ctx, "ffprobe", "-v", "error", "-show_entries", "format=duration",
+		"-of", "default=noprint_wrappers=1:nokey=1", input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to probe video: %w", err)
+	}
+	seconds, err := strconv.ParseFloat(strings.TrimSpace(out), 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read video duration %q: %w", strings.TrimSpace(out), err)
+	}
+
+	seek := "1"
+	if seconds < 1 {
+		seek = "0"
+	}
+	thumbnail := filepath.Join(dir, "thumbnail.jpg")
+	if _, err := runCommand(ctx, "ffmpeg", "-y", "-v", "error", "-ss", seek, "-i", input,
+		"-frames:v", "1", "-vf", "scale=480:-2", thumbnail); err != nil {
+		return nil, fmt.Errorf("failed to render thumbnail: %w", err)
+	}
+	image, err := os.ReadFile(thumbnail)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read thumbnail: %w", err)
+	}
+
+	return &ProcessedVideo{
+		Thumbnail:     image,
+		ThumbnailType: "image/jpeg",
+		Duration:      time.Duration(seconds * float64(time.Second)),
+	}, nil
+}
+
+func runCommand(ctx context.Context, name string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
+
+// placeholderThumbnail is a 1x1 grey PNG
+var placeholderThumbnail, _ = base64.StdEncoding.DecodeString(
+	"iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAAAAAA6fptVAAAACklEQVR4nGNoAAAAggCBd81ytgAAAABJRU5ErkJggg==")
+
+// StubVideoProcessor stands in for ffmpeg in development and tests. Every
+// video gets a placeholder thumbnail and the configured Duration, so the
+// worker's duration limit can be exercised by choosing it.
+type StubVideoProcessor struct {
+	Duration time.Duration
+}
+
+var _ VideoProcessor = (*StubVideoProcessor)(nil)
+
+func NewStubVideoProcessor(duration time.Duration) *StubVideoProcessor {
+	return &StubVideoProcessor{Duration: duration}
+}
+
+func (p *StubVideoProcessor) Process(ctx context.Context, video []byte, ext string) (*ProcessedVideo, error) {
+	if len(video) == 0 {
+		return nil, fmt.Errorf("video is empty")
+	}
+	return &ProcessedVideo{
+		Thumbnail:     placeholderThumbnail,
+		ThumbnailType: "image/png",
+		Duration:      p.Duration,
+	}, nil
+}
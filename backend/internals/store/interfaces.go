@@ -1 +1,303 @@
 package store
+
+import (
+	"time"
+
+	"sportsin_backend/internals/model"
+
+	"github.com/google/uuid"
+)
+
+// UserStore covers the account rows created on signup and the device
+// tokens used for push notifications.
+type UserStore interface {
+	CreateUserOnSignup(userId, email string, role model.Role) error
+	GetUserByID(userID string) (*model.User, error)
+	GetUserByEmail(email string) (*model.User, error)
+	AddUserDeviceTokenDetails(userID, deviceToken, snsEndpoint string) error
+}
+
+// CredentialStore covers the password logins kept by the local auth
+// provider.
+type CredentialStore interface {
+	CreateCredential(credential *model.Credential) error
+	GetCredentialByEmail(email string) (*model.Credential, error)
+	UpdateCredential(credential *model.Credential) error
+}
+
+// UserProfileStore covers player/recruiter profiles and referral codes.
+type UserProfileStore interface {
+	UserStore
+	CreateUserProfile(profile any) error
+	GetUserProfile(userId string) (any, error)
+	UpdateProfile(profile any) error
+	UpdateProfilePicture(email string, imageUrl string) error
+	UsernameExists(username string) (bool, error)
+	ReferalCodeExists(referralCode string) (bool, error)
+	UserHasReferalCode(userId string) (bool, error)
+	CreateReferalCode(userId, referalCode string) error
+	GetReferalCode(userId string) (string, error)
+	ReferUser(userId, referalCode string, actor model.AuditActor) error
+}
+
+// FollowStore covers the follow graph between users.
+type FollowStore interface {
+	FollowUser(followerID, followingID string) error
+	UnfollowUser(followerID, followingID string) error
+	GetFollowers(userID string, limit, offset int) ([]model.FollowUser, error)
+	GetFollowing(userID string, limit, offset int) ([]model.FollowUser, error)
+	GetMutualFollows(userID string, limit, offset int) ([]model.FollowUser, error)
+	GetFollowStats(userID, viewerID string) (*model.FollowStats, error)
+}
+
+// UserSkillStore covers the sports a user plays.
+type UserSkillStore interface {
+	AddUserSkill(userID, sportID string) error
+	RemoveUserSkill(userID, sportID string) error
+	GetUserSkills(userID string) ([]model.Sport, error)
+}
+
+// ProfileStore is what the profile routes need, since profiles are shown
+// with their follower counts and sports.
+type ProfileStore interface {
+	UserProfileStore
+	FollowStore
+	UserSkillStore
+}
+
+// PostStore covers posts together with their images, reactions and reposts.
+type PostStore interface {
+	CreatePost(post *model.Post) error
+	GetPostById(postId string) (*model.Post, error)
+	GetPostsByUserId(userId string, limit, offset int, after *model.Cursor) ([]model.Post, error)
+	GetAllPosts(limit, offset int, after *model.Cursor) ([]model.Post, error)
+	UpdatePost(post *model.Post) error
+	DeletePost(postId, userId string, action *model.AdminAction) ([]string, error)
+	CheckPostOwnership(postId, userId string) (bool, error)
+	GetAllPostsWithComments(limit, offset int, after *model.Cursor, userId string) ([]model.PostWithComment, error)
+	GetAllPostsByUserIdWithComments(targetUserId string, limit, offset int, after *model.Cursor, currentUserId string) ([]model.PostWithComment, error)
+
+	CreatePostImage(postImage *model.PostImage) error
+	GetImagesByPostId(postId string) ([]model.PostImage, error)
+	DeleteImagesByPostId(postId string) ([]string, error)
+	DeletePostImage(imageId string) (string, error)
+
+	ReactToPost(postID, userID string, reaction model.ReactionType) error
+	RemovePostReaction(postID, userID string) error
+
+	CreateRepost(post *model.Post) error
+	DeleteRepost(postId, userId string) error
+}
+
+// MediaStore covers the processing of videos attached to posts, which the
+// media worker moves from pending to ready or failed.
+type MediaStore interface {
+	GetUnprocessedPostMedia() ([]model.PostImage, error)
+	UpdatePostMediaStatus(mediaId string, status model.MediaStatus, processingError *string) error
+	CompletePostMedia(media *model.PostImage) error
+}
+
+// FeedStore covers the inputs of the ranked home feed. Candidates carry
+// the ranking signals; the chosen page is then loaded by ID.
+type FeedStore interface {
+	GetFeedCandidates(viewerID string, query *model.FeedQuery) ([]model.FeedCandidate, error)
+	GetPostsWithCommentsByIds(postIds []string, userId string) ([]model.PostWithComment, error)
+}
+
+// TagStore covers browsing posts by hashtag. Tags are set through the
+// Tags field of PostStore's CreatePost and UpdatePost.
+type TagStore interface {
+	GetPostsByTag(tag string, limit, offset int, after *model.Cursor, userId string) ([]model.PostWithComment, error)
+	SearchTags(prefix string, limit int) ([]model.Tag, error)
+	GetTrendingTags(since time.Time, limit int) ([]model.Tag, error)
+}
+
+// MentionStore covers @username mentions in posts and comments.
+type MentionStore interface {
+	SetPostMentions(postId, authorId string, usernames []string) ([]model.MentionedUser, error)
+	AddCommentMentions(commentId, postId, authorId string, usernames []string) ([]model.MentionedUser, error)
+	GetPostsMentioningUser(userId string, limit, offset int, after *model.Cursor) ([]model.PostWithComment, error)
+}
+
+// CommentStore covers comments, their replies and likes.
+type CommentStore interface {
+	CreateComment(userId, postId, content string, parentId *string) (*model.Comment, error)
+	GetCommentsByPostId(postId string, limit, offset int, after *model.Cursor, sortBy model.CommentSort, userId string) ([]model.CommentResponse, error)
+	GetCommentById(commentId string, replyLimit, replyOffset int, userId string) (*model.CommentResponse, error)
+	UpdateComment(id, content string) error
+	DeleteComment(id string, action *model.AdminAction) error
+
+	LikeComment(commentID, userID string) error
+	UnlikeComment(commentID, userID string) error
+}
+
+// TournamentStore covers tournaments and their participants.
+type TournamentStore interface {
+	CreateTournament(tournament *model.Tournament) error
+	GetTournamentByID(tournamentID string) (*model.Tournament, error)
+	GetTournamentDetailsByID(tournamentID string, userID *string) (*model.TournamentDetails, error)
+	GetAllTournamentDetails(userID *string) ([]*model.TournamentDetails, error)
+	GetTournamentDetailsByHostID(hostID string, userID *string) ([]*model.TournamentDetails, error)
+	GetTournamentDetailsBySportID(sportID string, userID *string) ([]*model.TournamentDetails, error)
+	GetTournamentDetailsByStatus(status model.TournamentStatus, userID *string) ([]*model.TournamentDetails, error)
+	UpdateTournament(tournament *model.Tournament, action *model.AdminAction) error
+	DeleteTournament(tournamentID string, actor model.AuditActor) error
+
+	AddTournamentParticipant(participant *model.TounramentParticipants) error
+	GetTournamentParticipants(tournamentID string) ([]*model.TounramentParticipants, error)
+	GetUserTournaments(userID string) ([]*model.TounramentParticipants, error)
+	UpdateParticipantStatus(userID, tournamentID string, status model.ParticipationStatus, actor model.AuditActor) error
+	RemoveTournamentParticipant(userID, tournamentID string) error
+	GetParticipantByUserAndTournament(userID, tournamentID string) (*model.TounramentParticipants, error)
+	GetTournamentParticipantsByStatus(tournamentID string, status model.ParticipationStatus) ([]*model.TounramentParticipants, error)
+}
+
+// OpeningStore covers recruiter job openings.
+type OpeningStore interface {
+	CreateOpening(opening *model.Opening, saddress *model.SAddress, sportName string) (string, error)
+	UpdateOpening(opening *model.OpeningDetails) error
+	DeleteOpening(openingID string, actor model.AuditActor) error
+	UpdateOpeningStatus(openingID string, status model.OpeningStatus, action *model.AdminAction) error
+	GetOpeningByID(openingID string, playerID *string) (*model.OpeningDetails, error)
+	GetOpeningsByRecruiterID(recruiterID string, limit, offset int, after *model.Cursor, playerID *string) ([]*model.OpeningDetails, error)
+	GetAllOpenings(limit, offset int, after *model.Cursor, playerID *string) ([]*model.OpeningDetails, error)
+	GetOpeningsBySport(sportName string, limit, offset int, after *model.Cursor, playerID *string) ([]*model.OpeningDetails, error)
+	GetOpeningsByFilter(filter *model.OpeningFilter, limit, offset int, after *model.Cursor, playerID *string) ([]*model.OpeningDetails, error)
+}
+
+// ApplicationStore covers player applications to openings.
+type ApplicationStore interface {
+	CheckApplicationExists(playerID, openingID string) (bool, error)
+	CreateApplication(application *model.Application) (string, error)
+	GetApplicationByID(applicationID string) (*model.Application, error)
+	GetApplicationByPlayerIDAndOpeningID(playerID, openingID string) (*model.Application, error)
+	GetApplicationsByPlayerID(playerID string) ([]*model.Application, error)
+	GetApplicationsByOpeningID(openingID string) ([]*model.Application, error)
+	GetApplicantsByOpeningID(openingID string) ([]*model.Applicant, error)
+	UpdateApplicationStatus(applicationID string, status model.ApplicationStatus, actor model.AuditActor) error
+}
+
+// RecruitmentStore is what the opening routes need, since applications are
+// always checked against the opening they belong to.
+type RecruitmentStore interface {
+	OpeningStore
+	ApplicationStore
+}
+
+// ChatStore covers one-to-one and group chat rooms, their members and their
+// messages. Read state is kept per member as the time they last read the
+// room, which MarkMessagesAsRead moves forward and returns.
+type ChatStore interface {
+	FindOrCreateChatRoom(user1ID, user2ID uuid.UUID) (*model.ChatRoom, error)
+	GetChatRoomById(roomID uuid.UUID) (*model.ChatRoom, error)
+	CreateGroupChatRoom(room *model.ChatRoom, ownerID uuid.UUID, memberIDs []uuid.UUID) error
+	UpdateChatRoom(room *model.ChatRoom) error
+	GetChatRoomMembers(roomID uuid.UUID) ([]model.ChatRoomMember, error)
+	GetChatRoomMember(roomID, userID uuid.UUID) (*model.ChatRoomMember, error)
+	AddChatRoomMember(roomID, userID uuid.UUID) (*model.ChatRoomMember, error)
+	UpdateChatRoomMemberRole(roomID, userID uuid.UUID, role model.ChatMemberRole) error
+	RemoveChatRoomMember(roomID, userID uuid.UUID) error
+	EnsureTournamentChatRoom(tournament *model.Tournament) (*model.ChatRoom, error)
+	GetTournamentChatRoom(tournamentID string) (*model.ChatRoom, error)
+	CreateMessage(roomID, senderID uuid.UUID, content, clientMessageID string) (*model.ChatMessage, error)
+	CreateAnnouncement(roomID, senderID uuid.UUID, content string) (*model.ChatMessage, error)
+	GetMessageByClientId(senderID uuid.UUID, clientMessageID string) (*model.ChatMessage, error)
+	GetMessagesForRoom(roomID uuid.UUID, limit, offset int, after *model.Cursor) ([]model.ChatMessage, error)
+	MarkMessagesAsRead(roomID, readerID uuid.UUID) (string, error)
+	GetChatRoomsForUser(userID uuid.UUID) ([]model.ChatRoom, error)
+	IsUserInChatRoom(roomID, userID uuid.UUID) (bool, error)
+	GetChatContacts(userID uuid.UUID) ([]string, error)
+	GetUserSnsEndpointArn(userID uuid.UUID) (string, error)
+}
+
+// AchievementStore covers user achievements and their certificates.
+type AchievementStore interface {
+	CreateAchievement(userId string, achievement *model.Achievement) error
+	GetAchievementById(id string) (*model.Achievement, error)
+	GetAchievementsByUserId(userId string) ([]model.Achievement, error)
+	GetAchievementsByUser(userId string, limit, offset int, after *model.Cursor) ([]model.Achievement, error)
+	UpdateAchievement(achievement *model.Achievement) error
+	DeleteAchievementById(id string, action *model.AdminAction) error
+}
+
+// SportStore covers the sports catalogue.
+type SportStore interface {
+	GetSports(limit, offset int) ([]model.Sport, error)
+	GetSportById(id string) (*model.Sport, error)
+	GetSportByName(name string) (*model.Sport, error)
+	CreateSport(sport *model.Sport, action *model.AdminAction) (string, error)
+	UpdateSport(sport *model.Sport, action *model.AdminAction) error
+	DeleteSport(id string, action *model.AdminAction) error
+}
+
+// AdminStore covers account moderation and the audit trail of the /admin
+// endpoints. Entries are written by the store methods that take a
+// *model.AdminAction, in the same transaction as the change; callers outside
+// /admin pass nil.
+type AdminStore interface {
+	ListUsers(filter *model.UserFilter, limit, offset int) ([]model.User, error)
+	SetUserSuspended(userID string, suspended bool, action *model.AdminAction) error
+	GetAdminActions(limit, offset int) ([]model.AdminAction, error)
+}
+
+// AuditStore covers the audit trail of sensitive state changes. Events are
+// written by the store methods that take a model.AuditActor.
+type AuditStore interface {
+	GetAuditEvents(filter *model.AuditEventFilter, limit, offset int) ([]model.AuditEvent, error)
+}
+
+// ReportStore covers user reports on content and the moderation queue.
+// Posts and comments with enough open reports are left out of the feed by
+// PostStore and CommentStore.
+type ReportStore interface {
+	CreateReport(report *model.Report) error
+	GetReportById(id string) (*model.Report, error)
+	GetReports(filter *model.ReportFilter, limit, offset int) ([]model.Report, error)
+	ResolveReport(id string, status model.ReportStatus, resolverID string, action *model.AdminAction) (*model.Report, error)
+}
+
+// SavedItemStore covers bookmarks on posts, openings and tournaments. The
+// saved flag of each is set by PostStore, OpeningStore and TournamentStore.
+type SavedItemStore interface {
+	SaveItem(item *model.SavedItem) error
+	UnsaveItem(userId string, itemType model.SavedItemType, itemId string) error
+	GetSavedItems(userId string, itemType *model.SavedItemType, limit, offset int, after *model.Cursor) ([]model.SavedItem, error)
+}
+
+// SavedStore is what the saved items routes need, since saved items are
+// listed together with the content they point at.
+type SavedStore interface {
+	SavedItemStore
+	FeedStore
+	OpeningStore
+	TournamentStore
+}
+
+// TournamentChatStore is what the channels of tournaments need, since their
+// members follow the participants of the tournament.
+type TournamentChatStore interface {
+	TournamentStore
+	ChatStore
+}
+
+// Store is the full set of persistence operations used by the HTTP and chat
+// layers. *repositories.Repository is the Postgres implementation.
+type Store interface {
+	ProfileStore
+	CredentialStore
+	PostStore
+	MediaStore
+	FeedStore
+	TagStore
+	MentionStore
+	CommentStore
+	TournamentStore
+	RecruitmentStore
+	ChatStore
+	AchievementStore
+	SportStore
+	AdminStore
+	AuditStore
+	ReportStore
+	SavedItemStore
+}
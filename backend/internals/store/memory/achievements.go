@@ -0,0 +1,111 @@
+package memory
+
+import (
+	"fmt"
+	"sort"
+
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/model"
+)
+
+func (s *Store) CreateAchievement(userId string, achievement *model.Achievement) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ts := now()
+	achievement.Id = newID()
+	achievement.UserId = userId
+	achievement.CreatedAt = ts
+	achievement.UpdatedAt = ts
+	stored := *achievement
+	s.achievements = append(s.achievements, &stored)
+	return nil
+}
+
+func (s *Store) GetAchievementById(id string) (*model.Achievement, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	a := s.findAchievement(id)
+	if a == nil {
+		return nil, db.NewNotFoundError("achievement", id)
+	}
+	achievement := *a
+	return &achievement, nil
+}
+
+func (s *Store) GetAchievementsByUserId(userId string) ([]model.Achievement, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var achievements []model.Achievement
+	for _, a := range s.achievements {
+		if a.UserId == userId {
+			achievements = append(achievements, *a)
+		}
+	}
+	sort.SliceStable(achievements, func(i, j int) bool {
+		return achievements[i].Date > achievements[j].Date
+	})
+	return achievements, nil
+}
+
+func (s *Store) GetAchievementsByUser(userId string, limit, offset int, after *model.Cursor) ([]model.Achievement, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var achievements []model.Achievement
+	for _, a := range s.achievements {
+		if a.UserId == userId {
+			achievements = append(achievements, *a)
+		}
+	}
+	return keysetPage(achievements, limit, offset, after, true, func(a model.Achievement) (string, string) {
+		return a.Date, a.Id
+	}), nil
+}
+
+func (s *Store) UpdateAchievement(achievement *model.Achievement) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a := s.findAchievement(achievement.Id)
+	if a == nil || a.UserId != achievement.UserId {
+		return fmt.Errorf("achievement not found or unauthorized")
+	}
+	a.Date = achievement.Date
+	a.SportId = achievement.SportId
+	a.Tournament = achievement.Tournament
+	a.Description = achievement.Description
+	a.Level = achievement.Level
+	a.Stats = achievement.Stats
+	a.CertificateUrl = achievement.CertificateUrl
+	a.UpdatedAt = now()
+	return nil
+}
+
+func (s *Store) DeleteAchievementById(id string, action *model.AdminAction) error {
+	if err := checkAdminAction(action); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, a := range s.achievements {
+		if a.Id == id {
+			s.achievements = append(s.achievements[:i], s.achievements[i+1:]...)
+			s.recordAdminAction(action)
+			return nil
+		}
+	}
+	return db.NewNotFoundError("achievement", id)
+}
+
+func (s *Store) findAchievement(id string) *model.Achievement {
+	for _, a := range s.achievements {
+		if a.Id == id {
+			return a
+		}
+	}
+	return nil
+}
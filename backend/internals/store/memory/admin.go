@@ -0,0 +1,109 @@
+package memory
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/model"
+)
+
+func (s *Store) ListUsers(filter *model.UserFilter, limit, offset int) ([]model.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	users := []model.User{}
+	for _, u := range s.users {
+		if filter.Role != nil && u.Role != *filter.Role {
+			continue
+		}
+		if filter.Suspended != nil && (u.SuspendedAt != nil) != *filter.Suspended {
+			continue
+		}
+		if filter.Query != nil && *filter.Query != "" && !s.userMatches(u, *filter.Query) {
+			continue
+		}
+		users = append(users, *u)
+	}
+
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].CreatedAt > users[j].CreatedAt
+	})
+	return paginate(users, limit, offset), nil
+}
+
+func (s *Store) SetUserSuspended(userID string, suspended bool, action *model.AdminAction) error {
+	if err := checkAdminAction(action); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, ok := s.users[userID]
+	if !ok {
+		return db.NewNotFoundError("user", userID)
+	}
+	switch {
+	case !suspended:
+		u.SuspendedAt = nil
+	case u.SuspendedAt == nil:
+		ts := now()
+		u.SuspendedAt = &ts
+	}
+	u.UpdatedAt = now()
+	s.recordAdminAction(action)
+	return nil
+}
+
+// checkAdminAction fails the way the repository's insert of action does when
+// its admin_id is not a UUID, which rolls the change back. Methods taking an
+// action call it before making any change.
+func checkAdminAction(action *model.AdminAction) error {
+	if action == nil {
+		return nil
+	}
+	if _, err := uuid.Parse(action.AdminId); err != nil {
+		return db.NewDatabaseError("insert", "AdminAction", err)
+	}
+	return nil
+}
+
+// recordAdminAction appends action to the admin audit trail, as the
+// repository does in the transaction of the change. A nil action records
+// nothing. The caller must hold s.mu.
+func (s *Store) recordAdminAction(action *model.AdminAction) {
+	if action == nil {
+		return
+	}
+	action.Id = newID()
+	action.CreatedAt = now()
+	stored := *action
+	s.adminActions = append(s.adminActions, &stored)
+}
+
+func (s *Store) GetAdminActions(limit, offset int) ([]model.AdminAction, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	actions := []model.AdminAction{}
+	for i := len(s.adminActions) - 1; i >= 0; i-- {
+		actions = append(actions, *s.adminActions[i])
+	}
+	return paginate(actions, limit, offset), nil
+}
+
+// userMatches mirrors the ILIKE search over the account and its profile.
+func (s *Store) userMatches(u *model.User, query string) bool {
+	q := strings.ToLower(query)
+	fields := []string{u.Username, u.Email}
+	if d := profileDetails(s.profiles[u.Id]); d != nil {
+		fields = append(fields, d.UserName, d.Name)
+	}
+	for _, f := range fields {
+		if strings.Contains(strings.ToLower(f), q) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,194 @@
+package memory
+
+import (
+	"strings"
+
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/model"
+)
+
+func (s *Store) CheckApplicationExists(playerID, openingID string) (bool, error) {
+	if strings.TrimSpace(playerID) == "" {
+		return false, db.NewValidationError("player_id", "player ID cannot be empty")
+	}
+	if strings.TrimSpace(openingID) == "" {
+		return false, db.NewValidationError("opening_id", "opening ID cannot be empty")
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.findApplication(playerID, openingID) != nil, nil
+}
+
+func (s *Store) CreateApplication(application *model.Application) (string, error) {
+	if application == nil {
+		return "", db.NewValidationError("application", "application cannot be nil")
+	}
+	if strings.TrimSpace(application.PlayerID) == "" {
+		return "", db.NewValidationError("player_id", "player ID cannot be empty")
+	}
+	if strings.TrimSpace(application.OpeningID) == "" {
+		return "", db.NewValidationError("opening_id", "opening ID cannot be empty")
+	}
+	if application.Status == "" {
+		application.Status = model.ApplicationStatusPending
+	}
+	if !isValidApplicationStatus(application.Status) {
+		return "", db.NewValidationError("status", "invalid application status")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.findApplication(application.PlayerID, application.OpeningID) != nil {
+		return "", db.NewAlreadyExistsError("application", "player_id and opening_id combination", application.PlayerID+" + "+application.OpeningID)
+	}
+	if s.findOpening(application.OpeningID) == nil {
+		return "", db.NewNotFoundError("opening", application.OpeningID)
+	}
+
+	ts := now()
+	application.Id = newID()
+	application.CreatedAt = ts
+	application.UpdatedAt = ts
+	stored := *application
+	s.applications = append(s.applications, &stored)
+	return application.Id, nil
+}
+
+func (s *Store) GetApplicationByID(applicationID string) (*model.Application, error) {
+	if strings.TrimSpace(applicationID) == "" {
+		return nil, db.NewValidationError("application_id", "application ID cannot be empty")
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, a := range s.applications {
+		if a.Id == applicationID {
+			application := *a
+			return &application, nil
+		}
+	}
+	return nil, db.NewNotFoundError("application", applicationID)
+}
+
+func (s *Store) GetApplicationByPlayerIDAndOpeningID(playerID, openingID string) (*model.Application, error) {
+	playerID = strings.TrimSpace(playerID)
+	openingID = strings.TrimSpace(openingID)
+	if playerID == "" || openingID == "" {
+		return nil, db.NewValidationError("player_id and application_id", "player ID and application ID cannot be empty")
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	a := s.findApplication(playerID, openingID)
+	if a == nil {
+		return nil, db.NewNotFoundError("application", openingID)
+	}
+	application := *a
+	return &application, nil
+}
+
+func (s *Store) GetApplicationsByPlayerID(playerID string) ([]*model.Application, error) {
+	if strings.TrimSpace(playerID) == "" {
+		return nil, db.NewValidationError("player_id", "player ID cannot be empty")
+	}
+	return s.filterApplications(func(a *model.Application) bool { return a.PlayerID == playerID }), nil
+}
+
+func (s *Store) GetApplicationsByOpeningID(openingID string) ([]*model.Application, error) {
+	if strings.TrimSpace(openingID) == "" {
+		return nil, db.NewValidationError("opening_id", "opening ID cannot be empty")
+	}
+	return s.filterApplications(func(a *model.Application) bool { return a.OpeningID == openingID }), nil
+}
+
+func (s *Store) GetApplicantsByOpeningID(openingID string) ([]*model.Applicant, error) {
+	if strings.TrimSpace(openingID) == "" {
+		return nil, db.NewValidationError("opening_id", "opening ID cannot be empty")
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var applicants []*model.Applicant
+	for i := len(s.applications) - 1; i >= 0; i-- {
+		a := s.applications[i]
+		if a.OpeningID != openingID {
+			continue
+		}
+		user, ok := s.users[a.PlayerID]
+		if !ok {
+			continue
+		}
+		player, ok := s.copyProfile(s.profiles[a.PlayerID], user).(*model.Player)
+		if !ok {
+			continue
+		}
+		applicants = append(applicants, &model.Applicant{
+			Player:    *player,
+			OpeningID: openingID,
+			Status:    a.Status,
+		})
+	}
+	return applicants, nil
+}
+
+func (s *Store) UpdateApplicationStatus(applicationID string, status model.ApplicationStatus, actor model.AuditActor) error {
+	if strings.TrimSpace(applicationID) == "" {
+		return db.NewValidationError("application_id", "application ID cannot be empty")
+	}
+	if !isValidApplicationStatus(status) {
+		return db.NewValidationError("status", "invalid application status")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, a := range s.applications {
+		if a.Id == applicationID {
+			before := *a
+			a.Status = status
+			a.UpdatedAt = now()
+			s.recordAudit(actor, model.AuditApplicationStatusChanged, "application", applicationID, before, *a)
+			return nil
+		}
+	}
+	return db.NewNotFoundError("application", applicationID)
+}
+
+func (s *Store) findApplication(playerID, openingID string) *model.Application {
+	for _, a := range s.applications {
+		if a.PlayerID == playerID && a.OpeningID == openingID {
+			return a
+		}
+	}
+	return nil
+}
+
+func (s *Store) filterApplications(keep func(*model.Application) bool) []*model.Application {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var applications []*model.Application
+	for _, a := range s.applications {
+		if keep(a) {
+			application := *a
+			applications = append(applications, &application)
+		}
+	}
+	return applications
+}
+
+func isValidApplicationStatus(status model.ApplicationStatus) bool {
+	switch status {
+	case model.ApplicationStatusPending, model.ApplicationStatusAccepted,
+		model.ApplicationStatusRejected, model.ApplicationStatusWithdrawn:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,59 @@
+package memory
+
+import (
+	"encoding/json"
+
+	"sportsin_backend/internals/model"
+)
+
+func (s *Store) GetAuditEvents(filter *model.AuditEventFilter, limit, offset int) ([]model.AuditEvent, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	events := []model.AuditEvent{}
+	for i := len(s.auditEvents) - 1; i >= 0; i-- {
+		e := s.auditEvents[i]
+		if filter.ActorId != nil && e.ActorId != *filter.ActorId {
+			continue
+		}
+		if filter.Action != nil && e.Action != *filter.Action {
+			continue
+		}
+		if filter.EntityType != nil && e.EntityType != *filter.EntityType {
+			continue
+		}
+		if filter.EntityId != nil && e.EntityId != *filter.EntityId {
+			continue
+		}
+		events = append(events, *e)
+	}
+	return paginate(events, limit, offset), nil
+}
+
+// recordAudit appends an audit event. before and after are snapshotted as
+// JSON right away, so later changes to the records do not leak into the
+// event. Callers must hold the write lock.
+func (s *Store) recordAudit(actor model.AuditActor, action model.AuditAction, entityType, entityID string, before, after any) {
+	s.auditEvents = append(s.auditEvents, &model.AuditEvent{
+		Id:         newID(),
+		ActorId:    actor.UserId,
+		Action:     action,
+		EntityType: entityType,
+		EntityId:   entityID,
+		Before:     snapshot(before),
+		After:      snapshot(after),
+		RequestId:  actor.RequestId,
+		CreatedAt:  now(),
+	})
+}
+
+func snapshot(v any) json.RawMessage {
+	if v == nil {
+		return nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	return data
+}
@@ -0,0 +1,252 @@
+package memory
+
+import (
+	"database/sql"
+	"errors"
+	"sort"
+
+	"github.com/google/uuid"
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/model"
+)
+
+func (s *Store) FindOrCreateChatRoom(user1ID, user2ID uuid.UUID) (*model.ChatRoom, error) {
+	// Ensure consistent ordering of user IDs
+	if user1ID.String() > user2ID.String() {
+		user1ID, user2ID = user2ID, user1ID
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, r := range s.chatRooms {
+		if r.User1 == user1ID.String() && r.User2 == user2ID.String() {
+			room := *r
+			return &room, nil
+		}
+	}
+
+	ts := now()
+	room := &model.ChatRoom{
+		AppModel: model.AppModel{Id: newID(), CreatedAt: ts, UpdatedAt: ts},
+		User1:    user1ID.String(),
+		User2:    user2ID.String(),
+	}
+	stored := *room
+	s.chatRooms = append(s.chatRooms, &stored)
+	s.addChatMember(room.Id, room.User1, model.ChatRoleMember, ts)
+	if room.User2 != room.User1 {
+		s.addChatMember(room.Id, room.User2, model.ChatRoleMember, ts)
+	}
+	return room, nil
+}
+
+func (s *Store) GetChatRoomById(roomID uuid.UUID) (*model.ChatRoom, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	r := s.findChatRoom(roomID.String())
+	if r == nil {
+		return nil, db.NewNotFoundError("chat room", roomID.String())
+	}
+	room := *r
+	return &room, nil
+}
+
+func (s *Store) CreateMessage(roomID, senderID uuid.UUID, content, clientMessageID string) (*model.ChatMessage, error) {
+	return s.createMessage(roomID, senderID, content, clientMessageID, false)
+}
+
+func (s *Store) CreateAnnouncement(roomID, senderID uuid.UUID, content string) (*model.ChatMessage, error) {
+	return s.createMessage(roomID, senderID, content, "", true)
+}
+
+func (s *Store) createMessage(roomID, senderID uuid.UUID, content, clientMessageID string, announcement bool) (*model.ChatMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	room := s.findChatRoom(roomID.String())
+	if room == nil {
+		return nil, errors.New("chat room does not exist")
+	}
+	if clientMessageID != "" && s.findMessageByClientId(senderID.String(), clientMessageID) != nil {
+		return nil, db.NewAlreadyExistsError("message", "client_message_id", clientMessageID)
+	}
+
+	ts := now()
+	msg := &model.ChatMessage{
+		AppModel:        model.AppModel{Id: newID(), CreatedAt: ts},
+		ChatRoomId:      roomID.String(),
+		SenderId:        senderID.String(),
+		Message:         content,
+		ClientMessageId: clientMessageID,
+		Announcement:    announcement,
+	}
+	stored := *msg
+	s.messages = append(s.messages, &stored)
+	room.LastMessageAt = sql.NullString{String: ts, Valid: true}
+	// Whoever sends a message has read the room up to it
+	if m := s.findChatMember(room.Id, msg.SenderId); m != nil {
+		markRead(m, ts)
+	}
+	msg.Read = s.messageRead(msg)
+	return msg, nil
+}
+
+func (s *Store) GetMessageByClientId(senderID uuid.UUID, clientMessageID string) (*model.ChatMessage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	m := s.findMessageByClientId(senderID.String(), clientMessageID)
+	if m == nil {
+		return nil, db.NewNotFoundError("message", clientMessageID)
+	}
+	msg := *m
+	msg.Read = s.messageRead(m)
+	return &msg, nil
+}
+
+func (s *Store) GetMessagesForRoom(roomID uuid.UUID, limit, offset int, after *model.Cursor) ([]model.ChatMessage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var messages []model.ChatMessage
+	for _, m := range s.messages {
+		if m.ChatRoomId == roomID.String() {
+			msg := *m
+			msg.Read = s.messageRead(m)
+			messages = append(messages, msg)
+		}
+	}
+	if len(messages) == 0 {
+		return nil, nil
+	}
+	return keysetPage(messages, limit, offset, after, false, func(m model.ChatMessage) (string, string) {
+		return m.CreatedAt, m.Id
+	}), nil
+}
+
+func (s *Store) MarkMessagesAsRead(roomID, readerID uuid.UUID) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m := s.findChatMember(roomID.String(), readerID.String())
+	if m == nil {
+		return "", db.NewNotFoundError("chat room member", readerID.String())
+	}
+	markRead(m, now())
+	return *m.LastReadAt, nil
+}
+
+func (s *Store) GetChatRoomsForUser(userID uuid.UUID) ([]model.ChatRoom, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var rooms []model.ChatRoom
+	for _, r := range s.chatRooms {
+		member := s.findChatMember(r.Id, userID.String())
+		if member == nil {
+			continue
+		}
+		room := *r
+		for _, m := range s.messages {
+			if m.ChatRoomId == r.Id && m.SenderId != member.UserId && !readBy(member, m) {
+				room.UnreadCount++
+			}
+		}
+		rooms = append(rooms, room)
+	}
+
+	// ORDER BY last_message_at DESC, created_at DESC; Postgres sorts NULLs
+	// first in descending order.
+	sort.SliceStable(rooms, func(i, j int) bool {
+		a, b := rooms[i], rooms[j]
+		if a.LastMessageAt.Valid != b.LastMessageAt.Valid {
+			return !a.LastMessageAt.Valid
+		}
+		if a.LastMessageAt.String != b.LastMessageAt.String {
+			return a.LastMessageAt.String > b.LastMessageAt.String
+		}
+		return a.CreatedAt > b.CreatedAt
+	})
+	return rooms, nil
+}
+
+func (s *Store) IsUserInChatRoom(roomID, userID uuid.UUID) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.findChatMember(roomID.String(), userID.String()) != nil, nil
+}
+
+func (s *Store) GetChatContacts(userID uuid.UUID) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rooms := map[string]bool{}
+	for _, m := range s.chatMembers {
+		if m.UserId == userID.String() {
+			rooms[m.RoomId] = true
+		}
+	}
+	seen := map[string]bool{userID.String(): true}
+	var contacts []string
+	for _, m := range s.chatMembers {
+		if rooms[m.RoomId] && !seen[m.UserId] {
+			seen[m.UserId] = true
+			contacts = append(contacts, m.UserId)
+		}
+	}
+	return contacts, nil
+}
+
+func (s *Store) GetUserSnsEndpointArn(userID uuid.UUID) (string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	u, ok := s.users[userID.String()]
+	if !ok {
+		return "", sql.ErrNoRows
+	}
+	return u.SnsEndpointArn, nil
+}
+
+// messageRead reports whether every member of the message's room other than
+// its sender has read the room past it
+func (s *Store) messageRead(msg *model.ChatMessage) bool {
+	for _, m := range s.chatMembers {
+		if m.RoomId == msg.ChatRoomId && m.UserId != msg.SenderId && !readBy(m, msg) {
+			return false
+		}
+	}
+	return true
+}
+
+func readBy(m *model.ChatRoomMember, msg *model.ChatMessage) bool {
+	return m.LastReadAt != nil && *m.LastReadAt >= msg.CreatedAt
+}
+
+// markRead moves the last_read_at of a member forward to at, never back
+func markRead(m *model.ChatRoomMember, at string) {
+	if m.LastReadAt == nil || *m.LastReadAt < at {
+		m.LastReadAt = &at
+	}
+}
+
+func (s *Store) findMessageByClientId(senderId, clientMessageId string) *model.ChatMessage {
+	for _, m := range s.messages {
+		if m.SenderId == senderId && m.ClientMessageId == clientMessageId {
+			return m
+		}
+	}
+	return nil
+}
+
+func (s *Store) findChatRoom(id string) *model.ChatRoom {
+	for _, r := range s.chatRooms {
+		if r.Id == id {
+			return r
+		}
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+package memory
+
+import (
+	"sort"
+
+	"github.com/google/uuid"
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/model"
+)
+
+func (s *Store) CreateGroupChatRoom(room *model.ChatRoom, ownerID uuid.UUID, memberIDs []uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	seen := map[uuid.UUID]bool{ownerID: true}
+	var members []string
+	for _, id := range memberIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		if _, ok := s.users[id.String()]; !ok {
+			return db.NewNotFoundError("user", id.String())
+		}
+		members = append(members, id.String())
+	}
+
+	ts := now()
+	room.AppModel = model.AppModel{Id: newID(), CreatedAt: ts, UpdatedAt: ts}
+	room.User1, room.User2 = "", ""
+	room.IsGroup = true
+	stored := *room
+	s.chatRooms = append(s.chatRooms, &stored)
+
+	s.addChatMember(room.Id, ownerID.String(), model.ChatRoleOwner, ts)
+	for _, id := range members {
+		s.addChatMember(room.Id, id, model.ChatRoleMember, ts)
+	}
+	room.Members = s.chatMembersOf(room.Id)
+	return nil
+}
+
+func (s *Store) UpdateChatRoom(room *model.ChatRoom) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := s.findChatRoom(room.Id)
+	if r == nil || !r.IsGroup {
+		return db.NewNotFoundError("chat room", room.Id)
+	}
+	r.Name = room.Name
+	r.AvatarUrl = room.AvatarUrl
+	return nil
+}
+
+func (s *Store) GetChatRoomMembers(roomID uuid.UUID) ([]model.ChatRoomMember, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.chatMembersOf(roomID.String()), nil
+}
+
+func (s *Store) GetChatRoomMember(roomID, userID uuid.UUID) (*model.ChatRoomMember, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	m := s.findChatMember(roomID.String(), userID.String())
+	if m == nil {
+		return nil, db.NewNotFoundError("chat room member", userID.String())
+	}
+	member := *m
+	return &member, nil
+}
+
+func (s *Store) AddChatRoomMember(roomID, userID uuid.UUID) (*model.ChatRoomMember, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.users[userID.String()]; !ok {
+		return nil, db.NewNotFoundError("user", userID.String())
+	}
+	if s.findChatMember(roomID.String(), userID.String()) != nil {
+		return nil, db.NewAlreadyExistsError("chat room member", "user_id", userID.String())
+	}
+	r := s.findChatRoom(roomID.String())
+	if r == nil || !r.IsGroup {
+		return nil, db.NewNotFoundError("chat room", roomID.String())
+	}
+
+	member := *s.addChatMember(r.Id, userID.String(), model.ChatRoleMember, now())
+	return &member, nil
+}
+
+func (s *Store) UpdateChatRoomMemberRole(roomID, userID uuid.UUID, role model.ChatMemberRole) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m := s.findChatMember(roomID.String(), userID.String())
+	if m == nil {
+		return db.NewNotFoundError("chat room member", userID.String())
+	}
+	m.Role = role
+	return nil
+}
+
+func (s *Store) RemoveChatRoomMember(roomID, userID uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := s.findChatRoom(roomID.String())
+	m := s.findChatMember(roomID.String(), userID.String())
+	if r == nil || !r.IsGroup || m == nil {
+		return db.NewNotFoundError("chat room member", userID.String())
+	}
+
+	kept := s.chatMembers[:0]
+	for _, cm := range s.chatMembers {
+		if cm != m {
+			kept = append(kept, cm)
+		}
+	}
+	s.chatMembers = kept
+
+	remaining := s.chatMembersOf(r.Id)
+	if len(remaining) == 0 {
+		s.removeChatRoom(r.Id)
+		return nil
+	}
+	if m.Role == model.ChatRoleOwner {
+		// The longest standing admin, or else member, takes over
+		next := remaining[0]
+		for _, cm := range remaining {
+			if cm.Role == model.ChatRoleAdmin {
+				next = cm
+				break
+			}
+		}
+		s.findChatMember(r.Id, next.UserId).Role = model.ChatRoleOwner
+	}
+	return nil
+}
+
+// removeChatRoom deletes a room and, like the cascade in Postgres, its
+// members and messages. Callers must hold the lock.
+func (s *Store) removeChatRoom(roomId string) {
+	rooms := s.chatRooms[:0]
+	for _, r := range s.chatRooms {
+		if r.Id != roomId {
+			rooms = append(rooms, r)
+		}
+	}
+	s.chatRooms = rooms
+
+	members := s.chatMembers[:0]
+	for _, m := range s.chatMembers {
+		if m.RoomId != roomId {
+			members = append(members, m)
+		}
+	}
+	s.chatMembers = members
+
+	messages := s.messages[:0]
+	for _, m := range s.messages {
+		if m.ChatRoomId != roomId {
+			messages = append(messages, m)
+		}
+	}
+	s.messages = messages
+}
+
+func (s *Store) addChatMember(roomId, userId string, role model.ChatMemberRole, joinedAt string) *model.ChatRoomMember {
+	m := &model.ChatRoomMember{RoomId: roomId, UserId: userId, Role: role, JoinedAt: joinedAt}
+	s.chatMembers = append(s.chatMembers, m)
+	return m
+}
+
+// chatMembersOf returns the members of a room in the order they joined
+func (s *Store) chatMembersOf(roomId string) []model.ChatRoomMember {
+	members := []model.ChatRoomMember{}
+	for _, m := range s.chatMembers {
+		if m.RoomId == roomId {
+			members = append(members, *m)
+		}
+	}
+	sort.SliceStable(members, func(i, j int) bool {
+		if members[i].JoinedAt != members[j].JoinedAt {
+			return members[i].JoinedAt < members[j].JoinedAt
+		}
+		return members[i].UserId < members[j].UserId
+	})
+	return members
+}
+
+func (s *Store) findChatMember(roomId, userId string) *model.ChatRoomMember {
+	for _, m := range s.chatMembers {
+		if m.RoomId == roomId && m.UserId == userId {
+			return m
+		}
+	}
+	return nil
+}
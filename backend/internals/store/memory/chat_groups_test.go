@@ -0,0 +1,209 @@
+package memory_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/model"
+	"sportsin_backend/internals/store/memory"
+)
+
+func newGroup(t *testing.T, repo *memory.Store, ownerID string, memberIDs ...string) *model.ChatRoom {
+	t.Helper()
+	var members []uuid.UUID
+	for _, id := range memberIDs {
+		members = append(members, uuid.MustParse(id))
+	}
+	name := "Sunday league"
+	room := &model.ChatRoom{Name: &name}
+	if err := repo.CreateGroupChatRoom(room, uuid.MustParse(ownerID), members); err != nil {
+		t.Fatalf("CreateGroupChatRoom: %v", err)
+	}
+	return room
+}
+
+func memberRoles(t *testing.T, repo *memory.Store, roomID string) map[string]model.ChatMemberRole {
+	t.Helper()
+	members, err := repo.GetChatRoomMembers(uuid.MustParse(roomID))
+	if err != nil {
+		t.Fatalf("GetChatRoomMembers: %v", err)
+	}
+	roles := make(map[string]model.ChatMemberRole)
+	for _, m := range members {
+		roles[m.UserId] = m.Role
+	}
+	return roles
+}
+
+func TestCreateGroupChatRoomMembers(t *testing.T) {
+	repo := memory.NewStore()
+	owner, member := memory.NewTestUser(t, repo), memory.NewTestUser(t, repo)
+
+	// The owner listed again as a member is only added once
+	room := newGroup(t, repo, owner, member, owner, member)
+
+	roles := memberRoles(t, repo, room.Id)
+	if len(roles) != 2 || roles[owner] != model.ChatRoleOwner || roles[member] != model.ChatRoleMember {
+		t.Fatalf("roles = %v, want %s as owner and %s as member", roles, owner, member)
+	}
+	if in, _ := repo.IsUserInChatRoom(uuid.MustParse(room.Id), uuid.MustParse(member)); !in {
+		t.Errorf("member is not in the room")
+	}
+
+	name := "ghosts"
+	err := repo.CreateGroupChatRoom(&model.ChatRoom{Name: &name}, uuid.MustParse(owner), []uuid.UUID{uuid.New()})
+	if !db.IsNotFoundError(err) {
+		t.Errorf("group with an unknown member: err = %v, want NotFoundError", err)
+	}
+}
+
+func TestAddChatRoomMember(t *testing.T) {
+	repo := memory.NewStore()
+	owner, invited := memory.NewTestUser(t, repo), memory.NewTestUser(t, repo)
+	room := newGroup(t, repo, owner)
+	roomID := uuid.MustParse(room.Id)
+
+	if _, err := repo.AddChatRoomMember(roomID, uuid.MustParse(invited)); err != nil {
+		t.Fatalf("AddChatRoomMember: %v", err)
+	}
+	if _, err := repo.AddChatRoomMember(roomID, uuid.MustParse(invited)); !db.IsAlreadyExistsError(err) {
+		t.Errorf("adding a member twice: err = %v, want AlreadyExistsError", err)
+	}
+
+	// One-to-one rooms cannot grow into groups
+	direct, err := repo.FindOrCreateChatRoom(uuid.MustParse(owner), uuid.MustParse(invited))
+	if err != nil {
+		t.Fatalf("FindOrCreateChatRoom: %v", err)
+	}
+	if _, err := repo.AddChatRoomMember(uuid.MustParse(direct.Id), uuid.MustParse(memory.NewTestUser(t, repo))); !db.IsNotFoundError(err) {
+		t.Errorf("adding to a direct room: err = %v, want NotFoundError", err)
+	}
+}
+
+func TestOwnerLeavingHandsOverToAdmin(t *testing.T) {
+	repo := memory.NewStore()
+	owner, member, admin := memory.NewTestUser(t, repo), memory.NewTestUser(t, repo), memory.NewTestUser(t, repo)
+	room := newGroup(t, repo, owner, member, admin)
+	roomID := uuid.MustParse(room.Id)
+
+	if err := repo.UpdateChatRoomMemberRole(roomID, uuid.MustParse(admin), model.ChatRoleAdmin); err != nil {
+		t.Fatalf("UpdateChatRoomMemberRole: %v", err)
+	}
+	if err := repo.RemoveChatRoomMember(roomID, uuid.MustParse(owner)); err != nil {
+		t.Fatalf("RemoveChatRoomMember: %v", err)
+	}
+
+	roles := memberRoles(t, repo, room.Id)
+	if _, ok := roles[owner]; ok {
+		t.Errorf("owner is still a member after leaving")
+	}
+	if roles[admin] != model.ChatRoleOwner || roles[member] != model.ChatRoleMember {
+		t.Errorf("roles = %v, want the admin %s to take over", roles, admin)
+	}
+}
+
+func TestOwnerLeavingHandsOverToLongestMember(t *testing.T) {
+	repo := memory.NewStore()
+	owner, first := memory.NewTestUser(t, repo), memory.NewTestUser(t, repo)
+	room := newGroup(t, repo, owner, first)
+	roomID := uuid.MustParse(room.Id)
+	// JoinedAt has microsecond resolution
+	time.Sleep(time.Millisecond)
+	later := memory.NewTestUser(t, repo)
+	if _, err := repo.AddChatRoomMember(roomID, uuid.MustParse(later)); err != nil {
+		t.Fatalf("AddChatRoomMember: %v", err)
+	}
+
+	if err := repo.RemoveChatRoomMember(roomID, uuid.MustParse(owner)); err != nil {
+		t.Fatalf("RemoveChatRoomMember: %v", err)
+	}
+	if roles := memberRoles(t, repo, room.Id); roles[first] != model.ChatRoleOwner {
+		t.Errorf("roles = %v, want the longest standing member %s to take over", roles, first)
+	}
+}
+
+func TestLastMemberLeavingDeletesRoom(t *testing.T) {
+	repo := memory.NewStore()
+	owner := memory.NewTestUser(t, repo)
+	room := newGroup(t, repo, owner)
+	roomID := uuid.MustParse(room.Id)
+	if _, err := repo.CreateMessage(roomID, uuid.MustParse(owner), "anyone?", ""); err != nil {
+		t.Fatalf("CreateMessage: %v", err)
+	}
+
+	if err := repo.RemoveChatRoomMember(roomID, uuid.MustParse(owner)); err != nil {
+		t.Fatalf("RemoveChatRoomMember: %v", err)
+	}
+	if _, err := repo.GetChatRoomById(roomID); !db.IsNotFoundError(err) {
+		t.Errorf("room after the last member left: err = %v, want NotFoundError", err)
+	}
+	if messages, _ := repo.GetMessagesForRoom(roomID, 10, 0, nil); len(messages) != 0 {
+		t.Errorf("room still has %d messages", len(messages))
+	}
+	if err := repo.RemoveChatRoomMember(roomID, uuid.MustParse(owner)); !db.IsNotFoundError(err) {
+		t.Errorf("leaving a deleted room: err = %v, want NotFoundError", err)
+	}
+}
+
+func unreadCount(t *testing.T, repo *memory.Store, userID, roomID string) int {
+	t.Helper()
+	rooms, err := repo.GetChatRoomsForUser(uuid.MustParse(userID))
+	if err != nil {
+		t.Fatalf("GetChatRoomsForUser: %v", err)
+	}
+	for _, r := range rooms {
+		if r.Id == roomID {
+			return r.UnreadCount
+		}
+	}
+	t.Fatalf("user %s is not in room %s", userID, roomID)
+	return 0
+}
+
+func TestGroupReadStateIsPerMember(t *testing.T) {
+	repo := memory.NewStore()
+	owner, first, second := memory.NewTestUser(t, repo), memory.NewTestUser(t, repo), memory.NewTestUser(t, repo)
+	room := newGroup(t, repo, owner, first, second)
+	roomID := uuid.MustParse(room.Id)
+	msg, err := repo.CreateMessage(roomID, uuid.MustParse(owner), "training at six", "")
+	if err != nil {
+		t.Fatalf("CreateMessage: %v", err)
+	}
+
+	readAt, err := repo.MarkMessagesAsRead(roomID, uuid.MustParse(first))
+	if err != nil {
+		t.Fatalf("MarkMessagesAsRead: %v", err)
+	}
+	if readAt < msg.CreatedAt {
+		t.Errorf("read_at %s is before the message sent at %s", readAt, msg.CreatedAt)
+	}
+
+	// One member reading the room leaves it unread for the other
+	if n := unreadCount(t, repo, first, room.Id); n != 0 {
+		t.Errorf("unread for the member who read = %d, want 0", n)
+	}
+	if n := unreadCount(t, repo, second, room.Id); n != 1 {
+		t.Errorf("unread for the member who did not read = %d, want 1", n)
+	}
+	if n := unreadCount(t, repo, owner, room.Id); n != 0 {
+		t.Errorf("unread for the sender = %d, want 0", n)
+	}
+	messages, _ := repo.GetMessagesForRoom(roomID, 10, 0, nil)
+	if len(messages) != 1 || messages[0].Read {
+		t.Fatalf("messages = %+v, want one not read by every member", messages)
+	}
+
+	if _, err := repo.MarkMessagesAsRead(roomID, uuid.MustParse(second)); err != nil {
+		t.Fatalf("MarkMessagesAsRead: %v", err)
+	}
+	messages, _ = repo.GetMessagesForRoom(roomID, 10, 0, nil)
+	if len(messages) != 1 || !messages[0].Read {
+		t.Errorf("messages = %+v, want one read by every member", messages)
+	}
+
+	if _, err := repo.MarkMessagesAsRead(roomID, uuid.New()); !db.IsNotFoundError(err) {
+		t.Errorf("marking read as a non-member: err = %v, want NotFoundError", err)
+	}
+}
@@ -0,0 +1,58 @@
+package memory
+
+import (
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/model"
+)
+
+// maxChatRoomNameRunes matches the name column of "ChatRoom"
+const maxChatRoomNameRunes = 100
+
+func (s *Store) EnsureTournamentChatRoom(tournament *model.Tournament) (*model.ChatRoom, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r := s.findTournamentChatRoom(tournament.Id); r != nil {
+		room := *r
+		return &room, nil
+	}
+
+	name := tournament.Title
+	if runes := []rune(name); len(runes) > maxChatRoomNameRunes {
+		name = string(runes[:maxChatRoomNameRunes])
+	}
+	tournamentID := tournament.Id
+	ts := now()
+	room := &model.ChatRoom{
+		AppModel:     model.AppModel{Id: newID(), CreatedAt: ts, UpdatedAt: ts},
+		IsGroup:      true,
+		Name:         &name,
+		AvatarUrl:    tournament.BannerUrl,
+		TournamentId: &tournamentID,
+	}
+	stored := *room
+	s.chatRooms = append(s.chatRooms, &stored)
+	s.addChatMember(room.Id, tournament.HostId, model.ChatRoleOwner, ts)
+	return room, nil
+}
+
+func (s *Store) GetTournamentChatRoom(tournamentID string) (*model.ChatRoom, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	r := s.findTournamentChatRoom(tournamentID)
+	if r == nil {
+		return nil, db.NewNotFoundError("tournament chat room", tournamentID)
+	}
+	room := *r
+	return &room, nil
+}
+
+func (s *Store) findTournamentChatRoom(tournamentID string) *model.ChatRoom {
+	for _, r := range s.chatRooms {
+		if r.TournamentId != nil && *r.TournamentId == tournamentID {
+			return r
+		}
+	}
+	return nil
+}
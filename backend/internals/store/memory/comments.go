@@ -0,0 +1,221 @@
+package memory
+
+import (
+	"sort"
+
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/model"
+)
+
+func (s *Store) CreateComment(userId, postId, content string, parentId *string) (*model.Comment, error) {
+	if userId == "" || postId == "" || content == "" {
+		return nil, db.NewValidationError("comment", "user_id, post_id, and content cannot be empty")
+	}
+	if len(content) > 500 {
+		return nil, db.NewValidationError("content", "comment content exceeds maximum length of 500 characters")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if parentId != nil {
+		parent := s.findComment(*parentId)
+		if parent == nil {
+			return nil, db.NewNotFoundError("comment", *parentId)
+		}
+		if parent.ParentId != nil {
+			return nil, db.NewValidationError("parent_id", "Cannot reply to a reply comment")
+		}
+	}
+	if s.findPost(postId) == nil {
+		return nil, db.NewNotFoundError("post", postId)
+	}
+
+	ts := now()
+	comment := &model.Comment{
+		Id:        newID(),
+		UserId:    userId,
+		PostId:    postId,
+		ParentId:  parentId,
+		Content:   content,
+		CreatedAt: ts,
+		UpdatedAt: ts,
+	}
+	stored := *comment
+	s.comments = append(s.comments, &stored)
+	return comment, nil
+}
+
+func (s *Store) GetCommentsByPostId(postId string, limit, offset int, after *model.Cursor, sortBy model.CommentSort, userId string) ([]model.CommentResponse, error) {
+	if postId == "" {
+		return nil, db.NewValidationError("post_id", "post_id cannot be empty")
+	}
+	if after != nil && sortBy == model.CommentSortTop {
+		return nil, db.NewValidationError("cursor", "cursor is not supported when sorting by top")
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	replyMap := make(map[string][]model.Comment)
+	var topLevel []model.Comment
+	for _, c := range s.comments {
+		if c.PostId != postId || s.hidden(model.ReportTargetComment, c.Id) {
+			continue
+		}
+		if c.ParentId != nil {
+			replyMap[*c.ParentId] = append(replyMap[*c.ParentId], *c)
+		} else {
+			topLevel = append(topLevel, *c)
+		}
+	}
+
+	var responses []model.CommentResponse
+	for _, c := range topLevel {
+		resp := model.CommentResponse{
+			Comment:   c,
+			Replies:   []model.Comment{},
+			LikeCount: len(s.commentLikes[c.Id]),
+			UserLiked: s.commentLikes[c.Id][userId],
+		}
+		if replies := replyMap[c.Id]; len(replies) > 0 {
+			resp.TotalReplyCount = len(replies)
+			resp.Replies = []model.Comment{replies[len(replies)-1]}
+			resp.ReplyCount = 1
+		}
+		responses = append(responses, resp)
+	}
+
+	if limit <= 0 {
+		limit = len(responses)
+	}
+	if sortBy == model.CommentSortTop {
+		sort.SliceStable(responses, func(i, j int) bool {
+			return responses[i].LikeCount > responses[j].LikeCount
+		})
+		return paginate(responses, limit, offset), nil
+	}
+	return keysetPage(responses, limit, offset, after, sortBy == model.CommentSortNewest, func(r model.CommentResponse) (string, string) {
+		return r.Comment.CreatedAt, r.Comment.Id
+	}), nil
+}
+
+func (s *Store) GetCommentById(commentId string, replyLimit, replyOffset int, userId string) (*model.CommentResponse, error) {
+	if commentId == "" {
+		return nil, db.NewValidationError("comment_id", "comment_id cannot be empty")
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	c := s.findComment(commentId)
+	if c == nil {
+		return nil, db.NewNotFoundError("comment", commentId)
+	}
+
+	var all []model.Comment
+	for _, r := range s.comments {
+		if r.ParentId != nil && *r.ParentId == commentId {
+			all = append(all, *r)
+		}
+	}
+
+	var replies []model.Comment
+	if len(all) > 0 {
+		replies = paginate(all, replyLimit, replyOffset)
+	}
+
+	return &model.CommentResponse{
+		Comment:         *c,
+		Replies:         replies,
+		ReplyCount:      len(replies),
+		TotalReplyCount: len(all),
+		LikeCount:       len(s.commentLikes[commentId]),
+		UserLiked:       s.commentLikes[commentId][userId],
+	}, nil
+}
+
+func (s *Store) UpdateComment(id, content string) error {
+	if id == "" || content == "" {
+		return db.NewValidationError("comment", "id and content cannot be empty")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.findComment(id)
+	if c == nil {
+		return db.NewNotFoundError("comment", id)
+	}
+	c.Content = content
+	c.UpdatedAt = now()
+	return nil
+}
+
+func (s *Store) DeleteComment(id string, action *model.AdminAction) error {
+	if err := checkAdminAction(action); err != nil {
+		return err
+	}
+	if id == "" {
+		return db.NewValidationError("comment", "id cannot be empty")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.findComment(id) == nil {
+		return db.NewNotFoundError("comment", id)
+	}
+
+	// Replies cascade with their parent.
+	removed := make(map[string]bool)
+	kept := s.comments[:0]
+	for _, c := range s.comments {
+		if c.Id == id || (c.ParentId != nil && *c.ParentId == id) {
+			removed[c.Id] = true
+			continue
+		}
+		kept = append(kept, c)
+	}
+	s.comments = kept
+	s.removeMentions("", removed)
+	for commentId := range removed {
+		delete(s.commentLikes, commentId)
+	}
+	s.recordAdminAction(action)
+	return nil
+}
+
+func (s *Store) LikeComment(commentID, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.findComment(commentID) == nil {
+		return db.NewNotFoundError("comment", commentID)
+	}
+	if s.commentLikes[commentID][userID] {
+		return db.NewAlreadyExistsError("comment like", "comment_id and user_id combination", commentID+" + "+userID)
+	}
+	if s.commentLikes[commentID] == nil {
+		s.commentLikes[commentID] = make(map[string]bool)
+	}
+	s.commentLikes[commentID][userID] = true
+	return nil
+}
+
+func (s *Store) UnlikeComment(commentID, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.commentLikes[commentID], userID)
+	return nil
+}
+
+func (s *Store) findComment(id string) *model.Comment {
+	for _, c := range s.comments {
+		if c.Id == id {
+			return c
+		}
+	}
+	return nil
+}
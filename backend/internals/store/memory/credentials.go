@@ -0,0 +1,51 @@
+package memory
+
+import (
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/model"
+)
+
+func (s *Store) CreateCredential(credential *model.Credential) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.credentials[credential.Email]; ok {
+		return db.NewAlreadyExistsError("credential", "email", credential.Email)
+	}
+
+	ts := now()
+	stored := *credential
+	stored.CreatedAt = ts
+	stored.UpdatedAt = ts
+	s.credentials[credential.Email] = &stored
+	return nil
+}
+
+func (s *Store) GetCredentialByEmail(email string) (*model.Credential, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	c, ok := s.credentials[email]
+	if !ok {
+		return nil, db.NewNotFoundError("credential", email)
+	}
+	credential := *c
+	return &credential, nil
+}
+
+func (s *Store) UpdateCredential(credential *model.Credential) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, c := range s.credentials {
+		if c.UserId == credential.UserId {
+			c.PasswordHash = credential.PasswordHash
+			c.Confirmed = credential.Confirmed
+			c.Code = credential.Code
+			c.CodeExpiresAt = credential.CodeExpiresAt
+			c.UpdatedAt = now()
+			return nil
+		}
+	}
+	return db.NewNotFoundError("credential", credential.UserId)
+}
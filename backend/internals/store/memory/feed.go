@@ -0,0 +1,91 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"sportsin_backend/internals/model"
+)
+
+func (s *Store) GetFeedCandidates(viewerID string, query *model.FeedQuery) ([]model.FeedCandidate, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	since := query.Since.UTC().Format(timeLayout)
+	until := query.Until.UTC().Format(timeLayout)
+	commentsSince := query.CommentsSince.UTC().Format(timeLayout)
+
+	// Every post of the window, newest first as postsNewestFirst keeps them
+	recent := []model.FeedCandidate{}
+	for _, p := range s.postsNewestFirst("") {
+		if p.CreatedAt <= since || p.CreatedAt > until || s.hidden(model.ReportTargetPost, p.Id) {
+			continue
+		}
+
+		createdAt, _ := time.Parse(timeLayout, p.CreatedAt)
+		candidate := model.FeedCandidate{
+			PostId:       p.Id,
+			UserId:       p.UserId,
+			CreatedAt:    createdAt,
+			FromFollowed: p.UserId == viewerID || s.findFollow(viewerID, p.UserId) != nil,
+			SharesSport:  s.sharesSport(viewerID, p.UserId),
+		}
+		for _, likedAt := range s.postLikedAt[p.Id] {
+			if likedAt <= until {
+				candidate.LikeCount++
+			}
+		}
+		for _, c := range s.comments {
+			if c.PostId != p.Id || c.CreatedAt > until {
+				continue
+			}
+			candidate.CommentCount++
+			if c.CreatedAt > commentsSince {
+				candidate.RecentComments++
+			}
+		}
+		recent = append(recent, candidate)
+	}
+
+	taken := make(map[string]bool)
+	take := func(posts []model.FeedCandidate, keep func(model.FeedCandidate) bool) {
+		n := 0
+		for _, c := range posts {
+			if n >= query.PerSignal {
+				break
+			}
+			if keep(c) {
+				taken[c.PostId] = true
+				n++
+			}
+		}
+	}
+	take(recent, func(c model.FeedCandidate) bool { return c.FromFollowed })
+	take(recent, func(c model.FeedCandidate) bool { return c.SharesSport })
+	popular := append([]model.FeedCandidate(nil), recent...)
+	sort.SliceStable(popular, func(i, j int) bool {
+		return popular[i].LikeCount+popular[i].CommentCount > popular[j].LikeCount+popular[j].CommentCount
+	})
+	take(popular, func(model.FeedCandidate) bool { return true })
+
+	candidates := []model.FeedCandidate{}
+	for _, c := range recent {
+		if taken[c.PostId] {
+			candidates = append(candidates, c)
+		}
+	}
+	return candidates, nil
+}
+
+func (s *Store) GetPostsWithCommentsByIds(postIds []string, userId string) ([]model.PostWithComment, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := []model.PostWithComment{}
+	for _, id := range postIds {
+		if p := s.findPost(id); p != nil {
+			result = append(result, s.postWithComment(p, userId))
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,31 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"sportsin_backend/internals/model"
+)
+
+// Fixtures shared by the tests of every package that runs against the
+// memory store.
+
+// NewTestUser signs up a player and returns their ID
+func NewTestUser(t testing.TB, s *Store) string {
+	t.Helper()
+	userID := uuid.NewString()
+	if err := s.CreateUserOnSignup(userID, userID+"@example.com", model.PlayerRole); err != nil {
+		t.Fatalf("CreateUserOnSignup: %v", err)
+	}
+	return userID
+}
+
+// NewTestPost creates a post by userID
+func NewTestPost(t testing.TB, s *Store, userID, content string) *model.Post {
+	t.Helper()
+	post := &model.Post{UserId: userID, Content: content}
+	if err := s.CreatePost(post); err != nil {
+		t.Fatalf("CreatePost: %v", err)
+	}
+	return post
+}
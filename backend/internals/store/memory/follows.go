@@ -0,0 +1,164 @@
+package memory
+
+import (
+	"sort"
+
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/model"
+)
+
+func (s *Store) FollowUser(followerID, followingID string) error {
+	if followerID == followingID {
+		return db.NewValidationError("following_id", "users cannot follow themselves")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.users[followingID]; !ok {
+		return db.NewNotFoundError("user", followingID)
+	}
+	if s.findFollow(followerID, followingID) != nil {
+		return db.NewAlreadyExistsError("follow", "follower_id and following_id combination", followerID+" + "+followingID)
+	}
+	s.follows = append(s.follows, &model.Follow{
+		FollowerId:  followerID,
+		FollowingId: followingID,
+		CreatedAt:   now(),
+	})
+	return nil
+}
+
+func (s *Store) UnfollowUser(followerID, followingID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, f := range s.follows {
+		if f.FollowerId == followerID && f.FollowingId == followingID {
+			s.follows = append(s.follows[:i], s.follows[i+1:]...)
+			return nil
+		}
+	}
+	return db.NewNotFoundError("follow", followingID)
+}
+
+func (s *Store) GetFollowers(userID string, limit, offset int) ([]model.FollowUser, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.users[userID]; !ok {
+		return nil, db.NewNotFoundError("user", userID)
+	}
+	users := []model.FollowUser{}
+	for i := len(s.follows) - 1; i >= 0; i-- {
+		f := s.follows[i]
+		if f.FollowingId == userID {
+			mutual := s.findFollow(userID, f.FollowerId) != nil
+			users = append(users, s.followUser(f.FollowerId, mutual, f.CreatedAt))
+		}
+	}
+	return paginate(users, limit, offset), nil
+}
+
+func (s *Store) GetFollowing(userID string, limit, offset int) ([]model.FollowUser, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.users[userID]; !ok {
+		return nil, db.NewNotFoundError("user", userID)
+	}
+	users := []model.FollowUser{}
+	for i := len(s.follows) - 1; i >= 0; i-- {
+		f := s.follows[i]
+		if f.FollowerId == userID {
+			mutual := s.findFollow(f.FollowingId, userID) != nil
+			users = append(users, s.followUser(f.FollowingId, mutual, f.CreatedAt))
+		}
+	}
+	return paginate(users, limit, offset), nil
+}
+
+func (s *Store) GetMutualFollows(userID string, limit, offset int) ([]model.FollowUser, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.users[userID]; !ok {
+		return nil, db.NewNotFoundError("user", userID)
+	}
+	users := []model.FollowUser{}
+	for _, f := range s.follows {
+		if f.FollowerId != userID {
+			continue
+		}
+		back := s.findFollow(f.FollowingId, userID)
+		if back == nil {
+			continue
+		}
+		mutualAt := f.CreatedAt
+		if back.CreatedAt > mutualAt {
+			mutualAt = back.CreatedAt
+		}
+		users = append(users, s.followUser(f.FollowingId, true, mutualAt))
+	}
+	sort.SliceStable(users, func(i, j int) bool {
+		if users[i].FollowedAt != users[j].FollowedAt {
+			return users[i].FollowedAt > users[j].FollowedAt
+		}
+		return users[i].UserId < users[j].UserId
+	})
+	return paginate(users, limit, offset), nil
+}
+
+func (s *Store) GetFollowStats(userID, viewerID string) (*model.FollowStats, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var stats model.FollowStats
+	for _, f := range s.follows {
+		if f.FollowingId == userID {
+			stats.FollowersCount++
+		}
+		if f.FollowerId == userID {
+			stats.FollowingCount++
+		}
+	}
+
+	if viewerID == "" || viewerID == userID {
+		return &stats, nil
+	}
+	isFollowing := s.findFollow(viewerID, userID) != nil
+	followsYou := s.findFollow(userID, viewerID) != nil
+	isMutual := isFollowing && followsYou
+	stats.IsFollowing = &isFollowing
+	stats.FollowsYou = &followsYou
+	stats.IsMutual = &isMutual
+	return &stats, nil
+}
+
+func (s *Store) findFollow(followerID, followingID string) *model.Follow {
+	for _, f := range s.follows {
+		if f.FollowerId == followerID && f.FollowingId == followingID {
+			return f
+		}
+	}
+	return nil
+}
+
+// followUser mirrors the COALESCE over the account and its profile used by
+// the Postgres follow lists.
+func (s *Store) followUser(userID string, mutual bool, followedAt string) model.FollowUser {
+	u := s.users[userID]
+	entry := model.FollowUser{
+		UserId:     userID,
+		Username:   u.Username,
+		Role:       u.Role,
+		IsMutual:   mutual,
+		FollowedAt: followedAt,
+	}
+	if d := profileDetails(s.profiles[userID]); d != nil {
+		entry.Username = d.UserName
+		entry.Name = d.Name
+		entry.ProfilePicture = d.ProfilePicture
+	}
+	return entry
+}
@@ -0,0 +1,58 @@
+package memory
+
+import (
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/model"
+)
+
+func (s *Store) GetUnprocessedPostMedia() ([]model.PostImage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	media := []model.PostImage{}
+	for _, img := range s.postImages {
+		if img.Status == model.MediaStatusPending || img.Status == model.MediaStatusProcessing {
+			media = append(media, *img)
+		}
+	}
+	return media, nil
+}
+
+func (s *Store) UpdatePostMediaStatus(mediaId string, status model.MediaStatus, processingError *string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	img := s.findPostImage(mediaId)
+	if img == nil {
+		return db.NewNotFoundError("post media", mediaId)
+	}
+	img.Status = status
+	img.ProcessingError = processingError
+	return nil
+}
+
+func (s *Store) CompletePostMedia(media *model.PostImage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	img := s.findPostImage(media.Id)
+	if img == nil {
+		return db.NewNotFoundError("post media", media.Id)
+	}
+	media.Status = model.MediaStatusReady
+	media.ProcessingError = nil
+	img.Status = media.Status
+	img.ThumbnailUrl = media.ThumbnailUrl
+	img.DurationSeconds = media.DurationSeconds
+	img.ProcessingError = nil
+	return nil
+}
+
+func (s *Store) findPostImage(id string) *model.PostImage {
+	for _, img := range s.postImages {
+		if img.Id == id {
+			return img
+		}
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package memory
+
+import (
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/model"
+)
+
+func (s *Store) SetPostMentions(postId, authorId string, usernames []string) ([]model.MentionedUser, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	users := s.resolveMentions(authorId, usernames)
+	keep := make(map[string]bool, len(users))
+	for _, u := range users {
+		keep[u.UserId] = true
+	}
+	kept := s.mentions[:0]
+	for _, m := range s.mentions {
+		if m.PostId == postId && m.CommentId == nil && !keep[m.MentionedUserId] {
+			continue
+		}
+		kept = append(kept, m)
+	}
+	s.mentions = kept
+
+	return s.insertMentions(postId, nil, authorId, users), nil
+}
+
+func (s *Store) AddCommentMentions(commentId, postId, authorId string, usernames []string) ([]model.MentionedUser, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.insertMentions(postId, &commentId, authorId, s.resolveMentions(authorId, usernames)), nil
+}
+
+func (s *Store) GetPostsMentioningUser(userId string, limit, offset int, after *model.Cursor) ([]model.PostWithComment, error) {
+	if userId == "" {
+		return nil, db.ErrUserIDMissing
+	}
+	if limit <= 0 {
+		return nil, db.ErrInvalidLimit
+	}
+	if offset < 0 {
+		return nil, db.ErrInvalidOffset
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	mentioned := make(map[string]bool)
+	for _, m := range s.mentions {
+		if m.MentionedUserId == userId && m.CommentId == nil {
+			mentioned[m.PostId] = true
+		}
+	}
+	var posts []*model.Post
+	for _, p := range s.postsNewestFirst("") {
+		if mentioned[p.Id] && !s.hidden(model.ReportTargetPost, p.Id) {
+			posts = append(posts, p)
+		}
+	}
+
+	var result []model.PostWithComment
+	for _, p := range keysetPage(posts, limit, offset, after, true, postKey) {
+		result = append(result, s.postWithComment(p, userId))
+	}
+	return result, nil
+}
+
+// resolveMentions looks up the users behind usernames, leaving out the
+// author and usernames nobody has
+func (s *Store) resolveMentions(authorId string, usernames []string) []model.MentionedUser {
+	wanted := make(map[string]bool, len(usernames))
+	for _, username := range usernames {
+		wanted[username] = true
+	}
+
+	users := []model.MentionedUser{}
+	for userId, profile := range s.profiles {
+		d := profileDetails(profile)
+		if d == nil || !wanted[d.UserName] || userId == authorId {
+			continue
+		}
+		u := model.MentionedUser{UserId: userId, Username: d.UserName}
+		if account, ok := s.users[userId]; ok {
+			u.SnsEndpointArn = account.SnsEndpointArn
+		}
+		users = append(users, u)
+	}
+	return users
+}
+
+// insertMentions records users as mentioned and returns those that were not
+// already
+func (s *Store) insertMentions(postId string, commentId *string, authorId string, users []model.MentionedUser) []model.MentionedUser {
+	added := []model.MentionedUser{}
+	for _, u := range users {
+		if s.hasMention(postId, commentId, u.UserId) {
+			continue
+		}
+		s.mentions = append(s.mentions, &model.Mention{
+			Id:              newID(),
+			PostId:          postId,
+			CommentId:       commentId,
+			MentionedUserId: u.UserId,
+			AuthorId:        authorId,
+			CreatedAt:       now(),
+		})
+		added = append(added, u)
+	}
+	return added
+}
+
+func (s *Store) hasMention(postId string, commentId *string, userId string) bool {
+	for _, m := range s.mentions {
+		if m.PostId != postId || m.MentionedUserId != userId {
+			continue
+		}
+		if (m.CommentId == nil && commentId == nil) || (m.CommentId != nil && commentId != nil && *m.CommentId == *commentId) {
+			return true
+		}
+	}
+	return false
+}
+
+// removeMentions deletes the mentions of a post, or of the given comments
+// when commentIds is set
+func (s *Store) removeMentions(postId string, commentIds map[string]bool) {
+	kept := s.mentions[:0]
+	for _, m := range s.mentions {
+		if commentIds == nil && m.PostId == postId {
+			continue
+		}
+		if commentIds != nil && m.CommentId != nil && commentIds[*m.CommentId] {
+			continue
+		}
+		kept = append(kept, m)
+	}
+	s.mentions = kept
+}
@@ -0,0 +1,227 @@
+package memory
+
+import (
+	"strings"
+
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/model"
+)
+
+func (s *Store) CreateOpening(opening *model.Opening, saddress *model.SAddress, sportName string) (string, error) {
+	sportName = strings.TrimSpace(sportName)
+	if sportName == "" {
+		return "", db.NewValidationError("sportname", "Sport name cannot be empty")
+	}
+	sportName = strings.ToLower(sportName)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ts := now()
+	address := *saddress
+	address.Id = newID()
+	address.CreatedAt = ts
+	address.UpdatedAt = ts
+	s.addresses[address.Id] = &address
+
+	opening.Id = newID()
+	opening.SportID = s.findOrCreateSport(sportName).Id
+	opening.AddressID = address.Id
+	opening.CreatedAt = ts
+	opening.UpdatedAt = ts
+	stored := *opening
+	s.openings = append(s.openings, &stored)
+	return opening.Id, nil
+}
+
+func (s *Store) UpdateOpening(opening *model.OpeningDetails) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o := s.findOpening(opening.Opening.Id)
+	if o == nil {
+		return nil
+	}
+
+	opening.Opening.SportID = s.findOrCreateSport(opening.SportName).Id
+
+	o.SportID = opening.Opening.SportID
+	o.RecruiterID = opening.Opening.RecruiterID
+	o.CompanyName = opening.Opening.CompanyName
+	o.Title = opening.Opening.Title
+	o.Description = opening.Opening.Description
+	o.Status = opening.Opening.Status
+	o.Position = opening.Opening.Position
+	o.MinAge = opening.Opening.MinAge
+	o.MaxAge = opening.Opening.MaxAge
+	o.MinSalary = opening.Opening.MinSalary
+	o.MaxSalary = opening.Opening.MaxSalary
+	o.CountryRestriction = opening.Opening.CountryRestriction
+	o.AddressID = opening.Opening.AddressID
+	o.Stats = opening.Opening.Stats
+	o.UpdatedAt = now()
+
+	if opening.Address == nil {
+		return nil
+	}
+	if a, ok := s.addresses[opening.Address.Id]; ok {
+		a.Country = opening.Address.Country
+		a.State = opening.Address.State
+		a.City = opening.Address.City
+		a.Street = opening.Address.Street
+		a.Building = opening.Address.Building
+		a.PostalCode = opening.Address.PostalCode
+		a.UpdatedAt = o.UpdatedAt
+	}
+	return nil
+}
+
+func (s *Store) DeleteOpening(openingID string, actor model.AuditActor) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, o := range s.openings {
+		if o.Id == openingID {
+			s.openings = append(s.openings[:i], s.openings[i+1:]...)
+			s.recordAudit(actor, model.AuditOpeningDeleted, "opening", openingID, o, nil)
+			return nil
+		}
+	}
+	return db.NewNotFoundError("opening", openingID)
+}
+
+func (s *Store) UpdateOpeningStatus(openingID string, status model.OpeningStatus, action *model.AdminAction) error {
+	if err := checkAdminAction(action); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if o := s.findOpening(openingID); o != nil {
+		o.Status = status
+		o.UpdatedAt = now()
+	}
+	s.recordAdminAction(action)
+	return nil
+}
+
+func (s *Store) GetOpeningByID(openingID string, playerID *string) (*model.OpeningDetails, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	o := s.findOpening(openingID)
+	if o == nil {
+		return nil, db.NewNotFoundError("opening", openingID)
+	}
+	return s.openingDetails(o, playerID), nil
+}
+
+func (s *Store) GetOpeningsByRecruiterID(recruiterID string, limit, offset int, after *model.Cursor, playerID *string) ([]*model.OpeningDetails, error) {
+	return s.filterOpenings(limit, offset, after, playerID, func(d *model.OpeningDetails) bool {
+		return d.Opening.RecruiterID == recruiterID
+	}), nil
+}
+
+func (s *Store) GetAllOpenings(limit, offset int, after *model.Cursor, playerID *string) ([]*model.OpeningDetails, error) {
+	return s.filterOpenings(limit, offset, after, playerID, func(*model.OpeningDetails) bool { return true }), nil
+}
+
+func (s *Store) GetOpeningsBySport(sportName string, limit, offset int, after *model.Cursor, playerID *string) ([]*model.OpeningDetails, error) {
+	return s.filterOpenings(limit, offset, after, playerID, func(d *model.OpeningDetails) bool {
+		return d.SportName == sportName
+	}), nil
+}
+
+func (s *Store) GetOpeningsByFilter(filter *model.OpeningFilter, limit, offset int, after *model.Cursor, playerID *string) ([]*model.OpeningDetails, error) {
+	if filter.Applied != nil && playerID == nil {
+		return nil, db.NewValidationError("authentication", "Authentication required to filter by applied status")
+	}
+
+	return s.filterOpenings(limit, offset, after, playerID, func(d *model.OpeningDetails) bool {
+		o := d.Opening
+		switch {
+		case filter.SportName != nil && d.SportName != *filter.SportName:
+			return false
+		case filter.Status != nil && o.Status != *filter.Status:
+			return false
+		case filter.MinAge != nil && o.MaxAge != nil && *o.MaxAge < *filter.MinAge:
+			return false
+		case filter.MaxAge != nil && o.MinAge != nil && *o.MinAge > *filter.MaxAge:
+			return false
+		case filter.MinSalary != nil && o.MaxSalary != nil && *o.MaxSalary < *filter.MinSalary:
+			return false
+		case filter.MaxSalary != nil && o.MinSalary != nil && *o.MinSalary > *filter.MaxSalary:
+			return false
+		case filter.CountryRestriction != nil && o.CountryRestriction != nil && *o.CountryRestriction != *filter.CountryRestriction:
+			return false
+		case filter.Country != nil && !containsFold(d.Address.Country, *filter.Country):
+			return false
+		case filter.State != nil && !containsFold(d.Address.State, *filter.State):
+			return false
+		case filter.City != nil && !containsFold(d.Address.City, *filter.City):
+			return false
+		case filter.CompanyName != nil && !containsFold(o.CompanyName, *filter.CompanyName):
+			return false
+		case filter.Position != nil && !containsFold(o.Position, *filter.Position):
+			return false
+		case filter.Applied != nil && d.Applied != *filter.Applied:
+			return false
+		}
+		return true
+	}), nil
+}
+
+func (s *Store) findOpening(id string) *model.Opening {
+	for _, o := range s.openings {
+		if o.Id == id {
+			return o
+		}
+	}
+	return nil
+}
+
+// filterOpenings returns matching openings ordered by created_at DESC with
+// LIMIT/OFFSET applied after filtering.
+func (s *Store) filterOpenings(limit, offset int, after *model.Cursor, playerID *string, keep func(*model.OpeningDetails) bool) []*model.OpeningDetails {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var details []*model.OpeningDetails
+	for i := len(s.openings) - 1; i >= 0; i-- {
+		d := s.openingDetails(s.openings[i], playerID)
+		if keep(d) {
+			details = append(details, d)
+		}
+	}
+	return keysetPage(details, limit, offset, after, true, func(d *model.OpeningDetails) (string, string) {
+		return d.Opening.CreatedAt, d.Opening.Id
+	})
+}
+
+func (s *Store) openingDetails(o *model.Opening, playerID *string) *model.OpeningDetails {
+	opening := *o
+	details := &model.OpeningDetails{
+		Opening: &opening,
+		Address: &model.SAddress{},
+	}
+	if sp := s.findSportByID(o.SportID); sp != nil {
+		details.SportName = sp.Name
+	}
+	if a, ok := s.addresses[o.AddressID]; ok {
+		address := *a
+		details.Address = &address
+	}
+	if playerID != nil {
+		if app := s.findApplication(*playerID, o.Id); app != nil {
+			status := app.Status
+			details.Applied = true
+			details.ApplicationStatus = &status
+		}
+		details.Saved = s.isItemSaved(*playerID, model.SavedItemOpening, o.Id)
+	}
+	return details
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
@@ -0,0 +1,463 @@
+package memory
+
+import (
+	"sort"
+	"strings"
+
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/model"
+)
+
+func (s *Store) CreatePost(post *model.Post) error {
+	if post == nil {
+		return db.NewValidationError("post", "post cannot be nil")
+	}
+	if post.UserId == "" {
+		return db.ErrUserIDMissing
+	}
+	if strings.TrimSpace(post.Content) == "" {
+		return db.ErrContentEmpty
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.users[post.UserId]; !ok {
+		return db.ErrUserNotFound
+	}
+
+	ts := now()
+	post.Id = newID()
+	post.CreatedAt = ts
+	post.UpdatedAt = ts
+	post.LikeCount = 0
+	post.Kind = model.PostKindPost
+
+	stored := *post
+	stored.Images = nil
+	stored.Tags = sortedTags(post.Tags)
+	s.posts = append(s.posts, &stored)
+	return nil
+}
+
+func (s *Store) GetPostById(postId string) (*model.Post, error) {
+	if postId == "" {
+		return nil, db.NewValidationError("post_id", "post ID is required")
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	p := s.findPost(postId)
+	if p == nil {
+		return nil, db.NewNotFoundError("post", postId)
+	}
+	post := s.postWithImages(p)
+	return &post, nil
+}
+
+func (s *Store) GetPostsByUserId(userId string, limit, offset int, after *model.Cursor) ([]model.Post, error) {
+	if userId == "" {
+		return nil, db.ErrUserIDMissing
+	}
+	if limit <= 0 {
+		return nil, db.ErrInvalidLimit
+	}
+	if offset < 0 {
+		return nil, db.ErrInvalidOffset
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var posts []model.Post
+	for _, p := range keysetPage(s.postsNewestFirst(userId), limit, offset, after, true, postKey) {
+		posts = append(posts, s.postWithImages(p))
+	}
+	return posts, nil
+}
+
+func (s *Store) GetAllPosts(limit, offset int, after *model.Cursor) ([]model.Post, error) {
+	if limit <= 0 {
+		return nil, db.ErrInvalidLimit
+	}
+	if offset < 0 {
+		return nil, db.ErrInvalidOffset
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var posts []model.Post
+	for _, p := range keysetPage(s.postsNewestFirst(""), limit, offset, after, true, postKey) {
+		posts = append(posts, s.postWithImages(p))
+	}
+	return posts, nil
+}
+
+func (s *Store) UpdatePost(post *model.Post) error {
+	if post == nil {
+		return db.NewValidationError("post", "post cannot be nil")
+	}
+	if post.Id == "" {
+		return db.NewValidationError("post_id", "post ID is required")
+	}
+	if post.UserId == "" {
+		return db.ErrUserIDMissing
+	}
+	if strings.TrimSpace(post.Content) == "" {
+		return db.ErrContentEmpty
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.findPost(post.Id)
+	if p == nil || p.UserId != post.UserId {
+		return db.NewAuthorizationError("update", "post", post.UserId)
+	}
+	p.Content = post.Content
+	if p.Kind == model.PostKindRepost {
+		p.Kind = model.PostKindQuote
+	}
+	p.Tags = sortedTags(post.Tags)
+	p.UpdatedAt = now()
+	post.UpdatedAt = p.UpdatedAt
+	return nil
+}
+
+func (s *Store) DeletePost(postId, userId string, action *model.AdminAction) ([]string, error) {
+	if err := checkAdminAction(action); err != nil {
+		return nil, err
+	}
+	if postId == "" {
+		return nil, db.NewValidationError("post_id", "post ID is required")
+	}
+	if userId == "" {
+		return nil, db.ErrUserIDMissing
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.findPost(postId)
+	if p == nil || p.UserId != userId {
+		return nil, db.NewAuthorizationError("delete", "post", userId)
+	}
+	var mediaUrls []string
+	for _, img := range s.postImages {
+		if img.PostId == postId {
+			mediaUrls = append(mediaUrls, img.ImageUrl)
+			if img.ThumbnailUrl != nil {
+				mediaUrls = append(mediaUrls, *img.ThumbnailUrl)
+			}
+		}
+	}
+	s.removePost(postId)
+	s.recordAdminAction(action)
+	return mediaUrls, nil
+}
+
+func (s *Store) CheckPostOwnership(postId, userId string) (bool, error) {
+	if postId == "" {
+		return false, db.NewValidationError("post_id", "post ID is required")
+	}
+	if userId == "" {
+		return false, db.ErrUserIDMissing
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	p := s.findPost(postId)
+	return p != nil && p.UserId == userId, nil
+}
+
+func (s *Store) GetAllPostsWithComments(limit, offset int, after *model.Cursor, userId string) ([]model.PostWithComment, error) {
+	if limit <= 0 {
+		return nil, db.ErrInvalidLimit
+	}
+	if offset < 0 {
+		return nil, db.ErrInvalidOffset
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var visible []*model.Post
+	for _, p := range s.postsNewestFirst("") {
+		if !s.hidden(model.ReportTargetPost, p.Id) {
+			visible = append(visible, p)
+		}
+	}
+
+	var result []model.PostWithComment
+	for _, p := range keysetPage(visible, limit, offset, after, true, postKey) {
+		result = append(result, s.postWithComment(p, userId))
+	}
+	return result, nil
+}
+
+func (s *Store) GetAllPostsByUserIdWithComments(targetUserId string, limit, offset int, after *model.Cursor, currentUserId string) ([]model.PostWithComment, error) {
+	if targetUserId == "" {
+		return nil, db.ErrUserIDMissing
+	}
+	if limit <= 0 {
+		return nil, db.ErrInvalidLimit
+	}
+	if offset < 0 {
+		return nil, db.ErrInvalidOffset
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var result []model.PostWithComment
+	for _, p := range keysetPage(s.postsNewestFirst(targetUserId), limit, offset, after, true, postKey) {
+		result = append(result, s.postWithComment(p, currentUserId))
+	}
+	return result, nil
+}
+
+func (s *Store) CreatePostImage(postImage *model.PostImage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.findPost(postImage.PostId) == nil {
+		return db.NewNotFoundError("post", postImage.PostId)
+	}
+	if postImage.MediaType == "" {
+		postImage.MediaType = model.PostMediaImage
+	}
+	if postImage.Status == "" {
+		postImage.Status = model.MediaStatusReady
+	}
+	postImage.Id = newID()
+	stored := *postImage
+	s.postImages = append(s.postImages, &stored)
+	return nil
+}
+
+func (s *Store) GetImagesByPostId(postId string) ([]model.PostImage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.imagesForPost(postId), nil
+}
+
+func (s *Store) DeleteImagesByPostId(postId string) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var urls []string
+	kept := s.postImages[:0]
+	for _, img := range s.postImages {
+		if img.PostId == postId {
+			urls = append(urls, img.ImageUrl)
+			if img.ThumbnailUrl != nil {
+				urls = append(urls, *img.ThumbnailUrl)
+			}
+			continue
+		}
+		kept = append(kept, img)
+	}
+	s.postImages = kept
+	return urls, nil
+}
+
+func (s *Store) DeletePostImage(imageId string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, img := range s.postImages {
+		if img.Id == imageId {
+			s.postImages = append(s.postImages[:i], s.postImages[i+1:]...)
+			return img.ImageUrl, nil
+		}
+	}
+	return "", db.NewNotFoundError("post image", imageId)
+}
+
+func (s *Store) ReactToPost(postID, userID string, reaction model.ReactionType) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.findPost(postID)
+	if p == nil {
+		return db.NewNotFoundError("post", postID)
+	}
+	current, reacted := s.postLikes[postID][userID]
+	if reacted && current == reaction {
+		return db.NewAlreadyExistsError("post reaction", "post_id and user_id combination", postID+" + "+userID)
+	}
+	if s.postLikes[postID] == nil {
+		s.postLikes[postID] = make(map[string]model.ReactionType)
+		s.postLikedAt[postID] = make(map[string]string)
+	}
+	s.postLikes[postID][userID] = reaction
+	if !reacted {
+		// Changing the reaction keeps created_at, as the upsert does
+		s.postLikedAt[postID][userID] = now()
+		p.LikeCount++
+	}
+	return nil
+}
+
+func (s *Store) RemovePostReaction(postID, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, reacted := s.postLikes[postID][userID]; !reacted {
+		return nil
+	}
+	delete(s.postLikes[postID], userID)
+	delete(s.postLikedAt[postID], userID)
+	if p := s.findPost(postID); p != nil {
+		p.LikeCount--
+	}
+	return nil
+}
+
+func (s *Store) findPost(postId string) *model.Post {
+	for _, p := range s.posts {
+		if p.Id == postId {
+			return p
+		}
+	}
+	return nil
+}
+
+// postsNewestFirst returns posts ordered by created_at DESC, optionally
+// restricted to a single author.
+func (s *Store) postsNewestFirst(userId string) []*model.Post {
+	var posts []*model.Post
+	for i := len(s.posts) - 1; i >= 0; i-- {
+		if userId == "" || s.posts[i].UserId == userId {
+			posts = append(posts, s.posts[i])
+		}
+	}
+	return posts
+}
+
+// postKey is the keyset pagination key of a post
+func postKey(p *model.Post) (string, string) {
+	return p.CreatedAt, p.Id
+}
+
+func (s *Store) imagesForPost(postId string) []model.PostImage {
+	var images []model.PostImage
+	for _, img := range s.postImages {
+		if img.PostId == postId {
+			images = append(images, *img)
+		}
+	}
+	sort.Slice(images, func(i, j int) bool { return images[i].Id < images[j].Id })
+	return images
+}
+
+func (s *Store) postWithImages(p *model.Post) model.Post {
+	post := s.postWithShare(p)
+	post.Images = s.imagesForPost(p.Id)
+	return post
+}
+
+// postWithShare fills in the post a share points at and how often the post
+// has been shared itself
+func (s *Store) postWithShare(p *model.Post) model.Post {
+	post := *p
+	for _, other := range s.posts {
+		if other.RepostOfId != nil && *other.RepostOfId == p.Id {
+			post.ShareCount++
+		}
+	}
+	if post.Kind == model.PostKindPost {
+		return post
+	}
+	if p.RepostOfId == nil {
+		post.RepostOf = &model.SharedPost{Deleted: true}
+		return post
+	}
+	if original := s.findPost(*p.RepostOfId); original != nil {
+		post.RepostOf = &model.SharedPost{
+			Id:        original.Id,
+			UserId:    original.UserId,
+			Content:   original.Content,
+			CreatedAt: original.CreatedAt,
+		}
+	}
+	return post
+}
+
+func (s *Store) postWithComment(p *model.Post, userId string) model.PostWithComment {
+	pwc := model.PostWithComment{
+		Post:      s.postWithShare(p),
+		Reactions: map[model.ReactionType]int{},
+		Images:    s.imagesForPost(p.Id),
+	}
+	for _, reaction := range s.postLikes[p.Id] {
+		pwc.Reactions[reaction]++
+	}
+	if reaction, ok := s.postLikes[p.Id][userId]; ok {
+		pwc.UserLiked = true
+		pwc.UserReaction = &reaction
+	}
+	pwc.Saved = s.isItemSaved(userId, model.SavedItemPost, p.Id)
+
+	for _, c := range s.comments {
+		if c.PostId != p.Id {
+			continue
+		}
+		pwc.TotalComments++
+		if c.ParentId == nil && !s.hidden(model.ReportTargetComment, c.Id) {
+			latest := *c
+			pwc.LatestComment = &latest
+		}
+	}
+	return pwc
+}
+
+// removePost deletes a post and everything that cascades from it. Plain
+// reposts of it are removed too, and quotes of it become tombstones.
+func (s *Store) removePost(postId string) {
+	var reposts []string
+	posts := s.posts[:0]
+	for _, p := range s.posts {
+		if p.Id == postId {
+			continue
+		}
+		if p.RepostOfId != nil && *p.RepostOfId == postId {
+			if p.Kind == model.PostKindRepost {
+				reposts = append(reposts, p.Id)
+			} else {
+				p.RepostOfId = nil
+			}
+		}
+		posts = append(posts, p)
+	}
+	s.posts = posts
+	for _, id := range reposts {
+		s.removePost(id)
+	}
+
+	images := s.postImages[:0]
+	for _, img := range s.postImages {
+		if img.PostId != postId {
+			images = append(images, img)
+		}
+	}
+	s.postImages = images
+
+	comments := s.comments[:0]
+	for _, c := range s.comments {
+		if c.PostId != postId {
+			comments = append(comments, c)
+			continue
+		}
+		delete(s.commentLikes, c.Id)
+	}
+	s.comments = comments
+
+	delete(s.postLikes, postId)
+	delete(s.postLikedAt, postId)
+	s.removeMentions(postId, nil)
+}
@@ -0,0 +1,172 @@
+package memory_test
+
+import (
+	"testing"
+
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/model"
+	"sportsin_backend/internals/store/memory"
+)
+
+func report(t *testing.T, repo *memory.Store, reporterID string, targetType model.ReportTargetType, targetID string) *model.Report {
+	t.Helper()
+	r := &model.Report{ReporterId: reporterID, TargetType: targetType, TargetId: targetID, Reason: model.ReportReasonSpam}
+	if err := repo.CreateReport(r); err != nil {
+		t.Fatalf("CreateReport: %v", err)
+	}
+	return r
+}
+
+func feedPostIds(t *testing.T, repo *memory.Store, viewerID string) []string {
+	t.Helper()
+	posts, err := repo.GetAllPostsWithComments(20, 0, nil, viewerID)
+	if err != nil {
+		t.Fatalf("GetAllPostsWithComments: %v", err)
+	}
+	var ids []string
+	for _, p := range posts {
+		ids = append(ids, p.Post.Id)
+	}
+	return ids
+}
+
+func TestReportedPostIsHiddenAtThreshold(t *testing.T) {
+	repo := memory.NewStore()
+	repo.ReportHideThreshold = 2
+	author, viewer := memory.NewTestUser(t, repo), memory.NewTestUser(t, repo)
+	kept := memory.NewTestPost(t, repo, author, "final score 3-1")
+	reported := memory.NewTestPost(t, repo, author, "buy followers here")
+
+	first := report(t, repo, memory.NewTestUser(t, repo), model.ReportTargetPost, reported.Id)
+	if ids := feedPostIds(t, repo, viewer); len(ids) != 2 {
+		t.Fatalf("feed after one report = %v, want both posts", ids)
+	}
+
+	report(t, repo, memory.NewTestUser(t, repo), model.ReportTargetPost, reported.Id)
+	if ids := feedPostIds(t, repo, viewer); len(ids) != 1 || ids[0] != kept.Id {
+		t.Fatalf("feed after two reports = %v, want only %s", ids, kept.Id)
+	}
+
+	// Dismissing the reports brings the post back
+	if _, err := repo.ResolveReport(first.Id, model.ReportStatusDismissed, viewer, nil); err != nil {
+		t.Fatalf("ResolveReport: %v", err)
+	}
+	if ids := feedPostIds(t, repo, viewer); len(ids) != 2 {
+		t.Fatalf("feed after dismissal = %v, want both posts", ids)
+	}
+}
+
+func TestReportedPostIsNotHiddenWithoutThreshold(t *testing.T) {
+	repo := memory.NewStore()
+	author := memory.NewTestUser(t, repo)
+	post := memory.NewTestPost(t, repo, author, "kickoff at 6")
+	for i := 0; i < 5; i++ {
+		report(t, repo, memory.NewTestUser(t, repo), model.ReportTargetPost, post.Id)
+	}
+	if ids := feedPostIds(t, repo, author); len(ids) != 1 {
+		t.Fatalf("feed = %v, want the post while auto-hiding is off", ids)
+	}
+}
+
+func TestReportedCommentIsHiddenAtThreshold(t *testing.T) {
+	repo := memory.NewStore()
+	repo.ReportHideThreshold = 1
+	author := memory.NewTestUser(t, repo)
+	post := memory.NewTestPost(t, repo, author, "who is coming to training?")
+	kept, err := repo.CreateComment(author, post.Id, "me", nil)
+	if err != nil {
+		t.Fatalf("CreateComment: %v", err)
+	}
+	reported, err := repo.CreateComment(author, post.Id, "spam spam spam", nil)
+	if err != nil {
+		t.Fatalf("CreateComment: %v", err)
+	}
+	report(t, repo, memory.NewTestUser(t, repo), model.ReportTargetComment, reported.Id)
+
+	comments, err := repo.GetCommentsByPostId(post.Id, 10, 0, nil, model.CommentSortOldest, author)
+	if err != nil {
+		t.Fatalf("GetCommentsByPostId: %v", err)
+	}
+	if len(comments) != 1 || comments[0].Comment.Id != kept.Id {
+		t.Fatalf("comments = %v, want only %s", comments, kept.Id)
+	}
+
+	posts, err := repo.GetAllPostsWithComments(10, 0, nil, author)
+	if err != nil {
+		t.Fatalf("GetAllPostsWithComments: %v", err)
+	}
+	if latest := posts[0].LatestComment; latest == nil || latest.Id != kept.Id {
+		t.Errorf("latest comment = %v, want %s", latest, kept.Id)
+	}
+	if posts[0].TotalComments != 2 {
+		t.Errorf("total comments = %d, want hidden comments still counted", posts[0].TotalComments)
+	}
+}
+
+func repost(t *testing.T, repo *memory.Store, userID, postID, content string) *model.Post {
+	t.Helper()
+	shared := postID
+	post := &model.Post{UserId: userID, RepostOfId: &shared, Content: content}
+	if err := repo.CreateRepost(post); err != nil {
+		t.Fatalf("CreateRepost: %v", err)
+	}
+	return post
+}
+
+func TestRepostOfRepostSharesTheOriginal(t *testing.T) {
+	repo := memory.NewStore()
+	original := memory.NewTestPost(t, repo, memory.NewTestUser(t, repo), "we won the cup")
+	first := repost(t, repo, memory.NewTestUser(t, repo), original.Id, "")
+	second := repost(t, repo, memory.NewTestUser(t, repo), first.Id, "")
+
+	if second.Kind != model.PostKindRepost || *second.RepostOfId != original.Id {
+		t.Fatalf("repost of a repost points at %s, want the original %s", *second.RepostOfId, original.Id)
+	}
+	got, err := repo.GetPostById(original.Id)
+	if err != nil {
+		t.Fatalf("GetPostById: %v", err)
+	}
+	if got.ShareCount != 2 {
+		t.Errorf("share count = %d, want 2", got.ShareCount)
+	}
+
+	dup := original.Id
+	err = repo.CreateRepost(&model.Post{UserId: first.UserId, RepostOfId: &dup})
+	if !db.IsAlreadyExistsError(err) {
+		t.Errorf("second plain repost by the same user: err = %v, want AlreadyExistsError", err)
+	}
+}
+
+func TestDeletingOriginalLeavesQuoteTombstones(t *testing.T) {
+	repo := memory.NewStore()
+	author := memory.NewTestUser(t, repo)
+	original := memory.NewTestPost(t, repo, author, "tryouts on saturday")
+	plain := repost(t, repo, memory.NewTestUser(t, repo), original.Id, "")
+	quote := repost(t, repo, memory.NewTestUser(t, repo), original.Id, "see you there")
+
+	got, err := repo.GetPostById(quote.Id)
+	if err != nil {
+		t.Fatalf("GetPostById: %v", err)
+	}
+	if got.Kind != model.PostKindQuote || got.RepostOf == nil || got.RepostOf.Id != original.Id {
+		t.Fatalf("quote before deletion = %+v, want it to show the original", got.RepostOf)
+	}
+
+	if _, err := repo.DeletePost(original.Id, author, nil); err != nil {
+		t.Fatalf("DeletePost: %v", err)
+	}
+
+	if _, err := repo.GetPostById(plain.Id); !db.IsNotFoundError(err) {
+		t.Errorf("plain repost after deletion: err = %v, want NotFoundError", err)
+	}
+	got, err = repo.GetPostById(quote.Id)
+	if err != nil {
+		t.Fatalf("GetPostById: %v", err)
+	}
+	if got.RepostOfId != nil || got.RepostOf == nil || !got.RepostOf.Deleted {
+		t.Errorf("quote after deletion = %+v, want a tombstone", got.RepostOf)
+	}
+	if got.Content != "see you there" {
+		t.Errorf("quote content = %q, want it kept", got.Content)
+	}
+}
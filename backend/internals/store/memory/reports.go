@@ -0,0 +1,144 @@
+package memory
+
+import (
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/model"
+)
+
+func (s *Store) CreateReport(report *model.Report) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.reportTargetExists(report.TargetType, report.TargetId, report.ReporterId) {
+		if !report.TargetType.IsValid() {
+			return db.NewValidationError("target_type", "unsupported report target type")
+		}
+		return db.NewNotFoundError(string(report.TargetType), report.TargetId)
+	}
+	for _, r := range s.reports {
+		if r.ReporterId == report.ReporterId && r.TargetType == report.TargetType && r.TargetId == report.TargetId {
+			return db.NewAlreadyExistsError("report", "reporter_id and target combination", report.ReporterId+" + "+report.TargetId)
+		}
+	}
+
+	report.Id = newID()
+	report.Status = model.ReportStatusOpen
+	report.ResolvedBy = nil
+	report.ResolvedAt = nil
+	report.CreatedAt = now()
+	stored := *report
+	s.reports = append(s.reports, &stored)
+	return nil
+}
+
+func (s *Store) GetReportById(id string) (*model.Report, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	r := s.findReport(id)
+	if r == nil {
+		return nil, db.NewNotFoundError("report", id)
+	}
+	report := *r
+	return &report, nil
+}
+
+func (s *Store) GetReports(filter *model.ReportFilter, limit, offset int) ([]model.Report, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	reports := []model.Report{}
+	for _, r := range s.reports {
+		if filter.Status != nil && r.Status != *filter.Status {
+			continue
+		}
+		if filter.TargetType != nil && r.TargetType != *filter.TargetType {
+			continue
+		}
+		if filter.TargetId != nil && r.TargetId != *filter.TargetId {
+			continue
+		}
+		reports = append(reports, *r)
+	}
+	return paginate(reports, limit, offset), nil
+}
+
+func (s *Store) ResolveReport(id string, status model.ReportStatus, resolverID string, action *model.AdminAction) (*model.Report, error) {
+	if err := checkAdminAction(action); err != nil {
+		return nil, err
+	}
+	if status != model.ReportStatusActioned && status != model.ReportStatusDismissed {
+		return nil, db.NewValidationError("status", "report can only be actioned or dismissed")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	report := s.findReport(id)
+	if report == nil {
+		return nil, db.NewNotFoundError("report", id)
+	}
+	if report.Status != model.ReportStatusOpen {
+		return nil, db.NewValidationError("status", "report has already been resolved")
+	}
+
+	resolvedAt := now()
+	for _, r := range s.reports {
+		if r.TargetType != report.TargetType || r.TargetId != report.TargetId || r.Status != model.ReportStatusOpen {
+			continue
+		}
+		r.Status = status
+		resolvedBy := resolverID
+		r.ResolvedBy = &resolvedBy
+		r.ResolvedAt = &resolvedAt
+	}
+
+	s.recordAdminAction(action)
+	resolved := *report
+	return &resolved, nil
+}
+
+func (s *Store) findReport(id string) *model.Report {
+	for _, r := range s.reports {
+		if r.Id == id {
+			return r
+		}
+	}
+	return nil
+}
+
+// reportTargetExists mirrors the target lookups of the Postgres store,
+// including the room membership check for chat messages.
+func (s *Store) reportTargetExists(targetType model.ReportTargetType, targetID, reporterID string) bool {
+	switch targetType {
+	case model.ReportTargetPost:
+		return s.findPost(targetID) != nil
+	case model.ReportTargetComment:
+		return s.findComment(targetID) != nil
+	case model.ReportTargetProfile:
+		_, ok := s.users[targetID]
+		return ok
+	case model.ReportTargetChatMessage:
+		for _, m := range s.messages {
+			if m.Id == targetID {
+				return s.findChatMember(m.ChatRoomId, reporterID) != nil
+			}
+		}
+	}
+	return false
+}
+
+// hidden reports whether content has collected ReportHideThreshold reports
+// that were not dismissed. Callers must hold the lock.
+func (s *Store) hidden(targetType model.ReportTargetType, targetID string) bool {
+	if s.ReportHideThreshold <= 0 {
+		return false
+	}
+	count := 0
+	for _, r := range s.reports {
+		if r.TargetType == targetType && r.TargetId == targetID && r.Status != model.ReportStatusDismissed {
+			count++
+		}
+	}
+	return count >= s.ReportHideThreshold
+}
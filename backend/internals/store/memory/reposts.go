@@ -0,0 +1,81 @@
+package memory
+
+import (
+	"strings"
+
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/model"
+)
+
+func (s *Store) CreateRepost(post *model.Post) error {
+	if post.UserId == "" {
+		return db.ErrUserIDMissing
+	}
+	if post.RepostOfId == nil || *post.RepostOfId == "" {
+		return db.NewValidationError("post_id", "post ID is required")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	shared := s.findPost(*post.RepostOfId)
+	if shared == nil {
+		return db.NewNotFoundError("post", *post.RepostOfId)
+	}
+	if shared.Kind == model.PostKindRepost {
+		if shared.RepostOfId == nil {
+			return db.NewValidationError("post_id", "the original post has been deleted")
+		}
+		original := *shared.RepostOfId
+		post.RepostOfId = &original
+	}
+	if _, ok := s.users[post.UserId]; !ok {
+		return db.ErrUserNotFound
+	}
+
+	post.Content = strings.TrimSpace(post.Content)
+	post.Kind = model.PostKindQuote
+	if post.Content == "" {
+		post.Kind = model.PostKindRepost
+		for _, p := range s.posts {
+			if p.Kind == model.PostKindRepost && p.UserId == post.UserId && p.RepostOfId != nil && *p.RepostOfId == *post.RepostOfId {
+				return db.NewAlreadyExistsError("repost", "post_id and user_id combination", *post.RepostOfId+" + "+post.UserId)
+			}
+		}
+	}
+
+	ts := now()
+	post.Id = newID()
+	post.CreatedAt = ts
+	post.UpdatedAt = ts
+	post.LikeCount = 0
+
+	stored := *post
+	repostOf := *post.RepostOfId
+	stored.RepostOfId = &repostOf
+	stored.RepostOf = nil
+	stored.Images = nil
+	stored.Tags = []string{}
+	s.posts = append(s.posts, &stored)
+	return nil
+}
+
+func (s *Store) DeleteRepost(postId, userId string) error {
+	if postId == "" {
+		return db.NewValidationError("post_id", "post ID is required")
+	}
+	if userId == "" {
+		return db.ErrUserIDMissing
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, p := range s.posts {
+		if p.Kind == model.PostKindRepost && p.UserId == userId && p.RepostOfId != nil && *p.RepostOfId == postId {
+			s.removePost(p.Id)
+			return nil
+		}
+	}
+	return db.NewNotFoundError("repost", postId)
+}
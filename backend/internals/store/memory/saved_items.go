@@ -0,0 +1,94 @@
+package memory
+
+import (
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/model"
+)
+
+func (s *Store) SaveItem(item *model.SavedItem) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.savedItemTargetExists(item.ItemType, item.ItemId) {
+		if !item.ItemType.IsValid() {
+			return db.NewValidationError("item_type", "unsupported saved item type")
+		}
+		return db.NewNotFoundError(string(item.ItemType), item.ItemId)
+	}
+	if s.isItemSaved(item.UserId, item.ItemType, item.ItemId) {
+		return db.NewAlreadyExistsError("saved item", "user_id and item combination", item.UserId+" + "+item.ItemId)
+	}
+
+	item.Id = newID()
+	item.CreatedAt = now()
+	stored := *item
+	s.savedItems = append(s.savedItems, &stored)
+	return nil
+}
+
+func (s *Store) UnsaveItem(userId string, itemType model.SavedItemType, itemId string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, item := range s.savedItems {
+		if item.UserId == userId && item.ItemType == itemType && item.ItemId == itemId {
+			s.savedItems = append(s.savedItems[:i], s.savedItems[i+1:]...)
+			return nil
+		}
+	}
+	return db.NewNotFoundError("saved item", itemId)
+}
+
+func (s *Store) GetSavedItems(userId string, itemType *model.SavedItemType, limit, offset int, after *model.Cursor) ([]model.SavedItem, error) {
+	if limit <= 0 {
+		return nil, db.ErrInvalidLimit
+	}
+	if offset < 0 {
+		return nil, db.ErrInvalidOffset
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var matching []*model.SavedItem
+	for _, item := range s.savedItems {
+		if item.UserId != userId || (itemType != nil && item.ItemType != *itemType) {
+			continue
+		}
+		if s.savedItemTargetExists(item.ItemType, item.ItemId) {
+			matching = append(matching, item)
+		}
+	}
+
+	items := []model.SavedItem{}
+	for _, item := range keysetPage(matching, limit, offset, after, true, savedItemKey) {
+		items = append(items, *item)
+	}
+	return items, nil
+}
+
+func (s *Store) savedItemTargetExists(itemType model.SavedItemType, itemId string) bool {
+	switch itemType {
+	case model.SavedItemPost:
+		return s.findPost(itemId) != nil
+	case model.SavedItemOpening:
+		return s.findOpening(itemId) != nil
+	case model.SavedItemTournament:
+		return s.findTournament(itemId) != nil
+	}
+	return false
+}
+
+func (s *Store) isItemSaved(userId string, itemType model.SavedItemType, itemId string) bool {
+	for _, item := range s.savedItems {
+		if item.UserId == userId && item.ItemType == itemType && item.ItemId == itemId {
+			return true
+		}
+	}
+	return false
+}
+
+// savedItemKey is the keyset pagination key of a saved item
+func savedItemKey(item *model.SavedItem) (string, string) {
+	return item.CreatedAt, item.Id
+}
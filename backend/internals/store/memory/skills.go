@@ -0,0 +1,60 @@
+package memory
+
+import (
+	"sort"
+
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/model"
+)
+
+func (s *Store) AddUserSkill(userID, sportID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.findSportByID(sportID) == nil {
+		return db.NewNotFoundError("sport", sportID)
+	}
+	if s.userSkills[userID][sportID] {
+		return db.NewAlreadyExistsError("user skill", "user_id and sport_id combination", userID+" + "+sportID)
+	}
+	if s.userSkills[userID] == nil {
+		s.userSkills[userID] = make(map[string]bool)
+	}
+	s.userSkills[userID][sportID] = true
+	return nil
+}
+
+func (s *Store) RemoveUserSkill(userID, sportID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.userSkills[userID][sportID] {
+		return db.NewNotFoundError("user skill", sportID)
+	}
+	delete(s.userSkills[userID], sportID)
+	return nil
+}
+
+func (s *Store) GetUserSkills(userID string) ([]model.Sport, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	sports := []model.Sport{}
+	for sportID := range s.userSkills[userID] {
+		if sp := s.findSportByID(sportID); sp != nil {
+			sports = append(sports, *sp)
+		}
+	}
+	sort.Slice(sports, func(i, j int) bool { return sports[i].Name < sports[j].Name })
+	return sports, nil
+}
+
+// sharesSport reports whether the two users play at least one common sport.
+func (s *Store) sharesSport(userID, otherID string) bool {
+	for sportID := range s.userSkills[userID] {
+		if s.userSkills[otherID][sportID] {
+			return true
+		}
+	}
+	return false
+}
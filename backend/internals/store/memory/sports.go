@@ -0,0 +1,194 @@
+package memory
+
+import (
+	"strings"
+
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/model"
+)
+
+func (s *Store) GetSports(limit, offset int) ([]model.Sport, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	sports := []model.Sport{}
+	for i := len(s.sports) - 1; i >= 0; i-- {
+		sports = append(sports, *s.sports[i])
+	}
+	return paginate(sports, limit, offset), nil
+}
+
+func (s *Store) GetSportById(id string) (*model.Sport, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	sp := s.findSportByID(id)
+	if sp == nil {
+		return nil, db.NewNotFoundError("sport", id)
+	}
+	sport := *sp
+	return &sport, nil
+}
+
+func (s *Store) GetSportByName(name string) (*model.Sport, error) {
+	sname, err := sanitizeSportName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	sp := s.findSportByName(sname)
+	if sp == nil {
+		return nil, db.NewNotFoundError("sport", name)
+	}
+	sport := *sp
+	return &sport, nil
+}
+
+func (s *Store) CreateSport(sport *model.Sport, action *model.AdminAction) (string, error) {
+	if err := checkAdminAction(action); err != nil {
+		return "", err
+	}
+	sname, err := sanitizeSportName(sport.Name)
+	if err != nil {
+		return "", err
+	}
+	sport.Name = sname
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.findSportByName(sport.Name) != nil {
+		return "", db.NewAlreadyExistsError("sport", "name", sport.Name)
+	}
+	s.insertSport(sport)
+	if action != nil {
+		action.TargetId = sport.Id
+	}
+	s.recordAdminAction(action)
+	return sport.Id, nil
+}
+
+func (s *Store) UpdateSport(sport *model.Sport, action *model.AdminAction) error {
+	if err := checkAdminAction(action); err != nil {
+		return err
+	}
+	sname, err := sanitizeSportName(sport.Name)
+	if err != nil {
+		return err
+	}
+	sport.Name = sname
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sp := s.findSportByID(sport.Id)
+	if sp == nil {
+		return db.NewNotFoundError("sport", sport.Id)
+	}
+	if other := s.findSportByName(sport.Name); other != nil && other.Id != sport.Id {
+		return db.NewAlreadyExistsError("sport", "name", sport.Name)
+	}
+	sp.Name = sport.Name
+	sp.Description = sport.Description
+	sp.UpdatedAt = now()
+	s.recordAdminAction(action)
+	return nil
+}
+
+// DeleteSport drops the rows Postgres removes through ON DELETE CASCADE.
+func (s *Store) DeleteSport(id string, action *model.AdminAction) error {
+	if err := checkAdminAction(action); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	index := -1
+	for i, sp := range s.sports {
+		if sp.Id == id {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return db.NewNotFoundError("sport", id)
+	}
+
+	var usage model.SportUsage
+	for _, t := range s.tournaments {
+		if t.SportId == id {
+			usage.Tournaments++
+		}
+	}
+	for _, o := range s.openings {
+		if o.SportID == id {
+			usage.Openings++
+		}
+	}
+	for _, a := range s.achievements {
+		if a.SportId == id {
+			usage.Achievements++
+		}
+	}
+	for _, skills := range s.userSkills {
+		if skills[id] {
+			usage.Skills++
+		}
+	}
+	if err := usage.Err(); err != nil {
+		return err
+	}
+
+	s.sports = append(s.sports[:index], s.sports[index+1:]...)
+	s.recordAdminAction(action)
+	return nil
+}
+
+// findOrCreateSport mirrors the select-then-insert the opening queries do
+// against "Sports".
+func (s *Store) findOrCreateSport(name string) *model.Sport {
+	if sp := s.findSportByName(name); sp != nil {
+		return sp
+	}
+	return s.insertSport(&model.Sport{Name: name})
+}
+
+func (s *Store) insertSport(sport *model.Sport) *model.Sport {
+	ts := now()
+	sport.Id = newID()
+	sport.CreatedAt = ts
+	sport.UpdatedAt = ts
+	stored := *sport
+	s.sports = append(s.sports, &stored)
+	return &stored
+}
+
+func (s *Store) findSportByName(name string) *model.Sport {
+	for _, sp := range s.sports {
+		if sp.Name == name {
+			return sp
+		}
+	}
+	return nil
+}
+
+func (s *Store) findSportByID(id string) *model.Sport {
+	for _, sp := range s.sports {
+		if sp.Id == id {
+			return sp
+		}
+	}
+	return nil
+}
+
+func sanitizeSportName(name string) (string, error) {
+	name = strings.Trim(name, " ")
+	name = strings.ReplaceAll(name, " ", "_")
+	if strings.ContainsAny(name, "!@#$%^&*()+") {
+		return "", db.NewValidationError("sport", "Invalid name")
+	}
+	return strings.ToLower(name), nil
+}
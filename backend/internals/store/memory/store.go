@@ -0,0 +1,131 @@
+// Package memory is an in-process implementation of store.Store. It keeps
+// every record in maps and slices guarded by a single mutex, so it is meant
+// for handler tests and for running the API locally without Postgres.
+package memory
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"sportsin_backend/internals/model"
+	"sportsin_backend/internals/store"
+)
+
+// timeLayout is fixed width so that timestamps sort lexicographically.
+const timeLayout = "2006-01-02T15:04:05.000000Z07:00"
+
+// Store holds all in-memory tables. Slices are kept in insertion order,
+// which is also creation order, so "newest first" is a reverse walk.
+type Store struct {
+	mu sync.RWMutex
+
+	users      map[string]*model.User
+	profiles   map[string]any // *model.Player or *model.Recruiter
+	referredBy map[string]string
+	coins      map[string]int
+
+	credentials map[string]*model.Credential // keyed by email
+
+	posts        []*model.Post
+	postImages   []*model.PostImage
+	postLikes    map[string]map[string]model.ReactionType // post ID -> user ID -> reaction
+	postLikedAt  map[string]map[string]string             // post ID -> user ID -> time of the first reaction
+	comments     []*model.Comment
+	commentLikes map[string]map[string]bool // comment ID -> user IDs
+
+	tournaments  []*model.Tournament
+	participants []*model.TounramentParticipants
+
+	sports       []*model.Sport
+	userSkills   map[string]map[string]bool // user ID -> sport IDs
+	addresses    map[string]*model.SAddress
+	openings     []*model.Opening
+	applications []*model.Application
+
+	chatRooms   []*model.ChatRoom
+	chatMembers []*model.ChatRoomMember
+	messages    []*model.ChatMessage
+
+	achievements []*model.Achievement
+
+	adminActions []*model.AdminAction
+	auditEvents  []*model.AuditEvent
+	reports      []*model.Report
+	follows      []*model.Follow
+	mentions     []*model.Mention
+	savedItems   []*model.SavedItem
+
+	// ReferalReward is the number of coins credited to a referrer, the
+	// equivalent of the referal_reward row in the Meta table.
+	ReferalReward int
+
+	// ReportHideThreshold is the number of reports that hides a post or
+	// comment, as on repositories.Repository. Zero turns auto-hiding off.
+	ReportHideThreshold int
+}
+
+var _ store.Store = (*Store)(nil)
+
+// NewStore returns an empty in-memory store.
+func NewStore() *Store {
+	return &Store{
+		users:         make(map[string]*model.User),
+		profiles:      make(map[string]any),
+		referredBy:    make(map[string]string),
+		coins:         make(map[string]int),
+		credentials:   make(map[string]*model.Credential),
+		postLikes:     make(map[string]map[string]model.ReactionType),
+		postLikedAt:   make(map[string]map[string]string),
+		commentLikes:  make(map[string]map[string]bool),
+		addresses:     make(map[string]*model.SAddress),
+		userSkills:    make(map[string]map[string]bool),
+		ReferalReward: 10,
+	}
+}
+
+func newID() string {
+	return uuid.New().String()
+}
+
+func now() string {
+	return time.Now().UTC().Format(timeLayout)
+}
+
+// paginate applies LIMIT/OFFSET semantics to an already ordered slice.
+func paginate[T any](items []T, limit, offset int) []T {
+	if offset >= len(items) {
+		return []T{}
+	}
+	end := offset + limit
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[offset:end]
+}
+
+// keysetPage orders items by their (timestamp, id) key, newest first when
+// desc, and returns the page after the cursor, or the page at offset when
+// there is none. It mirrors the row comparisons of the Postgres lists.
+func keysetPage[T any](items []T, limit, offset int, after *model.Cursor, desc bool, key func(T) (string, string)) []T {
+	before := func(at1, id1, at2, id2 string) bool {
+		if at1 != at2 {
+			return (at1 < at2) != desc
+		}
+		return id1 != id2 && (id1 < id2) != desc
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		at1, id1 := key(items[i])
+		at2, id2 := key(items[j])
+		return before(at1, id1, at2, id2)
+	})
+	if after == nil {
+		return paginate(items, limit, offset)
+	}
+	start := sort.Search(len(items), func(i int) bool {
+		at, id := key(items[i])
+		return before(after.At, after.Id, at, id)
+	})
+	return paginate(items[start:], limit, 0)
+}
@@ -0,0 +1,104 @@
+package memory
+
+import (
+	"sort"
+	"testing"
+
+	"sportsin_backend/internals/model"
+)
+
+type keyedRow struct {
+	at string
+	id string
+}
+
+func rowKey(r keyedRow) (string, string) {
+	return r.at, r.id
+}
+
+// keyedRows returns rows sharing timestamps, so that the ID breaks the ties
+// as in ORDER BY at, id
+func keyedRows() []keyedRow {
+	return []keyedRow{
+		{"2025-09-01T10:00:00.000000Z", "6f1c2a9e-0000-4000-8000-000000000003"},
+		{"2025-09-01T10:00:00.000000Z", "0a7d4b12-0000-4000-8000-000000000001"},
+		{"2025-09-01T09:00:00.000000Z", "c3e8f5d7-0000-4000-8000-000000000005"},
+		{"2025-09-01T11:00:00.000000Z", "2b9a6c41-0000-4000-8000-000000000002"},
+		{"2025-09-01T10:00:00.000000Z", "f4d2e8a1-0000-4000-8000-000000000004"},
+		{"2025-09-01T09:00:00.000000Z", "8e5b3f20-0000-4000-8000-000000000006"},
+		{"2025-09-01T12:00:00.000000Z", "1d6c7e93-0000-4000-8000-000000000007"},
+	}
+}
+
+// postgresOrder sorts rows as ORDER BY at, id would, descending when desc.
+// Lower-case UUID strings compare like the uuid type.
+func postgresOrder(rows []keyedRow, desc bool) []keyedRow {
+	sorted := append([]keyedRow(nil), rows...)
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.at != b.at {
+			return (a.at < b.at) != desc
+		}
+		return (a.id < b.id) != desc
+	})
+	return sorted
+}
+
+func TestKeysetPageWalksRowsInPostgresOrder(t *testing.T) {
+	for _, desc := range []bool{true, false} {
+		want := postgresOrder(keyedRows(), desc)
+
+		var got []keyedRow
+		var after *model.Cursor
+		for pages := 0; pages < 10; pages++ {
+			page := keysetPage(keyedRows(), 3, 0, after, desc, rowKey)
+			got = append(got, page...)
+			if len(page) < 3 {
+				break
+			}
+			last := page[len(page)-1]
+			after = &model.Cursor{At: last.at, Id: last.id}
+		}
+
+		if len(got) != len(want) {
+			t.Fatalf("desc=%v: walked %d rows, want %d", desc, len(got), len(want))
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("desc=%v: row %d = %v, want %v", desc, i, got[i], want[i])
+			}
+		}
+	}
+}
+
+func TestKeysetPageCursorBetweenTiedRows(t *testing.T) {
+	// The cursor sits on the middle one of three rows created at 10:00
+	after := &model.Cursor{At: "2025-09-01T10:00:00.000000Z", Id: "6f1c2a9e-0000-4000-8000-000000000003"}
+
+	page := keysetPage(keyedRows(), 10, 0, after, true, rowKey)
+	want := []string{
+		"0a7d4b12-0000-4000-8000-000000000001",
+		"c3e8f5d7-0000-4000-8000-000000000005",
+		"8e5b3f20-0000-4000-8000-000000000006",
+	}
+	if len(page) != len(want) {
+		t.Fatalf("got %d rows after the cursor, want %d", len(page), len(want))
+	}
+	for i, id := range want {
+		if page[i].id != id {
+			t.Errorf("row %d = %s, want %s", i, page[i].id, id)
+		}
+	}
+}
+
+func TestKeysetPageWithoutCursorUsesOffset(t *testing.T) {
+	want := postgresOrder(keyedRows(), true)
+
+	page := keysetPage(keyedRows(), 2, 3, nil, true, rowKey)
+	if len(page) != 2 || page[0] != want[3] || page[1] != want[4] {
+		t.Errorf("offset page = %v, want %v", page, want[3:5])
+	}
+	if page := keysetPage(keyedRows(), 2, 10, nil, true, rowKey); len(page) != 0 {
+		t.Errorf("page past the end has %d rows, want none", len(page))
+	}
+}
@@ -0,0 +1,93 @@
+package memory
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/model"
+)
+
+func (s *Store) GetPostsByTag(tag string, limit, offset int, after *model.Cursor, userId string) ([]model.PostWithComment, error) {
+	if limit <= 0 {
+		return nil, db.ErrInvalidLimit
+	}
+	if offset < 0 {
+		return nil, db.ErrInvalidOffset
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var tagged []*model.Post
+	for _, p := range s.postsNewestFirst("") {
+		if slices.Contains(p.Tags, tag) && !s.hidden(model.ReportTargetPost, p.Id) {
+			tagged = append(tagged, p)
+		}
+	}
+
+	var result []model.PostWithComment
+	for _, p := range keysetPage(tagged, limit, offset, after, true, postKey) {
+		result = append(result, s.postWithComment(p, userId))
+	}
+	return result, nil
+}
+
+func (s *Store) SearchTags(prefix string, limit int) ([]model.Tag, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	counts := make(map[string]int)
+	for _, p := range s.posts {
+		for _, tag := range p.Tags {
+			if strings.HasPrefix(tag, prefix) {
+				counts[tag]++
+			}
+		}
+	}
+	return topTags(counts, limit), nil
+}
+
+func (s *Store) GetTrendingTags(since time.Time, limit int) ([]model.Tag, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	after := since.UTC().Format(timeLayout)
+	counts := make(map[string]int)
+	for _, p := range s.posts {
+		if p.CreatedAt <= after || s.hidden(model.ReportTargetPost, p.Id) {
+			continue
+		}
+		for _, tag := range p.Tags {
+			counts[tag]++
+		}
+	}
+	return topTags(counts, limit), nil
+}
+
+// topTags orders tags by post count, most used first, then by name.
+func topTags(counts map[string]int, limit int) []model.Tag {
+	tags := []model.Tag{}
+	for name, count := range counts {
+		tags = append(tags, model.Tag{Name: name, PostCount: count})
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if tags[i].PostCount != tags[j].PostCount {
+			return tags[i].PostCount > tags[j].PostCount
+		}
+		return tags[i].Name < tags[j].Name
+	})
+	if limit < len(tags) {
+		tags = tags[:limit]
+	}
+	return tags
+}
+
+// sortedTags copies a post's tags in the name order Postgres returns them
+func sortedTags(tags []string) []string {
+	sorted := append([]string{}, tags...)
+	slices.Sort(sorted)
+	return sorted
+}
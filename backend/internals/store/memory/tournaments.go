@@ -0,0 +1,275 @@
+package memory
+
+import (
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/model"
+)
+
+func (s *Store) CreateTournament(tournament *model.Tournament) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ts := now()
+	tournament.Id = newID()
+	tournament.CreatedAt = ts
+	tournament.UpdatedAt = ts
+	stored := *tournament
+	s.tournaments = append(s.tournaments, &stored)
+	return nil
+}
+
+func (s *Store) GetTournamentByID(tournamentID string) (*model.Tournament, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	t := s.findTournament(tournamentID)
+	if t == nil {
+		return nil, db.ITEM_NOT_FOUND
+	}
+	tournament := *t
+	return &tournament, nil
+}
+
+func (s *Store) GetTournamentDetailsByID(tournamentID string, userID *string) (*model.TournamentDetails, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	t := s.findTournament(tournamentID)
+	if t == nil {
+		return nil, db.ITEM_NOT_FOUND
+	}
+	return s.tournamentDetails(t, userID), nil
+}
+
+func (s *Store) GetAllTournamentDetails(userID *string) ([]*model.TournamentDetails, error) {
+	return s.filterTournamentDetails(userID, func(*model.Tournament) bool { return true }), nil
+}
+
+func (s *Store) GetTournamentDetailsByHostID(hostID string, userID *string) ([]*model.TournamentDetails, error) {
+	return s.filterTournamentDetails(userID, func(t *model.Tournament) bool { return t.HostId == hostID }), nil
+}
+
+func (s *Store) GetTournamentDetailsBySportID(sportID string, userID *string) ([]*model.TournamentDetails, error) {
+	return s.filterTournamentDetails(userID, func(t *model.Tournament) bool { return t.SportId == sportID }), nil
+}
+
+func (s *Store) GetTournamentDetailsByStatus(status model.TournamentStatus, userID *string) ([]*model.TournamentDetails, error) {
+	return s.filterTournamentDetails(userID, func(t *model.Tournament) bool {
+		return t.Status != nil && *t.Status == status
+	}), nil
+}
+
+func (s *Store) UpdateTournament(tournament *model.Tournament, action *model.AdminAction) error {
+	if err := checkAdminAction(action); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := s.findTournament(tournament.Id)
+	if t == nil {
+		return db.ITEM_NOT_FOUND
+	}
+	t.Title = tournament.Title
+	t.Description = tournament.Description
+	t.Location = tournament.Location
+	t.SportId = tournament.SportId
+	t.MinAge = tournament.MinAge
+	t.MaxAge = tournament.MaxAge
+	t.Level = tournament.Level
+	t.Gender = tournament.Gender
+	t.Country = tournament.Country
+	t.Status = tournament.Status
+	t.BannerUrl = tournament.BannerUrl
+	t.UpdatedAt = now()
+	s.recordAdminAction(action)
+	return nil
+}
+
+func (s *Store) DeleteTournament(tournamentID string, actor model.AuditActor) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var deleted *model.Tournament
+	for i, t := range s.tournaments {
+		if t.Id == tournamentID {
+			s.tournaments = append(s.tournaments[:i], s.tournaments[i+1:]...)
+			deleted = t
+			break
+		}
+	}
+	if deleted == nil {
+		return db.ITEM_NOT_FOUND
+	}
+	s.recordAudit(actor, model.AuditTournamentDeleted, "tournament", tournamentID, deleted, nil)
+
+	kept := s.participants[:0]
+	for _, p := range s.participants {
+		if p.TournamentId != tournamentID {
+			kept = append(kept, p)
+		}
+	}
+	s.participants = kept
+
+	if room := s.findTournamentChatRoom(tournamentID); room != nil {
+		s.removeChatRoom(room.Id)
+	}
+	return nil
+}
+
+func (s *Store) AddTournamentParticipant(participant *model.TounramentParticipants) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.findTournament(participant.TournamentId) == nil {
+		return db.NewNotFoundError("tournament", participant.TournamentId)
+	}
+	if s.findParticipant(participant.UserId, participant.TournamentId) != nil {
+		return db.NewAlreadyExistsError("tournament participant", "user_id and tournament_id combination", participant.UserId+" + "+participant.TournamentId)
+	}
+
+	ts := now()
+	participant.Id = newID()
+	participant.CreatedAt = ts
+	participant.UpdatedAt = ts
+	stored := *participant
+	s.participants = append(s.participants, &stored)
+	return nil
+}
+
+func (s *Store) GetTournamentParticipants(tournamentID string) ([]*model.TounramentParticipants, error) {
+	return s.filterParticipants(func(p *model.TounramentParticipants) bool {
+		return p.TournamentId == tournamentID
+	}), nil
+}
+
+func (s *Store) GetUserTournaments(userID string) ([]*model.TounramentParticipants, error) {
+	return s.filterParticipants(func(p *model.TounramentParticipants) bool {
+		return p.UserId == userID
+	}), nil
+}
+
+func (s *Store) UpdateParticipantStatus(userID, tournamentID string, status model.ParticipationStatus, actor model.AuditActor) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.findParticipant(userID, tournamentID)
+	if p == nil {
+		return db.ITEM_NOT_FOUND
+	}
+	before := *p
+	p.Status = status
+	s.recordAudit(actor, model.AuditParticipantStatusChanged, "tournament_participant", p.Id, before, *p)
+	return nil
+}
+
+func (s *Store) RemoveTournamentParticipant(userID, tournamentID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, p := range s.participants {
+		if p.UserId == userID && p.TournamentId == tournamentID {
+			s.participants = append(s.participants[:i], s.participants[i+1:]...)
+			return nil
+		}
+	}
+	return db.ITEM_NOT_FOUND
+}
+
+func (s *Store) GetParticipantByUserAndTournament(userID, tournamentID string) (*model.TounramentParticipants, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	p := s.findParticipant(userID, tournamentID)
+	if p == nil {
+		return nil, db.ITEM_NOT_FOUND
+	}
+	participant := *p
+	return &participant, nil
+}
+
+func (s *Store) GetTournamentParticipantsByStatus(tournamentID string, status model.ParticipationStatus) ([]*model.TounramentParticipants, error) {
+	return s.filterParticipants(func(p *model.TounramentParticipants) bool {
+		return p.TournamentId == tournamentID && p.Status == status
+	}), nil
+}
+
+func (s *Store) findTournament(id string) *model.Tournament {
+	for _, t := range s.tournaments {
+		if t.Id == id {
+			return t
+		}
+	}
+	return nil
+}
+
+func (s *Store) findParticipant(userID, tournamentID string) *model.TounramentParticipants {
+	for _, p := range s.participants {
+		if p.UserId == userID && p.TournamentId == tournamentID {
+			return p
+		}
+	}
+	return nil
+}
+
+// filterTournamentDetails returns matching tournaments ordered by
+// created_at DESC.
+func (s *Store) filterTournamentDetails(userID *string, keep func(*model.Tournament) bool) []*model.TournamentDetails {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var details []*model.TournamentDetails
+	for i := len(s.tournaments) - 1; i >= 0; i-- {
+		if keep(s.tournaments[i]) {
+			details = append(details, s.tournamentDetails(s.tournaments[i], userID))
+		}
+	}
+	return details
+}
+
+// filterParticipants returns matching participants ordered by
+// registered_at DESC.
+func (s *Store) filterParticipants(keep func(*model.TounramentParticipants) bool) []*model.TounramentParticipants {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var participants []*model.TounramentParticipants
+	for i := len(s.participants) - 1; i >= 0; i-- {
+		if keep(s.participants[i]) {
+			p := *s.participants[i]
+			participants = append(participants, &p)
+		}
+	}
+	return participants
+}
+
+func (s *Store) tournamentDetails(t *model.Tournament, userID *string) *model.TournamentDetails {
+	tournament := *t
+	sport := model.Sport{}
+	if sp := s.findSportByID(t.SportId); sp != nil {
+		sport = *sp
+	}
+
+	count := 0
+	for _, p := range s.participants {
+		if p.TournamentId == t.Id && p.Status == model.Accepted {
+			count++
+		}
+	}
+
+	isEnrolled := false
+	isSaved := false
+	if userID != nil && *userID != "" {
+		isEnrolled = s.findParticipant(*userID, t.Id) != nil
+		isSaved = s.isItemSaved(*userID, model.SavedItemTournament, t.Id)
+	}
+
+	return &model.TournamentDetails{
+		Tournament:        &tournament,
+		HostName:          s.displayName(t.HostId),
+		Sport:             &sport,
+		IsEnrolled:        isEnrolled,
+		ParticipantsCount: count,
+		Saved:             isSaved,
+	}
+}
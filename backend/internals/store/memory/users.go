@@ -0,0 +1,327 @@
+package memory
+
+import (
+	"fmt"
+
+	"sportsin_backend/internals/db"
+	"sportsin_backend/internals/model"
+)
+
+func (s *Store) CreateUserOnSignup(userId, email string, role model.Role) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.users[userId]; ok {
+		return db.NewAlreadyExistsError("user", "id", userId)
+	}
+	for _, u := range s.users {
+		if u.Email == email {
+			return db.NewAlreadyExistsError("user", "email", email)
+		}
+	}
+
+	ts := now()
+	s.users[userId] = &model.User{
+		AppModel: model.AppModel{Id: userId, CreatedAt: ts, UpdatedAt: ts},
+		Username: email,
+		Email:    email,
+		Role:     role,
+	}
+	return nil
+}
+
+func (s *Store) AddUserDeviceTokenDetails(userID, deviceToken, snsEndpoint string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Postgres silently updates zero rows for an unknown user.
+	if u, ok := s.users[userID]; ok {
+		u.DeviceToken = deviceToken
+		u.SnsEndpointArn = snsEndpoint
+	}
+	return nil
+}
+
+func (s *Store) GetUserByID(userID string) (*model.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	u, ok := s.users[userID]
+	if !ok {
+		return nil, db.NewNotFoundError("user", userID)
+	}
+	user := *u
+	return &user, nil
+}
+
+func (s *Store) GetUserByEmail(email string) (*model.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, u := range s.users {
+		if u.Email == email {
+			user := *u
+			return &user, nil
+		}
+	}
+	return nil, db.NewNotFoundError("user", email)
+}
+
+func (s *Store) CreateUserProfile(profile any) error {
+	var roleToSet model.Role
+	var id string
+
+	switch p := profile.(type) {
+	case *model.Player:
+		roleToSet = model.PlayerRole
+		id = p.Id
+	case *model.Recruiter:
+		roleToSet = model.RecruiterRole
+		id = p.Id
+	default:
+		return db.NewValidationError("profile_type", "invalid profile type provided")
+	}
+
+	if id == "" {
+		return db.NewValidationError("user_id", "user ID is missing from the profile")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, ok := s.users[id]
+	if !ok {
+		return db.NewNotFoundError("user", id)
+	}
+	if user.Role != roleToSet {
+		return db.NewAuthorizationError("create_profile", "user profile", id)
+	}
+	if _, exists := s.profiles[id]; exists {
+		return db.NewAlreadyExistsError("user details", "user_id", id)
+	}
+
+	s.profiles[id] = s.copyProfile(profile, user)
+	return nil
+}
+
+func (s *Store) GetUserProfile(userId string) (any, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	user, ok := s.users[userId]
+	if !ok {
+		return nil, db.NewNotFoundError("user", userId)
+	}
+
+	profile, ok := s.profiles[userId]
+	switch user.Role {
+	case model.RecruiterRole:
+		if !ok {
+			return nil, db.NewNotFoundError("recruiter profile", userId)
+		}
+	case model.PlayerRole:
+		if !ok {
+			return nil, db.NewNotFoundError("player profile", userId)
+		}
+	default:
+		return nil, db.NewValidationError("role", fmt.Sprintf("invalid role '%s' found for user", user.Role))
+	}
+
+	return s.copyProfile(profile, user), nil
+}
+
+func (s *Store) UpdateProfile(profile any) error {
+	var id string
+
+	switch p := profile.(type) {
+	case *model.Player:
+		id = p.Id
+	case *model.Recruiter:
+		id = p.Id
+	default:
+		return db.NewValidationError("profile_type", "invalid profile type provided")
+	}
+
+	if id == "" {
+		return db.NewValidationError("user_id", "user ID is missing from the profile")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, ok := s.users[id]
+	if !ok {
+		return db.NewNotFoundError("user", id)
+	}
+	user.UpdatedAt = now()
+
+	existing, ok := s.profiles[id]
+	if !ok {
+		return nil
+	}
+
+	// Referral code and premium flag are not part of a profile update.
+	updated := s.copyProfile(profile, user)
+	if d := profileDetails(updated); d != nil {
+		if prev := profileDetails(existing); prev != nil {
+			d.ReferalCode = prev.ReferalCode
+			d.IsPremium = prev.IsPremium
+		}
+	}
+	s.profiles[id] = updated
+	return nil
+}
+
+func (s *Store) UpdateProfilePicture(email string, imageUrl string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var user *model.User
+	for _, u := range s.users {
+		if u.Email == email {
+			user = u
+			break
+		}
+	}
+	if user == nil {
+		return db.NewNotFoundError("user", email)
+	}
+
+	d := profileDetails(s.profiles[user.Id])
+	if d == nil {
+		return db.NewNotFoundError("user details", user.Id)
+	}
+	d.ProfilePicture = &imageUrl
+	return nil
+}
+
+func (s *Store) UsernameExists(username string) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, p := range s.profiles {
+		if d := profileDetails(p); d != nil && d.UserName == username {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *Store) ReferalCodeExists(referralCode string) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.userIdByReferalCode(referralCode) != "", nil
+}
+
+func (s *Store) UserHasReferalCode(userId string) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	d := profileDetails(s.profiles[userId])
+	return d != nil && d.ReferalCode != nil, nil
+}
+
+func (s *Store) CreateReferalCode(userId, referalCode string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if d := profileDetails(s.profiles[userId]); d != nil {
+		d.ReferalCode = &referalCode
+	}
+	return nil
+}
+
+func (s *Store) GetReferalCode(userId string) (string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	d := profileDetails(s.profiles[userId])
+	if d == nil {
+		return "", db.NewNotFoundError("user", userId)
+	}
+	if d.ReferalCode == nil {
+		return "", nil
+	}
+	return *d.ReferalCode, nil
+}
+
+func (s *Store) ReferUser(userId, referalCode string, actor model.AuditActor) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	referrer := s.userIdByReferalCode(referalCode)
+	if referrer == "" {
+		return db.NewNotFoundError("user", referalCode)
+	}
+	d := profileDetails(s.profiles[userId])
+	if d == nil {
+		return db.NewNotFoundError("user", userId)
+	}
+	before := map[string]any{
+		"referred_by":    d.RefferedBy,
+		"referrer_id":    referrer,
+		"referrer_coins": s.coins[referrer],
+	}
+
+	d.RefferedBy = &referrer
+	s.referredBy[userId] = referrer
+	s.coins[referrer] += s.ReferalReward
+	if d := profileDetails(s.profiles[referrer]); d != nil {
+		d.Coins = s.coins[referrer]
+	}
+
+	s.recordAudit(actor, model.AuditReferralRewarded, "referral", userId, before, map[string]any{
+		"referred_by":    referrer,
+		"referrer_id":    referrer,
+		"referrer_coins": s.coins[referrer],
+	})
+	return nil
+}
+
+func (s *Store) userIdByReferalCode(referralCode string) string {
+	for id, p := range s.profiles {
+		if d := profileDetails(p); d != nil && d.ReferalCode != nil && *d.ReferalCode == referralCode {
+			return id
+		}
+	}
+	return ""
+}
+
+// displayName mirrors COALESCE(ud.name, ud.username) used for host names.
+func (s *Store) displayName(userID string) string {
+	d := profileDetails(s.profiles[userID])
+	if d == nil {
+		return ""
+	}
+	if d.Name != "" {
+		return d.Name
+	}
+	return d.UserName
+}
+
+// copyProfile returns a detached copy of a profile with the account fields
+// filled in from the User row, the same way the Postgres join does.
+func (s *Store) copyProfile(profile any, user *model.User) any {
+	switch p := profile.(type) {
+	case *model.Player:
+		cp := *p
+		cp.User = *user
+		return &cp
+	case *model.Recruiter:
+		cp := *p
+		cp.User = *user
+		return &cp
+	}
+	return nil
+}
+
+func profileDetails(profile any) *model.UserDetails {
+	switch p := profile.(type) {
+	case *model.Player:
+		return &p.UserDetails
+	case *model.Recruiter:
+		return &p.UserDetails
+	}
+	return nil
+}
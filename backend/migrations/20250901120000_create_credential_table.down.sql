@@ -0,0 +1,4 @@
+-- Migration: create_credential_table (DOWN)
+-- Created: 2025-09-01 12:00:00
+
+DROP TABLE IF EXISTS "Credential";
@@ -0,0 +1,14 @@
+-- Migration: create_credential_table (UP)
+-- Created: 2025-09-01 12:00:00
+
+CREATE TABLE IF NOT EXISTS "Credential"(
+    user_id UUID PRIMARY KEY,
+    email VARCHAR(255) NOT NULL UNIQUE,
+    role VARCHAR(255) NOT NULL,
+    password_hash VARCHAR(255) NOT NULL,
+    confirmed BOOLEAN NOT NULL DEFAULT FALSE,
+    code VARCHAR(16) NOT NULL DEFAULT '',
+    code_expires_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,4 @@
+-- Migration: add_user_suspension (DOWN)
+-- Created: 2025-09-02 09:00:00
+
+ALTER TABLE "User" DROP COLUMN suspended_at;
@@ -0,0 +1,4 @@
+-- Migration: add_user_suspension (UP)
+-- Created: 2025-09-02 09:00:00
+
+ALTER TABLE "User" ADD COLUMN suspended_at TIMESTAMP;
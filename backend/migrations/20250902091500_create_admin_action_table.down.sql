@@ -0,0 +1,4 @@
+-- Migration: create_admin_action_table (DOWN)
+-- Created: 2025-09-02 09:15:00
+
+DROP TABLE IF EXISTS "AdminAction";
@@ -0,0 +1,15 @@
+-- Migration: create_admin_action_table (UP)
+-- Created: 2025-09-02 09:15:00
+
+CREATE TABLE IF NOT EXISTS "AdminAction"(
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    admin_id UUID NOT NULL,
+    action VARCHAR(64) NOT NULL,
+    target_type VARCHAR(64) NOT NULL,
+    target_id VARCHAR(255) NOT NULL,
+    reason TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_admin_action_created_at ON "AdminAction"(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_admin_action_target ON "AdminAction"(target_type, target_id);
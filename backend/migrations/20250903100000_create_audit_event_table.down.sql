@@ -0,0 +1,4 @@
+-- Migration: create_audit_event_table (DOWN)
+-- Created: 2025-09-03 10:00:00
+
+DROP TABLE IF EXISTS "AuditEvent";
@@ -0,0 +1,18 @@
+-- Migration: create_audit_event_table (UP)
+-- Created: 2025-09-03 10:00:00
+
+CREATE TABLE IF NOT EXISTS "AuditEvent"(
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    actor_id UUID NOT NULL,
+    action VARCHAR(64) NOT NULL,
+    entity_type VARCHAR(64) NOT NULL,
+    entity_id VARCHAR(255) NOT NULL,
+    before_state JSONB,
+    after_state JSONB,
+    request_id VARCHAR(64) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_event_created_at ON "AuditEvent"(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_event_entity ON "AuditEvent"(entity_type, entity_id);
+CREATE INDEX IF NOT EXISTS idx_audit_event_actor ON "AuditEvent"(actor_id);
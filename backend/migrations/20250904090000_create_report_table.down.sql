@@ -0,0 +1,4 @@
+-- Migration: create_report_table (DOWN)
+-- Created: 2025-09-04 09:00:00
+
+DROP TABLE IF EXISTS "Report";
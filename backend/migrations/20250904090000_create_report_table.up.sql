@@ -0,0 +1,21 @@
+-- Migration: create_report_table (UP)
+-- Created: 2025-09-04 09:00:00
+
+CREATE TABLE IF NOT EXISTS "Report"(
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    reporter_id UUID NOT NULL,
+    target_type VARCHAR(32) NOT NULL,
+    target_id UUID NOT NULL,
+    reason VARCHAR(32) NOT NULL,
+    details TEXT,
+    status VARCHAR(16) NOT NULL DEFAULT 'open',
+    resolved_by UUID,
+    resolved_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (reporter_id) REFERENCES "User"(id) ON DELETE CASCADE,
+    FOREIGN KEY (resolved_by) REFERENCES "User"(id) ON DELETE SET NULL,
+    UNIQUE (reporter_id, target_type, target_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_report_target ON "Report"(target_type, target_id) WHERE status <> 'dismissed';
+CREATE INDEX IF NOT EXISTS idx_report_status_created_at ON "Report"(status, created_at);
@@ -0,0 +1,4 @@
+-- Migration: create_follow_table (DOWN)
+-- Created: 2025-09-05 09:00:00
+
+DROP TABLE IF EXISTS "Follow";
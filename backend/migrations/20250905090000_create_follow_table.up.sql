@@ -0,0 +1,15 @@
+-- Migration: create_follow_table (UP)
+-- Created: 2025-09-05 09:00:00
+
+CREATE TABLE IF NOT EXISTS "Follow"(
+    follower_id UUID NOT NULL,
+    following_id UUID NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (follower_id, following_id),
+    CHECK (follower_id <> following_id),
+    FOREIGN KEY (follower_id) REFERENCES "User"(id) ON DELETE CASCADE,
+    FOREIGN KEY (following_id) REFERENCES "User"(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_follow_following ON "Follow"(following_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_follow_follower_created_at ON "Follow"(follower_id, created_at DESC);
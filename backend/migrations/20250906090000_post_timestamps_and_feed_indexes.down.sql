@@ -0,0 +1,9 @@
+-- Migration: post_timestamps_and_feed_indexes (DOWN)
+-- Created: 2025-09-06 09:00:00
+
+DROP INDEX IF EXISTS idx_user_skill_user_sport;
+DROP INDEX IF EXISTS idx_comment_post_created_at;
+DROP INDEX IF EXISTS idx_post_created_at;
+
+ALTER TABLE "Post" ALTER COLUMN updated_at TYPE DATE USING updated_at::date;
+ALTER TABLE "Post" ALTER COLUMN created_at TYPE DATE USING created_at::date;
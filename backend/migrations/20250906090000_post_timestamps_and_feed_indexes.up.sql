@@ -0,0 +1,13 @@
+-- Migration: post_timestamps_and_feed_indexes (UP)
+-- Created: 2025-09-06 09:00:00
+
+-- Posts were stored with day precision, which left every post of a day
+-- unordered. Existing rows keep midnight of their day.
+ALTER TABLE "Post" ALTER COLUMN created_at TYPE TIMESTAMP USING created_at::timestamp;
+ALTER TABLE "Post" ALTER COLUMN updated_at TYPE TIMESTAMP USING updated_at::timestamp;
+
+CREATE INDEX IF NOT EXISTS idx_post_created_at ON "Post"(created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_comment_post_created_at ON "Comment"(PostId, CreatedAt);
+DELETE FROM "UserSkill" a USING "UserSkill" b
+WHERE a.user_id = b.user_id AND a.sport_id = b.sport_id AND a.id > b.id;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_skill_user_sport ON "UserSkill"(user_id, sport_id);
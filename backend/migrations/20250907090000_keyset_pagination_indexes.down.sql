@@ -0,0 +1,11 @@
+-- Migration: keyset_pagination_indexes (DOWN)
+-- Created: 2025-09-07 09:00:00
+
+DROP INDEX IF EXISTS idx_achievements_user_date;
+DROP INDEX IF EXISTS idx_opening_created_at;
+DROP INDEX IF EXISTS idx_messages_room_sent_at;
+
+ALTER TABLE "Opening" ALTER COLUMN updated_at DROP NOT NULL;
+ALTER TABLE "Opening" ALTER COLUMN created_at DROP NOT NULL;
+
+ALTER TABLE "Messages" ALTER COLUMN sent_at TYPE DATE USING sent_at::date;
@@ -0,0 +1,17 @@
+-- Migration: keyset_pagination_indexes (UP)
+-- Created: 2025-09-07 09:00:00
+
+-- Messages were stored with day precision, so a room's messages of one day
+-- had no order and offset pages returned duplicates and gaps. Existing rows
+-- keep midnight of their day.
+ALTER TABLE "Messages" ALTER COLUMN sent_at TYPE TIMESTAMP USING sent_at::timestamp;
+
+-- Openings are paged by (created_at, id), which must not be NULL.
+UPDATE "Opening" SET created_at = CURRENT_TIMESTAMP WHERE created_at IS NULL;
+UPDATE "Opening" SET updated_at = created_at WHERE updated_at IS NULL;
+ALTER TABLE "Opening" ALTER COLUMN created_at SET NOT NULL;
+ALTER TABLE "Opening" ALTER COLUMN updated_at SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_messages_room_sent_at ON "Messages"(chat_room_id, sent_at, id);
+CREATE INDEX IF NOT EXISTS idx_opening_created_at ON "Opening"(created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_achievements_user_date ON "Achievements"(user_id, date DESC, id DESC);
@@ -0,0 +1,14 @@
+-- Migration: create_tag_tables (DOWN)
+-- Created: 2025-09-08 09:00:00
+
+ALTER TABLE "Post" ADD COLUMN IF NOT EXISTS tags TEXT;
+
+UPDATE "Post" p SET tags = (
+    SELECT string_agg(t.name, ',' ORDER BY t.name)
+    FROM "PostTag" pt
+    INNER JOIN "Tag" t ON t.id = pt.tag_id
+    WHERE pt.post_id = p.id
+);
+
+DROP TABLE IF EXISTS "PostTag";
+DROP TABLE IF EXISTS "Tag";
@@ -0,0 +1,40 @@
+-- Migration: create_tag_tables (UP)
+-- Created: 2025-09-08 09:00:00
+
+CREATE TABLE IF NOT EXISTS "Tag"(
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(50) NOT NULL UNIQUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS "PostTag"(
+    post_id UUID NOT NULL,
+    tag_id UUID NOT NULL,
+    PRIMARY KEY (post_id, tag_id),
+    FOREIGN KEY (post_id) REFERENCES "Post"(id) ON DELETE CASCADE,
+    FOREIGN KEY (tag_id) REFERENCES "Tag"(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_post_tag_tag ON "PostTag"(tag_id, post_id);
+CREATE INDEX IF NOT EXISTS idx_tag_name_prefix ON "Tag"(name text_pattern_ops);
+
+-- Move the comma-separated Post.tags into the new tables, normalized the
+-- way the API now stores them. Entries that are not valid tags are dropped.
+WITH post_tags AS (
+    SELECT DISTINCT p.id AS post_id, lower(ltrim(btrim(raw), '#')) AS name
+    FROM "Post" p, unnest(string_to_array(p.tags, ',')) AS raw
+    WHERE p.tags IS NOT NULL
+)
+INSERT INTO "Tag"(name)
+SELECT DISTINCT name FROM post_tags WHERE name ~ '^\w{1,50}$'
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO "PostTag"(post_id, tag_id)
+SELECT DISTINCT p.id, t.id
+FROM "Post" p
+CROSS JOIN LATERAL unnest(string_to_array(p.tags, ',')) AS raw
+INNER JOIN "Tag" t ON t.name = lower(ltrim(btrim(raw), '#'))
+WHERE p.tags IS NOT NULL
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE "Post" DROP COLUMN IF EXISTS tags;
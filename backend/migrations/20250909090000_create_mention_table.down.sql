@@ -0,0 +1,4 @@
+-- Migration: create_mention_table (DOWN)
+-- Created: 2025-09-09 09:00:00
+
+DROP TABLE IF EXISTS "Mention";
@@ -0,0 +1,20 @@
+-- Migration: create_mention_table (UP)
+-- Created: 2025-09-09 09:00:00
+
+CREATE TABLE IF NOT EXISTS "Mention"(
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    post_id UUID NOT NULL,
+    comment_id UUID,
+    mentioned_user_id UUID NOT NULL,
+    author_id UUID NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (post_id) REFERENCES "Post"(id) ON DELETE CASCADE,
+    FOREIGN KEY (comment_id) REFERENCES "Comment"(id) ON DELETE CASCADE,
+    FOREIGN KEY (mentioned_user_id) REFERENCES "User"(id) ON DELETE CASCADE,
+    FOREIGN KEY (author_id) REFERENCES "User"(id) ON DELETE CASCADE
+);
+
+-- A user is mentioned at most once by a post and once by each comment
+CREATE UNIQUE INDEX IF NOT EXISTS idx_mention_post_user ON "Mention"(post_id, mentioned_user_id) WHERE comment_id IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_mention_comment_user ON "Mention"(comment_id, mentioned_user_id) WHERE comment_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_mention_mentioned_user ON "Mention"(mentioned_user_id, post_id);
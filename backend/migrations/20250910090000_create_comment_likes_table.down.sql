@@ -0,0 +1,6 @@
+-- Migration: create_comment_likes_table (DOWN)
+-- Created: 2025-09-10 09:00:00
+
+DROP TABLE IF EXISTS "comment_likes";
+
+ALTER TABLE "Comment" DROP COLUMN IF EXISTS like_count;
@@ -0,0 +1,13 @@
+-- Migration: create_comment_likes_table (UP)
+-- Created: 2025-09-10 09:00:00
+
+ALTER TABLE "Comment" ADD COLUMN IF NOT EXISTS like_count INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS "comment_likes" (
+    comment_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (comment_id, user_id),
+    FOREIGN KEY (comment_id) REFERENCES "Comment"(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES "User"(id) ON DELETE CASCADE
+);
@@ -0,0 +1,5 @@
+-- Migration: add_post_reactions (DOWN)
+-- Created: 2025-09-11 09:00:00
+
+ALTER TABLE "post_likes" DROP CONSTRAINT IF EXISTS post_likes_reaction_check;
+ALTER TABLE "post_likes" DROP COLUMN IF EXISTS reaction;
@@ -0,0 +1,6 @@
+-- Migration: add_post_reactions (UP)
+-- Created: 2025-09-11 09:00:00
+
+-- A like becomes one type of reaction. Existing likes keep the default.
+ALTER TABLE "post_likes" ADD COLUMN IF NOT EXISTS reaction VARCHAR(20) NOT NULL DEFAULT 'like';
+ALTER TABLE "post_likes" ADD CONSTRAINT post_likes_reaction_check CHECK (reaction IN ('like', 'clap', 'fire', 'trophy'));
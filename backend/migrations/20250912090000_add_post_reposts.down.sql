@@ -0,0 +1,9 @@
+-- Migration: add_post_reposts (DOWN)
+-- Created: 2025-09-12 09:00:00
+
+DROP INDEX IF EXISTS idx_post_user_repost;
+DROP INDEX IF EXISTS idx_post_repost_of_id;
+DELETE FROM "Post" WHERE kind = 'repost';
+ALTER TABLE "Post" DROP COLUMN IF EXISTS repost_of_id;
+ALTER TABLE "Post" DROP CONSTRAINT IF EXISTS post_kind_check;
+ALTER TABLE "Post" DROP COLUMN IF EXISTS kind;
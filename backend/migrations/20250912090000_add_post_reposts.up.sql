@@ -0,0 +1,11 @@
+-- Migration: add_post_reposts (UP)
+-- Created: 2025-09-12 09:00:00
+
+-- A post may share another one, either as a plain repost or as a quote with
+-- content of its own. Quotes of a deleted post keep a NULL repost_of_id.
+ALTER TABLE "Post" ADD COLUMN IF NOT EXISTS kind VARCHAR(10) NOT NULL DEFAULT 'post';
+ALTER TABLE "Post" ADD CONSTRAINT post_kind_check CHECK (kind IN ('post', 'repost', 'quote'));
+ALTER TABLE "Post" ADD COLUMN IF NOT EXISTS repost_of_id UUID REFERENCES "Post"(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_post_repost_of_id ON "Post"(repost_of_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_post_user_repost ON "Post"(user_id, repost_of_id) WHERE kind = 'repost';
@@ -0,0 +1,6 @@
+-- Migration: create_saved_item_table (DOWN)
+-- Created: 2025-09-13 09:00:00
+
+DROP INDEX IF EXISTS idx_saved_item_target;
+DROP INDEX IF EXISTS idx_saved_item_user_created_at;
+DROP TABLE IF EXISTS "SavedItem";
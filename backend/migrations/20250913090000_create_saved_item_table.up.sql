@@ -0,0 +1,15 @@
+-- Migration: create_saved_item_table (UP)
+-- Created: 2025-09-13 09:00:00
+
+CREATE TABLE IF NOT EXISTS "SavedItem"(
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    item_type VARCHAR(16) NOT NULL CHECK (item_type IN ('post', 'opening', 'tournament')),
+    item_id UUID NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES "User"(id) ON DELETE CASCADE,
+    UNIQUE (user_id, item_type, item_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_saved_item_user_created_at ON "SavedItem"(user_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_saved_item_target ON "SavedItem"(item_type, item_id);
@@ -0,0 +1,12 @@
+-- Migration: add_post_media_processing (DOWN)
+-- Created: 2025-09-14 09:00:00
+
+DROP INDEX IF EXISTS idx_post_images_unprocessed;
+DELETE FROM "PostImages" WHERE media_type = 'video';
+ALTER TABLE "PostImages" DROP COLUMN IF EXISTS processing_error;
+ALTER TABLE "PostImages" DROP COLUMN IF EXISTS duration_seconds;
+ALTER TABLE "PostImages" DROP COLUMN IF EXISTS thumbnail_url;
+ALTER TABLE "PostImages" DROP CONSTRAINT IF EXISTS post_images_status_check;
+ALTER TABLE "PostImages" DROP COLUMN IF EXISTS status;
+ALTER TABLE "PostImages" DROP CONSTRAINT IF EXISTS post_images_media_type_check;
+ALTER TABLE "PostImages" DROP COLUMN IF EXISTS media_type;
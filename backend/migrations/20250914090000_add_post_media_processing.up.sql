@@ -0,0 +1,14 @@
+-- Migration: add_post_media_processing (UP)
+-- Created: 2025-09-14 09:00:00
+
+-- Post media can be videos, which are processed in the background. Existing
+-- rows are images and need no processing.
+ALTER TABLE "PostImages" ADD COLUMN IF NOT EXISTS media_type VARCHAR(10) NOT NULL DEFAULT 'image';
+ALTER TABLE "PostImages" ADD CONSTRAINT post_images_media_type_check CHECK (media_type IN ('image', 'video'));
+ALTER TABLE "PostImages" ADD COLUMN IF NOT EXISTS status VARCHAR(16) NOT NULL DEFAULT 'ready';
+ALTER TABLE "PostImages" ADD CONSTRAINT post_images_status_check CHECK (status IN ('pending', 'processing', 'ready', 'failed'));
+ALTER TABLE "PostImages" ADD COLUMN IF NOT EXISTS thumbnail_url VARCHAR(500);
+ALTER TABLE "PostImages" ADD COLUMN IF NOT EXISTS duration_seconds DOUBLE PRECISION;
+ALTER TABLE "PostImages" ADD COLUMN IF NOT EXISTS processing_error TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_post_images_unprocessed ON "PostImages"(status) WHERE status IN ('pending', 'processing');
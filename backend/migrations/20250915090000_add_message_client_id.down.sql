@@ -0,0 +1,6 @@
+-- Migration: add_message_client_id (DOWN)
+-- Created: 2025-09-15 09:00:00
+
+DROP INDEX IF EXISTS idx_messages_sender_client_id;
+
+ALTER TABLE "Messages" DROP COLUMN IF EXISTS client_message_id;
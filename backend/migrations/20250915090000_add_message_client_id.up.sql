@@ -0,0 +1,8 @@
+-- Migration: add_message_client_id (UP)
+-- Created: 2025-09-15 09:00:00
+
+-- Messages sent over the socket carry an ID generated by the sending client,
+-- so a message resent after a dropped connection is only stored once.
+ALTER TABLE "Messages" ADD COLUMN IF NOT EXISTS client_message_id VARCHAR(64);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_messages_sender_client_id ON "Messages"(sent_from, client_message_id) WHERE client_message_id IS NOT NULL;
@@ -0,0 +1,10 @@
+-- Migration: add_group_chat_rooms (DOWN)
+-- Created: 2025-09-16 09:00:00
+
+DROP INDEX IF EXISTS idx_chat_room_member_user;
+DROP TABLE IF EXISTS "ChatRoomMember";
+
+DELETE FROM "ChatRoom" WHERE is_group;
+ALTER TABLE "ChatRoom" DROP COLUMN IF EXISTS avatar_url;
+ALTER TABLE "ChatRoom" DROP COLUMN IF EXISTS name;
+ALTER TABLE "ChatRoom" DROP COLUMN IF EXISTS is_group;
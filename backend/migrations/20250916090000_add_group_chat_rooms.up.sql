@@ -0,0 +1,31 @@
+-- Migration: add_group_chat_rooms (UP)
+-- Created: 2025-09-16 09:00:00
+
+-- Group rooms have a name, an optional avatar and any number of members.
+-- One-to-one rooms keep user1 and user2 so they can be found again; group
+-- rooms leave them empty.
+ALTER TABLE "ChatRoom" ADD COLUMN IF NOT EXISTS is_group BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE "ChatRoom" ADD COLUMN IF NOT EXISTS name VARCHAR(100);
+ALTER TABLE "ChatRoom" ADD COLUMN IF NOT EXISTS avatar_url VARCHAR(500);
+ALTER TABLE "ChatRoom" ALTER COLUMN user1 DROP NOT NULL;
+ALTER TABLE "ChatRoom" ALTER COLUMN user2 DROP NOT NULL;
+ALTER TABLE "ChatRoom" ALTER COLUMN constraint_user1_user2 DROP NOT NULL;
+
+-- Members of every room, one-to-one rooms included
+CREATE TABLE IF NOT EXISTS "ChatRoomMember"(
+    room_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    role VARCHAR(10) NOT NULL DEFAULT 'member' CHECK (role IN ('owner', 'admin', 'member')),
+    joined_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (room_id, user_id),
+    FOREIGN KEY (room_id) REFERENCES "ChatRoom"(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES "User"(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_chat_room_member_user ON "ChatRoomMember"(user_id);
+
+INSERT INTO "ChatRoomMember" (room_id, user_id, joined_at)
+SELECT id, user1::uuid, created_at::timestamp FROM "ChatRoom" WHERE user1 IS NOT NULL
+UNION
+SELECT id, user2::uuid, created_at::timestamp FROM "ChatRoom" WHERE user2 IS NOT NULL
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,8 @@
+-- Migration: add_tournament_chat (DOWN)
+-- Created: 2025-09-17 09:00:00
+
+ALTER TABLE "Messages" DROP COLUMN IF EXISTS announcement;
+
+DROP INDEX IF EXISTS idx_chat_room_tournament;
+
+ALTER TABLE "ChatRoom" DROP COLUMN IF EXISTS tournament_id;
@@ -0,0 +1,14 @@
+-- Migration: add_tournament_chat (UP)
+-- Created: 2025-09-17 09:00:00
+
+-- A tournament has at most one group chat room, its channel. Accepted
+-- participants are its members and the host owns it.
+ALTER TABLE "ChatRoom"
+    ADD COLUMN IF NOT EXISTS tournament_id UUID REFERENCES "Tournament"(id) ON DELETE CASCADE;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_chat_room_tournament ON "ChatRoom"(tournament_id)
+    WHERE tournament_id IS NOT NULL;
+
+-- Announcements are messages the host broadcasts to the channel
+ALTER TABLE "Messages"
+    ADD COLUMN IF NOT EXISTS announcement BOOLEAN NOT NULL DEFAULT FALSE;
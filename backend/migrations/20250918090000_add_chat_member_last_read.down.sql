@@ -0,0 +1,12 @@
+-- Migration: add_chat_member_last_read (DOWN)
+-- Created: 2025-09-18 09:00:00
+
+ALTER TABLE "Messages" ADD COLUMN IF NOT EXISTS read_status BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE "Messages" m SET read_status = NOT EXISTS (
+    SELECT 1 FROM "ChatRoomMember" cm
+    WHERE cm.room_id = m.chat_room_id AND cm.user_id != m.sent_from
+        AND (cm.last_read_at IS NULL OR cm.last_read_at < m.sent_at)
+);
+
+ALTER TABLE "ChatRoomMember" DROP COLUMN IF EXISTS last_read_at;
@@ -0,0 +1,16 @@
+-- Migration: add_chat_member_last_read (UP)
+-- Created: 2025-09-18 09:00:00
+
+-- Read state is kept per member: everything sent in a room up to a member's
+-- last_read_at is read by them. The single read_status flag of a message
+-- cannot tell which members of a group have read it.
+ALTER TABLE "ChatRoomMember" ADD COLUMN IF NOT EXISTS last_read_at TIMESTAMP;
+
+-- A member has read up to the newest message from someone else that was
+-- marked read, or their own newest message, whichever is later
+UPDATE "ChatRoomMember" cm SET last_read_at = (
+    SELECT MAX(m.sent_at) FROM "Messages" m
+    WHERE m.chat_room_id = cm.room_id AND (m.read_status OR m.sent_from = cm.user_id)
+);
+
+ALTER TABLE "Messages" DROP COLUMN IF EXISTS read_status;
@@ -221,7 +221,7 @@ class AchievementRepository {
       final response = await DioClient.instance.get('achievements');
 
       if (response.statusCode == 200) {
-        final achievementsData = response.data;
+        final achievementsData = response.data?['items'];
         if (achievementsData == null) {
           return [];
         }
@@ -159,9 +159,9 @@ class CampOpeningRepository {
         }
 
         if (responseMap is Map<String, dynamic> &&
-            responseMap.containsKey('openings') &&
-            responseMap['openings'] is List) {
-          final List<dynamic> openingsDataList = responseMap['openings'];
+            responseMap.containsKey('items') &&
+            responseMap['items'] is List) {
+          final List<dynamic> openingsDataList = responseMap['items'];
 
           final List<CampOpenning> openings = openingsDataList
               .where((e) => e != null && e is Map<String, dynamic>)
@@ -324,8 +324,8 @@ class CampOpeningRepository {
         queryParameters: queryParams,
       );
 
-      if (response.statusCode == 200 && response.data?['openings'] is List) {
-        final List<dynamic> openingsDataList = response.data['openings'];
+      if (response.statusCode == 200 && response.data?['items'] is List) {
+        final List<dynamic> openingsDataList = response.data['items'];
         return openingsDataList
             .map((e) => CampOpenning.fromJson(e as Map<String, dynamic>))
             .toList();
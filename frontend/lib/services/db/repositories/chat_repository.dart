@@ -125,7 +125,7 @@ class ChatRepository {
       final response = await DioClient.instance
           .get('chat/rooms/$roomId/messages', queryParameters: queryParams);
       if (response.statusCode == 200) {
-        final List<dynamic> data = response.data;
+        final List<dynamic> data = response.data['items'];
         if (data.isEmpty) {
           return [];
         }
@@ -108,10 +108,10 @@ class CommentRepository {
         },
       );
       if (response.statusCode == 200) {
-        if (response.data == null) {
+        if (response.data?['items'] == null) {
           return [];
         }
-        return (response.data as List)
+        return (response.data['items'] as List)
             .map((comment) => CommentResponse.fromJson(comment))
             .toList();
       } else {
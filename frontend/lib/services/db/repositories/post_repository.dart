@@ -137,7 +137,7 @@ class PostRepository {
       );
 
       if (response.statusCode == 200) {
-        final postsData = response.data;
+        final postsData = response.data?['items'];
         if (postsData == null) {
           return [];
         }
@@ -249,7 +249,7 @@ class PostRepository {
       );
 
       if (response.statusCode == 200) {
-        final dynamic responseData = response.data;
+        final dynamic responseData = response.data?['items'];
         if (responseData == null) {
           return [];
         }